@@ -1,11 +1,23 @@
 package main
 
 import (
+	"context"
 	"crud-echo/internal/inbound/routers"
 	"crud-echo/internal/inbound/server"
 	"crud-echo/internal/outbound/database"
 	"crud-echo/pkg/di"
+	"crud-echo/pkg/health"
+	"crud-echo/pkg/metrics"
+	"crud-echo/pkg/migrations"
+	"errors"
+	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
 )
 
 func main() {
@@ -16,6 +28,16 @@ func main() {
 		log.Fatal("container error:", err)
 	}
 
+	// `server migrate <up|down|status|redo>` manages the schema and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := container.Invoke(func(m *migrations.Migrator) error {
+			return runMigrate(m, os.Args[2:])
+		}); err != nil {
+			log.Fatal("migrate error:", err)
+		}
+		return
+	}
+
 	if err := container.Invoke(func(dbConn database.RepositoryDBConn) {
 		if err := dbConn.Migrate(); err != nil {
 			log.Fatal("migrate error:", err)
@@ -24,15 +46,21 @@ func main() {
 		log.Fatal("migrate invoke error:", err)
 	}
 
+	// registered here rather than in metrics.New, the stats come from the
+	// usecase which itself sits on top of the instrumented db
+	if err := container.Invoke(func(m *metrics.Metrics, src metrics.BooksStatsSource) error {
+		return m.Register(metrics.NewBooksCollector(src))
+	}); err != nil {
+		log.Fatal("metrics invoke error:", err)
+	}
+
 	if err := container.Invoke(func(router *routers.Router) {
 		router.RegisterRoutes()
 	}); err != nil {
 		log.Fatal("router invoke error:", err)
 	}
 
-	if err := container.Invoke(func(server *server.Server) error {
-		return server.Start()
-	}); err != nil {
+	if err := container.Invoke(run); err != nil {
 		log.Fatal("server invoke error:", err)
 	}
 
@@ -74,3 +102,47 @@ func main() {
 	//
 	// e.Logger.Fatal(e.Start(":1323"))
 }
+
+// run serves until SIGINT or SIGTERM, then lets in-flight requests drain and
+// closes the database pool last since those requests may still need it.
+// Buffered spans are flushed once the requests that made them are done
+func run(srv *server.Server, dbConn database.RepositoryDBConn, registry *health.Registry, tp *sdktrace.TracerProvider, logger *zap.Logger) error {
+	defer logger.Sync()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- srv.Start()
+	}()
+
+	select {
+	case err := <-serverErr:
+		// never got to serve anything (port taken etc), nothing to drain
+		return errors.Join(err, tp.Shutdown(context.Background()), dbConn.Close())
+	case <-ctx.Done():
+	}
+	// a second signal falls back to the default and kills the process
+	stop()
+	registry.SetShuttingDown()
+	logger.Info("shutting down, waiting for in-flight requests", zap.Duration("timeout", srv.ShutdownTimeout()))
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), srv.ShutdownTimeout())
+	defer cancel()
+
+	shutdownErr := srv.Shutdown(shutdownCtx)
+	if err := <-serverErr; err != nil {
+		shutdownErr = errors.Join(shutdownErr, err)
+	}
+
+	if err := tp.Shutdown(shutdownCtx); err != nil {
+		shutdownErr = errors.Join(shutdownErr, fmt.Errorf("failed to flush traces: %w", err))
+	}
+
+	if err := dbConn.Close(); err != nil {
+		return errors.Join(shutdownErr, fmt.Errorf("failed to close database: %w", err))
+	}
+
+	return shutdownErr
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
@@ -11,11 +12,59 @@ import (
 type Config struct {
 	Server   *Server
 	Database *Database
+	Auth     *Auth
+	Tracing  *Tracing
+	Log      *Log
+	Lending  *Lending
 }
 
 type Server struct {
 	Host string
 	Port uint16
+
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	// how long in-flight requests get to finish once shutdown starts
+	ShutdownTimeout time.Duration
+	// "envelope" for our {status, message, data} or "problem" for RFC 7807,
+	// clients can still ask for problem+json with the envelope
+	ErrorFormat string
+}
+
+// Auth holds the bearer token for admin-only endpoints, an empty token
+// disables them altogether
+type Auth struct {
+	AdminToken string
+}
+
+// Tracing picks where spans go, "otlp" sends them over HTTP to Endpoint (or
+// OTEL_EXPORTER_OTLP_ENDPOINT when empty), "stdout" prints them for local
+// runs and "none" turns tracing off
+type Tracing struct {
+	Exporter    string
+	Endpoint    string
+	Insecure    bool
+	ServiceName string
+	SampleRatio float64
+}
+
+// Log sets the minimum level (debug, info, warn, error) and the encoding,
+// json or console
+type Log struct {
+	Level    string
+	Encoding string
+}
+
+// Lending is the loan policy: how long a member keeps a book and how many
+// times a loan can be renewed, each renewal adds another LoanPeriod.
+// HoldPickupWindow is how long a copy stays set aside for a ready hold
+type Lending struct {
+	LoanPeriod       time.Duration
+	MaxRenewals      int
+	HoldPickupWindow time.Duration
 }
 
 type Database struct {
@@ -27,6 +76,12 @@ type Database struct {
 	SSLMode  string
 	TimeZone string
 	LogMode  bool
+	// isolation level for units of work: "read committed", "repeatable read"
+	// or "serializable", empty leaves it to the server default
+	TxIsolation string
+	// how many times a unit of work is rerun after losing a serialization
+	// conflict or a deadlock
+	TxMaxRetries int
 }
 
 func LoadConfig(path string) (*Config, error) {
@@ -35,6 +90,28 @@ func LoadConfig(path string) (*Config, error) {
 	v.SetConfigType("yaml")
 	v.AddConfigPath(path)
 
+	v.SetDefault("server.readTimeout", 10*time.Second)
+	v.SetDefault("server.readHeaderTimeout", 5*time.Second)
+	v.SetDefault("server.writeTimeout", 30*time.Second)
+	v.SetDefault("server.idleTimeout", 120*time.Second)
+	v.SetDefault("server.maxHeaderBytes", 1<<20)
+	v.SetDefault("server.shutdownTimeout", 15*time.Second)
+	v.SetDefault("server.errorFormat", "envelope")
+
+	v.SetDefault("database.txIsolation", "read committed")
+	v.SetDefault("database.txMaxRetries", 3)
+
+	v.SetDefault("tracing.exporter", "none")
+	v.SetDefault("tracing.serviceName", "crud-echo")
+	v.SetDefault("tracing.sampleRatio", 1.0)
+
+	v.SetDefault("log.level", "info")
+	v.SetDefault("log.encoding", "json")
+
+	v.SetDefault("lending.loanPeriod", 14*24*time.Hour)
+	v.SetDefault("lending.maxRenewals", 2)
+	v.SetDefault("lending.holdPickupWindow", 3*24*time.Hour)
+
 	if err := v.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
@@ -55,6 +132,12 @@ func LoadConfig(path string) (*Config, error) {
 	if cfg.Database.Password == "" {
 		cfg.Database.Password = os.Getenv("DATABASE_PASSWORD")
 	}
+	if cfg.Auth == nil {
+		cfg.Auth = &Auth{}
+	}
+	if cfg.Auth.AdminToken == "" {
+		cfg.Auth.AdminToken = os.Getenv("ADMIN_TOKEN")
+	}
 
 	return &cfg, nil
 }
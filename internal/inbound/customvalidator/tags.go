@@ -0,0 +1,39 @@
+package customvalidator
+
+import (
+	"crud-echo/internal/models"
+	"regexp"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var customTags = []struct {
+	tag     string
+	fn      validator.Func
+	message string
+}{
+	{"no_leading_space", noLeadingSpace, "Should not start with a space"},
+	{"isbn", isbn, "Should be a valid ISBN-10 or ISBN-13"},
+	{"slug", slug, "Should be lowercase letters and digits separated by single hyphens"},
+}
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+func noLeadingSpace(fl validator.FieldLevel) bool {
+	s := fl.Field().String()
+	return s == "" || !unicode.IsSpace([]rune(s)[0])
+}
+
+// slug is the url friendly form categories are looked up by, "science-fiction"
+func slug(fl validator.FieldLevel) bool {
+	return slugPattern.MatchString(fl.Field().String())
+}
+
+// isbn accepts ISBN-10 and ISBN-13 with or without hyphens and spaces,
+// the check digit has to add up. Requests are validated by value so the
+// ISBN-13 form is left to models.NormalizeISBN in the usecase
+func isbn(fl validator.FieldLevel) bool {
+	_, err := models.NormalizeISBN(fl.Field().String())
+	return err == nil
+}
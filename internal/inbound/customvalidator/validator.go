@@ -1,50 +1,153 @@
 package customvalidator
 
 import (
+	"crud-echo/internal/models"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 )
 
 type CustomValidator struct {
 	Validator *validator.Validate
+	// messages for tags registered through RegisterValidation
+	messages map[string]string
 }
 
-func NewCustomValidator(validator *validator.Validate) *CustomValidator {
-	return &CustomValidator{Validator: validator}
+// NewCustomValidator reports fields under their json (or query) names and
+// registers the custom tags in tags.go
+func NewCustomValidator(validator *validator.Validate) (*CustomValidator, error) {
+	cv := &CustomValidator{Validator: validator, messages: make(map[string]string)}
+	cv.Validator.RegisterTagNameFunc(fieldName)
+
+	for _, t := range customTags {
+		if err := cv.RegisterValidation(t.tag, t.fn, t.message); err != nil {
+			return nil, err
+		}
+	}
+
+	return cv, nil
+}
+
+// RegisterValidation adds a custom tag along with the message clients get
+// when a field fails it
+func (cv *CustomValidator) RegisterValidation(tag string, fn validator.Func, message string) error {
+	if err := cv.Validator.RegisterValidation(tag, fn); err != nil {
+		return err
+	}
+	if cv.messages == nil {
+		cv.messages = make(map[string]string)
+	}
+	cv.messages[tag] = message
+
+	return nil
 }
 
 func (cv *CustomValidator) Validate(i interface{}) error {
 	if err := cv.Validator.Struct(i); err != nil {
-		return err
-		// fmterr := formatValidationErrors(err)
-		// return &models.ValidationError{Message: "invalid input", Errors: fmterr}
+		return cv.toValidationError(err)
+	}
+	return nil
+}
+
+// ValidatePartial only checks the given fields, for requests like PATCH
+// where anything that wasn't sent is left alone
+func (cv *CustomValidator) ValidatePartial(i interface{}, fields ...string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	if err := cv.Validator.StructPartial(i, fields...); err != nil {
+		return cv.toValidationError(err)
 	}
 	return nil
 }
 
-func formatValidationErrors(err error) map[string]string {
+// anything other than failed fields (a nil or non-struct value) is a bug on
+// our side and is returned as is
+func (cv *CustomValidator) toValidationError(err error) error {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+
+	return &models.ValidationError{
+		Message: models.ValidationFailed,
+		Errors:  cv.formatValidationErrors(verrs),
+	}
+}
+
+func (cv *CustomValidator) formatValidationErrors(verrs validator.ValidationErrors) map[string]string {
 	errors := make(map[string]string)
 
-	for _, err := range err.(validator.ValidationErrors) {
-		field := err.Field()
-		errors[field] = formatErrorMsg(err)
+	for _, err := range verrs {
+		errors[err.Field()] = cv.formatErrorMsg(err)
 	}
 
 	return errors
 }
 
-func formatErrorMsg(err validator.FieldError) string {
+func (cv *CustomValidator) formatErrorMsg(err validator.FieldError) string {
+	if msg, ok := cv.messages[err.Tag()]; ok {
+		return msg
+	}
+
+	// min and max count characters for strings but compare the value otherwise
+	unit := ""
+	if err.Kind() == reflect.String {
+		unit = " characters long"
+	}
+
 	switch err.Tag() {
 	case "required":
 		return "This field is required"
 	case "min":
-		return "Should be at least " + err.Param() + " characters long"
+		return "Should be at least " + err.Param() + unit
 	case "max":
-		return "Should be at most " + err.Param() + " characters long"
+		return "Should be at most " + err.Param() + unit
 	case "lte":
-		return "Should be less than " + err.Param()
+		return "Should be less than or equal to " + err.Param()
 	case "gte":
-		return "Should be greater than " + err.Param()
+		return "Should be greater than or equal to " + err.Param()
+	case "excluded_with":
+		return "Can't be combined with " + fieldList(err.Param())
+	case "oneof":
+		return "Should be one of " + strings.Join(strings.Fields(err.Param()), ", ")
+	case "unique":
+		return "Should not contain duplicates"
+	case "excludesall":
+		return "Should not contain any of " + strconv.Quote(err.Param())
+	case "bcp47_language_tag":
+		return "Should be a language tag like en or pt-BR"
+	case "email":
+		return "Should be an email address"
 	default:
 		return "Invalid value"
 	}
 }
+
+// fieldName is the name the client knows the field by, the json key for
+// bodies and the query key for query params
+func fieldName(f reflect.StructField) string {
+	for _, key := range []string{"json", "query", "param"} {
+		name, _, _ := strings.Cut(f.Tag.Get(key), ",")
+		if name == "-" {
+			continue
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// excluded_with and friends take Go field names, turn them into what the
+// client sees
+func fieldList(param string) string {
+	fields := strings.Fields(param)
+	for i, f := range fields {
+		fields[i] = strings.ToLower(f)
+	}
+	return strings.Join(fields, ", ")
+}
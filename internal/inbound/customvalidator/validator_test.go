@@ -0,0 +1,180 @@
+package customvalidator
+
+import (
+	"crud-echo/internal/models"
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+type testBody struct {
+	Title    string `json:"title" validate:"required,no_leading_space,min=3"`
+	Qty      int    `json:"qty" validate:"gte=0,lte=100"`
+	ISBN     string `json:"isbn" validate:"omitempty,isbn"`
+	IDs      *[]int `json:"ids" validate:"omitempty,max=3,unique,dive,gte=1"`
+	Slug     string `json:"slug" validate:"omitempty,slug"`
+	Label    string `json:"label" validate:"excludesall=0x2C"`
+	Language string `json:"language" validate:"omitempty,bcp47_language_tag"`
+	Email    string `json:"email" validate:"omitempty,email"`
+	Internal string `json:"-" validate:"omitempty,max=1"`
+}
+
+type testQuery struct {
+	Limit  int    `query:"limit" validate:"omitempty,lte=100"`
+	Format string `query:"format" validate:"omitempty,oneof=csv jsonl"`
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          any
+		expectedErrors map[string]string
+	}{
+		{
+			name:  "Valid body",
+			input: testBody{Title: "Dune", Qty: 1, ISBN: "978-0-441-17271-9"},
+		},
+		{
+			name:  "Valid ISBN-10 with X check digit",
+			input: testBody{Title: "Dune", ISBN: "0-8044-2957-X"},
+		},
+		{
+			name:  "Reports json names",
+			input: testBody{Title: "Du", Qty: 101},
+			expectedErrors: map[string]string{
+				"title": "Should be at least 3 characters long",
+				"qty":   "Should be less than or equal to 100",
+			},
+		},
+		{
+			name:           "Reports query names",
+			input:          testQuery{Limit: 1000},
+			expectedErrors: map[string]string{"limit": "Should be less than or equal to 100"},
+		},
+		{
+			name:           "Lists the allowed values",
+			input:          testQuery{Format: "xlsx"},
+			expectedErrors: map[string]string{"format": "Should be one of csv, jsonl"},
+		},
+		{
+			name:           "Falls back to the Go name when json is skipped",
+			input:          testBody{Title: "Dune", Internal: "xx"},
+			expectedErrors: map[string]string{"Internal": "Should be at most 1 characters long"},
+		},
+		{
+			name:           "Leading space",
+			input:          testBody{Title: "\tDune"},
+			expectedErrors: map[string]string{"title": "Should not start with a space"},
+		},
+		{
+			name:           "ISBN with a bad check digit",
+			input:          testBody{Title: "Dune", ISBN: "978-0-441-17271-8"},
+			expectedErrors: map[string]string{"isbn": "Should be a valid ISBN-10 or ISBN-13"},
+		},
+		{
+			name:           "Repeated IDs",
+			input:          testBody{Title: "Dune", IDs: &[]int{1, 2, 1}},
+			expectedErrors: map[string]string{"ids": "Should not contain duplicates"},
+		},
+		{
+			name:           "Reports the element that failed",
+			input:          testBody{Title: "Dune", IDs: &[]int{1, 0}},
+			expectedErrors: map[string]string{"ids[1]": "Should be greater than or equal to 1"},
+		},
+		{
+			name:  "Empty IDs are fine",
+			input: testBody{Title: "Dune", IDs: &[]int{}},
+		},
+		{
+			name:  "Valid slug",
+			input: testBody{Title: "Dune", Slug: "science-fiction-2"},
+		},
+		{
+			name:           "Slug with upper case",
+			input:          testBody{Title: "Dune", Slug: "Science-Fiction"},
+			expectedErrors: map[string]string{"slug": "Should be lowercase letters and digits separated by single hyphens"},
+		},
+		{
+			name:           "Slug with a double hyphen",
+			input:          testBody{Title: "Dune", Slug: "science--fiction"},
+			expectedErrors: map[string]string{"slug": "Should be lowercase letters and digits separated by single hyphens"},
+		},
+		{
+			name:           "Lists the excluded characters",
+			input:          testBody{Title: "Dune", Label: "classic,award"},
+			expectedErrors: map[string]string{"label": `Should not contain any of ","`},
+		},
+		{
+			name:           "ISBN with the wrong length",
+			input:          testBody{Title: "Dune", ISBN: "12345"},
+			expectedErrors: map[string]string{"isbn": "Should be a valid ISBN-10 or ISBN-13"},
+		},
+		{
+			name:           "ISBN-10 with X before the check digit",
+			input:          testBody{Title: "Dune", ISBN: "0-8044-29X7-0"},
+			expectedErrors: map[string]string{"isbn": "Should be a valid ISBN-10 or ISBN-13"},
+		},
+		{
+			name:  "Valid language tag",
+			input: testBody{Title: "Dune", Language: "pt-BR"},
+		},
+		{
+			name:           "Language that isn't a tag",
+			input:          testBody{Title: "Dune", Language: "english!"},
+			expectedErrors: map[string]string{"language": "Should be a language tag like en or pt-BR"},
+		},
+		{
+			name:           "Email without a domain",
+			input:          testBody{Title: "Dune", Email: "paul@"},
+			expectedErrors: map[string]string{"email": "Should be an email address"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv, err := NewCustomValidator(validator.New())
+			assert.NoError(t, err)
+
+			err = cv.Validate(tt.input)
+
+			if tt.expectedErrors == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var validationError *models.ValidationError
+			assert.ErrorAs(t, err, &validationError)
+			assert.ErrorIs(t, err, models.ErrValidationError)
+			assert.Equal(t, tt.expectedErrors, validationError.Errors)
+		})
+	}
+}
+
+func TestValidateNonStruct(t *testing.T) {
+	cv, err := NewCustomValidator(validator.New())
+	assert.NoError(t, err)
+
+	err = cv.Validate("not a struct")
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, models.ErrValidationError), "a bug on our side is not the client's fault")
+}
+
+func TestRegisterValidation(t *testing.T) {
+	cv, err := NewCustomValidator(validator.New())
+	assert.NoError(t, err)
+
+	err = cv.RegisterValidation("even", func(fl validator.FieldLevel) bool {
+		return fl.Field().Int()%2 == 0
+	}, "Should be even")
+	assert.NoError(t, err)
+
+	type body struct {
+		N int `json:"n" validate:"even"`
+	}
+
+	var validationError *models.ValidationError
+	assert.ErrorAs(t, cv.Validate(body{N: 3}), &validationError)
+	assert.Equal(t, map[string]string{"n": "Should be even"}, validationError.Errors)
+	assert.NoError(t, cv.Validate(body{N: 4}))
+}
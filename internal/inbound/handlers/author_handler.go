@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"context"
+	"crud-echo/internal/inbound/customvalidator"
+	"crud-echo/internal/models"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type HandlerAuthorUsecase interface {
+	CreateAuthor(ctx context.Context, author *models.CreateAuthorsRequest) (*models.AuthorsSummary, error)
+	GetAuthorByID(ctx context.Context, id int) (*models.AuthorsSummary, error)
+	GetAllAuthors(ctx context.Context, name string, page *models.PageRequest) (*[]models.AuthorsSummary, *models.PageMeta, error)
+	UpdateAuthor(ctx context.Context, author *models.UpdateAuthorsRequest) (*models.AuthorsSummary, error)
+	DeleteAuthor(ctx context.Context, id int) error
+	GetAuthorBooks(ctx context.Context, id int, page *models.PageRequest) (*[]models.BooksSummary, *models.PageMeta, error)
+}
+
+type AuthorsHandler struct {
+	auc HandlerAuthorUsecase
+	cv  *customvalidator.CustomValidator
+}
+
+func NewAuthorsHandler(auc HandlerAuthorUsecase, validator *customvalidator.CustomValidator) *AuthorsHandler {
+	return &AuthorsHandler{auc: auc, cv: validator}
+}
+
+func (h AuthorsHandler) CreateAuthor(c echo.Context) error {
+	var a models.CreateAuthorsRequest
+
+	if err := c.Bind(&a); err != nil {
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(a); err != nil {
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
+	}
+
+	resp, err := h.auc.CreateAuthor(c.Request().Context(), &a)
+	if err != nil {
+		logFor(c).Error("error creating author", zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Author has been created", resp)
+}
+
+func (h AuthorsHandler) GetAuthorByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	resp, err := h.auc.GetAuthorByID(c.Request().Context(), id)
+	if err != nil {
+		logFor(c).Error("error retrieving author", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Author retrieved successfully", resp)
+}
+
+func (h AuthorsHandler) GetAllAuthors(c echo.Context) error {
+	var q models.GetAllAuthorsRequest
+
+	if err := c.Bind(&q); err != nil {
+		logFor(c).Warn("error binding query params", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(q); err != nil {
+		logFor(c).Warn("error validating query params", zap.Error(err))
+		return err
+	}
+
+	resp, meta, err := h.auc.GetAllAuthors(c.Request().Context(), strings.TrimSpace(q.Name), pageRequest(q.Limit, q.Offset))
+	if err != nil {
+		logFor(c).Error("error retrieving authors", zap.Error(err))
+		return err
+	}
+
+	return CustomResponseWithMeta(c, http.StatusOK, true, "Authors retrieved successfully", resp, meta)
+}
+
+func (h AuthorsHandler) UpdateAuthor(c echo.Context) error {
+	var a models.UpdateAuthorsRequest
+
+	if err := c.Bind(&a); err != nil {
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(a); err != nil {
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
+	}
+
+	resp, err := h.auc.UpdateAuthor(c.Request().Context(), &a)
+	if err != nil {
+		logFor(c).Error("error updating author", zap.Int("id", a.ID), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Author with ID "+strconv.Itoa(a.ID)+" has been updated", resp)
+}
+
+func (h AuthorsHandler) DeleteAuthor(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	if err := h.auc.DeleteAuthor(c.Request().Context(), id); err != nil {
+		logFor(c).Error("error deleting author", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Author with ID "+strconv.Itoa(id)+" has been deleted", nil)
+}
+
+func (h AuthorsHandler) GetAuthorBooks(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	var q models.GetAuthorBooksRequest
+
+	if err := c.Bind(&q); err != nil {
+		logFor(c).Warn("error binding query params", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(q); err != nil {
+		logFor(c).Warn("error validating query params", zap.Error(err))
+		return err
+	}
+
+	resp, meta, err := h.auc.GetAuthorBooks(c.Request().Context(), id, pageRequest(q.Limit, q.Offset))
+	if err != nil {
+		logFor(c).Error("error retrieving books of author", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponseWithMeta(c, http.StatusOK, true, "Books retrieved successfully", resp, meta)
+}
+
+// pageRequest is a limit/offset page, a zero limit means the default
+func pageRequest(limit, offset int) *models.PageRequest {
+	if limit == 0 {
+		limit = models.DefaultPageLimit
+	}
+	return &models.PageRequest{Limit: limit, Offset: offset}
+}
@@ -0,0 +1,420 @@
+package handlers
+
+import (
+	vc "crud-echo/internal/inbound/customvalidator"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// authorsTestContext reuses the request helpers of TestContext, only the
+// handler and its mock differ
+type authorsTestContext struct {
+	*TestContext
+	Handler *AuthorsHandler
+	Mock    *mocks.MockhandlerAuthorUsecase
+}
+
+func authorsSetup(t *testing.T) *authorsTestContext {
+	e := echo.New()
+	e.HTTPErrorHandler = CustomHTTPErrorHandler
+
+	mockUsecase := mocks.NewMockhandlerAuthorUsecase(t)
+	testValidator, err := vc.NewCustomValidator(validator.New())
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	return &authorsTestContext{
+		TestContext: &TestContext{Echo: e},
+		Handler:     NewAuthorsHandler(mockUsecase, testValidator),
+		Mock:        mockUsecase,
+	}
+}
+
+func TestCreateAuthor(t *testing.T) {
+	tests := []struct {
+		name             string
+		requestBody      string
+		m                func(mockuc *mocks.MockhandlerAuthorUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:        "Success create author",
+			requestBody: `{"name":"Frank Herbert","bio":"Wrote Dune"}`,
+			m: func(mockuc *mocks.MockhandlerAuthorUsecase) {
+				mockuc.EXPECT().CreateAuthor(anyCtx, &models.CreateAuthorsRequest{Name: "Frank Herbert", Bio: "Wrote Dune"}).
+					Return(&models.AuthorsSummary{ID: 1, Name: "Frank Herbert", Bio: "Wrote Dune"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Author has been created",
+				Data:    map[string]any{"id": float64(1), "name": "Frank Herbert", "bio": "Wrote Dune"},
+			},
+		},
+		{
+			name:           "Failed create author due to bind error",
+			requestBody:    `{,,,}`,
+			m:              func(mockuc *mocks.MockhandlerAuthorUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.BadRequest,
+			},
+		},
+		{
+			name:           "Failed create author due to validation error",
+			requestBody:    `{"name":" F"}`,
+			m:              func(mockuc *mocks.MockhandlerAuthorUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"name": "Should not start with a space"},
+			},
+		},
+		{
+			name:        "Failed create author due to invalid DB",
+			requestBody: `{"name":"Frank Herbert"}`,
+			m: func(mockuc *mocks.MockhandlerAuthorUsecase) {
+				mockuc.EXPECT().CreateAuthor(anyCtx, &models.CreateAuthorsRequest{Name: "Frank Herbert"}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrInternalServerError))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "internal server error",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := authorsSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequest(http.MethodPost, "/authors", tt.requestBody, tc.Handler.CreateAuthor)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestGetAuthorByID(t *testing.T) {
+	tests := []struct {
+		name             string
+		param            string
+		m                func(mockuc *mocks.MockhandlerAuthorUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success get author by ID",
+			param: "1",
+			m: func(mockuc *mocks.MockhandlerAuthorUsecase) {
+				mockuc.EXPECT().GetAuthorByID(anyCtx, 1).Return(&models.AuthorsSummary{ID: 1, Name: "Frank Herbert"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Author retrieved successfully",
+				Data:    map[string]any{"id": float64(1), "name": "Frank Herbert"},
+			},
+		},
+		{
+			name:           "Failed get author by ID due to error converting ID param",
+			param:          "abc",
+			m:              func(mockuc *mocks.MockhandlerAuthorUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "invalid parameter",
+			},
+		},
+		{
+			name:  "Failed get author by ID due to author not found",
+			param: "99",
+			m: func(mockuc *mocks.MockhandlerAuthorUsecase) {
+				mockuc.EXPECT().GetAuthorByID(anyCtx, 99).Return(nil, fmt.Errorf("repository error: %w", models.ErrAuthorNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrAuthorNotFound.Message,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := authorsSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodGet, "/authors/:id", "id", tt.param, "", tc.Handler.GetAuthorByID)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestGetAllAuthors(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		m                func(mockuc *mocks.MockhandlerAuthorUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success get all authors with default limit",
+			query: "",
+			m: func(mockuc *mocks.MockhandlerAuthorUsecase) {
+				mockuc.EXPECT().GetAllAuthors(anyCtx, "", &models.PageRequest{Limit: models.DefaultPageLimit}).
+					Return(&[]models.AuthorsSummary{{ID: 1, Name: "Frank Herbert"}}, &models.PageMeta{Total: 1, Limit: models.DefaultPageLimit}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Authors retrieved successfully",
+				Data:    []any{map[string]any{"id": float64(1), "name": "Frank Herbert"}},
+				Meta:    map[string]any{"total": float64(1), "limit": float64(models.DefaultPageLimit)},
+			},
+		},
+		{
+			name:  "Success get all authors by name",
+			query: "name=%20herbert%20&limit=5&offset=5",
+			m: func(mockuc *mocks.MockhandlerAuthorUsecase) {
+				mockuc.EXPECT().GetAllAuthors(anyCtx, "herbert", &models.PageRequest{Limit: 5, Offset: 5}).
+					Return(&[]models.AuthorsSummary{}, &models.PageMeta{Total: 2, Limit: 5, Offset: 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Authors retrieved successfully",
+				Data:    []any{},
+				Meta:    map[string]any{"total": float64(2), "limit": float64(5), "offset": float64(5)},
+			},
+		},
+		{
+			name:           "Failed get all authors due to bind error",
+			query:          "limit=abc",
+			m:              func(mockuc *mocks.MockhandlerAuthorUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.BadRequest,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := authorsSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithQuery(http.MethodGet, "/authors", tt.query, "", tc.Handler.GetAllAuthors)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+			assert.Equal(t, tt.expectedResponse.Meta, actualResponse.Meta)
+		})
+	}
+}
+
+func TestUpdateAuthor(t *testing.T) {
+	tests := []struct {
+		name             string
+		param            string
+		requestBody      string
+		m                func(mockuc *mocks.MockhandlerAuthorUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:        "Success update author",
+			param:       "1",
+			requestBody: `{"name":"Frank Herbert","bio":"Wrote Dune"}`,
+			m: func(mockuc *mocks.MockhandlerAuthorUsecase) {
+				mockuc.EXPECT().UpdateAuthor(anyCtx, &models.UpdateAuthorsRequest{ID: 1, Name: "Frank Herbert", Bio: "Wrote Dune"}).
+					Return(&models.AuthorsSummary{ID: 1, Name: "Frank Herbert", Bio: "Wrote Dune"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Author with ID 1 has been updated",
+				Data:    map[string]any{"id": float64(1), "name": "Frank Herbert", "bio": "Wrote Dune"},
+			},
+		},
+		{
+			name:           "Failed update author due to validation error",
+			param:          "1",
+			requestBody:    `{"name":"F"}`,
+			m:              func(mockuc *mocks.MockhandlerAuthorUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"name": "Should be at least 2 characters long"},
+			},
+		},
+		{
+			name:        "Failed update author due to author not found",
+			param:       "99",
+			requestBody: `{"name":"Frank Herbert"}`,
+			m: func(mockuc *mocks.MockhandlerAuthorUsecase) {
+				mockuc.EXPECT().UpdateAuthor(anyCtx, &models.UpdateAuthorsRequest{ID: 99, Name: "Frank Herbert"}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrAuthorNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrAuthorNotFound.Message,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := authorsSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodPut, "/authors/:id", "id", tt.param, tt.requestBody, tc.Handler.UpdateAuthor)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestDeleteAuthor(t *testing.T) {
+	tests := []struct {
+		name             string
+		param            string
+		m                func(mockuc *mocks.MockhandlerAuthorUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success delete author",
+			param: "1",
+			m: func(mockuc *mocks.MockhandlerAuthorUsecase) {
+				mockuc.EXPECT().DeleteAuthor(anyCtx, 1).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Author with ID 1 has been deleted",
+			},
+		},
+		{
+			name:           "Failed delete author due to error converting ID param",
+			param:          "abc",
+			m:              func(mockuc *mocks.MockhandlerAuthorUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "invalid parameter",
+			},
+		},
+		{
+			name:  "Failed delete author due to author not found",
+			param: "99",
+			m: func(mockuc *mocks.MockhandlerAuthorUsecase) {
+				mockuc.EXPECT().DeleteAuthor(anyCtx, 99).Return(fmt.Errorf("repository error: %w", models.ErrAuthorNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrAuthorNotFound.Message,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := authorsSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodDelete, "/authors/:id", "id", tt.param, "", tc.Handler.DeleteAuthor)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+		})
+	}
+}
+
+func TestGetAuthorBooks(t *testing.T) {
+	tests := []struct {
+		name             string
+		param            string
+		m                func(mockuc *mocks.MockhandlerAuthorUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success get books of an author",
+			param: "7",
+			m: func(mockuc *mocks.MockhandlerAuthorUsecase) {
+				mockuc.EXPECT().GetAuthorBooks(anyCtx, 7, &models.PageRequest{Limit: models.DefaultPageLimit}).
+					Return(&[]models.BooksSummary{{ID: 1, Title: "Dune", Description: "Test Description", Qty: 10}},
+						&models.PageMeta{Total: 1, Limit: models.DefaultPageLimit}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Books retrieved successfully",
+				Data: []any{
+					map[string]any{"id": float64(1), "title": "Dune", "description": "Test Description", "qty": float64(10)},
+				},
+				Meta: map[string]any{"total": float64(1), "limit": float64(models.DefaultPageLimit)},
+			},
+		},
+		{
+			name:  "Failed get books due to author not found",
+			param: "99",
+			m: func(mockuc *mocks.MockhandlerAuthorUsecase) {
+				mockuc.EXPECT().GetAuthorBooks(anyCtx, 99, &models.PageRequest{Limit: models.DefaultPageLimit}).
+					Return(nil, nil, fmt.Errorf("repository error: %w", models.ErrAuthorNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrAuthorNotFound.Message,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := authorsSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodGet, "/authors/:id/books", "id", tt.param, "", tc.Handler.GetAuthorBooks)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+			assert.Equal(t, tt.expectedResponse.Meta, actualResponse.Meta)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"crud-echo/internal/inbound/customvalidator"
+	"crud-echo/internal/models"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// bulkRequest is a bound bulk body, items that failed validation already
+// have their result and only the valid ones go to the usecase
+type bulkRequest[T any] struct {
+	atomic  bool
+	results []models.BulkResult
+	valid   []T
+	indexes []int // where each valid item sits in the request
+}
+
+func bindBulk[T any](c echo.Context, cv *customvalidator.CustomValidator) (*bulkRequest[T], error) {
+	atomic, err := parseBoolParam(c.QueryParam("atomic"))
+	if err != nil {
+		return nil, models.ErrInvalidParam.Wrap(err)
+	}
+
+	var items []T
+	if err := c.Bind(&items); err != nil {
+		return nil, models.ErrBadRequest.Wrap(err)
+	}
+	if len(items) == 0 || len(items) > models.MaxBulkItems {
+		return nil, models.ErrInvalidParam.Wrap(fmt.Errorf("a bulk request takes 1 to %d items, got %d", models.MaxBulkItems, len(items)))
+	}
+
+	req := &bulkRequest[T]{
+		atomic:  atomic,
+		results: models.NewBulkResults(len(items)),
+	}
+	for i, item := range items {
+		if err := cv.Validate(item); err != nil {
+			req.results[i].Fail(err)
+			continue
+		}
+		req.valid = append(req.valid, item)
+		req.indexes = append(req.indexes, i)
+	}
+
+	return req, nil
+}
+
+// skipUsecase tells whether validation alone already failed an atomic request
+func (r *bulkRequest[T]) skipUsecase() bool {
+	return r.atomic && len(r.valid) < len(r.results)
+}
+
+// merge puts the usecase results back at the position of their item
+func (r *bulkRequest[T]) merge(results []models.BulkResult) {
+	for j, result := range results {
+		result.Index = r.indexes[j]
+		r.results[result.Index] = result
+	}
+}
+
+func (h BooksHandler) BulkCreateBooks(c echo.Context) error {
+	req, err := bindBulk[models.CreateBooksRequest](c, h.cv)
+	if err != nil {
+		logFor(c).Warn("error binding bulk request", zap.Error(err))
+		return err
+	}
+
+	if req.skipUsecase() {
+		models.RollBackBulk(req.results)
+		return bulkResponse(c, req.results, req.atomic, models.ErrValidationError, "created")
+	}
+
+	results, err := h.buc.BulkCreateBooks(c.Request().Context(), req.valid, req.atomic)
+	if results == nil {
+		logFor(c).Error("error creating books", zap.Error(err))
+		return err
+	}
+	req.merge(results)
+
+	return bulkResponse(c, req.results, req.atomic, err, "created")
+}
+
+func (h BooksHandler) BulkUpdateBooks(c echo.Context) error {
+	req, err := bindBulk[models.BulkUpdateBooksItem](c, h.cv)
+	if err != nil {
+		logFor(c).Warn("error binding bulk request", zap.Error(err))
+		return err
+	}
+
+	if req.skipUsecase() {
+		models.RollBackBulk(req.results)
+		return bulkResponse(c, req.results, req.atomic, models.ErrValidationError, "updated")
+	}
+
+	results, err := h.buc.BulkUpdateBooks(c.Request().Context(), req.valid, req.atomic)
+	if results == nil {
+		logFor(c).Error("error updating books", zap.Error(err))
+		return err
+	}
+	req.merge(results)
+
+	return bulkResponse(c, req.results, req.atomic, err, "updated")
+}
+
+func (h BooksHandler) BulkDeleteBooks(c echo.Context) error {
+	req, err := bindBulk[models.BulkDeleteBooksItem](c, h.cv)
+	if err != nil {
+		logFor(c).Warn("error binding bulk request", zap.Error(err))
+		return err
+	}
+
+	if req.skipUsecase() {
+		models.RollBackBulk(req.results)
+		return bulkResponse(c, req.results, req.atomic, models.ErrValidationError, "deleted")
+	}
+
+	results, err := h.buc.BulkDeleteBooks(c.Request().Context(), req.valid, req.atomic)
+	if results == nil {
+		logFor(c).Error("error deleting books", zap.Error(err))
+		return err
+	}
+	req.merge(results)
+
+	return bulkResponse(c, req.results, req.atomic, err, "deleted")
+}
+
+// bulkResponse always carries the per item results. A failed atomic request
+// takes its status and code from err, a best effort one is a 200 however many
+// items failed
+func bulkResponse(c echo.Context, results []models.BulkResult, atomic bool, err error, done string) error {
+	summary := models.SummarizeBulk(results, atomic)
+
+	if err != nil {
+		logFor(c).Warn("bulk request rolled back", zap.Error(err))
+		return &bulkError{err: err, done: done, results: results, summary: summary}
+	}
+
+	message := fmt.Sprintf("%d of %d books have been %s", summary.Succeeded, summary.Total, done)
+	return CustomResponseWithMeta(c, http.StatusOK, true, message, results, summary)
+}
+
+// bulkError is a rolled back atomic request, the error handler renders err
+// with the per item results next to it in either format
+type bulkError struct {
+	err     error
+	done    string
+	results []models.BulkResult
+	summary models.BulkSummary
+}
+
+func (e *bulkError) Error() string {
+	return "no books were " + e.done + ": " + e.err.Error()
+}
+
+func (e *bulkError) Unwrap() error {
+	return e.err
+}
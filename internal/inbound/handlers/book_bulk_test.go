@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// decoded turns v into what it looks like once it went through the response
+// body, so it compares with Response.Data and Response.Meta
+func decoded(t *testing.T, v any) any {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	return out
+}
+
+func rolledBack(index int) models.BulkResult {
+	return models.BulkResult{Index: index, Code: models.ErrBulkRolledBack.Code, Message: models.ErrBulkRolledBack.Message}
+}
+
+func TestBulkCreateBooks(t *testing.T) {
+	bookA := models.CreateBooksRequest{Title: "Title A", Description: "Test Description", Qty: 10}
+	bookB := models.CreateBooksRequest{Title: "Title B", Description: "Test Description", Qty: 10}
+	invalid := models.BulkResult{
+		Index:   1,
+		Code:    models.ErrValidationError.Code,
+		Message: models.ValidationFailed,
+		Errors:  map[string]string{"title": "Should be at least 3 characters long"},
+	}
+
+	tests := []struct {
+		name             string
+		query            string
+		requestBody      string
+		m                func(mockuc *mocks.MockhandlerBookUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:        "Success create books",
+			requestBody: `[{"title":"Title A","description":"Test Description","qty":10},{"title":"Title B","description":"Test Description","qty":10}]`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().BulkCreateBooks(anyCtx, []models.CreateBooksRequest{bookA, bookB}, false).
+					Return([]models.BulkResult{{Index: 0, ID: 1}, {Index: 1, ID: 2}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "2 of 2 books have been created",
+				Data:    []models.BulkResult{{Index: 0, ID: 1}, {Index: 1, ID: 2}},
+				Meta:    models.BulkSummary{Total: 2, Succeeded: 2},
+			},
+		},
+		{
+			name:        "Invalid item fails on its own best effort",
+			requestBody: `[{"title":"Title A","description":"Test Description","qty":10},{"title":"xx","description":"Test Description","qty":10},{"title":"Title B","description":"Test Description","qty":10}]`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().BulkCreateBooks(anyCtx, []models.CreateBooksRequest{bookA, bookB}, false).
+					Return([]models.BulkResult{
+						{Index: 0, ID: 1},
+						{Index: 1, Code: models.ErrBookTitleTaken.Code, Message: models.ErrBookTitleTaken.Message},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "1 of 3 books have been created",
+				Data: []models.BulkResult{
+					{Index: 0, ID: 1},
+					invalid,
+					{Index: 2, Code: models.ErrBookTitleTaken.Code, Message: models.ErrBookTitleTaken.Message},
+				},
+				Meta: models.BulkSummary{Total: 3, Succeeded: 1, Failed: 2},
+			},
+		},
+		{
+			name:           "Invalid item rolls back everything atomic",
+			query:          "atomic=true",
+			requestBody:    `[{"title":"Title A","description":"Test Description","qty":10},{"title":"xx","description":"Test Description","qty":10}]`,
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Code:    models.ErrValidationError.Code,
+				Message: "No books were created: " + models.ValidationFailed,
+				Data:    []models.BulkResult{rolledBack(0), invalid},
+				Meta:    models.BulkSummary{Atomic: true, Total: 2, Failed: 2},
+			},
+		},
+		{
+			name:        "Failed item rolls back everything atomic",
+			query:       "atomic=true",
+			requestBody: `[{"title":"Title A","description":"Test Description","qty":10},{"title":"Title B","description":"Test Description","qty":10}]`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().BulkCreateBooks(anyCtx, []models.CreateBooksRequest{bookA, bookB}, true).
+					Return([]models.BulkResult{
+						rolledBack(0),
+						{Index: 1, Code: models.ErrBookTitleTaken.Code, Message: models.ErrBookTitleTaken.Message},
+					}, models.ErrBookTitleTaken)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedResponse: Response{
+				Status:  false,
+				Code:    models.ErrBookTitleTaken.Code,
+				Message: "No books were created: " + models.ErrBookTitleTaken.Message,
+				Data: []models.BulkResult{
+					rolledBack(0),
+					{Index: 1, Code: models.ErrBookTitleTaken.Code, Message: models.ErrBookTitleTaken.Message},
+				},
+				Meta: models.BulkSummary{Atomic: true, Total: 2, Failed: 2},
+			},
+		},
+		{
+			name:           "Failed create books due to empty list",
+			requestBody:    `[]`,
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Code:    models.ErrInvalidParam.Code,
+				Message: models.InvalidParam,
+			},
+		},
+		{
+			name:           "Failed create books due to too many items",
+			requestBody:    "[" + strings.Repeat(`{"title":"Title A","description":"Test Description","qty":10},`, models.MaxBulkItems) + `{"title":"Title B","description":"Test Description","qty":10}]`,
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Code:    models.ErrInvalidParam.Code,
+				Message: models.InvalidParam,
+			},
+		},
+		{
+			name:           "Failed create books due to invalid atomic param",
+			query:          "atomic=maybe",
+			requestBody:    `[{"title":"Title A","description":"Test Description","qty":10}]`,
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Code:    models.ErrInvalidParam.Code,
+				Message: models.InvalidParam,
+			},
+		},
+		{
+			name:           "Failed create books due to bind error",
+			requestBody:    `{"title":"Title A"}`,
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Code:    models.ErrBadRequest.Code,
+				Message: models.BadRequest,
+			},
+		},
+		{
+			name:        "Failed create books due to usecase error",
+			requestBody: `[{"title":"Title A","description":"Test Description","qty":10}]`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().BulkCreateBooks(anyCtx, []models.CreateBooksRequest{bookA}, false).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrInternalServerError))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedResponse: Response{
+				Status:  false,
+				Code:    models.ErrInternalServerError.Code,
+				Message: models.InternalServerError,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := initialSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithQuery(http.MethodPost, "/books/bulk", tt.query, tt.requestBody, tc.Handler.BulkCreateBooks)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Code, actualResponse.Code)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, decoded(t, tt.expectedResponse.Data), actualResponse.Data)
+			assert.Equal(t, decoded(t, tt.expectedResponse.Meta), actualResponse.Meta)
+		})
+	}
+}
+
+func TestBulkUpdateBooks(t *testing.T) {
+	tc := initialSetup(t)
+	tc.Mock.EXPECT().BulkUpdateBooks(anyCtx, []models.BulkUpdateBooksItem{
+		{ID: 1, Title: "Title A", Description: "Test Description", Qty: 10, Version: 2},
+	}, false).Return([]models.BulkResult{{Index: 0, ID: 1}}, nil)
+
+	body := `[{"id":1,"title":"Title A","description":"Test Description","qty":10,"version":2},{"id":2,"title":"Title B","description":"Test Description","qty":10}]`
+	rec := tc.executeRequest(http.MethodPut, "/books/bulk", body, tc.Handler.BulkUpdateBooks)
+	actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1 of 2 books have been updated", actualResponse.Message)
+	assert.Equal(t, decoded(t, []models.BulkResult{
+		{Index: 0, ID: 1},
+		{
+			Index:   1,
+			Code:    models.ErrValidationError.Code,
+			Message: models.ValidationFailed,
+			Errors:  map[string]string{"version": "This field is required"},
+		},
+	}), actualResponse.Data)
+}
+
+func TestBulkDeleteBooks(t *testing.T) {
+	tc := initialSetup(t)
+	tc.Mock.EXPECT().BulkDeleteBooks(anyCtx, []models.BulkDeleteBooksItem{{ID: 1, Version: 2}, {ID: 99, Version: 1}}, true).
+		Return([]models.BulkResult{
+			rolledBack(0),
+			{Index: 1, Code: models.ErrBookNotFound.Code, Message: models.ErrBookNotFound.Message},
+		}, fmt.Errorf("repository error: %w", models.ErrBookNotFound))
+
+	rec := tc.executeRequestWithQuery(http.MethodDelete, "/books/bulk", "atomic=true", `[{"id":1,"version":2},{"id":99,"version":1}]`, tc.Handler.BulkDeleteBooks)
+	actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, models.ErrBookNotFound.Code, actualResponse.Code)
+	assert.Equal(t, "No books were deleted: "+models.ErrBookNotFound.Message, actualResponse.Message)
+	assert.Equal(t, decoded(t, models.BulkSummary{Atomic: true, Total: 2, Failed: 2}), actualResponse.Meta)
+}
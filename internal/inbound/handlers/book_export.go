@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"crud-echo/internal/models"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// flush the export to the client every this many rows
+const exportFlushEvery = 100
+
+type exportEncoder interface {
+	Header() error
+	Encode(book *models.BooksExport) error
+	Flush() error
+}
+
+// ExportBooks streams every book as CSV or JSON Lines. The status goes out
+// with the first row, an error after that can only cut the connection so
+// the client doesn't take a partial file for a whole one
+func (h BooksHandler) ExportBooks(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = models.ExportFormatCSV
+	}
+
+	res := c.Response()
+	var enc exportEncoder
+	switch format {
+	case models.ExportFormatCSV:
+		enc = &csvExport{w: csv.NewWriter(res)}
+	case models.ExportFormatJSONL:
+		enc = &jsonlExport{enc: json.NewEncoder(res)}
+	default:
+		err := fmt.Errorf("unknown export format %q, want %s or %s", format, models.ExportFormatCSV, models.ExportFormatJSONL)
+		logFor(c).Warn("error parsing export format", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	// a big catalog takes longer than the server write timeout
+	_ = http.NewResponseController(res.Writer).SetWriteDeadline(time.Time{})
+
+	var rows int
+	start := func() error {
+		res.Header().Set(echo.HeaderContentType, exportContentType(format))
+		res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="books.`+format+`"`)
+		res.WriteHeader(http.StatusOK)
+		return enc.Header()
+	}
+
+	err := h.buc.ExportBooks(c.Request().Context(), func(book *models.BooksExport) error {
+		if rows == 0 {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		rows++
+		if err := enc.Encode(book); err != nil {
+			return err
+		}
+		if rows%exportFlushEvery == 0 {
+			if err := enc.Flush(); err != nil {
+				return err
+			}
+			res.Flush()
+		}
+		return nil
+	})
+	if err == nil && rows == 0 {
+		err = start()
+	}
+	if err == nil {
+		err = enc.Flush()
+	}
+
+	if err != nil && !res.Committed {
+		logFor(c).Error("error exporting books", zap.Error(err))
+		return err
+	}
+	if err != nil {
+		logFor(c).Error("error exporting books, aborting the response", zap.Int("rows", rows), zap.Error(err))
+		panic(http.ErrAbortHandler)
+	}
+
+	return nil
+}
+
+func exportContentType(format string) string {
+	if format == models.ExportFormatJSONL {
+		return "application/jsonl; charset=utf-8"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+type csvExport struct {
+	w *csv.Writer
+}
+
+func (e *csvExport) Header() error {
+	return e.w.Write(models.BooksExportColumns)
+}
+
+func (e *csvExport) Encode(book *models.BooksExport) error {
+	return e.w.Write([]string{
+		strconv.Itoa(book.ID),
+		book.Title,
+		book.Description,
+		strconv.Itoa(book.Qty),
+		strconv.Itoa(book.Version),
+		book.CreatedAt.Format(time.RFC3339),
+		book.UpdatedAt.Format(time.RFC3339),
+		book.ISBN,
+		book.Publisher,
+		optionalInt(book.PublicationYear),
+		book.Language,
+		optionalInt(book.PageCount),
+		book.Edition,
+	})
+}
+
+// optionalInt leaves the cell empty for a number the book doesn't have
+func optionalInt(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+func (e *csvExport) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type jsonlExport struct {
+	enc *json.Encoder
+}
+
+func (e *jsonlExport) Header() error {
+	return nil
+}
+
+// Encode ends every object with a newline, which is all JSON Lines asks for
+func (e *jsonlExport) Encode(book *models.BooksExport) error {
+	return e.enc.Encode(book)
+}
+
+func (e *jsonlExport) Flush() error {
+	return nil
+}
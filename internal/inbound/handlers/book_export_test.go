@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var exportedAt = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// exportBooks makes the usecase hand over books and then fail with err
+func exportBooks(err error, books ...models.BooksExport) func(context.Context, func(*models.BooksExport) error) error {
+	return func(_ context.Context, fn func(*models.BooksExport) error) error {
+		for _, book := range books {
+			if err := fn(&book); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+}
+
+func TestExportBooks(t *testing.T) {
+	bookA := models.BooksExport{ID: 1, Title: "Title A", Description: "Says \"hi\", twice", Qty: 3, Version: 2, CreatedAt: exportedAt, UpdatedAt: exportedAt}
+	year := 1965
+	bookB := models.BooksExport{ID: 2, Title: "Title B", Description: "Test Description", Qty: 0, Version: 1, CreatedAt: exportedAt, UpdatedAt: exportedAt,
+		BooksBibliography: models.BooksBibliography{ISBN: "9780441172719", Publisher: "Ace", PublicationYear: &year, Language: "en", Edition: "1st"}}
+
+	tests := []struct {
+		name                string
+		query               string
+		m                   func(mockuc *mocks.MockhandlerBookUsecase)
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:  "Success export books as csv by default",
+			query: "",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().ExportBooks(anyCtx, mock.Anything).RunAndReturn(exportBooks(nil, bookA, bookB))
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody: "id,title,description,qty,version,created_at,updated_at,isbn,publisher,publication_year,language,page_count,edition\n" +
+				"1,Title A,\"Says \"\"hi\"\", twice\",3,2,2025-01-02T03:04:05Z,2025-01-02T03:04:05Z,,,,,,\n" +
+				"2,Title B,Test Description,0,1,2025-01-02T03:04:05Z,2025-01-02T03:04:05Z,9780441172719,Ace,1965,en,,1st\n",
+		},
+		{
+			name:  "Success export books as json lines",
+			query: "format=jsonl",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().ExportBooks(anyCtx, mock.Anything).RunAndReturn(exportBooks(nil, bookB))
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/jsonl; charset=utf-8",
+			expectedBody:        `{"id":2,"title":"Title B","description":"Test Description","qty":0,"version":1,"created_at":"2025-01-02T03:04:05Z","updated_at":"2025-01-02T03:04:05Z","isbn":"9780441172719","publisher":"Ace","publication_year":1965,"language":"en","edition":"1st"}` + "\n",
+		},
+		{
+			name:  "Success export empty catalog as csv",
+			query: "format=csv",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().ExportBooks(anyCtx, mock.Anything).RunAndReturn(exportBooks(nil))
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        "id,title,description,qty,version,created_at,updated_at,isbn,publisher,publication_year,language,page_count,edition\n",
+		},
+		{
+			name:                "Failed export due to unknown format",
+			query:               "format=xlsx",
+			m:                   func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/json",
+			expectedBody:        `{"status":false,"code":"INVALID_PARAMETER","message":"invalid parameter"}` + "\n",
+		},
+		{
+			name:  "Failed export before the first row",
+			query: "format=csv",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().ExportBooks(anyCtx, mock.Anything).
+					RunAndReturn(exportBooks(fmt.Errorf("repository error: %w", models.ErrInternalServerError)))
+			},
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: "application/json",
+			expectedBody:        `{"status":false,"code":"INTERNAL_SERVER_ERROR","message":"internal server error"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := initialSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithQuery(http.MethodGet, "/books/export", tt.query, "", tc.Handler.ExportBooks)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Contains(t, rec.Header().Get("Content-Type"), tt.expectedContentType)
+			assert.Equal(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestExportBooksAbortsMidStream(t *testing.T) {
+	tc := initialSetup(t)
+	tc.Mock.EXPECT().ExportBooks(anyCtx, mock.Anything).
+		RunAndReturn(exportBooks(errors.New("connection reset"), models.BooksExport{ID: 1, Title: "Title A"}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		tc.executeRequestWithQuery(http.MethodGet, "/books/export", "format=jsonl", "", tc.Handler.ExportBooks)
+	})
+}
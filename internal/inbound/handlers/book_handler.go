@@ -1,21 +1,38 @@
 package handlers
 
 import (
+	"context"
 	"crud-echo/internal/inbound/customvalidator"
 	"crud-echo/internal/models"
-	"log"
+	"crud-echo/pkg/logger"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
 )
 
 type HandlerBookUsecase interface {
-	CreateBook(book *models.CreateBooksRequest) (*models.Books, error)
-	GetBookByID(id int) (*models.BooksSummary, error)
-	GetAllBooks(available bool) (*[]models.BooksSummary, error)
-	UpdateBook(book *models.UpdateBooksRequest) error
-	DeleteBook(book *models.DeleteBooksRequest) error
+	CreateBook(ctx context.Context, book *models.CreateBooksRequest) (*models.Books, error)
+	GetBookByID(ctx context.Context, id int, include models.BooksInclude) (*models.BooksSummary, error)
+	GetBookByISBN(ctx context.Context, isbn string, include models.BooksInclude) (*models.BooksSummary, error)
+	GetAllBooks(ctx context.Context, filter *models.BooksFilter, page *models.PageRequest, include models.BooksInclude) (*[]models.BooksSummary, *models.PageMeta, error)
+	SearchBooks(ctx context.Context, query string, limit int) (*[]models.BooksSearchResult, error)
+	UpdateBook(ctx context.Context, book *models.UpdateBooksRequest) error
+	PatchBook(ctx context.Context, id int, patch *models.BooksPatch) (*models.BooksSummary, error)
+	DeleteBook(ctx context.Context, book *models.DeleteBooksRequest) error
+	GetTrashedBooks(ctx context.Context, page *models.PageRequest) (*[]models.BooksSummary, *models.PageMeta, error)
+	RestoreBook(ctx context.Context, id int) (*models.BooksSummary, error)
+	PurgeBook(ctx context.Context, id int) error
+	BulkCreateBooks(ctx context.Context, books []models.CreateBooksRequest, atomic bool) ([]models.BulkResult, error)
+	BulkUpdateBooks(ctx context.Context, books []models.BulkUpdateBooksItem, atomic bool) ([]models.BulkResult, error)
+	BulkDeleteBooks(ctx context.Context, books []models.BulkDeleteBooksItem, atomic bool) ([]models.BulkResult, error)
+	ExportBooks(ctx context.Context, fn func(book *models.BooksExport) error) error
+	ImportBooks(ctx context.Context, rows []models.ImportRow, opts models.ImportOptions, report *models.ImportReport) error
+	MoveStock(ctx context.Context, id int, change models.StockChange) (*models.StockMoveResult, error)
+	GetStockHistory(ctx context.Context, id int, page *models.PageRequest) (*[]models.StockMovement, *models.PageMeta, error)
 }
 
 type BooksHandler struct {
@@ -23,6 +40,11 @@ type BooksHandler struct {
 	cv  *customvalidator.CustomValidator
 }
 
+// logFor returns the request scoped logger, the request ID is already on it
+func logFor(c echo.Context) *zap.Logger {
+	return logger.FromContext(c.Request().Context())
+}
+
 func NewBooksHandler(buc HandlerBookUsecase, validator *customvalidator.CustomValidator) *BooksHandler {
 	return &BooksHandler{buc: buc, cv: validator}
 }
@@ -31,19 +53,19 @@ func (h BooksHandler) CreateBook(c echo.Context) error {
 	var b models.CreateBooksRequest
 
 	if err := c.Bind(&b); err != nil {
-		log.Printf("Error binding request: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.BadRequest)
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
 	}
 
 	if err := h.cv.Validate(b); err != nil {
-		log.Printf("Error validating request: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrValidationError.Error())
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
 	}
 
-	_, err := h.buc.CreateBook(&b)
+	_, err := h.buc.CreateBook(c.Request().Context(), &b)
 	if err != nil {
-		log.Printf("Error creating book: %v", err)
-		return echo.NewHTTPError(models.GetErrorHTTPStatusCode(err), models.GetErrorHTTPStatusMessage(err))
+		logFor(c).Error("error creating book", zap.Error(err))
+		return err
 	}
 
 	return CustomResponse(c, http.StatusOK, true, "Book has been created", nil)
@@ -52,34 +74,121 @@ func (h BooksHandler) CreateBook(c echo.Context) error {
 func (h BooksHandler) GetBookByID(c echo.Context) error {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		log.Printf("Error converting id to integer: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.InvalidParam)
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	include, err := models.ParseBooksInclude(c.QueryParam("include"))
+	if err != nil {
+		logFor(c).Warn("error parsing include param", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
 	}
 
-	resp, err := h.buc.GetBookByID(id)
+	resp, err := h.buc.GetBookByID(c.Request().Context(), id, include)
 	if err != nil {
-		log.Printf("Error retrieving book with ID %d: %v", id, err)
-		return echo.NewHTTPError(models.GetErrorHTTPStatusCode(err), models.GetErrorHTTPStatusMessage(err))
+		logFor(c).Error("error retrieving book", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	// the version only covers the book itself, the authors linked to it
+	// don't change it
+	etag := bookETag(resp.Version)
+	c.Response().Header().Set(headerETag, etag)
+	if ifNoneMatch(c.Request().Header.Get(headerIfNoneMatch), etag) {
+		return c.NoContent(http.StatusNotModified)
 	}
 
 	// return c.JSON(http.StatusOK, resp)
 	return CustomResponse(c, http.StatusOK, true, "Book retrieved successfully", resp)
 }
 
+// GetBookByISBN takes an ISBN-10 or ISBN-13, hyphens and all, an ISBN-10
+// finds the book stored under its ISBN-13
+func (h BooksHandler) GetBookByISBN(c echo.Context) error {
+	isbn, err := models.NormalizeISBN(c.Param("isbn"))
+	if err != nil {
+		logFor(c).Warn("error reading isbn", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	include, err := models.ParseBooksInclude(c.QueryParam("include"))
+	if err != nil {
+		logFor(c).Warn("error parsing include param", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	resp, err := h.buc.GetBookByISBN(c.Request().Context(), isbn, include)
+	if err != nil {
+		logFor(c).Error("error retrieving book", zap.String("isbn", isbn), zap.Error(err))
+		return err
+	}
+
+	etag := bookETag(resp.Version)
+	c.Response().Header().Set(headerETag, etag)
+	if ifNoneMatch(c.Request().Header.Get(headerIfNoneMatch), etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Book retrieved successfully", resp)
+}
+
 func (h BooksHandler) GetAllBooks(c echo.Context) error {
-	available, err := strconv.ParseBool(c.QueryParam("available"))
+	var q models.GetAllBooksRequest
+
+	if err := c.Bind(&q); err != nil {
+		logFor(c).Warn("error binding query params", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(q); err != nil {
+		logFor(c).Warn("error validating query params", zap.Error(err))
+		return err
+	}
+
+	filter, page, err := parseBooksQuery(&q)
 	if err != nil {
-		log.Printf("Error parsing available param: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.BadRequest)
+		logFor(c).Warn("error parsing query params", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
 	}
 
-	resp, err := h.buc.GetAllBooks(available)
+	include, err := models.ParseBooksInclude(q.Include)
 	if err != nil {
-		log.Printf("Error retrieving books: %v", err)
-		return echo.NewHTTPError(models.GetErrorHTTPStatusCode(err), models.GetErrorHTTPStatusMessage(err))
+		logFor(c).Warn("error parsing include param", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	resp, meta, err := h.buc.GetAllBooks(c.Request().Context(), filter, page, include)
+	if err != nil {
+		logFor(c).Error("error retrieving books", zap.Error(err))
+		return err
+	}
+
+	return CustomResponseWithMeta(c, http.StatusOK, true, "Books retrieved successfully", resp, meta)
+}
+
+func (h BooksHandler) SearchBooks(c echo.Context) error {
+	var q models.SearchBooksRequest
+
+	if err := c.Bind(&q); err != nil {
+		logFor(c).Warn("error binding query params", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	q.Q = strings.TrimSpace(q.Q)
+	if err := h.cv.Validate(q); err != nil {
+		logFor(c).Warn("error validating query params", zap.Error(err))
+		return err
+	}
+	if q.Limit == 0 {
+		q.Limit = models.DefaultPageLimit
+	}
+
+	resp, err := h.buc.SearchBooks(c.Request().Context(), q.Q, q.Limit)
+	if err != nil {
+		logFor(c).Error("error searching books", zap.Error(err))
+		return err
 	}
 
-	// return c.JSON(http.StatusOK, resp)
 	return CustomResponse(c, http.StatusOK, true, "Books retrieved successfully", resp)
 }
 
@@ -87,40 +196,209 @@ func (h BooksHandler) UpdateBook(c echo.Context) error {
 	var b models.UpdateBooksRequest
 
 	if err := c.Bind(&b); err != nil {
-		log.Printf("Error binding request: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.BadRequest)
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
 	}
 
 	if err := h.cv.Validate(b); err != nil {
-		log.Printf("Error validating request: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrValidationError.Error())
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
 	}
 
-	if err := h.buc.UpdateBook(&b); err != nil {
-		log.Printf("Error updating book: %v", err)
-		return echo.NewHTTPError(models.GetErrorHTTPStatusCode(err), models.GetErrorHTTPStatusMessage(err))
+	version, err := parseIfMatch(c.Request().Header.Get(headerIfMatch))
+	if err != nil {
+		logFor(c).Warn("error reading If-Match header", zap.Error(err))
+		return err
 	}
+	b.Version = version
 
+	if err := h.buc.UpdateBook(c.Request().Context(), &b); err != nil {
+		logFor(c).Error("error updating book", zap.Error(err))
+		return err
+	}
+
+	c.Response().Header().Set(headerETag, bookETag(version+1))
 	return CustomResponse(c, http.StatusOK, true, "Book with ID "+strconv.Itoa(b.ID)+" has been updated", nil)
 }
 
+func (h BooksHandler) PatchBook(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	mediaType, err := patchMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if err != nil {
+		logFor(c).Warn("error reading content type", zap.Error(err))
+		return models.ErrUnsupportedMediaType.Wrap(err)
+	}
+
+	version, err := parseIfMatch(c.Request().Header.Get(headerIfMatch))
+	if err != nil {
+		logFor(c).Warn("error reading If-Match header", zap.Error(err))
+		return err
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		logFor(c).Warn("error reading request body", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	current, err := h.buc.GetBookByID(c.Request().Context(), id, models.BooksInclude{})
+	if err != nil {
+		logFor(c).Error("error retrieving book", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	// no point applying the patch to a copy the client hasn't seen
+	if current.Version != version {
+		logFor(c).Warn("error patching book: stale version", zap.Int("id", id), zap.Int("version", version))
+		return models.ErrPreconditionFailed
+	}
+
+	original := current.ToBooksPatchDocument()
+	patched, err := applyBooksPatch(mediaType, body, original)
+	if err != nil {
+		logFor(c).Warn("error applying patch", zap.Error(err))
+		return err
+	}
+
+	patch, fields := original.Diff(*patched)
+	if err := h.cv.ValidatePartial(patched, fields...); err != nil {
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
+	}
+	patch.Version = version
+
+	resp, err := h.buc.PatchBook(c.Request().Context(), id, patch)
+	if err != nil {
+		logFor(c).Error("error patching book", zap.Error(err))
+		return err
+	}
+
+	c.Response().Header().Set(headerETag, bookETag(resp.Version))
+	return CustomResponse(c, http.StatusOK, true, "Book with ID "+strconv.Itoa(id)+" has been updated", resp)
+}
+
 func (h BooksHandler) DeleteBook(c echo.Context) error {
 	var b models.DeleteBooksRequest
 
 	if err := c.Bind(&b); err != nil {
-		log.Printf("Error binding request: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.BadRequest)
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
 	}
 
 	if err := h.cv.Validate(b); err != nil {
-		log.Printf("Error validating request: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrValidationError.Error())
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
 	}
 
-	if err := h.buc.DeleteBook(&b); err != nil {
-		log.Printf("Error deleting book: %v", err)
-		return echo.NewHTTPError(models.GetErrorHTTPStatusCode(err), models.GetErrorHTTPStatusMessage(err))
+	version, err := parseIfMatch(c.Request().Header.Get(headerIfMatch))
+	if err != nil {
+		logFor(c).Warn("error reading If-Match header", zap.Error(err))
+		return err
+	}
+	b.Version = version
+
+	if err := h.buc.DeleteBook(c.Request().Context(), &b); err != nil {
+		logFor(c).Error("error deleting book", zap.Error(err))
+		return err
 	}
 
 	return CustomResponse(c, http.StatusOK, true, "Book with ID "+strconv.Itoa(b.ID)+" has been deleted", nil)
 }
+
+// DeleteBookByID trashes the book like DeleteBook, or with ?purge=true removes
+// it for good. The admin check for purging happens in the route middleware
+func (h BooksHandler) DeleteBookByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	purge, err := parseBoolParam(c.QueryParam("purge"))
+	if err != nil {
+		logFor(c).Warn("error parsing purge param", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	if purge {
+		if err := h.buc.PurgeBook(c.Request().Context(), id); err != nil {
+			logFor(c).Error("error purging book", zap.Int("id", id), zap.Error(err))
+			return err
+		}
+
+		return CustomResponse(c, http.StatusOK, true, "Book with ID "+strconv.Itoa(id)+" has been purged", nil)
+	}
+
+	version, err := parseIfMatch(c.Request().Header.Get(headerIfMatch))
+	if err != nil {
+		logFor(c).Warn("error reading If-Match header", zap.Error(err))
+		return err
+	}
+
+	if err := h.buc.DeleteBook(c.Request().Context(), &models.DeleteBooksRequest{ID: id, Version: version}); err != nil {
+		logFor(c).Error("error deleting book", zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Book with ID "+strconv.Itoa(id)+" has been deleted", nil)
+}
+
+func (h BooksHandler) GetTrashedBooks(c echo.Context) error {
+	var q models.GetTrashedBooksRequest
+
+	if err := c.Bind(&q); err != nil {
+		logFor(c).Warn("error binding query params", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(q); err != nil {
+		logFor(c).Warn("error validating query params", zap.Error(err))
+		return err
+	}
+
+	page := &models.PageRequest{
+		Limit:  q.Limit,
+		Offset: q.Offset,
+	}
+	if page.Limit == 0 {
+		page.Limit = models.DefaultPageLimit
+	}
+
+	resp, meta, err := h.buc.GetTrashedBooks(c.Request().Context(), page)
+	if err != nil {
+		logFor(c).Error("error retrieving trashed books", zap.Error(err))
+		return err
+	}
+
+	return CustomResponseWithMeta(c, http.StatusOK, true, "Books retrieved successfully", resp, meta)
+}
+
+func (h BooksHandler) RestoreBook(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	resp, err := h.buc.RestoreBook(c.Request().Context(), id)
+	if err != nil {
+		logFor(c).Error("error restoring book", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	c.Response().Header().Set(headerETag, bookETag(resp.Version))
+	return CustomResponse(c, http.StatusOK, true, "Book with ID "+strconv.Itoa(id)+" has been restored", resp)
+}
+
+// parseBoolParam treats a missing param as false
+func parseBoolParam(raw string) (bool, error) {
+	if raw == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(raw)
+}
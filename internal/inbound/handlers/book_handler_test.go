@@ -10,12 +10,17 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+// handlers pass the request context, which middleware is free to replace
+var anyCtx = mock.Anything
+
 type TestContext struct {
 	Echo    *echo.Echo
 	Handler *BooksHandler
@@ -27,7 +32,10 @@ func initialSetup(t *testing.T) *TestContext {
 	e.HTTPErrorHandler = CustomHTTPErrorHandler
 
 	mockUsecase := mocks.NewMockhandlerBookUsecase(t)
-	testValidator := &vc.CustomValidator{Validator: validator.New()}
+	testValidator, err := vc.NewCustomValidator(validator.New())
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
 
 	handler := NewBooksHandler(mockUsecase, testValidator)
 
@@ -132,10 +140,54 @@ func TestCreateBook(t *testing.T) {
 			name:        "Success create book",
 			requestBody: `{"title":"Test Book","description":"Test Description","qty":10}`,
 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
-				mockuc.EXPECT().CreateBook(&models.CreateBooksRequest{
+				mockuc.EXPECT().CreateBook(anyCtx, &models.CreateBooksRequest{
+					Title:       "Test Book",
+					Description: "Test Description",
+					Qty:         10,
+				}).Return(&models.Books{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Book has been created",
+				Data:    nil,
+			},
+		},
+		{
+			name:        "Success create book with authors",
+			requestBody: `{"title":"Test Book","description":"Test Description","qty":10,"author_ids":[7,8]}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().CreateBook(anyCtx, &models.CreateBooksRequest{
+					Title:       "Test Book",
+					Description: "Test Description",
+					Qty:         10,
+					AuthorIDs:   []int{7, 8},
+				}).Return(&models.Books{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Book has been created",
+				Data:    nil,
+			},
+		},
+		{
+			name:        "Success create book with bibliographic fields",
+			requestBody: `{"title":"Test Book","description":"Test Description","qty":10,"isbn":"0-441-17271-7","publisher":"Ace","publication_year":1990,"language":"en","page_count":541,"edition":"Reissue"}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				year, pages := 1990, 541
+				mockuc.EXPECT().CreateBook(anyCtx, &models.CreateBooksRequest{
 					Title:       "Test Book",
 					Description: "Test Description",
 					Qty:         10,
+					BooksBibliography: models.BooksBibliography{
+						ISBN:            "0-441-17271-7",
+						Publisher:       "Ace",
+						PublicationYear: &year,
+						Language:        "en",
+						PageCount:       &pages,
+						Edition:         "Reissue",
+					},
 				}).Return(&models.Books{}, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -145,6 +197,69 @@ func TestCreateBook(t *testing.T) {
 				Data:    nil,
 			},
 		},
+		{
+			name:           "Failed create book due to invalid bibliographic fields",
+			requestBody:    `{"title":"Test Book","description":"Test Description","qty":10,"isbn":"978-0-441-17271-8","language":"english!","page_count":0,"publication_year":10000}`,
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data: map[string]any{
+					"isbn":             "Should be a valid ISBN-10 or ISBN-13",
+					"language":         "Should be a language tag like en or pt-BR",
+					"page_count":       "Should be greater than or equal to 1",
+					"publication_year": "Should be less than or equal to 9999",
+				},
+			},
+		},
+		{
+			name:        "Failed create book due to ISBN taken",
+			requestBody: `{"title":"Test Book","description":"Test Description","qty":10,"isbn":"9780441172719"}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().CreateBook(anyCtx, &models.CreateBooksRequest{
+					Title:             "Test Book",
+					Description:       "Test Description",
+					Qty:               10,
+					BooksBibliography: models.BooksBibliography{ISBN: "9780441172719"},
+				}).Return(nil, fmt.Errorf("repository error: %w", models.ErrBookISBNTaken))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrBookISBNTaken.Message,
+				Data:    nil,
+			},
+		},
+		{
+			name:           "Failed create book due to duplicate authors",
+			requestBody:    `{"title":"Test Book","description":"Test Description","qty":10,"author_ids":[7,7]}`,
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"author_ids": "Should not contain duplicates"},
+			},
+		},
+		{
+			name:        "Failed create book due to unknown author",
+			requestBody: `{"title":"Test Book","description":"Test Description","qty":10,"author_ids":[99]}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().CreateBook(anyCtx, &models.CreateBooksRequest{
+					Title:       "Test Book",
+					Description: "Test Description",
+					Qty:         10,
+					AuthorIDs:   []int{99},
+				}).Return(nil, fmt.Errorf("repository error: %w", models.ErrUnknownAuthor))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrUnknownAuthor.Message,
+				Data:    nil,
+			},
+		},
 		{
 			name:           "Failed create book due to bind error",
 			requestBody:    `{,,,}`,
@@ -160,18 +275,33 @@ func TestCreateBook(t *testing.T) {
 			name:           "Failed create book due to validation error",
 			requestBody:    `{"title":"xx","description":"xx","qty":101}`,
 			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedResponse: Response{
 				Status:  false,
-				Message: models.ValidationError,
-				Data:    nil,
+				Message: models.ValidationFailed,
+				Data: map[string]any{
+					"title":       "Should be at least 3 characters long",
+					"description": "Should be at least 3 characters long",
+					"qty":         "Should be less than or equal to 100",
+				},
+			},
+		},
+		{
+			name:           "Failed create book due to title with leading space",
+			requestBody:    `{"title":" Test Book","description":"Test Description","qty":10}`,
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"title": "Should not start with a space"},
 			},
 		},
 		{
 			name:        "Failed create book due to book exist already",
 			requestBody: `{"title":"Test Book","description":"Test Description","qty":10}`,
 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
-				mockuc.EXPECT().CreateBook(&models.CreateBooksRequest{
+				mockuc.EXPECT().CreateBook(anyCtx, &models.CreateBooksRequest{
 					Title:       "Test Book",
 					Description: "Test Description",
 					Qty:         10,
@@ -188,7 +318,7 @@ func TestCreateBook(t *testing.T) {
 			name:        "Failed create book due to 0 ID", // don't know about this
 			requestBody: `{"title":"Test Book","description":"Test Description","qty":10}`,
 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
-				mockuc.EXPECT().CreateBook(&models.CreateBooksRequest{
+				mockuc.EXPECT().CreateBook(anyCtx, &models.CreateBooksRequest{
 					Title:       "Test Book",
 					Description: "Test Description",
 					Qty:         10,
@@ -222,22 +352,27 @@ func TestGetBookByID(t *testing.T) {
 	tests := []struct {
 		name             string
 		param            string
+		query            string
+		ifNoneMatch      string
 		m                func(mockuc *mocks.MockhandlerBookUsecase)
 		expectedStatus   int
+		expectedETag     string
 		expectedResponse Response
 	}{
 		{
 			name:  "Success get book by ID",
 			param: "1",
 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
-				mockuc.EXPECT().GetBookByID(1).Return(&models.BooksSummary{
+				mockuc.EXPECT().GetBookByID(anyCtx, 1, models.BooksInclude{}).Return(&models.BooksSummary{
 					ID:          1,
 					Title:       "Test Book",
 					Description: "Test Description",
 					Qty:         10,
+					Version:     2,
 				}, nil)
 			},
 			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
 			expectedResponse: Response{
 				Status:  true,
 				Message: "Book retrieved successfully",
@@ -249,6 +384,59 @@ func TestGetBookByID(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:        "Success get book by ID not modified",
+			param:       "1",
+			ifNoneMatch: `"1", W/"2"`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByID(anyCtx, 1, models.BooksInclude{}).Return(&models.BooksSummary{
+					ID:          1,
+					Title:       "Test Book",
+					Description: "Test Description",
+					Qty:         10,
+					Version:     2,
+				}, nil)
+			},
+			expectedStatus: http.StatusNotModified,
+			expectedETag:   `"2"`,
+		},
+		{
+			name:  "Success get book by ID with authors",
+			param: "1",
+			query: "include=authors",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByID(anyCtx, 1, models.BooksInclude{Authors: true}).Return(&models.BooksSummary{
+					ID:      1,
+					Title:   "Test Book",
+					Qty:     10,
+					Version: 2,
+					Authors: []models.AuthorsSummary{{ID: 7, Name: "Frank Herbert"}},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Book retrieved successfully",
+				Data: &models.BooksSummary{
+					ID:    1,
+					Title: "Test Book",
+					Qty:   10,
+				},
+			},
+		},
+		{
+			name:           "Failed get book by ID due to unknown include",
+			param:          "1",
+			query:          "include=publisher",
+			m:              func(mock *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "invalid parameter",
+				Data:    nil,
+			},
+		},
 		{
 			name:           "Failed get book by ID due to error converting ID param",
 			param:          "!@#$%^&*()",
@@ -264,7 +452,7 @@ func TestGetBookByID(t *testing.T) {
 			name:  "Failed get book by ID due to book not found",
 			param: "99",
 			m: func(mock *mocks.MockhandlerBookUsecase) {
-				mock.EXPECT().GetBookByID(99).
+				mock.EXPECT().GetBookByID(anyCtx, 99, models.BooksInclude{}).
 					Return(nil, fmt.Errorf("repository error: %w", models.ErrNotFound))
 			},
 			expectedStatus: http.StatusNotFound,
@@ -280,8 +468,28 @@ func TestGetBookByID(t *testing.T) {
 			tc := initialSetup(t)
 			tt.m(tc.Mock)
 
-			rec := tc.executeRequestWithParam(http.MethodPost, "/books/:id", "id", tt.param, "", tc.Handler.GetBookByID)
+			req := httptest.NewRequest(http.MethodGet, "/book/:id", nil)
+			req.URL.RawQuery = tt.query
+			if tt.ifNoneMatch != "" {
+				req.Header.Set(headerIfNoneMatch, tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := tc.Echo.NewContext(req, rec)
+			c.SetPath("/book/:id")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.param)
+
+			if err := tc.Handler.GetBookByID(c); err != nil {
+				tc.Echo.HTTPErrorHandler(err, c)
+			}
 			t.Logf("rec.Body.String(): %#v", rec.Body.String())
+
+			assert.Equal(t, tt.expectedETag, rec.Header().Get(headerETag))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+				assert.Empty(t, rec.Body.String())
+				return
+			}
 			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
 
 			assert.Equal(t, tt.expectedStatus, rec.Code)
@@ -305,238 +513,405 @@ func TestGetBookByID(t *testing.T) {
 	}
 }
 
-// func TestGetAllBooks(t *testing.T) {
-// 	tests := []struct {
-// 		name             string
-// 		param            string
-// 		m                func(mockuc *mocks.MockhandlerBookUsecase)
-// 		expectedStatus   int
-// 		expectedResponse Response
-// 	}{
-// 		{
-// 			name:  "Success get all books",
-// 			param: "true",
-// 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
-// 				mockuc.EXPECT().GetAllBooks(true).Return(&[]models.BooksSummary{
-// 					{
-// 						ID:          1,
-// 						Title:       "Test Book",
-// 						Description: "Test Description",
-// 						Qty:         10,
-// 					},
-// 					{
-// 						ID:          2,
-// 						Title:       "Test Book 2",
-// 						Description: "Test Description 2",
-// 						Qty:         20,
-// 					},
-// 				}, nil)
-// 			},
-// 			expectedStatus: http.StatusOK,
-// 			expectedResponse: Response{
-// 				Status:  true,
-// 				Message: "Books retrieved successfully",
-// 				Data: &[]models.BooksSummary{
-// 					{
-// 						ID:          1,
-// 						Title:       "Test Book",
-// 						Description: "Test Description",
-// 						Qty:         10,
-// 					},
-// 					{
-// 						ID:          2,
-// 						Title:       "Test Book 2",
-// 						Description: "Test Description 2",
-// 						Qty:         20,
-// 					},
-// 				},
-// 			},
-// 		},
-// 		{
-// 			name:           "Failed get all books due to error parsing available param",
-// 			param:          "!@#$%^&*()",
-// 			m:              func(mock *mocks.MockhandlerBookUsecase) {},
-// 			expectedStatus: http.StatusBadRequest,
-// 			expectedResponse: Response{
-// 				Status:  false,
-// 				Message: "bad request",
-// 				Data:    nil,
-// 			},
-// 		},
-// 		{
-// 			name:  "Failed get all books due to false available param",
-// 			param: "false",
-// 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
-// 				mockuc.EXPECT().GetAllBooks(false).Return(nil, fmt.Errorf("repository error: %w", models.ErrInvalidParam))
-// 			},
-// 			expectedStatus: http.StatusBadRequest,
-// 			expectedResponse: Response{
-// 				Status:  false,
-// 				Message: "invalid parameter",
-// 				Data:    nil,
-// 			},
-// 		},
-// 		{
-// 			name:  "Failed get all books due to empty table", // hmm maybe not fail?
-// 			param: "true",
-// 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
-// 				mockuc.EXPECT().GetAllBooks(true).Return(nil, fmt.Errorf("repository error: %w", models.ErrEmptyTable))
-// 			},
-// 			expectedStatus: http.StatusOK,
-// 			expectedResponse: Response{
-// 				Status:  false,
-// 				Message: "table is empty",
-// 				Data:    nil,
-// 			},
-// 		},
-// 	}
-// 	for _, tt := range tests {
-// 		t.Run(tt.name, func(t *testing.T) {
-// 			e, handler, mock := initialSetup(t)
-// 			tt.m(mock)
-//
-// 			expectedResponseBytes, err := json.Marshal(tt.expectedResponse)
-// 			if err != nil {
-// 				t.Fatalf("Failed to marshal expected response: %v", err)
-// 			}
-//
-// 			req := httptest.NewRequest(http.MethodPost, "/", nil)
-// 			req.URL.RawQuery = fmt.Sprintf("available=%s", tt.param)
-// 			rec := httptest.NewRecorder()
-// 			c := e.NewContext(req, rec)
-//
-// 			err = handler.GetAllBooks(c)
-// 			if err != nil {
-// 				e.HTTPErrorHandler(err, c)
-// 			}
-//
-// 			assert.Equal(t, tt.expectedStatus, rec.Code)
-// 			assert.JSONEq(t, string(expectedResponseBytes), rec.Body.String())
-// 		})
-// 	}
-// }
-//
-// func TestUpdateBook(t *testing.T) {
-// 	tests := []struct {
-// 		name             string
-// 		requestBody      string
-// 		m                func(mockuc *mocks.MockhandlerBookUsecase)
-// 		expectedStatus   int
-// 		expectedResponse Response
-// 	}{
-// 		{
-// 			name:        "Success update book",
-// 			requestBody: `{"id": 1,"title":"Test Book","description":"Test Description","qty":10}`,
-// 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
-// 				mockuc.EXPECT().UpdateBook(&models.UpdateBooksRequest{
-// 					ID:          1,
-// 					Title:       "Test Book",
-// 					Description: "Test Description",
-// 					Qty:         10,
-// 				}).Return(nil)
-// 			},
-// 			expectedStatus: http.StatusOK,
-// 			expectedResponse: Response{
-// 				Status:  true,
-// 				Message: "Book with ID 1 has been updated",
-// 				Data:    nil,
-// 			},
-// 		},
-// 		{
-// 			name:           "Failed update book due to bind error",
-// 			requestBody:    `{,,,}`,
-// 			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
-// 			expectedStatus: http.StatusBadRequest,
-// 			expectedResponse: Response{
-// 				Status:  false,
-// 				Message: models.BadRequest,
-// 				Data:    nil,
-// 			},
-// 		},
-// 		{
-// 			name:           "Failed update book due to validation error",
-// 			requestBody:    `{"id": -1,"title":"xx","description":"xx","qty":101}`,
-// 			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
-// 			expectedStatus: http.StatusBadRequest,
-// 			expectedResponse: Response{
-// 				Status:  false,
-// 				Message: models.ValidationError,
-// 				Data:    nil,
-// 			},
-// 		},
-// 		{
-// 			name:        "Failed update book due to book not found",
-// 			requestBody: `{"id": 1,"title":"Test Book","description":"Test Description","qty":10}`,
-// 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
-// 				mockuc.EXPECT().UpdateBook(&models.UpdateBooksRequest{
-// 					ID:          1,
-// 					Title:       "Test Book",
-// 					Description: "Test Description",
-// 					Qty:         10,
-// 				}).Return(fmt.Errorf("repository error: %w", models.ErrNotFound))
-// 			},
-// 			expectedStatus: http.StatusNotFound,
-// 			expectedResponse: Response{
-// 				Status:  false,
-// 				Message: models.NotFound,
-// 				Data:    nil,
-// 			},
-// 		},
-// 	}
-// 	for _, tt := range tests {
-// 		t.Run(tt.name, func(t *testing.T) {
-// 			e, handler, mock := initialSetup(t)
-// 			tt.m(mock)
-//
-// 			expectedResponseBytes, err := json.Marshal(tt.expectedResponse)
-// 			if err != nil {
-// 				t.Fatalf("Failed to marshal expected response: %v", err)
-// 			}
-//
-// 			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.requestBody))
-// 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
-// 			rec := httptest.NewRecorder()
-// 			c := e.NewContext(req, rec)
-//
-// 			err = handler.UpdateBook(c)
-// 			if err != nil {
-// 				e.HTTPErrorHandler(err, c)
-// 			}
-//
-// 			assert.Equal(t, tt.expectedStatus, rec.Code)
-// 			assert.JSONEq(t, string(expectedResponseBytes), rec.Body.String())
-// 		})
-// 	}
-// }
-//
-// func TestDeleteBook(t *testing.T) {
+func TestGetBookByISBN(t *testing.T) {
+	tests := []struct {
+		name             string
+		param            string
+		m                func(mockuc *mocks.MockhandlerBookUsecase)
+		expectedStatus   int
+		expectedETag     string
+		expectedResponse Response
+	}{
+		{
+			name:  "Success get book by ISBN-13",
+			param: "978-0-441-17271-9",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByISBN(anyCtx, "9780441172719", models.BooksInclude{}).Return(&models.BooksSummary{
+					ID:                1,
+					Title:             "Dune",
+					Version:           2,
+					BooksBibliography: models.BooksBibliography{ISBN: "9780441172719"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Book retrieved successfully",
+				Data:    map[string]any{"id": float64(1), "title": "Dune", "description": "", "qty": float64(0), "version": float64(2), "isbn": "9780441172719"},
+			},
+		},
+		{
+			name:  "Success get book by ISBN-10",
+			param: "0441172717",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByISBN(anyCtx, "9780441172719", models.BooksInclude{}).Return(&models.BooksSummary{
+					ID:                1,
+					Title:             "Dune",
+					Version:           2,
+					BooksBibliography: models.BooksBibliography{ISBN: "9780441172719"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Book retrieved successfully",
+				Data:    map[string]any{"id": float64(1), "title": "Dune", "description": "", "qty": float64(0), "version": float64(2), "isbn": "9780441172719"},
+			},
+		},
+		{
+			name:           "Failed get book by ISBN due to bad check digit",
+			param:          "9780441172718",
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.InvalidParam,
+				Data:    nil,
+			},
+		},
+		{
+			name:  "Failed get book by ISBN due to book not found",
+			param: "9780441172719",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByISBN(anyCtx, "9780441172719", models.BooksInclude{}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrBookNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrBookNotFound.Message,
+				Data:    nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := initialSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodGet, "/book/isbn/:isbn", "isbn", tt.param, "", tc.Handler.GetBookByISBN)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedETag, rec.Header().Get(headerETag))
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestGetAllBooks(t *testing.T) {
+	cursor := models.Cursor{ID: 2, CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Direction: models.CursorDirectionNext}
+	available := true
+	qtyMin, qtyMax := 5, 50
+	createdAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		query            string
+		m                func(mockuc *mocks.MockhandlerBookUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success get all books with default limit",
+			query: "",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetAllBooks(anyCtx, &models.BooksFilter{}, &models.PageRequest{Limit: models.DefaultPageLimit}, models.BooksInclude{}).
+					Return(&[]models.BooksSummary{
+						{
+							ID:          1,
+							Title:       "Test Book",
+							Description: "Test Description",
+							Qty:         10,
+						},
+					}, &models.PageMeta{Total: 1, Limit: models.DefaultPageLimit}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Books retrieved successfully",
+				Data: []any{
+					map[string]any{"id": float64(1), "title": "Test Book", "description": "Test Description", "qty": float64(10)},
+				},
+				Meta: map[string]any{"total": float64(1), "limit": float64(models.DefaultPageLimit)},
+			},
+		},
+		{
+			name:  "Success get all books with authors",
+			query: "include=authors",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetAllBooks(anyCtx, &models.BooksFilter{}, &models.PageRequest{Limit: models.DefaultPageLimit}, models.BooksInclude{Authors: true}).
+					Return(&[]models.BooksSummary{
+						{
+							ID:          1,
+							Title:       "Dune",
+							Description: "Test Description",
+							Qty:         10,
+							Authors:     []models.AuthorsSummary{{ID: 7, Name: "Frank Herbert"}},
+						},
+					}, &models.PageMeta{Total: 1, Limit: models.DefaultPageLimit}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Books retrieved successfully",
+				Data: []any{
+					map[string]any{
+						"id":          float64(1),
+						"title":       "Dune",
+						"description": "Test Description",
+						"qty":         float64(10),
+						"authors":     []any{map[string]any{"id": float64(7), "name": "Frank Herbert"}},
+					},
+				},
+				Meta: map[string]any{"total": float64(1), "limit": float64(models.DefaultPageLimit)},
+			},
+		},
+		{
+			name:           "Failed get all books due to unknown include",
+			query:          "include=authors,publisher",
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "invalid parameter",
+			},
+		},
+		{
+			name:  "Success get all books by category and tags",
+			query: "category=fiction&tags=Classic,%20award,classic",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetAllBooks(anyCtx, &models.BooksFilter{Category: "fiction", Tags: []string{"classic", "award"}}, &models.PageRequest{Limit: models.DefaultPageLimit}, models.BooksInclude{}).
+					Return(&[]models.BooksSummary{}, &models.PageMeta{Limit: models.DefaultPageLimit}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Books retrieved successfully",
+				Data:    []any{},
+				Meta:    map[string]any{"total": float64(0), "limit": float64(models.DefaultPageLimit)},
+			},
+		},
+		{
+			name:           "Failed get all books due to invalid category slug",
+			query:          "category=Science%20Fiction",
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"category": "Should be lowercase letters and digits separated by single hyphens"},
+			},
+		},
+		{
+			name:           "Failed get all books due to empty tag",
+			query:          "tags=classic,,award",
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "invalid parameter",
+			},
+		},
+		{
+			name:  "Success get all books with cursor",
+			query: "limit=5&cursor=" + cursor.Encode(),
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetAllBooks(anyCtx, &models.BooksFilter{}, &models.PageRequest{Limit: 5, Cursor: &cursor}, models.BooksInclude{}).
+					Return(&[]models.BooksSummary{}, &models.PageMeta{Total: 2, Limit: 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Books retrieved successfully",
+				Data:    []any{},
+				Meta:    map[string]any{"total": float64(2), "limit": float64(5)},
+			},
+		},
+		{
+			name:  "Success get all books with filters and sort",
+			query: "title=%20dune%20&qty_min=5&qty_max=50&created_after=2025-01-01T00:00:00Z&available=true&sort=title,-created_at&offset=10",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetAllBooks(anyCtx, &models.BooksFilter{
+					Title:        "dune",
+					QtyMin:       &qtyMin,
+					QtyMax:       &qtyMax,
+					CreatedAfter: &createdAfter,
+					Available:    &available,
+				}, &models.PageRequest{
+					Limit:  models.DefaultPageLimit,
+					Offset: 10,
+					Sort:   []models.SortField{{Column: "title"}, {Column: "created_at", Desc: true}},
+				}, models.BooksInclude{}).Return(&[]models.BooksSummary{}, &models.PageMeta{Total: 0, Limit: models.DefaultPageLimit, Offset: 10}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Books retrieved successfully",
+				Data:    []any{},
+				Meta:    map[string]any{"total": float64(0), "limit": float64(models.DefaultPageLimit), "offset": float64(10)},
+			},
+		},
+		{
+			name:           "Failed get all books due to unknown sort column",
+			query:          "sort=title,description",
+			m:              func(mock *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.InvalidParam,
+			},
+		},
+		{
+			name:           "Failed get all books due to sort injection attempt",
+			query:          "sort=title%3BDROP%20TABLE%20books",
+			m:              func(mock *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.InvalidParam,
+			},
+		},
+		{
+			name:           "Failed get all books due to qty_min greater than qty_max",
+			query:          "qty_min=10&qty_max=5",
+			m:              func(mock *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.InvalidParam,
+			},
+		},
+		{
+			name:           "Failed get all books due to malformed created_before",
+			query:          "created_before=yesterday",
+			m:              func(mock *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.BadRequest,
+			},
+		},
+		{
+			name:           "Failed get all books due to cursor combined with sort",
+			query:          "sort=title&cursor=" + cursor.Encode(),
+			m:              func(mock *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"cursor": "Can't be combined with offset, sort"},
+			},
+		},
+		{
+			name:           "Failed get all books due to error parsing available param",
+			query:          "available=notabool",
+			m:              func(mock *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.BadRequest,
+			},
+		},
+		{
+			name:           "Failed get all books due to limit out of range",
+			query:          "limit=1000",
+			m:              func(mock *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"limit": "Should be less than or equal to 100"},
+			},
+		},
+		{
+			name:           "Failed get all books due to cursor combined with offset",
+			query:          "offset=10&cursor=" + cursor.Encode(),
+			m:              func(mock *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"cursor": "Can't be combined with offset, sort"},
+			},
+		},
+		{
+			name:           "Failed get all books due to malformed cursor",
+			query:          "cursor=not-a-cursor",
+			m:              func(mock *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.InvalidParam,
+			},
+		},
+		{
+			name:  "Success get all books from an empty table",
+			query: "",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetAllBooks(anyCtx, &models.BooksFilter{}, &models.PageRequest{Limit: models.DefaultPageLimit}, models.BooksInclude{}).
+					Return(&[]models.BooksSummary{}, &models.PageMeta{Total: 0, Limit: models.DefaultPageLimit}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Books retrieved successfully",
+				Data:    []any{},
+				Meta:    map[string]any{"total": float64(0), "limit": float64(models.DefaultPageLimit)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := initialSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithQuery(http.MethodGet, "/books", tt.query, "", tc.Handler.GetAllBooks)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+			assert.Equal(t, tt.expectedResponse.Meta, actualResponse.Meta)
+		})
+	}
+}
+
+// func TestUpdateBook(t *testing.T) {
 // 	tests := []struct {
 // 		name             string
 // 		requestBody      string
-// 		m                func(mock *mocks.MockhandlerBookUsecase)
+// 		m                func(mockuc *mocks.MockhandlerBookUsecase)
 // 		expectedStatus   int
 // 		expectedResponse Response
 // 	}{
 // 		{
 // 			name:        "Success update book",
-// 			requestBody: `{"id": 1}`,
+// 			requestBody: `{"id": 1,"title":"Test Book","description":"Test Description","qty":10}`,
 // 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
-// 				mockuc.EXPECT().DeleteBook(&models.DeleteBooksRequest{
-// 					ID: 1,
+// 				mockuc.EXPECT().UpdateBook(anyCtx, &models.UpdateBooksRequest{
+// 					ID:          1,
+// 					Title:       "Test Book",
+// 					Description: "Test Description",
+// 					Qty:         10,
 // 				}).Return(nil)
 // 			},
 // 			expectedStatus: http.StatusOK,
 // 			expectedResponse: Response{
 // 				Status:  true,
-// 				Message: "Book with ID 1 has been deleted",
+// 				Message: "Book with ID 1 has been updated",
 // 				Data:    nil,
 // 			},
 // 		},
 // 		{
-// 			name:        "Failed delete book due to bind error",
-// 			requestBody: `{,,,}`,
-// 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
-// 			},
+// 			name:           "Failed update book due to bind error",
+// 			requestBody:    `{,,,}`,
+// 			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
 // 			expectedStatus: http.StatusBadRequest,
 // 			expectedResponse: Response{
 // 				Status:  false,
@@ -545,24 +920,115 @@ func TestGetBookByID(t *testing.T) {
 // 			},
 // 		},
 // 		{
-// 			name:        "Failed delete book due to validation error",
-// 			requestBody: `{"id": -1}`,
-// 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
-// 			},
+// 			name:           "Failed update book due to validation error",
+// 			requestBody:    `{"id": -1,"title":"xx","description":"xx","qty":101}`,
+// 			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
 // 			expectedStatus: http.StatusBadRequest,
 // 			expectedResponse: Response{
 // 				Status:  false,
-// 				Message: models.ValidationError,
+// 				Message: models.ValidationFailed,
 // 				Data:    nil,
 // 			},
 // 		},
 // 		{
-// 			name:        "Failed delete book due to book not found",
-// 			requestBody: `{"id": 1}`,
+// 			name:        "Failed update book due to book not found",
+// 			requestBody: `{"id": 1,"title":"Test Book","description":"Test Description","qty":10}`,
 // 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
-// 				mockuc.EXPECT().DeleteBook(&models.DeleteBooksRequest{
-// 					ID: 1,
-// 				}).Return(fmt.Errorf("repository error: %w", models.ErrNotFound))
+// 				mockuc.EXPECT().UpdateBook(anyCtx, &models.UpdateBooksRequest{
+// 					ID:          1,
+// 					Title:       "Test Book",
+// 					Description: "Test Description",
+// 					Qty:         10,
+// 				}).Return(fmt.Errorf("repository error: %w", models.ErrNotFound))
+// 			},
+// 			expectedStatus: http.StatusNotFound,
+// 			expectedResponse: Response{
+// 				Status:  false,
+// 				Message: models.NotFound,
+// 				Data:    nil,
+// 			},
+// 		},
+// 	}
+// 	for _, tt := range tests {
+// 		t.Run(tt.name, func(t *testing.T) {
+// 			e, handler, mock := initialSetup(t)
+// 			tt.m(mock)
+//
+// 			expectedResponseBytes, err := json.Marshal(tt.expectedResponse)
+// 			if err != nil {
+// 				t.Fatalf("Failed to marshal expected response: %v", err)
+// 			}
+//
+// 			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.requestBody))
+// 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+// 			rec := httptest.NewRecorder()
+// 			c := e.NewContext(req, rec)
+//
+// 			err = handler.UpdateBook(c)
+// 			if err != nil {
+// 				e.HTTPErrorHandler(err, c)
+// 			}
+//
+// 			assert.Equal(t, tt.expectedStatus, rec.Code)
+// 			assert.JSONEq(t, string(expectedResponseBytes), rec.Body.String())
+// 		})
+// 	}
+// }
+//
+// func TestDeleteBook(t *testing.T) {
+// 	tests := []struct {
+// 		name             string
+// 		requestBody      string
+// 		m                func(mock *mocks.MockhandlerBookUsecase)
+// 		expectedStatus   int
+// 		expectedResponse Response
+// 	}{
+// 		{
+// 			name:        "Success update book",
+// 			requestBody: `{"id": 1}`,
+// 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+// 				mockuc.EXPECT().DeleteBook(anyCtx, &models.DeleteBooksRequest{
+// 					ID: 1,
+// 				}).Return(nil)
+// 			},
+// 			expectedStatus: http.StatusOK,
+// 			expectedResponse: Response{
+// 				Status:  true,
+// 				Message: "Book with ID 1 has been deleted",
+// 				Data:    nil,
+// 			},
+// 		},
+// 		{
+// 			name:        "Failed delete book due to bind error",
+// 			requestBody: `{,,,}`,
+// 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+// 			},
+// 			expectedStatus: http.StatusBadRequest,
+// 			expectedResponse: Response{
+// 				Status:  false,
+// 				Message: models.BadRequest,
+// 				Data:    nil,
+// 			},
+// 		},
+// 		{
+// 			name:        "Failed delete book due to validation error",
+// 			requestBody: `{"id": -1}`,
+// 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+// 			},
+// 			expectedStatus: http.StatusBadRequest,
+// 			expectedResponse: Response{
+// 				Status:  false,
+// 				Message: models.ValidationFailed,
+// 				Data:    nil,
+// 			},
+// 		},
+// 		{
+// 			name:        "Failed delete book due to book not found",
+// 			requestBody: `{"id": 1}`,
+// 			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+// 				mockuc.EXPECT().DeleteBook(anyCtx, &models.DeleteBooksRequest{
+// 					ID: 1,
+// 				}).Return(fmt.Errorf("repository error: %w", models.ErrNotFound))
 // 			},
 // 			expectedStatus: http.StatusNotFound,
 // 			expectedResponse: Response{
@@ -597,3 +1063,632 @@ func TestGetBookByID(t *testing.T) {
 // 		})
 // 	}
 // }
+
+func TestPatchBook(t *testing.T) {
+	current := &models.BooksSummary{
+		ID:          1,
+		Title:       "Test Book",
+		Description: "Test Description",
+		Qty:         10,
+		Version:     3,
+	}
+	zero := 0
+	title := "Patched Book"
+	isbn := "0-441-17271-7"
+	publisher := "Ace"
+	year := 1965
+	yearRef := &year
+
+	tests := []struct {
+		name             string
+		param            string
+		contentType      string
+		ifMatch          string
+		requestBody      string
+		m                func(mockuc *mocks.MockhandlerBookUsecase)
+		expectedStatus   int
+		expectedETag     string
+		expectedResponse Response
+	}{
+		{
+			name:        "Success merge patch qty to zero",
+			param:       "1",
+			contentType: models.MIMEApplicationMergePatch,
+			ifMatch:     `"3"`,
+			requestBody: `{"qty":0}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByID(anyCtx, 1, models.BooksInclude{}).Return(current, nil)
+				mockuc.EXPECT().PatchBook(anyCtx, 1, &models.BooksPatch{Qty: &zero, Version: 3}).
+					Return(&models.BooksSummary{ID: 1, Title: "Test Book", Description: "Test Description", Qty: 0, Version: 4}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Book with ID 1 has been updated",
+				Data:    map[string]any{"id": float64(1), "title": "Test Book", "description": "Test Description", "qty": float64(0), "version": float64(4)},
+			},
+		},
+		{
+			name:        "Success json patch with passing test op",
+			param:       "1",
+			contentType: models.MIMEApplicationJSONPatch,
+			ifMatch:     `"3"`,
+			requestBody: `[{"op":"test","path":"/qty","value":10},{"op":"replace","path":"/title","value":"Patched Book"}]`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByID(anyCtx, 1, models.BooksInclude{}).Return(current, nil)
+				mockuc.EXPECT().PatchBook(anyCtx, 1, &models.BooksPatch{Title: &title, Version: 3}).
+					Return(&models.BooksSummary{ID: 1, Title: "Patched Book", Description: "Test Description", Qty: 10, Version: 4}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Book with ID 1 has been updated",
+				Data:    map[string]any{"id": float64(1), "title": "Patched Book", "description": "Test Description", "qty": float64(10), "version": float64(4)},
+			},
+		},
+		{
+			name:        "Success merge patch bibliography",
+			param:       "1",
+			contentType: models.MIMEApplicationMergePatch,
+			ifMatch:     `"3"`,
+			requestBody: `{"isbn":"0-441-17271-7","publication_year":1965}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByID(anyCtx, 1, models.BooksInclude{}).Return(current, nil)
+				mockuc.EXPECT().PatchBook(anyCtx, 1, &models.BooksPatch{ISBN: &isbn, PublicationYear: &yearRef, Version: 3}).
+					Return(&models.BooksSummary{ID: 1, Title: "Test Book", Description: "Test Description", Qty: 10, Version: 4,
+						BooksBibliography: models.BooksBibliography{ISBN: "9780441172719", Publisher: "Ace", PublicationYear: &year}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Book with ID 1 has been updated",
+				Data: map[string]any{"id": float64(1), "title": "Test Book", "description": "Test Description", "qty": float64(10), "version": float64(4),
+					"isbn": "9780441172719", "publisher": "Ace", "publication_year": float64(1965)},
+			},
+		},
+		{
+			name:        "Success json patch adds publisher the book doesn't have",
+			param:       "1",
+			contentType: models.MIMEApplicationJSONPatch,
+			ifMatch:     `"3"`,
+			requestBody: `[{"op":"replace","path":"/publisher","value":"Ace"}]`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByID(anyCtx, 1, models.BooksInclude{}).Return(current, nil)
+				mockuc.EXPECT().PatchBook(anyCtx, 1, &models.BooksPatch{Publisher: &publisher, Version: 3}).
+					Return(&models.BooksSummary{ID: 1, Title: "Test Book", Description: "Test Description", Qty: 10, Version: 4,
+						BooksBibliography: models.BooksBibliography{Publisher: "Ace"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Book with ID 1 has been updated",
+				Data: map[string]any{"id": float64(1), "title": "Test Book", "description": "Test Description", "qty": float64(10), "version": float64(4),
+					"publisher": "Ace"},
+			},
+		},
+		{
+			name:        "Failed patch book due to invalid isbn",
+			param:       "1",
+			contentType: models.MIMEApplicationMergePatch,
+			ifMatch:     `"3"`,
+			requestBody: `{"isbn":"978-0-441-17271-0"}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByID(anyCtx, 1, models.BooksInclude{}).Return(current, nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"isbn": "Should be a valid ISBN-10 or ISBN-13"},
+			},
+		},
+		{
+			name:           "Failed patch book due to unsupported content type",
+			param:          "1",
+			contentType:    echo.MIMETextPlain,
+			ifMatch:        `"3"`,
+			requestBody:    `qty=0`,
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.UnsupportedMediaType,
+			},
+		},
+		{
+			name:        "Failed patch book due to malformed merge patch",
+			param:       "1",
+			contentType: models.MIMEApplicationMergePatch,
+			ifMatch:     `"3"`,
+			requestBody: `{,,,}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByID(anyCtx, 1, models.BooksInclude{}).Return(current, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.BadRequest,
+			},
+		},
+		{
+			name:        "Failed patch book due to patching id",
+			param:       "1",
+			contentType: models.MIMEApplicationMergePatch,
+			ifMatch:     `"3"`,
+			requestBody: `{"id":2}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByID(anyCtx, 1, models.BooksInclude{}).Return(current, nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+			},
+		},
+		{
+			name:        "Failed patch book due to removing required title",
+			param:       "1",
+			contentType: models.MIMEApplicationMergePatch,
+			ifMatch:     `"3"`,
+			requestBody: `{"title":null}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByID(anyCtx, 1, models.BooksInclude{}).Return(current, nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"title": "This field is required"},
+			},
+		},
+		{
+			name:        "Failed patch book due to failing test op",
+			param:       "1",
+			contentType: models.MIMEApplicationJSONPatch,
+			ifMatch:     `"3"`,
+			requestBody: `[{"op":"test","path":"/qty","value":3},{"op":"replace","path":"/qty","value":0}]`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByID(anyCtx, 1, models.BooksInclude{}).Return(current, nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+			},
+		},
+		{
+			name:        "Failed patch book due to qty out of range",
+			param:       "1",
+			contentType: models.MIMEApplicationMergePatch,
+			ifMatch:     `"3"`,
+			requestBody: `{"qty":101}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByID(anyCtx, 1, models.BooksInclude{}).Return(current, nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"qty": "Should be less than or equal to 100"},
+			},
+		},
+		{
+			name:        "Failed patch book due to book not found",
+			param:       "99",
+			contentType: models.MIMEApplicationMergePatch,
+			ifMatch:     `"3"`,
+			requestBody: `{"qty":0}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByID(anyCtx, 99, models.BooksInclude{}).Return(nil, fmt.Errorf("repository error: %w", models.ErrNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.NotFound,
+			},
+		},
+		{
+			name:           "Failed patch book due to missing If-Match",
+			param:          "1",
+			contentType:    models.MIMEApplicationMergePatch,
+			requestBody:    `{"qty":0}`,
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.PreconditionRequired,
+			},
+		},
+		{
+			name:        "Failed patch book due to stale If-Match",
+			param:       "1",
+			contentType: models.MIMEApplicationMergePatch,
+			ifMatch:     `"2"`,
+			requestBody: `{"qty":0}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByID(anyCtx, 1, models.BooksInclude{}).Return(current, nil)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.PreconditionFailed,
+			},
+		},
+		{
+			name:        "Failed patch book due to concurrent write",
+			param:       "1",
+			contentType: models.MIMEApplicationMergePatch,
+			ifMatch:     `"3"`,
+			requestBody: `{"qty":0}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetBookByID(anyCtx, 1, models.BooksInclude{}).Return(current, nil)
+				mockuc.EXPECT().PatchBook(anyCtx, 1, &models.BooksPatch{Qty: &zero, Version: 3}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrPreconditionFailed))
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.PreconditionFailed,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := initialSetup(t)
+			tt.m(tc.Mock)
+
+			req := httptest.NewRequest(http.MethodPatch, "/book/"+tt.param, bytes.NewBufferString(tt.requestBody))
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+			if tt.ifMatch != "" {
+				req.Header.Set(headerIfMatch, tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := tc.Echo.NewContext(req, rec)
+			c.SetPath("/book/:id")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.param)
+
+			if err := tc.Handler.PatchBook(c); err != nil {
+				tc.Echo.HTTPErrorHandler(err, c)
+			}
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedETag, rec.Header().Get(headerETag))
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestSearchBooks(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		m                func(mockuc *mocks.MockhandlerBookUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success search books",
+			query: "q=%20dune%20&limit=5",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().SearchBooks(anyCtx, "dune", 5).Return(&[]models.BooksSearchResult{
+					{
+						BooksSummary: models.BooksSummary{ID: 2, Title: "Dune", Description: "Desert planet", Qty: 3},
+						Rank:         0.5,
+						Snippet:      "<mark>Dune</mark> - Desert planet",
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Books retrieved successfully",
+				Data: []any{
+					map[string]any{
+						"id":          float64(2),
+						"title":       "Dune",
+						"description": "Desert planet",
+						"qty":         float64(3),
+						"rank":        0.5,
+						"snippet":     "<mark>Dune</mark> - Desert planet",
+					},
+				},
+			},
+		},
+		{
+			name:  "Success search books with default limit",
+			query: "q=dune",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().SearchBooks(anyCtx, "dune", models.DefaultPageLimit).Return(&[]models.BooksSearchResult{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Books retrieved successfully",
+				Data:    []any{},
+			},
+		},
+		{
+			name:           "Failed search books due to missing query",
+			query:          "q=%20%20",
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"q": "This field is required"},
+			},
+		},
+		{
+			name:  "Failed search books due to usecase error",
+			query: "q=dune",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().SearchBooks(anyCtx, "dune", models.DefaultPageLimit).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrInternalServerError))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.InternalServerError,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := initialSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithQuery(http.MethodGet, "/books/search", tt.query, "", tc.Handler.SearchBooks)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestDeleteBookByID(t *testing.T) {
+	tests := []struct {
+		name             string
+		param            string
+		query            string
+		ifMatch          string
+		m                func(mockuc *mocks.MockhandlerBookUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:    "Success move book to trash",
+			param:   "1",
+			ifMatch: `"2"`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().DeleteBook(anyCtx, &models.DeleteBooksRequest{ID: 1, Version: 2}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Book with ID 1 has been deleted",
+			},
+		},
+		{
+			name:  "Success purge book",
+			param: "1",
+			query: "purge=true",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().PurgeBook(anyCtx, 1).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Book with ID 1 has been purged",
+			},
+		},
+		{
+			name:           "Failed delete book due to missing If-Match",
+			param:          "1",
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.PreconditionRequired,
+			},
+		},
+		{
+			name:           "Failed delete book due to invalid purge param",
+			param:          "1",
+			query:          "purge=maybe",
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.InvalidParam,
+			},
+		},
+		{
+			name:  "Failed purge book due to book not found",
+			param: "99",
+			query: "purge=true",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().PurgeBook(anyCtx, 99).Return(fmt.Errorf("repository error: %w", models.ErrNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.NotFound,
+			},
+		},
+		{
+			name:  "Failed purge book due to open loans",
+			param: "1",
+			query: "purge=true",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().PurgeBook(anyCtx, 1).Return(fmt.Errorf("repository error: %w", models.ErrBookHasOpenLoans))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrBookHasOpenLoans.Message,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := initialSetup(t)
+			tt.m(tc.Mock)
+
+			req := httptest.NewRequest(http.MethodDelete, "/book/"+tt.param, nil)
+			req.URL.RawQuery = tt.query
+			if tt.ifMatch != "" {
+				req.Header.Set(headerIfMatch, tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := tc.Echo.NewContext(req, rec)
+			c.SetPath("/book/:id")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.param)
+
+			if err := tc.Handler.DeleteBookByID(c); err != nil {
+				tc.Echo.HTTPErrorHandler(err, c)
+			}
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Nil(t, actualResponse.Data)
+		})
+	}
+}
+
+func TestGetTrashedBooks(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		m                func(mockuc *mocks.MockhandlerBookUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success get trashed books",
+			query: "limit=1&offset=1",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetTrashedBooks(anyCtx, &models.PageRequest{Limit: 1, Offset: 1}).
+					Return(&[]models.BooksSummary{{ID: 2, Title: "Test Book", Description: "Test Description", Qty: 10, Version: 1}},
+						&models.PageMeta{Total: 2, Limit: 1, Offset: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Books retrieved successfully",
+				Data: []any{
+					map[string]any{"id": float64(2), "title": "Test Book", "description": "Test Description", "qty": float64(10), "version": float64(1)},
+				},
+				Meta: map[string]any{"total": float64(2), "limit": float64(1), "offset": float64(1)},
+			},
+		},
+		{
+			name:  "Success get trashed books with default limit",
+			query: "",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetTrashedBooks(anyCtx, &models.PageRequest{Limit: models.DefaultPageLimit}).
+					Return(&[]models.BooksSummary{}, &models.PageMeta{Limit: models.DefaultPageLimit}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Books retrieved successfully",
+				Data:    []any{},
+				Meta:    map[string]any{"total": float64(0), "limit": float64(models.DefaultPageLimit)},
+			},
+		},
+		{
+			name:           "Failed get trashed books due to limit out of range",
+			query:          "limit=1000",
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"limit": "Should be less than or equal to 100"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := initialSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithQuery(http.MethodGet, "/books/trash", tt.query, "", tc.Handler.GetTrashedBooks)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+			assert.Equal(t, tt.expectedResponse.Meta, actualResponse.Meta)
+		})
+	}
+}
+
+func TestRestoreBook(t *testing.T) {
+	tests := []struct {
+		name             string
+		param            string
+		m                func(mockuc *mocks.MockhandlerBookUsecase)
+		expectedStatus   int
+		expectedETag     string
+		expectedResponse Response
+	}{
+		{
+			name:  "Success restore book",
+			param: "1",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().RestoreBook(anyCtx, 1).
+					Return(&models.BooksSummary{ID: 1, Title: "Test Book", Description: "Test Description", Qty: 10, Version: 3}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"3"`,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Book with ID 1 has been restored",
+				Data:    map[string]any{"id": float64(1), "title": "Test Book", "description": "Test Description", "qty": float64(10), "version": float64(3)},
+			},
+		},
+		{
+			name:  "Failed restore book due to book not in trash",
+			param: "99",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().RestoreBook(anyCtx, 99).Return(nil, fmt.Errorf("repository error: %w", models.ErrNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.NotFound,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := initialSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodPost, "/book/:id/restore", "id", tt.param, "", tc.Handler.RestoreBook)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedETag, rec.Header().Get(headerETag))
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"crud-echo/internal/inbound/customvalidator"
+	"crud-echo/internal/models"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const (
+	maxImportBytes = 16 << 20
+	// longest JSON Lines line we read, a book is nowhere near it
+	maxImportLine = 64 << 10
+)
+
+var errTooManyRows = fmt.Errorf("an import takes at most %d rows", models.MaxImportRows)
+
+// ImportBooks loads books from the multipart "file" field, CSV or JSON Lines.
+// A "mapping" field can rename the file's columns to ours, e.g.
+// {"Book Name":"title","Stock":"qty"}. Rows are checked one by one and every
+// problem ends up in the report, ?report=csv sends it back as a download
+func (h BooksHandler) ImportBooks(c echo.Context) error {
+	var q models.ImportBooksRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &q); err != nil {
+		logFor(c).Warn("error binding query params", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+	if err := h.cv.Validate(q); err != nil {
+		logFor(c).Warn("error validating query params", zap.Error(err))
+		return err
+	}
+	if q.OnDuplicate == "" {
+		q.OnDuplicate = models.DuplicateFail
+	}
+
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxImportBytes)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		logFor(c).Warn("error reading import file", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	format := q.Format
+	if format == "" {
+		format = importFormat(fileHeader.Filename)
+	}
+	if format == "" {
+		err := fmt.Errorf("can't tell the format of %q, pass ?format=csv or jsonl", fileHeader.Filename)
+		logFor(c).Warn("error reading import file", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	mapping, err := parseImportMapping(c.FormValue("mapping"))
+	if err != nil {
+		logFor(c).Warn("error parsing column mapping", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		logFor(c).Error("error opening import file", zap.Error(err))
+		return err
+	}
+	defer file.Close()
+
+	parser := &importParser{cv: h.cv, mapping: mapping, report: &models.ImportReport{}}
+	if format == models.ExportFormatCSV {
+		err = parser.parseCSV(file)
+	} else {
+		err = parser.parseJSONL(file)
+	}
+	if err != nil {
+		logFor(c).Warn("error parsing import file", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	report := parser.report
+	opts := models.ImportOptions{DryRun: q.DryRun, OnDuplicate: q.OnDuplicate}
+	if err := h.buc.ImportBooks(c.Request().Context(), parser.rows, opts, report); err != nil {
+		logFor(c).Error("error importing books", zap.Error(err))
+		return err
+	}
+	report.SortErrors()
+
+	if q.Report == "csv" {
+		return writeImportReport(c, report)
+	}
+
+	message := fmt.Sprintf("Import finished: %d created, %d updated, %d skipped, %d failed", report.Created, report.Updated, report.Skipped, report.Failed)
+	if report.DryRun {
+		message = fmt.Sprintf("Dry run, nothing was saved: %d to create, %d to update, %d to skip, %d failing", report.Created, report.Updated, report.Skipped, report.Failed)
+	}
+	return CustomResponse(c, http.StatusOK, true, message, report)
+}
+
+// importFormat goes by the file extension when the format isn't given
+func importFormat(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return models.ExportFormatCSV
+	case ".jsonl", ".ndjson":
+		return models.ExportFormatJSONL
+	default:
+		return ""
+	}
+}
+
+// parseImportMapping reads the mapping field, keys are the file's column
+// names and are matched ignoring case and surrounding spaces
+func parseImportMapping(raw string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	if strings.TrimSpace(raw) == "" {
+		return mapping, nil
+	}
+
+	var m map[string]string
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		return nil, fmt.Errorf("mapping must be a JSON object of column names: %w", err)
+	}
+	for from, to := range m {
+		if !slices.Contains(models.BooksImportColumns, to) {
+			return nil, fmt.Errorf("can't map %q to %q, columns are %s", from, to, strings.Join(models.BooksImportColumns, ", "))
+		}
+		mapping[importKey(from)] = to
+	}
+
+	return mapping, nil
+}
+
+func importKey(column string) string {
+	return strings.ToLower(strings.TrimSpace(column))
+}
+
+// importParser turns the file into rows, rows that don't make a valid book
+// go to the report instead
+type importParser struct {
+	cv      *customvalidator.CustomValidator
+	mapping map[string]string
+	rows    []models.ImportRow
+	report  *models.ImportReport
+}
+
+// column says which of our columns a file column fills, "" for none
+func (p *importParser) column(name string) string {
+	key := importKey(name)
+	if to, ok := p.mapping[key]; ok {
+		return to
+	}
+	if slices.Contains(models.BooksImportColumns, key) {
+		return key
+	}
+	return ""
+}
+
+func (p *importParser) parseCSV(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return errors.New("the file is empty")
+	} else if err != nil {
+		return err
+	}
+	// spreadsheets like to start their CSVs with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	columns := make(map[int]string, len(header))
+	for i, name := range header {
+		if column := p.column(name); column != "" {
+			columns[i] = column
+		}
+	}
+	if !slices.Contains(slices.Collect(maps.Values(columns)), "title") {
+		return errors.New("no title column, map one with the mapping field")
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		line, _ := reader.FieldPos(0)
+
+		fields := make(map[string]string, len(columns))
+		for i, column := range columns {
+			if i < len(record) {
+				fields[column] = record[i]
+			}
+		}
+		if err := p.add(line, fields); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *importParser) parseJSONL(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxImportLine)
+
+	for line := 1; scanner.Scan(); line++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		fields, err := p.jsonFields(raw)
+		if err != nil {
+			if err := p.count(); err != nil {
+				return err
+			}
+			p.report.AddError(line, err)
+			continue
+		}
+		if err := p.add(line, fields); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// jsonFields reads one JSON Lines object, numbers are kept as written so
+// they get the same treatment as a CSV cell
+func (p *importParser) jsonFields(raw []byte) (map[string]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var object map[string]any
+	if err := dec.Decode(&object); err != nil {
+		return nil, &models.ValidationError{Message: "line is not a JSON object"}
+	}
+
+	fields := make(map[string]string, len(object))
+	invalid := make(map[string]string)
+	for name, value := range object {
+		column := p.column(name)
+		if column == "" {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			fields[column] = v
+		case json.Number:
+			fields[column] = v.String()
+		case nil:
+		default:
+			invalid[column] = "Should be a string or a number"
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, &models.ValidationError{Message: models.ValidationFailed, Errors: invalid}
+	}
+
+	return fields, nil
+}
+
+func (p *importParser) count() error {
+	p.report.Total++
+	if p.report.Total > models.MaxImportRows {
+		return errTooManyRows
+	}
+	return nil
+}
+
+// add checks one row the way POST /book would
+func (p *importParser) add(line int, fields map[string]string) error {
+	if err := p.count(); err != nil {
+		return err
+	}
+
+	book := models.CreateBooksRequest{
+		Title:       strings.TrimSpace(fields["title"]),
+		Description: strings.TrimSpace(fields["description"]),
+
+		BooksBibliography: models.BooksBibliography{
+			ISBN:      strings.TrimSpace(fields["isbn"]),
+			Publisher: strings.TrimSpace(fields["publisher"]),
+			Language:  strings.TrimSpace(fields["language"]),
+			Edition:   strings.TrimSpace(fields["edition"]),
+		},
+	}
+	invalid := make(map[string]string)
+	if qty := importInt(fields, "qty", invalid); qty != nil {
+		book.Qty = *qty
+	}
+	book.PublicationYear = importInt(fields, "publication_year", invalid)
+	book.PageCount = importInt(fields, "page_count", invalid)
+	if len(invalid) > 0 {
+		p.report.AddError(line, &models.ValidationError{Message: models.ValidationFailed, Errors: invalid})
+		return nil
+	}
+
+	if err := p.cv.Validate(book); err != nil {
+		p.report.AddError(line, err)
+		return nil
+	}
+	book.BooksBibliography = book.Normalized()
+	p.rows = append(p.rows, models.ImportRow{Line: line, Book: book})
+
+	return nil
+}
+
+// importInt reads a whole number column, nil when the cell is empty. A cell
+// that isn't a number is noted in invalid
+func importInt(fields map[string]string, column string, invalid map[string]string) *int {
+	raw := strings.TrimSpace(fields[column])
+	if raw == "" {
+		return nil
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		invalid[column] = "Should be a whole number"
+		return nil
+	}
+	return &n
+}
+
+// writeImportReport sends the report's errors as a CSV download
+func writeImportReport(c echo.Context, report *models.ImportReport) error {
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="books-import-report.csv"`)
+	res.WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(res)
+	if err := w.Write([]string{"line", "field", "code", "message"}); err != nil {
+		return err
+	}
+	for _, e := range report.Errors {
+		if err := w.Write([]string{strconv.Itoa(e.Line), e.Field, e.Code, e.Message}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+
+	return w.Error()
+}
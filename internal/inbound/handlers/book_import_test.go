@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// executeImport posts file as the multipart "file" field, with the mapping
+// field when it isn't empty
+func (tc *TestContext) executeImport(t *testing.T, query, filename, file, mapping string) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatalf("Failed to create form file: %v", err)
+	}
+	part.Write([]byte(file))
+	if mapping != "" {
+		w.WriteField("mapping", mapping)
+	}
+	w.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/books/import?"+query, &body)
+	req.Header.Set(echo.HeaderContentType, w.FormDataContentType())
+
+	rec := httptest.NewRecorder()
+	c := tc.Echo.NewContext(req, rec)
+	if err := tc.Handler.ImportBooks(c); err != nil {
+		tc.Echo.HTTPErrorHandler(err, c)
+	}
+
+	return rec
+}
+
+func importRow(line int, title, description string, qty int) models.ImportRow {
+	return models.ImportRow{Line: line, Book: models.CreateBooksRequest{Title: title, Description: description, Qty: qty}}
+}
+
+func TestImportBooks(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		filename         string
+		file             string
+		mapping          string
+		m                func(mockuc *mocks.MockhandlerBookUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:     "Success import csv with mapped columns",
+			query:    "on_duplicate=skip",
+			filename: "books.csv",
+			file:     "\ufeffBook Name,Summary,Stock,id\nTitle A,Test Description,3,10\n\"Title, B\",Test Description,4,11\n",
+			mapping:  `{"book name":"title","Summary":"description","STOCK":"qty"}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().ImportBooks(anyCtx,
+					[]models.ImportRow{importRow(2, "Title A", "Test Description", 3), importRow(3, "Title, B", "Test Description", 4)},
+					models.ImportOptions{OnDuplicate: models.DuplicateSkip},
+					&models.ImportReport{Total: 2},
+				).Run(func(_ context.Context, _ []models.ImportRow, _ models.ImportOptions, report *models.ImportReport) {
+					report.Created = 1
+					report.Skipped = 1
+				}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Import finished: 1 created, 0 updated, 1 skipped, 0 failed",
+				Data:    models.ImportReport{Total: 2, Created: 1, Skipped: 1},
+			},
+		},
+		{
+			name:     "Invalid rows go to the report",
+			query:    "dry_run=true",
+			filename: "books.jsonl",
+			file: `{"title":"Title A","description":"Test Description","qty":3}` + "\n" +
+				"\n" +
+				`{"title":"xx","description":"Test Description","qty":"many"}` + "\n" +
+				`not json` + "\n" +
+				`{"title":"Title B","description":["Test Description"],"qty":1}` + "\n" +
+				`{"title":"Title C","description":"Test Description","qty":101}` + "\n",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().ImportBooks(anyCtx,
+					[]models.ImportRow{importRow(1, "Title A", "Test Description", 3)},
+					models.ImportOptions{DryRun: true, OnDuplicate: models.DuplicateFail},
+					&models.ImportReport{Total: 5, Failed: 4, Errors: []models.ImportRowError{
+						{Line: 3, Field: "qty", Code: "VALIDATION_ERROR", Message: "Should be a whole number"},
+						{Line: 4, Code: "VALIDATION_ERROR", Message: "line is not a JSON object"},
+						{Line: 5, Field: "description", Code: "VALIDATION_ERROR", Message: "Should be a string or a number"},
+						{Line: 6, Field: "qty", Code: "VALIDATION_ERROR", Message: "Should be less than or equal to 100"},
+					}},
+				).Run(func(_ context.Context, _ []models.ImportRow, opts models.ImportOptions, report *models.ImportReport) {
+					report.DryRun = opts.DryRun
+					report.Created = 1
+				}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Dry run, nothing was saved: 1 to create, 0 to update, 0 to skip, 4 failing",
+				Data: models.ImportReport{DryRun: true, Total: 5, Created: 1, Failed: 4, Errors: []models.ImportRowError{
+					{Line: 3, Field: "qty", Code: "VALIDATION_ERROR", Message: "Should be a whole number"},
+					{Line: 4, Code: "VALIDATION_ERROR", Message: "line is not a JSON object"},
+					{Line: 5, Field: "description", Code: "VALIDATION_ERROR", Message: "Should be a string or a number"},
+					{Line: 6, Field: "qty", Code: "VALIDATION_ERROR", Message: "Should be less than or equal to 100"},
+				}},
+			},
+		},
+		{
+			name:     "Success import csv with bibliography columns",
+			filename: "books.csv",
+			file: "title,description,qty,isbn,publisher,publication_year,language,page_count,edition\n" +
+				"Title A,Test Description,3,0-441-17271-7,Ace,1965,en,,1st\n" +
+				"Title B,Test Description,1,978-0-441-17271-0,,,,,\n" +
+				"Title C,Test Description,1,,,nineteen,,0,\n",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				year := 1965
+				row := importRow(2, "Title A", "Test Description", 3)
+				row.Book.BooksBibliography = models.BooksBibliography{
+					ISBN:            "9780441172719",
+					Publisher:       "Ace",
+					PublicationYear: &year,
+					Language:        "en",
+					Edition:         "1st",
+				}
+				mockuc.EXPECT().ImportBooks(anyCtx,
+					[]models.ImportRow{row},
+					models.ImportOptions{OnDuplicate: models.DuplicateFail},
+					&models.ImportReport{Total: 3, Failed: 2, Errors: []models.ImportRowError{
+						{Line: 3, Field: "isbn", Code: "VALIDATION_ERROR", Message: "Should be a valid ISBN-10 or ISBN-13"},
+						{Line: 4, Field: "publication_year", Code: "VALIDATION_ERROR", Message: "Should be a whole number"},
+					}},
+				).Run(func(_ context.Context, _ []models.ImportRow, _ models.ImportOptions, report *models.ImportReport) {
+					report.Created = 1
+				}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Import finished: 1 created, 0 updated, 0 skipped, 2 failed",
+				Data: models.ImportReport{Total: 3, Created: 1, Failed: 2, Errors: []models.ImportRowError{
+					{Line: 3, Field: "isbn", Code: "VALIDATION_ERROR", Message: "Should be a valid ISBN-10 or ISBN-13"},
+					{Line: 4, Field: "publication_year", Code: "VALIDATION_ERROR", Message: "Should be a whole number"},
+				}},
+			},
+		},
+		{
+			name:           "Failed import due to missing title column",
+			filename:       "books.csv",
+			file:           "name,qty\nTitle A,3\n",
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Code:    models.ErrInvalidParam.Code,
+				Message: models.InvalidParam,
+			},
+		},
+		{
+			name:           "Failed import due to mapping onto an unknown column",
+			filename:       "books.csv",
+			file:           "name,qty\nTitle A,3\n",
+			mapping:        `{"name":"author"}`,
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Code:    models.ErrInvalidParam.Code,
+				Message: models.InvalidParam,
+			},
+		},
+		{
+			name:           "Failed import due to unknown file type",
+			filename:       "books.xlsx",
+			file:           "whatever",
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Code:    models.ErrInvalidParam.Code,
+				Message: models.InvalidParam,
+			},
+		},
+		{
+			name:           "Failed import due to invalid duplicate policy",
+			query:          "on_duplicate=merge",
+			filename:       "books.csv",
+			file:           "title\nTitle A\n",
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Code:    models.ErrValidationError.Code,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"on_duplicate": "Should be one of skip, update, fail"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := initialSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeImport(t, tt.query, tt.filename, tt.file, tt.mapping)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Code, actualResponse.Code)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, decoded(t, tt.expectedResponse.Data), actualResponse.Data)
+		})
+	}
+}
+
+func TestImportBooksReportDownload(t *testing.T) {
+	tc := initialSetup(t)
+	tc.Mock.EXPECT().ImportBooks(anyCtx, []models.ImportRow{importRow(2, "Title A", "Test Description", 3)},
+		models.ImportOptions{OnDuplicate: models.DuplicateFail}, &models.ImportReport{Total: 2, Failed: 1, Errors: []models.ImportRowError{
+			{Line: 3, Field: "title", Code: "VALIDATION_ERROR", Message: "Should be at least 3 characters long"},
+		}}).
+		Run(func(_ context.Context, _ []models.ImportRow, _ models.ImportOptions, report *models.ImportReport) {
+			report.Failed++
+			report.Errors = append(report.Errors, models.ImportRowError{Line: 2, Code: "BOOK_TITLE_TAKEN", Message: "a book with this title already exists"})
+		}).
+		Return(nil)
+
+	rec := tc.executeImport(t, "report=csv", "books.csv", "title,description,qty\nTitle A,Test Description,3\nxx,Test Description,3\n", "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `attachment; filename="books-import-report.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, "line,field,code,message\n"+
+		"2,,BOOK_TITLE_TAKEN,a book with this title already exists\n"+
+		"3,title,VALIDATION_ERROR,Should be at least 3 characters long\n", rec.Body.String())
+}
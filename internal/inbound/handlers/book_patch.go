@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"bytes"
+	"crud-echo/internal/models"
+	"encoding/json"
+	"mime"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// patchMediaType accepts merge patch (plain JSON is treated as one) and JSON patch
+func patchMediaType(contentType string) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", models.ErrUnsupportedMediaType
+	}
+
+	switch mediaType {
+	case models.MIMEApplicationMergePatch, echo.MIMEApplicationJSON:
+		return models.MIMEApplicationMergePatch, nil
+	case models.MIMEApplicationJSONPatch:
+		return models.MIMEApplicationJSONPatch, nil
+	default:
+		return "", models.ErrUnsupportedMediaType
+	}
+}
+
+// applyBooksPatch applies an RFC 7396 merge patch or an RFC 6902 JSON patch
+// to the current state of a book
+func applyBooksPatch(mediaType string, body []byte, current *models.BooksPatchDocument) (*models.BooksPatchDocument, error) {
+	original, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched []byte
+	switch mediaType {
+	case models.MIMEApplicationMergePatch:
+		if !json.Valid(body) {
+			return nil, models.ErrBadRequest
+		}
+		patched, err = jsonpatch.MergePatch(original, body)
+		if err != nil {
+			return nil, models.ErrBadRequest
+		}
+	case models.MIMEApplicationJSONPatch:
+		patch, err := jsonpatch.DecodePatch(body)
+		if err != nil {
+			return nil, models.ErrBadRequest
+		}
+		// failed "test" ops and paths that don't exist end up here
+		patched, err = patch.Apply(original)
+		if err != nil {
+			return nil, models.ErrValidationError
+		}
+	default:
+		return nil, models.ErrUnsupportedMediaType
+	}
+
+	// unknown keys (id, timestamps, typos) and wrong types are rejected
+	// rather than silently dropped
+	var doc models.BooksPatchDocument
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, models.ErrValidationError
+	}
+
+	return &doc, nil
+}
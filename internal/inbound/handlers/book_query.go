@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"crud-echo/internal/models"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseBooksQuery turns the already bound and validated query params of
+// GET /books into the filter and page the usecase works with
+func parseBooksQuery(q *models.GetAllBooksRequest) (*models.BooksFilter, *models.PageRequest, error) {
+	filter := &models.BooksFilter{
+		Title:         strings.TrimSpace(q.Title),
+		QtyMin:        q.QtyMin,
+		QtyMax:        q.QtyMax,
+		CreatedAfter:  q.CreatedAfter,
+		CreatedBefore: q.CreatedBefore,
+		Available:     q.Available,
+		Category:      q.Category,
+	}
+	if q.Tags != "" {
+		tags, err := parseTags(q.Tags)
+		if err != nil {
+			return nil, nil, err
+		}
+		filter.Tags = tags
+	}
+	if filter.QtyMin != nil && filter.QtyMax != nil && *filter.QtyMin > *filter.QtyMax {
+		return nil, nil, models.ErrInvalidParam
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return nil, nil, models.ErrInvalidParam
+	}
+
+	sort, err := parseSort(q.Sort)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := &models.PageRequest{
+		Limit:  q.Limit,
+		Offset: q.Offset,
+		Sort:   sort,
+	}
+	if page.Limit == 0 {
+		page.Limit = models.DefaultPageLimit
+	}
+	if q.Cursor != "" {
+		cursor, err := models.DecodeCursor(q.Cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		page.Cursor = cursor
+	}
+
+	return filter, page, nil
+}
+
+// parseSort reads "title,-created_at" style specs, a leading dash means descending
+func parseSort(raw string) ([]models.SortField, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	var fields []models.SortField
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		name := strings.TrimPrefix(part, "-")
+
+		if _, ok := models.BooksSortableColumns[name]; !ok || seen[name] {
+			return nil, models.ErrInvalidParam
+		}
+		seen[name] = true
+
+		fields = append(fields, models.SortField{Column: name, Desc: desc})
+	}
+
+	return fields, nil
+}
+
+// parseTags reads "classic,award" style lists, an empty or overlong name is
+// rejected and repeats are dropped
+func parseTags(raw string) ([]string, error) {
+	names := strings.Split(raw, ",")
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || utf8.RuneCountInString(name) > models.MaxTagLen {
+			return nil, models.ErrInvalidParam
+		}
+	}
+
+	return models.NormalizeTags(names), nil
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"crud-echo/internal/models"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+func (h BooksHandler) IncreaseStock(c echo.Context) error {
+	var b models.IncreaseStockRequest
+
+	if err := c.Bind(&b); err != nil {
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(b); err != nil {
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
+	}
+
+	if b.Kind == "" {
+		b.Kind = models.StockReceive
+	}
+
+	return h.moveStock(c, models.StockChange{Kind: b.Kind, Quantity: b.Quantity, Reason: b.Reason})
+}
+
+func (h BooksHandler) DecreaseStock(c echo.Context) error {
+	var b models.DecreaseStockRequest
+
+	if err := c.Bind(&b); err != nil {
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(b); err != nil {
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
+	}
+
+	if b.Kind == "" {
+		b.Kind = models.StockSell
+	}
+
+	return h.moveStock(c, models.StockChange{Kind: b.Kind, Quantity: -b.Quantity, Reason: b.Reason})
+}
+
+// moveStock doesn't take an If-Match, the change is relative so it can't
+// lose an update, but the new ETag is handed back all the same
+func (h BooksHandler) moveStock(c echo.Context, change models.StockChange) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	resp, err := h.buc.MoveStock(c.Request().Context(), id, change)
+	if err != nil {
+		logFor(c).Error("error moving stock", zap.Int("id", id), zap.String("kind", change.Kind), zap.Error(err))
+		return err
+	}
+
+	c.Response().Header().Set(headerETag, bookETag(resp.Book.Version))
+	return CustomResponse(c, http.StatusOK, true, "Stock of book with ID "+strconv.Itoa(id)+" is now "+strconv.Itoa(resp.Book.Qty), resp)
+}
+
+func (h BooksHandler) GetStockHistory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	var q models.GetStockHistoryRequest
+
+	if err := c.Bind(&q); err != nil {
+		logFor(c).Warn("error binding query params", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(q); err != nil {
+		logFor(c).Warn("error validating query params", zap.Error(err))
+		return err
+	}
+
+	page := &models.PageRequest{
+		Limit:  q.Limit,
+		Offset: q.Offset,
+	}
+	if page.Limit == 0 {
+		page.Limit = models.DefaultPageLimit
+	}
+
+	resp, meta, err := h.buc.GetStockHistory(c.Request().Context(), id, page)
+	if err != nil {
+		logFor(c).Error("error retrieving stock history", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponseWithMeta(c, http.StatusOK, true, "Stock history retrieved successfully", resp, meta)
+}
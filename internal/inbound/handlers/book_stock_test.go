@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoveStock(t *testing.T) {
+	moved := func(kind string, quantity, balance int) *models.StockMoveResult {
+		return &models.StockMoveResult{
+			Book:     &models.BooksSummary{ID: 1, Title: "Test Book", Description: "Test Description", Qty: balance, Version: 4},
+			Movement: &models.StockMovement{ID: 9, BookID: 1, Kind: kind, Quantity: quantity, Balance: balance, Actor: models.DefaultActor},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		decrease         bool
+		param            string
+		body             string
+		m                func(mockuc *mocks.MockhandlerBookUsecase)
+		expectedStatus   int
+		expectedETag     string
+		expectedResponse Response
+	}{
+		{
+			name:  "Success increase stock defaults to receive",
+			param: "1",
+			body:  `{"quantity": 5}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().MoveStock(anyCtx, 1, models.StockChange{Kind: models.StockReceive, Quantity: 5}).
+					Return(moved(models.StockReceive, 5, 15), nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Stock of book with ID 1 is now 15",
+				Data:    moved(models.StockReceive, 5, 15),
+			},
+		},
+		{
+			name:  "Success increase stock with a return",
+			param: "1",
+			body:  `{"kind": "return", "quantity": 1, "reason": "loan 42"}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().MoveStock(anyCtx, 1, models.StockChange{Kind: models.StockReturn, Quantity: 1, Reason: "loan 42"}).
+					Return(moved(models.StockReturn, 1, 11), nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Stock of book with ID 1 is now 11",
+				Data:    moved(models.StockReturn, 1, 11),
+			},
+		},
+		{
+			name:     "Success decrease stock defaults to sell",
+			decrease: true,
+			param:    "1",
+			body:     `{"quantity": 2}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().MoveStock(anyCtx, 1, models.StockChange{Kind: models.StockSell, Quantity: -2}).
+					Return(moved(models.StockSell, -2, 8), nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Stock of book with ID 1 is now 8",
+				Data:    moved(models.StockSell, -2, 8),
+			},
+		},
+		{
+			name:     "Failed decrease stock due to insufficient stock",
+			decrease: true,
+			param:    "1",
+			body:     `{"kind": "lend", "quantity": 50}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().MoveStock(anyCtx, 1, models.StockChange{Kind: models.StockLend, Quantity: -50}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrInsufficientStock))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrInsufficientStock.Message,
+			},
+		},
+		{
+			name:           "Failed decrease stock with a kind that adds",
+			decrease:       true,
+			param:          "1",
+			body:           `{"kind": "receive", "quantity": 1}`,
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"kind": "Should be one of sell, lend, adjust"},
+			},
+		},
+		{
+			name:           "Failed increase stock without a quantity",
+			param:          "1",
+			body:           `{"kind": "adjust"}`,
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"quantity": "This field is required"},
+			},
+		},
+		{
+			name:  "Failed increase stock due to book not found",
+			param: "99",
+			body:  `{"quantity": 1}`,
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().MoveStock(anyCtx, 99, models.StockChange{Kind: models.StockReceive, Quantity: 1}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrBookNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrBookNotFound.Message,
+			},
+		},
+		{
+			name:           "Failed increase stock due to invalid id",
+			param:          "abc",
+			body:           `{"quantity": 1}`,
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrInvalidParam.Message,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := initialSetup(t)
+			tt.m(tc.Mock)
+
+			path, handler := "/book/:id/stock/increase", tc.Handler.IncreaseStock
+			if tt.decrease {
+				path, handler = "/book/:id/stock/decrease", tc.Handler.DecreaseStock
+			}
+			rec := tc.executeRequestWithParam(http.MethodPost, path, "id", tt.param, tt.body, handler)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedETag, rec.Header().Get(headerETag))
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			if tt.expectedResponse.Data != nil {
+				assert.Equal(t, decoded(t, tt.expectedResponse.Data), actualResponse.Data)
+			}
+		})
+	}
+}
+
+func TestGetStockHistory(t *testing.T) {
+	history := &[]models.StockMovement{
+		{ID: 2, BookID: 1, Kind: models.StockSell, Quantity: -1, Balance: 9, Actor: "alice"},
+		{ID: 1, BookID: 1, Kind: models.StockReceive, Quantity: 10, Balance: 10, Reason: "initial stock", Actor: "alice"},
+	}
+
+	tests := []struct {
+		name             string
+		query            string
+		m                func(mockuc *mocks.MockhandlerBookUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success get stock history",
+			query: "?limit=2",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetStockHistory(anyCtx, 1, &models.PageRequest{Limit: 2}).
+					Return(history, &models.PageMeta{Total: 2, Limit: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Stock history retrieved successfully",
+				Data:    history,
+				Meta:    map[string]any{"total": float64(2), "limit": float64(2)},
+			},
+		},
+		{
+			name:  "Failed get stock history due to book not found",
+			query: "",
+			m: func(mockuc *mocks.MockhandlerBookUsecase) {
+				mockuc.EXPECT().GetStockHistory(anyCtx, 1, &models.PageRequest{Limit: models.DefaultPageLimit}).
+					Return(nil, nil, fmt.Errorf("repository error: %w", models.ErrBookNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrBookNotFound.Message,
+			},
+		},
+		{
+			name:           "Failed get stock history due to negative offset",
+			query:          "?offset=-1",
+			m:              func(mockuc *mocks.MockhandlerBookUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"offset": "Should be greater than or equal to 0"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := initialSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodGet, "/book/:id/stock/history"+tt.query, "id", "1", "", tc.Handler.GetStockHistory)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			if tt.expectedResponse.Data != nil {
+				assert.Equal(t, decoded(t, tt.expectedResponse.Data), actualResponse.Data)
+			}
+			assert.Equal(t, tt.expectedResponse.Meta, actualResponse.Meta)
+		})
+	}
+}
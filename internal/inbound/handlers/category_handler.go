@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"crud-echo/internal/inbound/customvalidator"
+	"crud-echo/internal/models"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type HandlerCategoryUsecase interface {
+	CreateCategory(ctx context.Context, category *models.CreateCategoriesRequest) (*models.CategoriesSummary, error)
+	GetCategoryByID(ctx context.Context, id int) (*models.CategoriesSummary, error)
+	GetAllCategories(ctx context.Context) (*[]models.CategoriesSummary, error)
+	UpdateCategory(ctx context.Context, category *models.UpdateCategoriesRequest) (*models.CategoriesSummary, error)
+	DeleteCategory(ctx context.Context, id int) error
+	SetBookCategories(ctx context.Context, bookID int, categoryIDs []int) (*[]models.CategoriesSummary, error)
+	GetBookCategories(ctx context.Context, bookID int) (*[]models.CategoriesSummary, error)
+}
+
+type CategoriesHandler struct {
+	cuc HandlerCategoryUsecase
+	cv  *customvalidator.CustomValidator
+}
+
+func NewCategoriesHandler(cuc HandlerCategoryUsecase, validator *customvalidator.CustomValidator) *CategoriesHandler {
+	return &CategoriesHandler{cuc: cuc, cv: validator}
+}
+
+func (h CategoriesHandler) CreateCategory(c echo.Context) error {
+	var cat models.CreateCategoriesRequest
+
+	if err := c.Bind(&cat); err != nil {
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(cat); err != nil {
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
+	}
+
+	resp, err := h.cuc.CreateCategory(c.Request().Context(), &cat)
+	if err != nil {
+		logFor(c).Error("error creating category", zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Category has been created", resp)
+}
+
+func (h CategoriesHandler) GetCategoryByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	resp, err := h.cuc.GetCategoryByID(c.Request().Context(), id)
+	if err != nil {
+		logFor(c).Error("error retrieving category", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Category retrieved successfully", resp)
+}
+
+func (h CategoriesHandler) GetAllCategories(c echo.Context) error {
+	resp, err := h.cuc.GetAllCategories(c.Request().Context())
+	if err != nil {
+		logFor(c).Error("error retrieving categories", zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Categories retrieved successfully", resp)
+}
+
+func (h CategoriesHandler) UpdateCategory(c echo.Context) error {
+	var cat models.UpdateCategoriesRequest
+
+	if err := c.Bind(&cat); err != nil {
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(cat); err != nil {
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
+	}
+
+	resp, err := h.cuc.UpdateCategory(c.Request().Context(), &cat)
+	if err != nil {
+		logFor(c).Error("error updating category", zap.Int("id", cat.ID), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Category with ID "+strconv.Itoa(cat.ID)+" has been updated", resp)
+}
+
+func (h CategoriesHandler) DeleteCategory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	if err := h.cuc.DeleteCategory(c.Request().Context(), id); err != nil {
+		logFor(c).Error("error deleting category", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Category with ID "+strconv.Itoa(id)+" has been deleted", nil)
+}
+
+// SetBookCategories replaces the categories of the book, an empty list
+// takes it out of all of them
+func (h CategoriesHandler) SetBookCategories(c echo.Context) error {
+	var req models.SetBookCategoriesRequest
+
+	if err := c.Bind(&req); err != nil {
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(req); err != nil {
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
+	}
+
+	resp, err := h.cuc.SetBookCategories(c.Request().Context(), req.BookID, req.CategoryIDs)
+	if err != nil {
+		logFor(c).Error("error setting categories of book", zap.Int("id", req.BookID), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Categories of book with ID "+strconv.Itoa(req.BookID)+" have been set", resp)
+}
+
+func (h CategoriesHandler) GetBookCategories(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	resp, err := h.cuc.GetBookCategories(c.Request().Context(), id)
+	if err != nil {
+		logFor(c).Error("error retrieving categories of book", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Categories retrieved successfully", resp)
+}
@@ -0,0 +1,320 @@
+package handlers
+
+import (
+	vc "crud-echo/internal/inbound/customvalidator"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type categoriesTestContext struct {
+	*TestContext
+	Handler *CategoriesHandler
+	Mock    *mocks.MockhandlerCategoryUsecase
+}
+
+func categoriesSetup(t *testing.T) *categoriesTestContext {
+	e := echo.New()
+	e.HTTPErrorHandler = CustomHTTPErrorHandler
+
+	mockUsecase := mocks.NewMockhandlerCategoryUsecase(t)
+	testValidator, err := vc.NewCustomValidator(validator.New())
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	return &categoriesTestContext{
+		TestContext: &TestContext{Echo: e},
+		Handler:     NewCategoriesHandler(mockUsecase, testValidator),
+		Mock:        mockUsecase,
+	}
+}
+
+func TestCreateCategory(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name             string
+		requestBody      string
+		m                func(mockuc *mocks.MockhandlerCategoryUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:        "Success create category",
+			requestBody: `{"name":"Fantasy","slug":"fantasy","parent_id":1}`,
+			m: func(mockuc *mocks.MockhandlerCategoryUsecase) {
+				mockuc.EXPECT().CreateCategory(anyCtx, &models.CreateCategoriesRequest{Name: "Fantasy", Slug: "fantasy", ParentID: &parentID}).
+					Return(&models.CategoriesSummary{ID: 4, Name: "Fantasy", Slug: "fantasy", ParentID: &parentID}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Category has been created",
+				Data:    map[string]any{"id": float64(4), "name": "Fantasy", "slug": "fantasy", "parent_id": float64(1)},
+			},
+		},
+		{
+			name:           "Failed create category due to validation error",
+			requestBody:    `{"name":"Fantasy","slug":"Fantasy Books","parent_id":0}`,
+			m:              func(mockuc *mocks.MockhandlerCategoryUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data: map[string]any{
+					"slug":      "Should be lowercase letters and digits separated by single hyphens",
+					"parent_id": "Should be greater than or equal to 1",
+				},
+			},
+		},
+		{
+			name:        "Failed create category due to unknown parent",
+			requestBody: `{"name":"Fantasy","slug":"fantasy","parent_id":1}`,
+			m: func(mockuc *mocks.MockhandlerCategoryUsecase) {
+				mockuc.EXPECT().CreateCategory(anyCtx, &models.CreateCategoriesRequest{Name: "Fantasy", Slug: "fantasy", ParentID: &parentID}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrUnknownCategory))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrUnknownCategory.Message,
+			},
+		},
+		{
+			name:        "Failed create category due to slug taken",
+			requestBody: `{"name":"Fantasy","slug":"fantasy"}`,
+			m: func(mockuc *mocks.MockhandlerCategoryUsecase) {
+				mockuc.EXPECT().CreateCategory(anyCtx, &models.CreateCategoriesRequest{Name: "Fantasy", Slug: "fantasy"}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrCategorySlugTaken))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrCategorySlugTaken.Message,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := categoriesSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequest(http.MethodPost, "/categories", tt.requestBody, tc.Handler.CreateCategory)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestGetAllCategories(t *testing.T) {
+	parentID := 1
+
+	tc := categoriesSetup(t)
+	tc.Mock.EXPECT().GetAllCategories(anyCtx).Return(&[]models.CategoriesSummary{
+		{ID: 1, Name: "Fiction", Slug: "fiction"},
+		{ID: 4, Name: "Fantasy", Slug: "fantasy", ParentID: &parentID},
+	}, nil)
+
+	rec := tc.executeRequest(http.MethodGet, "/categories", "", tc.Handler.GetAllCategories)
+	actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "Categories retrieved successfully", actualResponse.Message)
+	assert.Equal(t, []any{
+		map[string]any{"id": float64(1), "name": "Fiction", "slug": "fiction"},
+		map[string]any{"id": float64(4), "name": "Fantasy", "slug": "fantasy", "parent_id": float64(1)},
+	}, actualResponse.Data)
+}
+
+func TestUpdateCategory(t *testing.T) {
+	parentID := 4
+
+	tests := []struct {
+		name             string
+		param            string
+		requestBody      string
+		m                func(mockuc *mocks.MockhandlerCategoryUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:        "Success move category to the root",
+			param:       "4",
+			requestBody: `{"name":"Fantasy","slug":"fantasy"}`,
+			m: func(mockuc *mocks.MockhandlerCategoryUsecase) {
+				mockuc.EXPECT().UpdateCategory(anyCtx, &models.UpdateCategoriesRequest{ID: 4, Name: "Fantasy", Slug: "fantasy"}).
+					Return(&models.CategoriesSummary{ID: 4, Name: "Fantasy", Slug: "fantasy"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Category with ID 4 has been updated",
+				Data:    map[string]any{"id": float64(4), "name": "Fantasy", "slug": "fantasy"},
+			},
+		},
+		{
+			name:        "Failed update category due to cycle",
+			param:       "4",
+			requestBody: `{"name":"Fantasy","slug":"fantasy","parent_id":4}`,
+			m: func(mockuc *mocks.MockhandlerCategoryUsecase) {
+				mockuc.EXPECT().UpdateCategory(anyCtx, &models.UpdateCategoriesRequest{ID: 4, Name: "Fantasy", Slug: "fantasy", ParentID: &parentID}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrCategoryCycle))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrCategoryCycle.Message,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := categoriesSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodPut, "/categories/:id", "id", tt.param, tt.requestBody, tc.Handler.UpdateCategory)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestDeleteCategory(t *testing.T) {
+	tests := []struct {
+		name             string
+		param            string
+		m                func(mockuc *mocks.MockhandlerCategoryUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success delete category",
+			param: "4",
+			m: func(mockuc *mocks.MockhandlerCategoryUsecase) {
+				mockuc.EXPECT().DeleteCategory(anyCtx, 4).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Category with ID 4 has been deleted",
+			},
+		},
+		{
+			name:  "Failed delete category with children",
+			param: "1",
+			m: func(mockuc *mocks.MockhandlerCategoryUsecase) {
+				mockuc.EXPECT().DeleteCategory(anyCtx, 1).Return(fmt.Errorf("repository error: %w", models.ErrCategoryHasChildren))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrCategoryHasChildren.Message,
+			},
+		},
+		{
+			name:           "Failed delete category due to error converting ID param",
+			param:          "abc",
+			m:              func(mockuc *mocks.MockhandlerCategoryUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "invalid parameter",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := categoriesSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodDelete, "/categories/:id", "id", tt.param, "", tc.Handler.DeleteCategory)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+		})
+	}
+}
+
+func TestSetBookCategories(t *testing.T) {
+	tests := []struct {
+		name             string
+		param            string
+		requestBody      string
+		m                func(mockuc *mocks.MockhandlerCategoryUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:        "Success set categories of a book",
+			param:       "1",
+			requestBody: `{"category_ids":[4]}`,
+			m: func(mockuc *mocks.MockhandlerCategoryUsecase) {
+				mockuc.EXPECT().SetBookCategories(anyCtx, 1, []int{4}).
+					Return(&[]models.CategoriesSummary{{ID: 4, Name: "Fantasy", Slug: "fantasy"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Categories of book with ID 1 have been set",
+				Data:    []any{map[string]any{"id": float64(4), "name": "Fantasy", "slug": "fantasy"}},
+			},
+		},
+		{
+			name:           "Failed set categories due to duplicates",
+			param:          "1",
+			requestBody:    `{"category_ids":[4,4]}`,
+			m:              func(mockuc *mocks.MockhandlerCategoryUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"category_ids": "Should not contain duplicates"},
+			},
+		},
+		{
+			name:        "Failed set categories due to book not found",
+			param:       "99",
+			requestBody: `{"category_ids":[]}`,
+			m: func(mockuc *mocks.MockhandlerCategoryUsecase) {
+				mockuc.EXPECT().SetBookCategories(anyCtx, 99, []int{}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrBookNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrBookNotFound.Message,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := categoriesSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodPut, "/book/:id/categories", "id", tt.param, tt.requestBody, tc.Handler.SetBookCategories)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
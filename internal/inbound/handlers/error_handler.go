@@ -3,32 +3,171 @@ package handlers
 import (
 	"crud-echo/internal/models"
 	"errors"
+	"fmt"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
 
-func CustomHTTPErrorHandler(err error, c echo.Context) {
-	var code int
-	var message string
-	// maybe this can be used if needed?
-	// var data any
+const (
+	ErrorFormatEnvelope = "envelope"
+	ErrorFormatProblem  = "problem"
+)
 
-	var httpError *echo.HTTPError
-	if errors.As(err, &httpError) {
-		code = httpError.Code
-		message = httpError.Message.(string)
-	} else {
-		code = http.StatusInternalServerError
-		message = models.InternalServerError
+// NewHTTPErrorHandler renders errors in the given format, with the envelope
+// a client can still ask for problem+json through its Accept header
+func NewHTTPErrorHandler(format string) (echo.HTTPErrorHandler, error) {
+	switch format {
+	case ErrorFormatEnvelope, "":
+		return CustomHTTPErrorHandler, nil
+	case ErrorFormatProblem:
+		return problemHTTPErrorHandler, nil
+	default:
+		return nil, fmt.Errorf("unknown error format %q, want %s or %s", format, ErrorFormatEnvelope, ErrorFormatProblem)
 	}
+}
+
+// CustomHTTPErrorHandler can see the same error more than once since the
+// request logger and the tracing middleware both hand it over, only the
+// first call writes the response
+func CustomHTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+	if acceptsProblem(c.Request().Header.Get(echo.HeaderAccept)) {
+		writeProblem(err, c)
+		return
+	}
+
+	r := describeError(err)
 
 	resp := Response{
 		Status:  false,
-		Message: message,
-		Data:    nil,
+		Code:    r.code,
+		Message: r.message,
+	}
+	if r.errors != nil {
+		resp.Data = r.errors
 	}
+	if r.results != nil {
+		resp.Data = r.results
+		resp.Meta = r.summary
+	}
+
+	c.JSON(r.status, resp)
+}
+
+func problemHTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	writeProblem(err, c)
+}
+
+func writeProblem(err error, c echo.Context) {
+	r := describeError(err)
 
-	c.Logger().Errorf("Response error: %#v", resp)
-	c.JSON(code, resp)
+	problem := &models.Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(r.status),
+		Status:    r.status,
+		Detail:    r.message,
+		Instance:  c.Request().URL.Path,
+		Code:      r.code,
+		Errors:    r.errors,
+		Results:   r.results,
+		Summary:   r.summary,
+		RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+	}
+	// the title has to be the same for every occurrence of a type, so the
+	// registered message goes there and detail only keeps what differs
+	if de, ok := models.LookupDomainError(r.code); ok {
+		problem.Type = models.ProblemType(de.Code)
+		problem.Title = de.Message
+	}
+	if problem.Detail == problem.Title {
+		problem.Detail = ""
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, models.MIMEApplicationProblemJSON)
+	c.JSON(r.status, problem)
+}
+
+type errorReport struct {
+	status  int
+	code    string
+	message string
+	errors  map[string]string
+	results []models.BulkResult
+	summary *models.BulkSummary
+}
+
+// describeError works out what to tell the client from whatever a handler
+// or middleware returned. The outermost error it recognises wins, causes
+// wrapped inside it never make it into the response
+func describeError(err error) errorReport {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		switch t := e.(type) {
+		case *bulkError:
+			r := describeError(t.err)
+			r.message = "No books were " + t.done + ": " + r.message
+			r.results, r.summary = t.results, &t.summary
+			return r
+		case *models.ValidationError:
+			return errorReport{
+				status:  models.ErrValidationError.Status,
+				code:    models.ErrValidationError.Code,
+				message: t.Message,
+				errors:  t.Errors,
+			}
+		case *models.DomainError:
+			return errorReport{status: t.Status, code: t.Code, message: t.Message}
+		case *echo.HTTPError:
+			// echo's own errors (unknown routes and the like) or one built by
+			// hand, its status and message win over a domain error inside
+			r := errorReport{status: t.Code, message: httpErrorMessage(t)}
+			var de *models.DomainError
+			if errors.As(t.Internal, &de) {
+				r.code = de.Code
+			}
+			return r
+		}
+	}
+
+	de := models.DomainErrorFor(err)
+	return errorReport{status: de.Status, code: de.Code, message: de.Message}
+}
+
+// echo.HTTPError.Message is an any, middlewares don't always put a string in it
+func httpErrorMessage(httpError *echo.HTTPError) string {
+	switch m := httpError.Message.(type) {
+	case string:
+		return m
+	case error:
+		return m.Error()
+	case nil:
+		return http.StatusText(httpError.Code)
+	default:
+		return fmt.Sprint(m)
+	}
+}
+
+// acceptsProblem reports whether problem+json is among the media types the
+// client listed, any q other than 0 counts
+func acceptsProblem(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mediaType != models.MIMEApplicationProblemJSON {
+			continue
+		}
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+			return false
+		}
+		return true
+	}
+	return false
 }
@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"crud-echo/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorHandler(t *testing.T) {
+	validationError := &models.ValidationError{
+		Message: models.ValidationFailed,
+		Errors:  map[string]string{"title": "This field is required"},
+	}
+	// a rolled back atomic bulk create
+	results := []models.BulkResult{
+		{Index: 0, Code: models.ErrBulkRolledBack.Code, Message: models.ErrBulkRolledBack.Message},
+		{Index: 1, Code: models.ErrBookTitleTaken.Code, Message: models.ErrBookTitleTaken.Message},
+	}
+	summary := models.BulkSummary{Atomic: true, Total: 2, Failed: 2}
+	bulkErr := &bulkError{
+		err:     fmt.Errorf("repository error: %w", models.ErrBookTitleTaken),
+		done:    "created",
+		results: results,
+		summary: summary,
+	}
+
+	tests := []struct {
+		name             string
+		format           string
+		accept           string
+		err              error
+		expectedStatus   int
+		expectedType     string
+		expectedEnvelope *Response
+		expectedProblem  *models.Problem
+	}{
+		{
+			name:             "Envelope by default",
+			format:           ErrorFormatEnvelope,
+			err:              fmt.Errorf("repository error: %w", models.ErrBookNotFound),
+			expectedStatus:   http.StatusNotFound,
+			expectedType:     echo.MIMEApplicationJSON,
+			expectedEnvelope: &Response{Status: false, Code: "BOOK_NOT_FOUND", Message: "book not found"},
+		},
+		{
+			name:             "Envelope keeps the cause out of the message",
+			format:           ErrorFormatEnvelope,
+			err:              models.ErrBadRequest.Wrap(errors.New("invalid character ',' looking for beginning of object key string")),
+			expectedStatus:   http.StatusBadRequest,
+			expectedType:     echo.MIMEApplicationJSON,
+			expectedEnvelope: &Response{Status: false, Code: "BAD_REQUEST", Message: models.BadRequest},
+		},
+		{
+			name:             "Envelope with a non-string message does not panic",
+			format:           ErrorFormatEnvelope,
+			err:              echo.NewHTTPError(http.StatusBadGateway, errors.New("upstream went away")),
+			expectedStatus:   http.StatusBadGateway,
+			expectedType:     echo.MIMEApplicationJSON,
+			expectedEnvelope: &Response{Status: false, Message: "upstream went away"},
+		},
+		{
+			name:             "Envelope when the client turns problem+json down",
+			format:           ErrorFormatEnvelope,
+			accept:           "application/problem+json;q=0, application/json",
+			err:              echo.NewHTTPError(http.StatusNotFound, models.NotFound),
+			expectedStatus:   http.StatusNotFound,
+			expectedType:     echo.MIMEApplicationJSON,
+			expectedEnvelope: &Response{Status: false, Message: models.NotFound},
+		},
+		{
+			name:           "Problem when the client asks for it",
+			format:         ErrorFormatEnvelope,
+			accept:         "application/json;q=0.5, application/problem+json",
+			err:            fmt.Errorf("repository error: %w", models.ErrBookNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedType:   models.MIMEApplicationProblemJSON,
+			expectedProblem: &models.Problem{
+				Type:      models.ProblemTypeBase + "book-not-found",
+				Title:     "book not found",
+				Status:    http.StatusNotFound,
+				Instance:  "/book/99",
+				Code:      "BOOK_NOT_FOUND",
+				RequestID: "req-1",
+			},
+		},
+		{
+			name:           "Problem from config with validation errors",
+			format:         ErrorFormatProblem,
+			err:            validationError,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedType:   models.MIMEApplicationProblemJSON,
+			expectedProblem: &models.Problem{
+				Type:      models.ProblemTypeBase + "validation-error",
+				Title:     models.ValidationFailed,
+				Status:    http.StatusUnprocessableEntity,
+				Instance:  "/book/99",
+				Code:      "VALIDATION_ERROR",
+				Errors:    map[string]string{"title": "This field is required"},
+				RequestID: "req-1",
+			},
+		},
+		{
+			name:           "Problem keeps a detail that differs from the title",
+			format:         ErrorFormatProblem,
+			err:            echo.NewHTTPError(http.StatusConflict, "title already taken").SetInternal(fmt.Errorf("repository error: %w", models.ErrResourceAlreadyExist)),
+			expectedStatus: http.StatusConflict,
+			expectedType:   models.MIMEApplicationProblemJSON,
+			expectedProblem: &models.Problem{
+				Type:      models.ProblemTypeBase + "resource-already-exist",
+				Title:     models.ResourceAlreadyExist,
+				Status:    http.StatusConflict,
+				Detail:    "title already taken",
+				Instance:  "/book/99",
+				Code:      "RESOURCE_ALREADY_EXIST",
+				RequestID: "req-1",
+			},
+		},
+		{
+			name:           "Problem for errors we don't know is about:blank",
+			format:         ErrorFormatProblem,
+			err:            echo.ErrMethodNotAllowed,
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedType:   models.MIMEApplicationProblemJSON,
+			expectedProblem: &models.Problem{
+				Type:      "about:blank",
+				Title:     http.StatusText(http.StatusMethodNotAllowed),
+				Status:    http.StatusMethodNotAllowed,
+				Instance:  "/book/99",
+				RequestID: "req-1",
+			},
+		},
+		{
+			name:           "Envelope for a rolled back bulk request",
+			format:         ErrorFormatEnvelope,
+			err:            bulkErr,
+			expectedStatus: http.StatusConflict,
+			expectedType:   echo.MIMEApplicationJSON,
+			expectedEnvelope: &Response{
+				Status:  false,
+				Code:    "BOOK_TITLE_TAKEN",
+				Message: "No books were created: " + models.ErrBookTitleTaken.Message,
+				Data:    decoded(t, results),
+				Meta:    decoded(t, summary),
+			},
+		},
+		{
+			name:           "Problem for a rolled back bulk request",
+			format:         ErrorFormatProblem,
+			err:            bulkErr,
+			expectedStatus: http.StatusConflict,
+			expectedType:   models.MIMEApplicationProblemJSON,
+			expectedProblem: &models.Problem{
+				Type:      models.ProblemTypeBase + "book-title-taken",
+				Title:     models.ErrBookTitleTaken.Message,
+				Status:    http.StatusConflict,
+				Detail:    "No books were created: " + models.ErrBookTitleTaken.Message,
+				Instance:  "/book/99",
+				Code:      "BOOK_TITLE_TAKEN",
+				Results:   results,
+				Summary:   &summary,
+				RequestID: "req-1",
+			},
+		},
+		{
+			name:           "Problem for a rolled back bulk request the client asked for",
+			format:         ErrorFormatEnvelope,
+			accept:         models.MIMEApplicationProblemJSON,
+			err:            bulkErr,
+			expectedStatus: http.StatusConflict,
+			expectedType:   models.MIMEApplicationProblemJSON,
+			expectedProblem: &models.Problem{
+				Type:      models.ProblemTypeBase + "book-title-taken",
+				Title:     models.ErrBookTitleTaken.Message,
+				Status:    http.StatusConflict,
+				Detail:    "No books were created: " + models.ErrBookTitleTaken.Message,
+				Instance:  "/book/99",
+				Code:      "BOOK_TITLE_TAKEN",
+				Results:   results,
+				Summary:   &summary,
+				RequestID: "req-1",
+			},
+		},
+		{
+			name:           "Problem hides plain errors behind a 500",
+			format:         ErrorFormatProblem,
+			err:            errors.New("pq: connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedType:   models.MIMEApplicationProblemJSON,
+			expectedProblem: &models.Problem{
+				Type:      models.ProblemTypeBase + "internal-server-error",
+				Title:     models.InternalServerError,
+				Status:    http.StatusInternalServerError,
+				Instance:  "/book/99",
+				Code:      "INTERNAL_SERVER_ERROR",
+				RequestID: "req-1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, err := NewHTTPErrorHandler(tt.format)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/book/99", nil)
+			if tt.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.Response().Header().Set(echo.HeaderXRequestID, "req-1")
+
+			handler(tt.err, c)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Contains(t, rec.Header().Get(echo.HeaderContentType), tt.expectedType)
+
+			if tt.expectedEnvelope != nil {
+				var actual Response
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+				assert.Equal(t, *tt.expectedEnvelope, actual)
+			}
+			if tt.expectedProblem != nil {
+				var actual models.Problem
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+				assert.Equal(t, *tt.expectedProblem, actual)
+			}
+		})
+	}
+}
+
+func TestNewHTTPErrorHandlerUnknownFormat(t *testing.T) {
+	_, err := NewHTTPErrorHandler("xml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"crud-echo/internal/models"
+	"strconv"
+	"strings"
+)
+
+const (
+	headerETag        = "ETag"
+	headerIfMatch     = "If-Match"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// bookETag is a strong validator built from the row version
+func bookETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// parseIfMatch reads the version out of an If-Match header. We only ever hand
+// out single strong ETags, so anything else can't match and fails the precondition
+func parseIfMatch(header string) (int, error) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, models.ErrPreconditionRequired
+	}
+
+	if len(header) < 3 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		return 0, models.ErrPreconditionFailed
+	}
+	version, err := strconv.Atoi(header[1 : len(header)-1])
+	if err != nil || version < 1 {
+		return 0, models.ErrPreconditionFailed
+	}
+
+	return version, nil
+}
+
+// ifNoneMatch reports whether the copy the client already has is current,
+// using the weak comparison RFC 9110 asks for on GET
+func ifNoneMatch(header string, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
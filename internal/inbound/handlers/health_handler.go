@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"crud-echo/pkg/health"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type HealthHandler struct {
+	registry *health.Registry
+}
+
+func NewHealthHandler(registry *health.Registry) *HealthHandler {
+	return &HealthHandler{registry: registry}
+}
+
+// Healthz only tells the process is alive, dependencies are left to Readyz so
+// a database outage doesn't get every replica restarted
+func (h HealthHandler) Healthz(c echo.Context) error {
+	return CustomResponse(c, http.StatusOK, true, "alive", map[string]string{"status": health.StatusUp})
+}
+
+func (h HealthHandler) Readyz(c echo.Context) error {
+	report := h.registry.Check(c.Request().Context())
+	if !report.IsUp() {
+		return CustomResponse(c, http.StatusServiceUnavailable, false, "not ready", report)
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "ready", report)
+}
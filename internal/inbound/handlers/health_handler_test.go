@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"crud-echo/pkg/health"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthz(t *testing.T) {
+	e := echo.New()
+	h := NewHealthHandler(health.NewRegistry(health.NewChecker("database", func(ctx context.Context) error {
+		return errors.New("database is down")
+	})))
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	assert.NoError(t, h.Healthz(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":true,"message":"alive","data":{"status":"up"}}`, rec.Body.String())
+}
+
+func TestReadyz(t *testing.T) {
+	tests := []struct {
+		name             string
+		checkErr         error
+		shuttingDown     bool
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:           "Ready when dependencies are up",
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "ready",
+				Data: map[string]any{
+					"status": "up",
+					"checks": map[string]any{
+						"database": map[string]any{"status": "up"},
+						"shutdown": map[string]any{"status": "up"},
+					},
+				},
+			},
+		},
+		{
+			name:           "Not ready when database is down",
+			checkErr:       errors.New("connection refused"),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "not ready",
+				Data: map[string]any{
+					"status": "down",
+					"checks": map[string]any{
+						"database": map[string]any{"status": "down", "error": "connection refused"},
+						"shutdown": map[string]any{"status": "up"},
+					},
+				},
+			},
+		},
+		{
+			name:           "Not ready while shutting down",
+			shuttingDown:   true,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "not ready",
+				Data: map[string]any{
+					"status": "down",
+					"checks": map[string]any{
+						"database": map[string]any{"status": "up"},
+						"shutdown": map[string]any{"status": "down", "error": health.ErrShuttingDown.Error()},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			registry := health.NewRegistry(health.NewChecker("database", func(ctx context.Context) error {
+				return tt.checkErr
+			}))
+			if tt.shuttingDown {
+				registry.SetShuttingDown()
+			}
+			h := NewHealthHandler(registry)
+
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, h.Readyz(c))
+
+			var actualResponse Response
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actualResponse))
+			// latency varies from run to run
+			for _, check := range actualResponse.Data.(map[string]any)["checks"].(map[string]any) {
+				delete(check.(map[string]any), "latency_ms")
+			}
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse, actualResponse)
+		})
+	}
+}
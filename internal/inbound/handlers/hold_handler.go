@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"crud-echo/internal/inbound/customvalidator"
+	"crud-echo/internal/models"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type HandlerHoldUsecase interface {
+	PlaceHold(ctx context.Context, hold *models.CreateHoldsRequest) (*models.HoldsSummary, error)
+	GetHoldByID(ctx context.Context, id int) (*models.HoldsSummary, error)
+	CancelHold(ctx context.Context, id int) error
+}
+
+type HoldsHandler struct {
+	huc HandlerHoldUsecase
+	cv  *customvalidator.CustomValidator
+}
+
+func NewHoldsHandler(huc HandlerHoldUsecase, validator *customvalidator.CustomValidator) *HoldsHandler {
+	return &HoldsHandler{huc: huc, cv: validator}
+}
+
+func (h HoldsHandler) PlaceHold(c echo.Context) error {
+	var req models.CreateHoldsRequest
+
+	if err := c.Bind(&req); err != nil {
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(req); err != nil {
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
+	}
+
+	resp, err := h.huc.PlaceHold(c.Request().Context(), &req)
+	if err != nil {
+		logFor(c).Error("error placing hold", zap.Int("book_id", req.BookID), zap.Int("member_id", req.MemberID), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Hold has been placed", resp)
+}
+
+func (h HoldsHandler) GetHoldByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	resp, err := h.huc.GetHoldByID(c.Request().Context(), id)
+	if err != nil {
+		logFor(c).Error("error retrieving hold", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Hold retrieved successfully", resp)
+}
+
+func (h HoldsHandler) CancelHold(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	if err := h.huc.CancelHold(c.Request().Context(), id); err != nil {
+		logFor(c).Error("error cancelling hold", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Hold with ID "+strconv.Itoa(id)+" has been cancelled", nil)
+}
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	vc "crud-echo/internal/inbound/customvalidator"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type holdsTestContext struct {
+	*TestContext
+	Handler *HoldsHandler
+	Mock    *mocks.MockhandlerHoldUsecase
+}
+
+func holdsSetup(t *testing.T) *holdsTestContext {
+	e := echo.New()
+	e.HTTPErrorHandler = CustomHTTPErrorHandler
+
+	mockUsecase := mocks.NewMockhandlerHoldUsecase(t)
+	testValidator, err := vc.NewCustomValidator(validator.New())
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	return &holdsTestContext{
+		TestContext: &TestContext{Echo: e},
+		Handler:     NewHoldsHandler(mockUsecase, testValidator),
+		Mock:        mockUsecase,
+	}
+}
+
+func TestPlaceHold(t *testing.T) {
+	createdAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		param            string
+		requestBody      string
+		m                func(mockuc *mocks.MockhandlerHoldUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:        "Success place hold",
+			param:       "1",
+			requestBody: `{"member_id":7}`,
+			m: func(mockuc *mocks.MockhandlerHoldUsecase) {
+				mockuc.EXPECT().PlaceHold(anyCtx, &models.CreateHoldsRequest{BookID: 1, MemberID: 7}).
+					Return(&models.HoldsSummary{
+						ID:        3,
+						BookID:    1,
+						MemberID:  7,
+						Status:    models.HoldStatusWaiting,
+						Position:  2,
+						CreatedAt: createdAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Hold has been placed",
+				Data: map[string]any{
+					"id":         float64(3),
+					"book_id":    float64(1),
+					"member_id":  float64(7),
+					"status":     "waiting",
+					"position":   float64(2),
+					"created_at": "2026-10-01T12:00:00Z",
+				},
+			},
+		},
+		{
+			name:           "Failed place hold due to validation error",
+			param:          "1",
+			requestBody:    `{}`,
+			m:              func(mockuc *mocks.MockhandlerHoldUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"member_id": "This field is required"},
+			},
+		},
+		{
+			name:        "Failed place hold on book with copies",
+			param:       "1",
+			requestBody: `{"member_id":7}`,
+			m: func(mockuc *mocks.MockhandlerHoldUsecase) {
+				mockuc.EXPECT().PlaceHold(anyCtx, &models.CreateHoldsRequest{BookID: 1, MemberID: 7}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrCopiesAvailable))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "copies of the book are available, check one out instead",
+			},
+		},
+		{
+			name:        "Failed place second hold on same book",
+			param:       "1",
+			requestBody: `{"member_id":7}`,
+			m: func(mockuc *mocks.MockhandlerHoldUsecase) {
+				mockuc.EXPECT().PlaceHold(anyCtx, &models.CreateHoldsRequest{BookID: 1, MemberID: 7}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrHoldAlreadyExists))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "the member already holds this book",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := holdsSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodPost, "/book/:id/holds", "id", tt.param, tt.requestBody, tc.Handler.PlaceHold)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestGetHoldByID(t *testing.T) {
+	createdAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	readyAt := createdAt.Add(48 * time.Hour)
+	expiresAt := readyAt.Add(72 * time.Hour)
+
+	tests := []struct {
+		name             string
+		param            string
+		m                func(mockuc *mocks.MockhandlerHoldUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success get ready hold",
+			param: "3",
+			m: func(mockuc *mocks.MockhandlerHoldUsecase) {
+				mockuc.EXPECT().GetHoldByID(anyCtx, 3).Return(&models.HoldsSummary{
+					ID:        3,
+					BookID:    1,
+					MemberID:  7,
+					Status:    models.HoldStatusReady,
+					CreatedAt: createdAt,
+					ReadyAt:   &readyAt,
+					ExpiresAt: &expiresAt,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Hold retrieved successfully",
+				Data: map[string]any{
+					"id":         float64(3),
+					"book_id":    float64(1),
+					"member_id":  float64(7),
+					"status":     "ready",
+					"created_at": "2026-10-01T12:00:00Z",
+					"ready_at":   "2026-10-03T12:00:00Z",
+					"expires_at": "2026-10-06T12:00:00Z",
+				},
+			},
+		},
+		{
+			name:           "Failed get hold due to error converting ID param",
+			param:          "abc",
+			m:              func(mockuc *mocks.MockhandlerHoldUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.InvalidParam,
+			},
+		},
+		{
+			name:  "Failed get unknown hold",
+			param: "99",
+			m: func(mockuc *mocks.MockhandlerHoldUsecase) {
+				mockuc.EXPECT().GetHoldByID(anyCtx, 99).Return(nil, fmt.Errorf("repository error: %w", models.ErrHoldNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "hold not found",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := holdsSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodGet, "/holds/:id", "id", tt.param, "", tc.Handler.GetHoldByID)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestCancelHold(t *testing.T) {
+	tests := []struct {
+		name             string
+		param            string
+		m                func(mockuc *mocks.MockhandlerHoldUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success cancel hold",
+			param: "3",
+			m: func(mockuc *mocks.MockhandlerHoldUsecase) {
+				mockuc.EXPECT().CancelHold(anyCtx, 3).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Hold with ID 3 has been cancelled",
+			},
+		},
+		{
+			name:  "Failed cancel closed hold",
+			param: "3",
+			m: func(mockuc *mocks.MockhandlerHoldUsecase) {
+				mockuc.EXPECT().CancelHold(anyCtx, 3).Return(fmt.Errorf("repository error: %w", models.ErrHoldClosed))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "hold has been fulfilled, cancelled or has expired already",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := holdsSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodDelete, "/holds/:id", "id", tt.param, "", tc.Handler.CancelHold)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"crud-echo/internal/inbound/customvalidator"
+	"crud-echo/internal/models"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type HandlerLoanUsecase interface {
+	CheckoutBook(ctx context.Context, loan *models.CreateLoansRequest) (*models.LoansSummary, error)
+	GetLoanByID(ctx context.Context, id int) (*models.LoansSummary, error)
+	ReturnLoan(ctx context.Context, id int) (*models.LoansSummary, error)
+	RenewLoan(ctx context.Context, id int) (*models.LoansSummary, error)
+}
+
+type LoansHandler struct {
+	luc HandlerLoanUsecase
+	cv  *customvalidator.CustomValidator
+}
+
+func NewLoansHandler(luc HandlerLoanUsecase, validator *customvalidator.CustomValidator) *LoansHandler {
+	return &LoansHandler{luc: luc, cv: validator}
+}
+
+func (h LoansHandler) CheckoutBook(c echo.Context) error {
+	var l models.CreateLoansRequest
+
+	if err := c.Bind(&l); err != nil {
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(l); err != nil {
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
+	}
+
+	resp, err := h.luc.CheckoutBook(c.Request().Context(), &l)
+	if err != nil {
+		logFor(c).Error("error checking out book", zap.Int("book_id", l.BookID), zap.Int("member_id", l.MemberID), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Book has been checked out", resp)
+}
+
+func (h LoansHandler) GetLoanByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	resp, err := h.luc.GetLoanByID(c.Request().Context(), id)
+	if err != nil {
+		logFor(c).Error("error retrieving loan", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Loan retrieved successfully", resp)
+}
+
+func (h LoansHandler) ReturnLoan(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	resp, err := h.luc.ReturnLoan(c.Request().Context(), id)
+	if err != nil {
+		logFor(c).Error("error returning loan", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Loan with ID "+strconv.Itoa(id)+" has been returned", resp)
+}
+
+func (h LoansHandler) RenewLoan(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	resp, err := h.luc.RenewLoan(c.Request().Context(), id)
+	if err != nil {
+		logFor(c).Error("error renewing loan", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Loan with ID "+strconv.Itoa(id)+" has been renewed", resp)
+}
@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	vc "crud-echo/internal/inbound/customvalidator"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type loansTestContext struct {
+	*TestContext
+	Handler *LoansHandler
+	Mock    *mocks.MockhandlerLoanUsecase
+}
+
+func loansSetup(t *testing.T) *loansTestContext {
+	e := echo.New()
+	e.HTTPErrorHandler = CustomHTTPErrorHandler
+
+	mockUsecase := mocks.NewMockhandlerLoanUsecase(t)
+	testValidator, err := vc.NewCustomValidator(validator.New())
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	return &loansTestContext{
+		TestContext: &TestContext{Echo: e},
+		Handler:     NewLoansHandler(mockUsecase, testValidator),
+		Mock:        mockUsecase,
+	}
+}
+
+var (
+	testLoanedAt = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	testDueAt    = testLoanedAt.Add(14 * 24 * time.Hour)
+)
+
+func TestCheckoutBook(t *testing.T) {
+	tests := []struct {
+		name             string
+		requestBody      string
+		m                func(mockuc *mocks.MockhandlerLoanUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:        "Success checkout book",
+			requestBody: `{"book_id":2,"member_id":7}`,
+			m: func(mockuc *mocks.MockhandlerLoanUsecase) {
+				mockuc.EXPECT().CheckoutBook(anyCtx, &models.CreateLoansRequest{BookID: 2, MemberID: 7}).
+					Return(&models.LoansSummary{
+						ID:       1,
+						BookID:   2,
+						MemberID: 7,
+						Status:   models.LoanStatusActive,
+						LoanedAt: testLoanedAt,
+						DueAt:    testDueAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Book has been checked out",
+				Data: map[string]any{
+					"id":        float64(1),
+					"book_id":   float64(2),
+					"member_id": float64(7),
+					"status":    "active",
+					"loaned_at": "2026-10-01T12:00:00Z",
+					"due_at":    "2026-10-15T12:00:00Z",
+					"renewals":  float64(0),
+				},
+			},
+		},
+		{
+			name:           "Failed checkout book due to bind error",
+			requestBody:    `{,,,}`,
+			m:              func(mockuc *mocks.MockhandlerLoanUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.BadRequest,
+			},
+		},
+		{
+			name:           "Failed checkout book due to validation error",
+			requestBody:    `{"book_id":2}`,
+			m:              func(mockuc *mocks.MockhandlerLoanUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"member_id": "This field is required"},
+			},
+		},
+		{
+			name:        "Failed checkout book without copies left",
+			requestBody: `{"book_id":2,"member_id":7}`,
+			m: func(mockuc *mocks.MockhandlerLoanUsecase) {
+				mockuc.EXPECT().CheckoutBook(anyCtx, &models.CreateLoansRequest{BookID: 2, MemberID: 7}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrNoCopiesAvailable.Wrap(models.ErrInsufficientStock)))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "no copies of the book are available",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := loansSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequest(http.MethodPost, "/loans", tt.requestBody, tc.Handler.CheckoutBook)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestReturnLoan(t *testing.T) {
+	returnedAt := testLoanedAt.Add(3 * 24 * time.Hour)
+
+	tests := []struct {
+		name             string
+		param            string
+		m                func(mockuc *mocks.MockhandlerLoanUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success return loan",
+			param: "1",
+			m: func(mockuc *mocks.MockhandlerLoanUsecase) {
+				mockuc.EXPECT().ReturnLoan(anyCtx, 1).Return(&models.LoansSummary{
+					ID:         1,
+					BookID:     2,
+					MemberID:   7,
+					Status:     models.LoanStatusReturned,
+					LoanedAt:   testLoanedAt,
+					DueAt:      testDueAt,
+					ReturnedAt: &returnedAt,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Loan with ID 1 has been returned",
+				Data: map[string]any{
+					"id":          float64(1),
+					"book_id":     float64(2),
+					"member_id":   float64(7),
+					"status":      "returned",
+					"loaned_at":   "2026-10-01T12:00:00Z",
+					"due_at":      "2026-10-15T12:00:00Z",
+					"returned_at": "2026-10-04T12:00:00Z",
+					"renewals":    float64(0),
+				},
+			},
+		},
+		{
+			name:           "Failed return loan due to error converting ID param",
+			param:          "abc",
+			m:              func(mockuc *mocks.MockhandlerLoanUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.InvalidParam,
+			},
+		},
+		{
+			name:  "Failed return loan already returned",
+			param: "1",
+			m: func(mockuc *mocks.MockhandlerLoanUsecase) {
+				mockuc.EXPECT().ReturnLoan(anyCtx, 1).Return(nil, fmt.Errorf("repository error: %w", models.ErrLoanAlreadyReturned))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "loan has been returned already",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := loansSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodPost, "/loans/:id/return", "id", tt.param, "", tc.Handler.ReturnLoan)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestRenewLoan(t *testing.T) {
+	tests := []struct {
+		name             string
+		param            string
+		m                func(mockuc *mocks.MockhandlerLoanUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success renew loan",
+			param: "1",
+			m: func(mockuc *mocks.MockhandlerLoanUsecase) {
+				mockuc.EXPECT().RenewLoan(anyCtx, 1).Return(&models.LoansSummary{
+					ID:       1,
+					BookID:   2,
+					MemberID: 7,
+					Status:   models.LoanStatusActive,
+					LoanedAt: testLoanedAt,
+					DueAt:    testDueAt.Add(14 * 24 * time.Hour),
+					Renewals: 1,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Loan with ID 1 has been renewed",
+				Data: map[string]any{
+					"id":        float64(1),
+					"book_id":   float64(2),
+					"member_id": float64(7),
+					"status":    "active",
+					"loaned_at": "2026-10-01T12:00:00Z",
+					"due_at":    "2026-10-29T12:00:00Z",
+					"renewals":  float64(1),
+				},
+			},
+		},
+		{
+			name:  "Failed renew loan out of renewals",
+			param: "1",
+			m: func(mockuc *mocks.MockhandlerLoanUsecase) {
+				mockuc.EXPECT().RenewLoan(anyCtx, 1).Return(nil, fmt.Errorf("repository error: %w", models.ErrLoanRenewalLimit))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "loan has been renewed as often as allowed",
+			},
+		},
+		{
+			name:  "Failed renew unknown loan",
+			param: "99",
+			m: func(mockuc *mocks.MockhandlerLoanUsecase) {
+				mockuc.EXPECT().RenewLoan(anyCtx, 99).Return(nil, fmt.Errorf("repository error: %w", models.ErrLoanNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "loan not found",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := loansSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodPost, "/loans/:id/renew", "id", tt.param, "", tc.Handler.RenewLoan)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"crud-echo/internal/inbound/customvalidator"
+	"crud-echo/internal/models"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type HandlerMemberUsecase interface {
+	CreateMember(ctx context.Context, member *models.CreateMembersRequest) (*models.MembersSummary, error)
+	GetMemberByID(ctx context.Context, id int) (*models.MembersSummary, error)
+	GetAllMembers(ctx context.Context, name string, page *models.PageRequest) (*[]models.MembersSummary, *models.PageMeta, error)
+	UpdateMember(ctx context.Context, member *models.UpdateMembersRequest) (*models.MembersSummary, error)
+	GetMemberLoans(ctx context.Context, id int, status string, page *models.PageRequest) (*[]models.LoansSummary, *models.PageMeta, error)
+}
+
+type MembersHandler struct {
+	muc HandlerMemberUsecase
+	cv  *customvalidator.CustomValidator
+}
+
+func NewMembersHandler(muc HandlerMemberUsecase, validator *customvalidator.CustomValidator) *MembersHandler {
+	return &MembersHandler{muc: muc, cv: validator}
+}
+
+func (h MembersHandler) CreateMember(c echo.Context) error {
+	var m models.CreateMembersRequest
+
+	if err := c.Bind(&m); err != nil {
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(m); err != nil {
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
+	}
+
+	resp, err := h.muc.CreateMember(c.Request().Context(), &m)
+	if err != nil {
+		logFor(c).Error("error creating member", zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Member has been created", resp)
+}
+
+func (h MembersHandler) GetMemberByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	resp, err := h.muc.GetMemberByID(c.Request().Context(), id)
+	if err != nil {
+		logFor(c).Error("error retrieving member", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Member retrieved successfully", resp)
+}
+
+func (h MembersHandler) GetAllMembers(c echo.Context) error {
+	var q models.GetAllMembersRequest
+
+	if err := c.Bind(&q); err != nil {
+		logFor(c).Warn("error binding query params", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(q); err != nil {
+		logFor(c).Warn("error validating query params", zap.Error(err))
+		return err
+	}
+
+	resp, meta, err := h.muc.GetAllMembers(c.Request().Context(), strings.TrimSpace(q.Name), pageRequest(q.Limit, q.Offset))
+	if err != nil {
+		logFor(c).Error("error retrieving members", zap.Error(err))
+		return err
+	}
+
+	return CustomResponseWithMeta(c, http.StatusOK, true, "Members retrieved successfully", resp, meta)
+}
+
+func (h MembersHandler) UpdateMember(c echo.Context) error {
+	var m models.UpdateMembersRequest
+
+	if err := c.Bind(&m); err != nil {
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(m); err != nil {
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
+	}
+
+	resp, err := h.muc.UpdateMember(c.Request().Context(), &m)
+	if err != nil {
+		logFor(c).Error("error updating member", zap.Int("id", m.ID), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Member with ID "+strconv.Itoa(m.ID)+" has been updated", resp)
+}
+
+func (h MembersHandler) GetMemberLoans(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	var q models.GetMemberLoansRequest
+
+	if err := c.Bind(&q); err != nil {
+		logFor(c).Warn("error binding query params", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(q); err != nil {
+		logFor(c).Warn("error validating query params", zap.Error(err))
+		return err
+	}
+
+	resp, meta, err := h.muc.GetMemberLoans(c.Request().Context(), id, q.Status, pageRequest(q.Limit, q.Offset))
+	if err != nil {
+		logFor(c).Error("error retrieving loans of member", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponseWithMeta(c, http.StatusOK, true, "Loans retrieved successfully", resp, meta)
+}
@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	vc "crud-echo/internal/inbound/customvalidator"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type membersTestContext struct {
+	*TestContext
+	Handler *MembersHandler
+	Mock    *mocks.MockhandlerMemberUsecase
+}
+
+func membersSetup(t *testing.T) *membersTestContext {
+	e := echo.New()
+	e.HTTPErrorHandler = CustomHTTPErrorHandler
+
+	mockUsecase := mocks.NewMockhandlerMemberUsecase(t)
+	testValidator, err := vc.NewCustomValidator(validator.New())
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	return &membersTestContext{
+		TestContext: &TestContext{Echo: e},
+		Handler:     NewMembersHandler(mockUsecase, testValidator),
+		Mock:        mockUsecase,
+	}
+}
+
+func TestCreateMember(t *testing.T) {
+	tests := []struct {
+		name             string
+		requestBody      string
+		m                func(mockuc *mocks.MockhandlerMemberUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:        "Success create member",
+			requestBody: `{"name":"Paul Atreides","email":"paul@arrakis.org"}`,
+			m: func(mockuc *mocks.MockhandlerMemberUsecase) {
+				mockuc.EXPECT().CreateMember(anyCtx, &models.CreateMembersRequest{Name: "Paul Atreides", Email: "paul@arrakis.org"}).
+					Return(&models.MembersSummary{ID: 1, Name: "Paul Atreides", Email: "paul@arrakis.org"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Member has been created",
+				Data:    map[string]any{"id": float64(1), "name": "Paul Atreides", "email": "paul@arrakis.org"},
+			},
+		},
+		{
+			name:           "Failed create member due to bind error",
+			requestBody:    `{,,,}`,
+			m:              func(mockuc *mocks.MockhandlerMemberUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.BadRequest,
+			},
+		},
+		{
+			name:           "Failed create member due to validation error",
+			requestBody:    `{"name":"Paul Atreides","email":"paul"}`,
+			m:              func(mockuc *mocks.MockhandlerMemberUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"email": "Should be an email address"},
+			},
+		},
+		{
+			name:        "Failed create member due to email taken",
+			requestBody: `{"name":"Paul Atreides","email":"paul@arrakis.org"}`,
+			m: func(mockuc *mocks.MockhandlerMemberUsecase) {
+				mockuc.EXPECT().CreateMember(anyCtx, &models.CreateMembersRequest{Name: "Paul Atreides", Email: "paul@arrakis.org"}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrMemberEmailTaken))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "a member with this email already exists",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := membersSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequest(http.MethodPost, "/members", tt.requestBody, tc.Handler.CreateMember)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestGetMemberByID(t *testing.T) {
+	tests := []struct {
+		name             string
+		param            string
+		m                func(mockuc *mocks.MockhandlerMemberUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success get member by ID",
+			param: "1",
+			m: func(mockuc *mocks.MockhandlerMemberUsecase) {
+				mockuc.EXPECT().GetMemberByID(anyCtx, 1).Return(&models.MembersSummary{ID: 1, Name: "Paul Atreides", Email: "paul@arrakis.org"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Member retrieved successfully",
+				Data:    map[string]any{"id": float64(1), "name": "Paul Atreides", "email": "paul@arrakis.org"},
+			},
+		},
+		{
+			name:           "Failed get member by ID due to error converting ID param",
+			param:          "abc",
+			m:              func(mockuc *mocks.MockhandlerMemberUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.InvalidParam,
+			},
+		},
+		{
+			name:  "Failed get member by ID not found",
+			param: "99",
+			m: func(mockuc *mocks.MockhandlerMemberUsecase) {
+				mockuc.EXPECT().GetMemberByID(anyCtx, 99).Return(nil, fmt.Errorf("repository error: %w", models.ErrMemberNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "member not found",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := membersSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodGet, "/members/:id", "id", tt.param, "", tc.Handler.GetMemberByID)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestUpdateMember(t *testing.T) {
+	tests := []struct {
+		name             string
+		param            string
+		requestBody      string
+		m                func(mockuc *mocks.MockhandlerMemberUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:        "Success update member",
+			param:       "1",
+			requestBody: `{"name":"Paul Atreides","email":"muaddib@arrakis.org"}`,
+			m: func(mockuc *mocks.MockhandlerMemberUsecase) {
+				mockuc.EXPECT().UpdateMember(anyCtx, &models.UpdateMembersRequest{ID: 1, Name: "Paul Atreides", Email: "muaddib@arrakis.org"}).
+					Return(&models.MembersSummary{ID: 1, Name: "Paul Atreides", Email: "muaddib@arrakis.org"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Member with ID 1 has been updated",
+				Data:    map[string]any{"id": float64(1), "name": "Paul Atreides", "email": "muaddib@arrakis.org"},
+			},
+		},
+		{
+			name:           "Failed update member due to validation error",
+			param:          "1",
+			requestBody:    `{"name":"P"}`,
+			m:              func(mockuc *mocks.MockhandlerMemberUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"name": "Should be at least 2 characters long", "email": "This field is required"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := membersSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodPut, "/members/:id", "id", tt.param, tt.requestBody, tc.Handler.UpdateMember)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestGetMemberLoans(t *testing.T) {
+	loanedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	dueAt := loanedAt.Add(14 * 24 * time.Hour)
+
+	tests := []struct {
+		name             string
+		path             string
+		param            string
+		m                func(mockuc *mocks.MockhandlerMemberUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success get overdue loans of member",
+			path:  "/members/:id/loans?status=overdue&limit=5",
+			param: "7",
+			m: func(mockuc *mocks.MockhandlerMemberUsecase) {
+				mockuc.EXPECT().GetMemberLoans(anyCtx, 7, models.LoanStatusOverdue, &models.PageRequest{Limit: 5}).
+					Return(&[]models.LoansSummary{{
+						ID:       1,
+						BookID:   2,
+						MemberID: 7,
+						Status:   models.LoanStatusOverdue,
+						LoanedAt: loanedAt,
+						DueAt:    dueAt,
+					}}, &models.PageMeta{Total: 1, Limit: 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Loans retrieved successfully",
+				Data: []any{map[string]any{
+					"id":        float64(1),
+					"book_id":   float64(2),
+					"member_id": float64(7),
+					"status":    "overdue",
+					"loaned_at": "2026-10-01T12:00:00Z",
+					"due_at":    "2026-10-15T12:00:00Z",
+					"renewals":  float64(0),
+				}},
+				Meta: map[string]any{"total": float64(1), "limit": float64(5)},
+			},
+		},
+		{
+			name:           "Failed get loans of member due to unknown status",
+			path:           "/members/:id/loans?status=lost",
+			param:          "7",
+			m:              func(mockuc *mocks.MockhandlerMemberUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"status": "Should be one of active, overdue, returned"},
+			},
+		},
+		{
+			name:  "Failed get loans of unknown member",
+			path:  "/members/:id/loans",
+			param: "99",
+			m: func(mockuc *mocks.MockhandlerMemberUsecase) {
+				mockuc.EXPECT().GetMemberLoans(anyCtx, 99, "", &models.PageRequest{Limit: models.DefaultPageLimit}).
+					Return(nil, nil, fmt.Errorf("repository error: %w", models.ErrMemberNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: "member not found",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := membersSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodGet, tt.path, "id", tt.param, "", tc.Handler.GetMemberLoans)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+			assert.Equal(t, tt.expectedResponse.Meta, actualResponse.Meta)
+		})
+	}
+}
@@ -6,8 +6,10 @@ import (
 
 type Response struct {
 	Status  bool   `json:"status"`
+	Code    string `json:"code,omitempty"` // the DomainError code when status is false
 	Message string `json:"message"`
 	Data    any    `json:"data,omitempty"` // maybe for other things
+	Meta    any    `json:"meta,omitempty"` // pagination and the like
 }
 
 func CustomResponse(c echo.Context, code int, status bool, message string, data any) error {
@@ -19,3 +21,14 @@ func CustomResponse(c echo.Context, code int, status bool, message string, data
 
 	return c.JSON(code, resp)
 }
+
+func CustomResponseWithMeta(c echo.Context, code int, status bool, message string, data any, meta any) error {
+	resp := Response{
+		Status:  status,
+		Message: message,
+		Data:    data,
+		Meta:    meta,
+	}
+
+	return c.JSON(code, resp)
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"crud-echo/internal/inbound/customvalidator"
+	"crud-echo/internal/models"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type HandlerTagUsecase interface {
+	CreateTag(ctx context.Context, tag *models.CreateTagsRequest) (*models.TagsSummary, error)
+	GetAllTags(ctx context.Context, name string, page *models.PageRequest) (*[]models.TagsSummary, *models.PageMeta, error)
+	DeleteTag(ctx context.Context, id int) error
+	SetBookTags(ctx context.Context, bookID int, names []string) (*[]models.TagsSummary, error)
+	GetBookTags(ctx context.Context, bookID int) (*[]models.TagsSummary, error)
+}
+
+type TagsHandler struct {
+	tuc HandlerTagUsecase
+	cv  *customvalidator.CustomValidator
+}
+
+func NewTagsHandler(tuc HandlerTagUsecase, validator *customvalidator.CustomValidator) *TagsHandler {
+	return &TagsHandler{tuc: tuc, cv: validator}
+}
+
+func (h TagsHandler) CreateTag(c echo.Context) error {
+	var t models.CreateTagsRequest
+
+	if err := c.Bind(&t); err != nil {
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(t); err != nil {
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
+	}
+
+	resp, err := h.tuc.CreateTag(c.Request().Context(), &t)
+	if err != nil {
+		logFor(c).Error("error creating tag", zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Tag has been created", resp)
+}
+
+func (h TagsHandler) GetAllTags(c echo.Context) error {
+	var q models.GetAllTagsRequest
+
+	if err := c.Bind(&q); err != nil {
+		logFor(c).Warn("error binding query params", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(q); err != nil {
+		logFor(c).Warn("error validating query params", zap.Error(err))
+		return err
+	}
+
+	resp, meta, err := h.tuc.GetAllTags(c.Request().Context(), q.Name, pageRequest(q.Limit, q.Offset))
+	if err != nil {
+		logFor(c).Error("error retrieving tags", zap.Error(err))
+		return err
+	}
+
+	return CustomResponseWithMeta(c, http.StatusOK, true, "Tags retrieved successfully", resp, meta)
+}
+
+func (h TagsHandler) DeleteTag(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	if err := h.tuc.DeleteTag(c.Request().Context(), id); err != nil {
+		logFor(c).Error("error deleting tag", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Tag with ID "+strconv.Itoa(id)+" has been deleted", nil)
+}
+
+// SetBookTags replaces the tags of the book, an empty list removes them all
+func (h TagsHandler) SetBookTags(c echo.Context) error {
+	var req models.SetBookTagsRequest
+
+	if err := c.Bind(&req); err != nil {
+		logFor(c).Warn("error binding request", zap.Error(err))
+		return models.ErrBadRequest.Wrap(err)
+	}
+
+	if err := h.cv.Validate(req); err != nil {
+		logFor(c).Warn("error validating request", zap.Error(err))
+		return err
+	}
+
+	resp, err := h.tuc.SetBookTags(c.Request().Context(), req.BookID, req.Tags)
+	if err != nil {
+		logFor(c).Error("error setting tags of book", zap.Int("id", req.BookID), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Tags of book with ID "+strconv.Itoa(req.BookID)+" have been set", resp)
+}
+
+func (h TagsHandler) GetBookTags(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logFor(c).Warn("error converting id to integer", zap.Error(err))
+		return models.ErrInvalidParam.Wrap(err)
+	}
+
+	resp, err := h.tuc.GetBookTags(c.Request().Context(), id)
+	if err != nil {
+		logFor(c).Error("error retrieving tags of book", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	return CustomResponse(c, http.StatusOK, true, "Tags retrieved successfully", resp)
+}
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	vc "crud-echo/internal/inbound/customvalidator"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type tagsTestContext struct {
+	*TestContext
+	Handler *TagsHandler
+	Mock    *mocks.MockhandlerTagUsecase
+}
+
+func tagsSetup(t *testing.T) *tagsTestContext {
+	e := echo.New()
+	e.HTTPErrorHandler = CustomHTTPErrorHandler
+
+	mockUsecase := mocks.NewMockhandlerTagUsecase(t)
+	testValidator, err := vc.NewCustomValidator(validator.New())
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	return &tagsTestContext{
+		TestContext: &TestContext{Echo: e},
+		Handler:     NewTagsHandler(mockUsecase, testValidator),
+		Mock:        mockUsecase,
+	}
+}
+
+func TestCreateTag(t *testing.T) {
+	tests := []struct {
+		name             string
+		requestBody      string
+		m                func(mockuc *mocks.MockhandlerTagUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:        "Success create tag",
+			requestBody: `{"name":"Classic"}`,
+			m: func(mockuc *mocks.MockhandlerTagUsecase) {
+				mockuc.EXPECT().CreateTag(anyCtx, &models.CreateTagsRequest{Name: "Classic"}).
+					Return(&models.TagsSummary{ID: 1, Name: "classic"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Tag has been created",
+				Data:    map[string]any{"id": float64(1), "name": "classic"},
+			},
+		},
+		{
+			name:           "Failed create tag due to comma in name",
+			requestBody:    `{"name":"classic,award"}`,
+			m:              func(mockuc *mocks.MockhandlerTagUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"name": `Should not contain any of ","`},
+			},
+		},
+		{
+			name:        "Failed create tag due to name taken",
+			requestBody: `{"name":"classic"}`,
+			m: func(mockuc *mocks.MockhandlerTagUsecase) {
+				mockuc.EXPECT().CreateTag(anyCtx, &models.CreateTagsRequest{Name: "classic"}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrTagNameTaken))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrTagNameTaken.Message,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := tagsSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequest(http.MethodPost, "/tags", tt.requestBody, tc.Handler.CreateTag)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
+
+func TestGetAllTags(t *testing.T) {
+	tc := tagsSetup(t)
+	tc.Mock.EXPECT().GetAllTags(anyCtx, "cl", &models.PageRequest{Limit: 5}).
+		Return(&[]models.TagsSummary{{ID: 1, Name: "classic"}}, &models.PageMeta{Total: 1, Limit: 5}, nil)
+
+	rec := tc.executeRequestWithQuery(http.MethodGet, "/tags", "name=cl&limit=5", "", tc.Handler.GetAllTags)
+	actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "Tags retrieved successfully", actualResponse.Message)
+	assert.Equal(t, []any{map[string]any{"id": float64(1), "name": "classic"}}, actualResponse.Data)
+	assert.Equal(t, map[string]any{"total": float64(1), "limit": float64(5)}, actualResponse.Meta)
+}
+
+func TestDeleteTag(t *testing.T) {
+	tests := []struct {
+		name             string
+		param            string
+		m                func(mockuc *mocks.MockhandlerTagUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:  "Success delete tag",
+			param: "1",
+			m: func(mockuc *mocks.MockhandlerTagUsecase) {
+				mockuc.EXPECT().DeleteTag(anyCtx, 1).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Tag with ID 1 has been deleted",
+			},
+		},
+		{
+			name:  "Failed delete tag due to tag not found",
+			param: "99",
+			m: func(mockuc *mocks.MockhandlerTagUsecase) {
+				mockuc.EXPECT().DeleteTag(anyCtx, 99).Return(fmt.Errorf("repository error: %w", models.ErrTagNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrTagNotFound.Message,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := tagsSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodDelete, "/tags/:id", "id", tt.param, "", tc.Handler.DeleteTag)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+		})
+	}
+}
+
+func TestSetBookTags(t *testing.T) {
+	tests := []struct {
+		name             string
+		param            string
+		requestBody      string
+		m                func(mockuc *mocks.MockhandlerTagUsecase)
+		expectedStatus   int
+		expectedResponse Response
+	}{
+		{
+			name:        "Success set tags of a book",
+			param:       "1",
+			requestBody: `{"tags":["Classic","award"]}`,
+			m: func(mockuc *mocks.MockhandlerTagUsecase) {
+				mockuc.EXPECT().SetBookTags(anyCtx, 1, []string{"Classic", "award"}).
+					Return(&[]models.TagsSummary{{ID: 2, Name: "award"}, {ID: 1, Name: "classic"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: Response{
+				Status:  true,
+				Message: "Tags of book with ID 1 have been set",
+				Data: []any{
+					map[string]any{"id": float64(2), "name": "award"},
+					map[string]any{"id": float64(1), "name": "classic"},
+				},
+			},
+		},
+		{
+			name:           "Failed set tags due to empty name",
+			param:          "1",
+			requestBody:    `{"tags":["classic",""]}`,
+			m:              func(mockuc *mocks.MockhandlerTagUsecase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ValidationFailed,
+				Data:    map[string]any{"tags[1]": "This field is required"},
+			},
+		},
+		{
+			name:        "Failed set tags due to book not found",
+			param:       "99",
+			requestBody: `{"tags":["classic"]}`,
+			m: func(mockuc *mocks.MockhandlerTagUsecase) {
+				mockuc.EXPECT().SetBookTags(anyCtx, 99, []string{"classic"}).
+					Return(nil, fmt.Errorf("repository error: %w", models.ErrBookNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedResponse: Response{
+				Status:  false,
+				Message: models.ErrBookNotFound.Message,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := tagsSetup(t)
+			tt.m(tc.Mock)
+
+			rec := tc.executeRequestWithParam(http.MethodPut, "/book/:id/tags", "id", tt.param, tt.requestBody, tc.Handler.SetBookTags)
+			actualResponse := tc.unmarshalJSONResponse(t, rec.Body.String())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedResponse.Status, actualResponse.Status)
+			assert.Equal(t, tt.expectedResponse.Message, actualResponse.Message)
+			assert.Equal(t, tt.expectedResponse.Data, actualResponse.Data)
+		})
+	}
+}
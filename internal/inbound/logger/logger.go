@@ -1,7 +0,0 @@
-package logger
-
-import "go.uber.org/zap"
-
-func init() {
-	zap.ReplaceGlobals(zap.Must(zap.NewProduction()))
-}
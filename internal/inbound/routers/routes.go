@@ -1,22 +1,46 @@
 package routers
 
 import (
+	"crud-echo/internal/config"
 	"crud-echo/internal/inbound/handlers"
 	"crud-echo/internal/inbound/server"
+	"crud-echo/internal/models"
+	"crud-echo/pkg/metrics"
+	"crypto/subtle"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 )
 
 type Router struct {
-	srv *server.Server
-	h   *handlers.BooksHandler
+	srv     *server.Server
+	h       *handlers.BooksHandler
+	ah      *handlers.AuthorsHandler
+	ch      *handlers.CategoriesHandler
+	th      *handlers.TagsHandler
+	mh      *handlers.MembersHandler
+	lh      *handlers.LoansHandler
+	hoh     *handlers.HoldsHandler
+	hh      *handlers.HealthHandler
+	cfg     *config.Config
+	metrics *metrics.Metrics
 }
 
-func NewRouter(srv *server.Server, h *handlers.BooksHandler) *Router {
+func NewRouter(srv *server.Server, h *handlers.BooksHandler, ah *handlers.AuthorsHandler, ch *handlers.CategoriesHandler, th *handlers.TagsHandler, mh *handlers.MembersHandler, lh *handlers.LoansHandler, hoh *handlers.HoldsHandler, hh *handlers.HealthHandler, cfg *config.Config, m *metrics.Metrics) *Router {
 	return &Router{
-		srv: srv,
-		h:   h,
+		srv:     srv,
+		h:       h,
+		ah:      ah,
+		ch:      ch,
+		th:      th,
+		mh:      mh,
+		lh:      lh,
+		hoh:     hoh,
+		hh:      hh,
+		cfg:     cfg,
+		metrics: m,
 	}
 }
 
@@ -27,9 +51,85 @@ func (r *Router) RegisterRoutes() {
 		return c.String(http.StatusOK, "Hello, World!")
 	})
 
+	e.GET("/healthz", r.hh.Healthz)
+	e.GET("/readyz", r.hh.Readyz)
+	e.GET("/metrics", r.metrics.Handler())
+
 	e.POST("/book", r.h.CreateBook)
 	e.GET("/books", r.h.GetAllBooks)
+	e.GET("/books/search", r.h.SearchBooks)
+	e.GET("/books/trash", r.h.GetTrashedBooks)
+	e.POST("/books/bulk", r.h.BulkCreateBooks)
+	e.PUT("/books/bulk", r.h.BulkUpdateBooks)
+	e.DELETE("/books/bulk", r.h.BulkDeleteBooks)
+	e.GET("/books/export", r.h.ExportBooks)
+	e.POST("/books/import", r.h.ImportBooks)
 	e.GET("/book/:id", r.h.GetBookByID)
+	e.GET("/book/isbn/:isbn", r.h.GetBookByISBN)
 	e.PUT("/book", r.h.UpdateBook)
+	e.PATCH("/book/:id", r.h.PatchBook)
 	e.DELETE("/book", r.h.DeleteBook)
+	e.DELETE("/book/:id", r.h.DeleteBookByID, r.adminOnly(skipUnlessPurge))
+	e.POST("/book/:id/restore", r.h.RestoreBook)
+	e.POST("/book/:id/stock/increase", r.h.IncreaseStock)
+	e.POST("/book/:id/stock/decrease", r.h.DecreaseStock)
+	e.GET("/book/:id/stock/history", r.h.GetStockHistory)
+	e.GET("/book/:id/categories", r.ch.GetBookCategories)
+	e.PUT("/book/:id/categories", r.ch.SetBookCategories)
+	e.GET("/book/:id/tags", r.th.GetBookTags)
+	e.PUT("/book/:id/tags", r.th.SetBookTags)
+	e.POST("/book/:id/holds", r.hoh.PlaceHold)
+
+	e.POST("/authors", r.ah.CreateAuthor)
+	e.GET("/authors", r.ah.GetAllAuthors)
+	e.GET("/authors/:id", r.ah.GetAuthorByID)
+	e.PUT("/authors/:id", r.ah.UpdateAuthor)
+	e.DELETE("/authors/:id", r.ah.DeleteAuthor)
+	e.GET("/authors/:id/books", r.ah.GetAuthorBooks)
+
+	e.POST("/categories", r.ch.CreateCategory)
+	e.GET("/categories", r.ch.GetAllCategories)
+	e.GET("/categories/:id", r.ch.GetCategoryByID)
+	e.PUT("/categories/:id", r.ch.UpdateCategory)
+	e.DELETE("/categories/:id", r.ch.DeleteCategory)
+
+	e.POST("/tags", r.th.CreateTag)
+	e.GET("/tags", r.th.GetAllTags)
+	e.DELETE("/tags/:id", r.th.DeleteTag)
+
+	e.POST("/members", r.mh.CreateMember)
+	e.GET("/members", r.mh.GetAllMembers)
+	e.GET("/members/:id", r.mh.GetMemberByID)
+	e.PUT("/members/:id", r.mh.UpdateMember)
+	e.GET("/members/:id/loans", r.mh.GetMemberLoans)
+
+	e.POST("/loans", r.lh.CheckoutBook)
+	e.GET("/loans/:id", r.lh.GetLoanByID)
+	e.POST("/loans/:id/return", r.lh.ReturnLoan)
+	e.POST("/loans/:id/renew", r.lh.RenewLoan)
+
+	e.GET("/holds/:id", r.hoh.GetHoldByID)
+	e.DELETE("/holds/:id", r.hoh.CancelHold)
+}
+
+// adminOnly checks the admin bearer token unless skip says the request
+// doesn't need it
+func (r *Router) adminOnly(skip middleware.Skipper) echo.MiddlewareFunc {
+	token := []byte(r.cfg.Auth.AdminToken)
+
+	return middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
+		Skipper: skip,
+		Validator: func(key string, c echo.Context) (bool, error) {
+			return len(token) > 0 && subtle.ConstantTimeCompare([]byte(key), token) == 1, nil
+		},
+		ErrorHandler: func(err error, c echo.Context) error {
+			return models.ErrUnauthorized.Wrap(err)
+		},
+	})
+}
+
+// a purge param that doesn't parse is left for the handler to reject
+func skipUnlessPurge(c echo.Context) bool {
+	purge, err := strconv.ParseBool(c.QueryParam("purge"))
+	return err != nil || !purge
 }
@@ -0,0 +1,19 @@
+package server
+
+import (
+	"crud-echo/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// actor puts who the client says it acts for in the request context, it
+// ends up on the stock ledger. There is no authentication behind it
+func actor() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(models.WithActor(req.Context(), req.Header.Get(models.HeaderActor))))
+			return next(c)
+		}
+	}
+}
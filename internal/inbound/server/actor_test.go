@@ -0,0 +1,48 @@
+package server
+
+import (
+	"crud-echo/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActor(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "Takes the header", header: "alice", expected: "alice"},
+		{name: "Trims the header", header: "  bob ", expected: "bob"},
+		{name: "Anonymous without a header", expected: models.DefaultActor},
+		{name: "Cuts long names", header: strings.Repeat("a", 150), expected: strings.Repeat("a", models.MaxActorLen)},
+		{name: "Cuts long names by character", header: strings.Repeat("ž", 150), expected: strings.Repeat("ž", models.MaxActorLen)},
+		{name: "Keeps multi-byte names", header: "Zoë Ødegård", expected: "Zoë Ødegård"},
+		{name: "Replaces invalid UTF-8", header: "carol\xff\xfe", expected: "carol\uFFFD"},
+		{name: "Drops control characters", header: "dave\x00\x1b[31m", expected: "dave[31m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Use(actor())
+			e.GET("/", func(c echo.Context) error {
+				return c.String(http.StatusOK, models.ActorFromContext(c.Request().Context()))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(models.HeaderActor, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expected, rec.Body.String())
+		})
+	}
+}
@@ -1,39 +1,97 @@
 package server
 
 import (
+	"context"
 	"crud-echo/internal/config"
 	"crud-echo/internal/inbound/handlers"
+	"crud-echo/pkg/metrics"
+	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
 )
 
 type Server struct {
-	e   *echo.Echo
-	cfg *config.Config
+	e       *echo.Echo
+	cfg     *config.Config
+	metrics *metrics.Metrics
+	tp      *sdktrace.TracerProvider
+	log     *zap.Logger
 }
 
-func NewServer(cfg *config.Config) *Server {
+func NewServer(cfg *config.Config, m *metrics.Metrics, tp *sdktrace.TracerProvider, log *zap.Logger) *Server {
 	e := echo.New()
 
 	return &Server{
-		e:   e,
-		cfg: cfg,
+		e:       e,
+		cfg:     cfg,
+		metrics: m,
+		tp:      tp,
+		log:     log,
 	}
 }
 
+// Start blocks until the server stops, a stop caused by Shutdown is not an error
 func (s *Server) Start() error {
-	s.e.Use(middleware.Logger())
-	s.e.Use(middleware.Recover())
+	errorHandler, err := handlers.NewHTTPErrorHandler(s.cfg.Server.ErrorFormat)
+	if err != nil {
+		return err
+	}
+	s.e.HTTPErrorHandler = errorHandler
 
-	s.e.HTTPErrorHandler = handlers.CustomHTTPErrorHandler
+	// first so the latency covers the other middlewares too
+	s.e.Use(s.metrics.Middleware())
+	// picks up an inbound traceparent, the span ends up in the request
+	// context handlers pass down to the usecase
+	s.e.Use(otelecho.Middleware(s.cfg.Tracing.ServiceName,
+		otelecho.WithTracerProvider(s.tp),
+		otelecho.WithSkipper(skipTracing),
+	))
+	s.e.Use(requestID(s.log))
+	s.e.Use(requestLogger())
+	s.e.Use(actor())
+	s.e.Use(middleware.Recover())
 
 	s.e.Debug = true
 
+	s.e.Server.ReadTimeout = s.cfg.Server.ReadTimeout
+	s.e.Server.ReadHeaderTimeout = s.cfg.Server.ReadHeaderTimeout
+	s.e.Server.WriteTimeout = s.cfg.Server.WriteTimeout
+	s.e.Server.IdleTimeout = s.cfg.Server.IdleTimeout
+	s.e.Server.MaxHeaderBytes = s.cfg.Server.MaxHeaderBytes
+
 	// Start server
 	addr := fmt.Sprintf("%s:%d", s.cfg.Server.Host, s.cfg.Server.Port)
-	return s.e.Start(addr)
+	if err := s.e.Start(addr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Shutdown stops accepting connections and waits for in-flight requests until
+// ctx is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.e.Shutdown(ctx)
+}
+
+func (s *Server) ShutdownTimeout() time.Duration {
+	return s.cfg.Server.ShutdownTimeout
+}
+
+// probes and scrapes would drown out the traces worth looking at
+func skipTracing(c echo.Context) bool {
+	switch c.Path() {
+	case "/healthz", "/readyz", "/metrics":
+		return true
+	}
+	return false
 }
 
 func (s *Server) GetEcho() *echo.Echo {
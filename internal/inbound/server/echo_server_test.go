@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"crud-echo/internal/config"
+	"crud-echo/pkg/metrics"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+)
+
+func TestShutdownDrainsInFlightRequests(t *testing.T) {
+	srv := NewServer(&config.Config{Server: &config.Server{
+		Host:            "127.0.0.1",
+		Port:            0,
+		ReadTimeout:     time.Second,
+		WriteTimeout:    5 * time.Second,
+		ShutdownTimeout: 5 * time.Second,
+	}, Tracing: &config.Tracing{ServiceName: "test"}}, metrics.New(), sdktrace.NewTracerProvider(), zap.NewNop())
+
+	started := make(chan struct{})
+	srv.GetEcho().GET("/slow", func(c echo.Context) error {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		return c.String(http.StatusOK, "done")
+	})
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- srv.Start()
+	}()
+
+	var addr string
+	assert.Eventually(t, func() bool {
+		if a := srv.GetEcho().ListenerAddr(); a != nil {
+			addr = a.String()
+			return true
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 5*time.Second, srv.GetEcho().Server.WriteTimeout)
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	response := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + addr + "/slow")
+		if err != nil {
+			response <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		response <- result{status: resp.StatusCode, body: string(body), err: err}
+	}()
+
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), srv.ShutdownTimeout())
+	defer cancel()
+	assert.NoError(t, srv.Shutdown(ctx))
+
+	res := <-response
+	assert.NoError(t, res.err)
+	assert.Equal(t, http.StatusOK, res.status)
+	assert.Equal(t, "done", res.body)
+
+	assert.NoError(t, <-serverErr, "a shutdown should not be reported as an error")
+}
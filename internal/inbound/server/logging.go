@@ -0,0 +1,68 @@
+package server
+
+import (
+	"crud-echo/pkg/logger"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// requestID reuses an incoming X-Request-ID or makes one up, echoes it back
+// and puts a logger carrying it (and the trace ID when sampled) in the
+// request context for handlers and usecases
+func requestID(base *zap.Logger) echo.MiddlewareFunc {
+	return middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		RequestIDHandler: func(c echo.Context, id string) {
+			req := c.Request()
+			l := base.With(zap.String("request_id", id))
+			if sc := trace.SpanContextFromContext(req.Context()); sc.IsSampled() {
+				l = l.With(zap.String("trace_id", sc.TraceID().String()))
+			}
+			c.SetRequest(req.WithContext(logger.WithContext(req.Context(), l)))
+		},
+	})
+}
+
+// requestLogger writes one line per request once the error handler has
+// settled the status, 5xx as errors and 4xx as warnings
+func requestLogger() echo.MiddlewareFunc {
+	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
+		LogMethod:       true,
+		LogURI:          true,
+		LogRoutePath:    true,
+		LogStatus:       true,
+		LogLatency:      true,
+		LogResponseSize: true,
+		LogRemoteIP:     true,
+		LogError:        true,
+		HandleError:     true,
+		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+			fields := []zap.Field{
+				zap.String("method", v.Method),
+				zap.String("route", v.RoutePath),
+				zap.String("uri", v.URI),
+				zap.Int("status", v.Status),
+				zap.Duration("latency", v.Latency),
+				zap.Int64("bytes", v.ResponseSize),
+				zap.String("remote_ip", v.RemoteIP),
+			}
+			if v.Error != nil {
+				fields = append(fields, zap.Error(v.Error))
+			}
+
+			l := logger.FromContext(c.Request().Context())
+			switch {
+			case v.Status >= http.StatusInternalServerError:
+				l.Error("request", fields...)
+			case v.Status >= http.StatusBadRequest:
+				l.Warn("request", fields...)
+			default:
+				l.Info("request", fields...)
+			}
+			return nil
+		},
+	})
+}
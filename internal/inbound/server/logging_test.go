@@ -0,0 +1,100 @@
+package server
+
+import (
+	"crud-echo/internal/inbound/handlers"
+	"crud-echo/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestLogging(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		requestID     string
+		expectedLevel zapcore.Level
+		expectedCode  int
+		expectedBody  string
+	}{
+		{
+			name:          "Honours an incoming request ID",
+			path:          "/book/1",
+			requestID:     "abc-123",
+			expectedLevel: zapcore.InfoLevel,
+			expectedCode:  http.StatusOK,
+			expectedBody:  "ok",
+		},
+		{
+			name:          "Generates a request ID when there is none",
+			path:          "/book/1",
+			expectedLevel: zapcore.InfoLevel,
+			expectedCode:  http.StatusOK,
+			expectedBody:  "ok",
+		},
+		{
+			name:          "Client errors are warnings",
+			path:          "/book/0",
+			requestID:     "abc-123",
+			expectedLevel: zapcore.WarnLevel,
+			expectedCode:  http.StatusNotFound,
+			expectedBody:  "{\"status\":false,\"message\":\"not found\"}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+
+			e := echo.New()
+			e.HTTPErrorHandler = handlers.CustomHTTPErrorHandler
+			e.Use(requestID(zap.New(core)))
+			e.Use(requestLogger())
+			e.GET("/book/:id", func(c echo.Context) error {
+				logger.FromContext(c.Request().Context()).Info("from handler")
+				if c.Param("id") == "0" {
+					return echo.NewHTTPError(http.StatusNotFound, "not found")
+				}
+				return c.String(http.StatusOK, "ok")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.requestID != "" {
+				req.Header.Set(echo.HeaderXRequestID, tt.requestID)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			id := rec.Header().Get(echo.HeaderXRequestID)
+			if tt.requestID != "" {
+				assert.Equal(t, tt.requestID, id)
+			} else {
+				assert.NotEmpty(t, id)
+			}
+			// the error handler only writes once even though the logger hands the error on
+			assert.Equal(t, tt.expectedBody, rec.Body.String())
+
+			entries := logs.All()
+			assert.Len(t, entries, 2)
+			for _, entry := range entries {
+				assert.Equal(t, id, entry.ContextMap()["request_id"])
+			}
+
+			request := entries[1]
+			assert.Equal(t, "request", request.Message)
+			assert.Equal(t, tt.expectedLevel, request.Level)
+			fields := request.ContextMap()
+			assert.Equal(t, "/book/:id", fields["route"])
+			assert.Equal(t, int64(tt.expectedCode), fields["status"])
+			assert.Contains(t, fields, "latency")
+			assert.Contains(t, fields, "bytes")
+		})
+	}
+}
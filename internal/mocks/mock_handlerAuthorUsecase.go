@@ -0,0 +1,400 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "crud-echo/internal/models"
+)
+
+// MockhandlerAuthorUsecase is an autogenerated mock type for the handlerAuthorUsecase type
+type MockhandlerAuthorUsecase struct {
+	mock.Mock
+}
+
+type MockhandlerAuthorUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockhandlerAuthorUsecase) EXPECT() *MockhandlerAuthorUsecase_Expecter {
+	return &MockhandlerAuthorUsecase_Expecter{mock: &_m.Mock}
+}
+
+// CreateAuthor provides a mock function with given fields: ctx, author
+func (_m *MockhandlerAuthorUsecase) CreateAuthor(ctx context.Context, author *models.CreateAuthorsRequest) (*models.AuthorsSummary, error) {
+	ret := _m.Called(ctx, author)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAuthor")
+	}
+
+	var r0 *models.AuthorsSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateAuthorsRequest) (*models.AuthorsSummary, error)); ok {
+		return rf(ctx, author)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateAuthorsRequest) *models.AuthorsSummary); ok {
+		r0 = rf(ctx, author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthorsSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.CreateAuthorsRequest) error); ok {
+		r1 = rf(ctx, author)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerAuthorUsecase_CreateAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAuthor'
+type MockhandlerAuthorUsecase_CreateAuthor_Call struct {
+	*mock.Call
+}
+
+// CreateAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - author *models.CreateAuthorsRequest
+func (_e *MockhandlerAuthorUsecase_Expecter) CreateAuthor(ctx interface{}, author interface{}) *MockhandlerAuthorUsecase_CreateAuthor_Call {
+	return &MockhandlerAuthorUsecase_CreateAuthor_Call{Call: _e.mock.On("CreateAuthor", ctx, author)}
+}
+
+func (_c *MockhandlerAuthorUsecase_CreateAuthor_Call) Run(run func(ctx context.Context, author *models.CreateAuthorsRequest)) *MockhandlerAuthorUsecase_CreateAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.CreateAuthorsRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerAuthorUsecase_CreateAuthor_Call) Return(_a0 *models.AuthorsSummary, _a1 error) *MockhandlerAuthorUsecase_CreateAuthor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerAuthorUsecase_CreateAuthor_Call) RunAndReturn(run func(context.Context, *models.CreateAuthorsRequest) (*models.AuthorsSummary, error)) *MockhandlerAuthorUsecase_CreateAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAuthor provides a mock function with given fields: ctx, id
+func (_m *MockhandlerAuthorUsecase) DeleteAuthor(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAuthor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockhandlerAuthorUsecase_DeleteAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAuthor'
+type MockhandlerAuthorUsecase_DeleteAuthor_Call struct {
+	*mock.Call
+}
+
+// DeleteAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockhandlerAuthorUsecase_Expecter) DeleteAuthor(ctx interface{}, id interface{}) *MockhandlerAuthorUsecase_DeleteAuthor_Call {
+	return &MockhandlerAuthorUsecase_DeleteAuthor_Call{Call: _e.mock.On("DeleteAuthor", ctx, id)}
+}
+
+func (_c *MockhandlerAuthorUsecase_DeleteAuthor_Call) Run(run func(ctx context.Context, id int)) *MockhandlerAuthorUsecase_DeleteAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerAuthorUsecase_DeleteAuthor_Call) Return(_a0 error) *MockhandlerAuthorUsecase_DeleteAuthor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockhandlerAuthorUsecase_DeleteAuthor_Call) RunAndReturn(run func(context.Context, int) error) *MockhandlerAuthorUsecase_DeleteAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllAuthors provides a mock function with given fields: ctx, name, page
+func (_m *MockhandlerAuthorUsecase) GetAllAuthors(ctx context.Context, name string, page *models.PageRequest) (*[]models.AuthorsSummary, *models.PageMeta, error) {
+	ret := _m.Called(ctx, name, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllAuthors")
+	}
+
+	var r0 *[]models.AuthorsSummary
+	var r1 *models.PageMeta
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PageRequest) (*[]models.AuthorsSummary, *models.PageMeta, error)); ok {
+		return rf(ctx, name, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PageRequest) *[]models.AuthorsSummary); ok {
+		r0 = rf(ctx, name, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.AuthorsSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.PageRequest) *models.PageMeta); ok {
+		r1 = rf(ctx, name, page)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.PageMeta)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, *models.PageRequest) error); ok {
+		r2 = rf(ctx, name, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockhandlerAuthorUsecase_GetAllAuthors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllAuthors'
+type MockhandlerAuthorUsecase_GetAllAuthors_Call struct {
+	*mock.Call
+}
+
+// GetAllAuthors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - page *models.PageRequest
+func (_e *MockhandlerAuthorUsecase_Expecter) GetAllAuthors(ctx interface{}, name interface{}, page interface{}) *MockhandlerAuthorUsecase_GetAllAuthors_Call {
+	return &MockhandlerAuthorUsecase_GetAllAuthors_Call{Call: _e.mock.On("GetAllAuthors", ctx, name, page)}
+}
+
+func (_c *MockhandlerAuthorUsecase_GetAllAuthors_Call) Run(run func(ctx context.Context, name string, page *models.PageRequest)) *MockhandlerAuthorUsecase_GetAllAuthors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerAuthorUsecase_GetAllAuthors_Call) Return(_a0 *[]models.AuthorsSummary, _a1 *models.PageMeta, _a2 error) *MockhandlerAuthorUsecase_GetAllAuthors_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockhandlerAuthorUsecase_GetAllAuthors_Call) RunAndReturn(run func(context.Context, string, *models.PageRequest) (*[]models.AuthorsSummary, *models.PageMeta, error)) *MockhandlerAuthorUsecase_GetAllAuthors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthorBooks provides a mock function with given fields: ctx, id, page
+func (_m *MockhandlerAuthorUsecase) GetAuthorBooks(ctx context.Context, id int, page *models.PageRequest) (*[]models.BooksSummary, *models.PageMeta, error) {
+	ret := _m.Called(ctx, id, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorBooks")
+	}
+
+	var r0 *[]models.BooksSummary
+	var r1 *models.PageMeta
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *models.PageRequest) (*[]models.BooksSummary, *models.PageMeta, error)); ok {
+		return rf(ctx, id, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *models.PageRequest) *[]models.BooksSummary); ok {
+		r0 = rf(ctx, id, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.BooksSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *models.PageRequest) *models.PageMeta); ok {
+		r1 = rf(ctx, id, page)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.PageMeta)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, *models.PageRequest) error); ok {
+		r2 = rf(ctx, id, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockhandlerAuthorUsecase_GetAuthorBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthorBooks'
+type MockhandlerAuthorUsecase_GetAuthorBooks_Call struct {
+	*mock.Call
+}
+
+// GetAuthorBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - page *models.PageRequest
+func (_e *MockhandlerAuthorUsecase_Expecter) GetAuthorBooks(ctx interface{}, id interface{}, page interface{}) *MockhandlerAuthorUsecase_GetAuthorBooks_Call {
+	return &MockhandlerAuthorUsecase_GetAuthorBooks_Call{Call: _e.mock.On("GetAuthorBooks", ctx, id, page)}
+}
+
+func (_c *MockhandlerAuthorUsecase_GetAuthorBooks_Call) Run(run func(ctx context.Context, id int, page *models.PageRequest)) *MockhandlerAuthorUsecase_GetAuthorBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerAuthorUsecase_GetAuthorBooks_Call) Return(_a0 *[]models.BooksSummary, _a1 *models.PageMeta, _a2 error) *MockhandlerAuthorUsecase_GetAuthorBooks_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockhandlerAuthorUsecase_GetAuthorBooks_Call) RunAndReturn(run func(context.Context, int, *models.PageRequest) (*[]models.BooksSummary, *models.PageMeta, error)) *MockhandlerAuthorUsecase_GetAuthorBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthorByID provides a mock function with given fields: ctx, id
+func (_m *MockhandlerAuthorUsecase) GetAuthorByID(ctx context.Context, id int) (*models.AuthorsSummary, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorByID")
+	}
+
+	var r0 *models.AuthorsSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.AuthorsSummary, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.AuthorsSummary); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthorsSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerAuthorUsecase_GetAuthorByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthorByID'
+type MockhandlerAuthorUsecase_GetAuthorByID_Call struct {
+	*mock.Call
+}
+
+// GetAuthorByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockhandlerAuthorUsecase_Expecter) GetAuthorByID(ctx interface{}, id interface{}) *MockhandlerAuthorUsecase_GetAuthorByID_Call {
+	return &MockhandlerAuthorUsecase_GetAuthorByID_Call{Call: _e.mock.On("GetAuthorByID", ctx, id)}
+}
+
+func (_c *MockhandlerAuthorUsecase_GetAuthorByID_Call) Run(run func(ctx context.Context, id int)) *MockhandlerAuthorUsecase_GetAuthorByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerAuthorUsecase_GetAuthorByID_Call) Return(_a0 *models.AuthorsSummary, _a1 error) *MockhandlerAuthorUsecase_GetAuthorByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerAuthorUsecase_GetAuthorByID_Call) RunAndReturn(run func(context.Context, int) (*models.AuthorsSummary, error)) *MockhandlerAuthorUsecase_GetAuthorByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAuthor provides a mock function with given fields: ctx, author
+func (_m *MockhandlerAuthorUsecase) UpdateAuthor(ctx context.Context, author *models.UpdateAuthorsRequest) (*models.AuthorsSummary, error) {
+	ret := _m.Called(ctx, author)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAuthor")
+	}
+
+	var r0 *models.AuthorsSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UpdateAuthorsRequest) (*models.AuthorsSummary, error)); ok {
+		return rf(ctx, author)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UpdateAuthorsRequest) *models.AuthorsSummary); ok {
+		r0 = rf(ctx, author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthorsSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UpdateAuthorsRequest) error); ok {
+		r1 = rf(ctx, author)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerAuthorUsecase_UpdateAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAuthor'
+type MockhandlerAuthorUsecase_UpdateAuthor_Call struct {
+	*mock.Call
+}
+
+// UpdateAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - author *models.UpdateAuthorsRequest
+func (_e *MockhandlerAuthorUsecase_Expecter) UpdateAuthor(ctx interface{}, author interface{}) *MockhandlerAuthorUsecase_UpdateAuthor_Call {
+	return &MockhandlerAuthorUsecase_UpdateAuthor_Call{Call: _e.mock.On("UpdateAuthor", ctx, author)}
+}
+
+func (_c *MockhandlerAuthorUsecase_UpdateAuthor_Call) Run(run func(ctx context.Context, author *models.UpdateAuthorsRequest)) *MockhandlerAuthorUsecase_UpdateAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.UpdateAuthorsRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerAuthorUsecase_UpdateAuthor_Call) Return(_a0 *models.AuthorsSummary, _a1 error) *MockhandlerAuthorUsecase_UpdateAuthor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerAuthorUsecase_UpdateAuthor_Call) RunAndReturn(run func(context.Context, *models.UpdateAuthorsRequest) (*models.AuthorsSummary, error)) *MockhandlerAuthorUsecase_UpdateAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockhandlerAuthorUsecase creates a new instance of MockhandlerAuthorUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockhandlerAuthorUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockhandlerAuthorUsecase {
+	mock := &MockhandlerAuthorUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
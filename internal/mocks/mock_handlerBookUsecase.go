@@ -3,9 +3,11 @@
 package mocks
 
 import (
-	models "crud-echo/internal/models"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	models "crud-echo/internal/models"
 )
 
 // MockhandlerBookUsecase is an autogenerated mock type for the handlerBookUsecase type
@@ -21,9 +23,189 @@ func (_m *MockhandlerBookUsecase) EXPECT() *MockhandlerBookUsecase_Expecter {
 	return &MockhandlerBookUsecase_Expecter{mock: &_m.Mock}
 }
 
-// CreateBook provides a mock function with given fields: book
-func (_m *MockhandlerBookUsecase) CreateBook(book *models.CreateBooksRequest) (*models.Books, error) {
-	ret := _m.Called(book)
+// BulkCreateBooks provides a mock function with given fields: ctx, books, atomic
+func (_m *MockhandlerBookUsecase) BulkCreateBooks(ctx context.Context, books []models.CreateBooksRequest, atomic bool) ([]models.BulkResult, error) {
+	ret := _m.Called(ctx, books, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkCreateBooks")
+	}
+
+	var r0 []models.BulkResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.CreateBooksRequest, bool) ([]models.BulkResult, error)); ok {
+		return rf(ctx, books, atomic)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []models.CreateBooksRequest, bool) []models.BulkResult); ok {
+		r0 = rf(ctx, books, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BulkResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []models.CreateBooksRequest, bool) error); ok {
+		r1 = rf(ctx, books, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerBookUsecase_BulkCreateBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkCreateBooks'
+type MockhandlerBookUsecase_BulkCreateBooks_Call struct {
+	*mock.Call
+}
+
+// BulkCreateBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - books []models.CreateBooksRequest
+//   - atomic bool
+func (_e *MockhandlerBookUsecase_Expecter) BulkCreateBooks(ctx interface{}, books interface{}, atomic interface{}) *MockhandlerBookUsecase_BulkCreateBooks_Call {
+	return &MockhandlerBookUsecase_BulkCreateBooks_Call{Call: _e.mock.On("BulkCreateBooks", ctx, books, atomic)}
+}
+
+func (_c *MockhandlerBookUsecase_BulkCreateBooks_Call) Run(run func(ctx context.Context, books []models.CreateBooksRequest, atomic bool)) *MockhandlerBookUsecase_BulkCreateBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.CreateBooksRequest), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_BulkCreateBooks_Call) Return(_a0 []models.BulkResult, _a1 error) *MockhandlerBookUsecase_BulkCreateBooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_BulkCreateBooks_Call) RunAndReturn(run func(context.Context, []models.CreateBooksRequest, bool) ([]models.BulkResult, error)) *MockhandlerBookUsecase_BulkCreateBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkDeleteBooks provides a mock function with given fields: ctx, books, atomic
+func (_m *MockhandlerBookUsecase) BulkDeleteBooks(ctx context.Context, books []models.BulkDeleteBooksItem, atomic bool) ([]models.BulkResult, error) {
+	ret := _m.Called(ctx, books, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkDeleteBooks")
+	}
+
+	var r0 []models.BulkResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.BulkDeleteBooksItem, bool) ([]models.BulkResult, error)); ok {
+		return rf(ctx, books, atomic)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []models.BulkDeleteBooksItem, bool) []models.BulkResult); ok {
+		r0 = rf(ctx, books, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BulkResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []models.BulkDeleteBooksItem, bool) error); ok {
+		r1 = rf(ctx, books, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerBookUsecase_BulkDeleteBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkDeleteBooks'
+type MockhandlerBookUsecase_BulkDeleteBooks_Call struct {
+	*mock.Call
+}
+
+// BulkDeleteBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - books []models.BulkDeleteBooksItem
+//   - atomic bool
+func (_e *MockhandlerBookUsecase_Expecter) BulkDeleteBooks(ctx interface{}, books interface{}, atomic interface{}) *MockhandlerBookUsecase_BulkDeleteBooks_Call {
+	return &MockhandlerBookUsecase_BulkDeleteBooks_Call{Call: _e.mock.On("BulkDeleteBooks", ctx, books, atomic)}
+}
+
+func (_c *MockhandlerBookUsecase_BulkDeleteBooks_Call) Run(run func(ctx context.Context, books []models.BulkDeleteBooksItem, atomic bool)) *MockhandlerBookUsecase_BulkDeleteBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.BulkDeleteBooksItem), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_BulkDeleteBooks_Call) Return(_a0 []models.BulkResult, _a1 error) *MockhandlerBookUsecase_BulkDeleteBooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_BulkDeleteBooks_Call) RunAndReturn(run func(context.Context, []models.BulkDeleteBooksItem, bool) ([]models.BulkResult, error)) *MockhandlerBookUsecase_BulkDeleteBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkUpdateBooks provides a mock function with given fields: ctx, books, atomic
+func (_m *MockhandlerBookUsecase) BulkUpdateBooks(ctx context.Context, books []models.BulkUpdateBooksItem, atomic bool) ([]models.BulkResult, error) {
+	ret := _m.Called(ctx, books, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkUpdateBooks")
+	}
+
+	var r0 []models.BulkResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.BulkUpdateBooksItem, bool) ([]models.BulkResult, error)); ok {
+		return rf(ctx, books, atomic)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []models.BulkUpdateBooksItem, bool) []models.BulkResult); ok {
+		r0 = rf(ctx, books, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BulkResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []models.BulkUpdateBooksItem, bool) error); ok {
+		r1 = rf(ctx, books, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerBookUsecase_BulkUpdateBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkUpdateBooks'
+type MockhandlerBookUsecase_BulkUpdateBooks_Call struct {
+	*mock.Call
+}
+
+// BulkUpdateBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - books []models.BulkUpdateBooksItem
+//   - atomic bool
+func (_e *MockhandlerBookUsecase_Expecter) BulkUpdateBooks(ctx interface{}, books interface{}, atomic interface{}) *MockhandlerBookUsecase_BulkUpdateBooks_Call {
+	return &MockhandlerBookUsecase_BulkUpdateBooks_Call{Call: _e.mock.On("BulkUpdateBooks", ctx, books, atomic)}
+}
+
+func (_c *MockhandlerBookUsecase_BulkUpdateBooks_Call) Run(run func(ctx context.Context, books []models.BulkUpdateBooksItem, atomic bool)) *MockhandlerBookUsecase_BulkUpdateBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.BulkUpdateBooksItem), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_BulkUpdateBooks_Call) Return(_a0 []models.BulkResult, _a1 error) *MockhandlerBookUsecase_BulkUpdateBooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_BulkUpdateBooks_Call) RunAndReturn(run func(context.Context, []models.BulkUpdateBooksItem, bool) ([]models.BulkResult, error)) *MockhandlerBookUsecase_BulkUpdateBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBook provides a mock function with given fields: ctx, book
+func (_m *MockhandlerBookUsecase) CreateBook(ctx context.Context, book *models.CreateBooksRequest) (*models.Books, error) {
+	ret := _m.Called(ctx, book)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateBook")
@@ -31,19 +213,19 @@ func (_m *MockhandlerBookUsecase) CreateBook(book *models.CreateBooksRequest) (*
 
 	var r0 *models.Books
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*models.CreateBooksRequest) (*models.Books, error)); ok {
-		return rf(book)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateBooksRequest) (*models.Books, error)); ok {
+		return rf(ctx, book)
 	}
-	if rf, ok := ret.Get(0).(func(*models.CreateBooksRequest) *models.Books); ok {
-		r0 = rf(book)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateBooksRequest) *models.Books); ok {
+		r0 = rf(ctx, book)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Books)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*models.CreateBooksRequest) error); ok {
-		r1 = rf(book)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.CreateBooksRequest) error); ok {
+		r1 = rf(ctx, book)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -57,14 +239,15 @@ type MockhandlerBookUsecase_CreateBook_Call struct {
 }
 
 // CreateBook is a helper method to define mock.On call
+//   - ctx context.Context
 //   - book *models.CreateBooksRequest
-func (_e *MockhandlerBookUsecase_Expecter) CreateBook(book interface{}) *MockhandlerBookUsecase_CreateBook_Call {
-	return &MockhandlerBookUsecase_CreateBook_Call{Call: _e.mock.On("CreateBook", book)}
+func (_e *MockhandlerBookUsecase_Expecter) CreateBook(ctx interface{}, book interface{}) *MockhandlerBookUsecase_CreateBook_Call {
+	return &MockhandlerBookUsecase_CreateBook_Call{Call: _e.mock.On("CreateBook", ctx, book)}
 }
 
-func (_c *MockhandlerBookUsecase_CreateBook_Call) Run(run func(book *models.CreateBooksRequest)) *MockhandlerBookUsecase_CreateBook_Call {
+func (_c *MockhandlerBookUsecase_CreateBook_Call) Run(run func(ctx context.Context, book *models.CreateBooksRequest)) *MockhandlerBookUsecase_CreateBook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*models.CreateBooksRequest))
+		run(args[0].(context.Context), args[1].(*models.CreateBooksRequest))
 	})
 	return _c
 }
@@ -74,22 +257,22 @@ func (_c *MockhandlerBookUsecase_CreateBook_Call) Return(_a0 *models.Books, _a1
 	return _c
 }
 
-func (_c *MockhandlerBookUsecase_CreateBook_Call) RunAndReturn(run func(*models.CreateBooksRequest) (*models.Books, error)) *MockhandlerBookUsecase_CreateBook_Call {
+func (_c *MockhandlerBookUsecase_CreateBook_Call) RunAndReturn(run func(context.Context, *models.CreateBooksRequest) (*models.Books, error)) *MockhandlerBookUsecase_CreateBook_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteBook provides a mock function with given fields: book
-func (_m *MockhandlerBookUsecase) DeleteBook(book *models.DeleteBooksRequest) error {
-	ret := _m.Called(book)
+// DeleteBook provides a mock function with given fields: ctx, book
+func (_m *MockhandlerBookUsecase) DeleteBook(ctx context.Context, book *models.DeleteBooksRequest) error {
+	ret := _m.Called(ctx, book)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteBook")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.DeleteBooksRequest) error); ok {
-		r0 = rf(book)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeleteBooksRequest) error); ok {
+		r0 = rf(ctx, book)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -103,14 +286,15 @@ type MockhandlerBookUsecase_DeleteBook_Call struct {
 }
 
 // DeleteBook is a helper method to define mock.On call
+//   - ctx context.Context
 //   - book *models.DeleteBooksRequest
-func (_e *MockhandlerBookUsecase_Expecter) DeleteBook(book interface{}) *MockhandlerBookUsecase_DeleteBook_Call {
-	return &MockhandlerBookUsecase_DeleteBook_Call{Call: _e.mock.On("DeleteBook", book)}
+func (_e *MockhandlerBookUsecase_Expecter) DeleteBook(ctx interface{}, book interface{}) *MockhandlerBookUsecase_DeleteBook_Call {
+	return &MockhandlerBookUsecase_DeleteBook_Call{Call: _e.mock.On("DeleteBook", ctx, book)}
 }
 
-func (_c *MockhandlerBookUsecase_DeleteBook_Call) Run(run func(book *models.DeleteBooksRequest)) *MockhandlerBookUsecase_DeleteBook_Call {
+func (_c *MockhandlerBookUsecase_DeleteBook_Call) Run(run func(ctx context.Context, book *models.DeleteBooksRequest)) *MockhandlerBookUsecase_DeleteBook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*models.DeleteBooksRequest))
+		run(args[0].(context.Context), args[1].(*models.DeleteBooksRequest))
 	})
 	return _c
 }
@@ -120,39 +304,95 @@ func (_c *MockhandlerBookUsecase_DeleteBook_Call) Return(_a0 error) *Mockhandler
 	return _c
 }
 
-func (_c *MockhandlerBookUsecase_DeleteBook_Call) RunAndReturn(run func(*models.DeleteBooksRequest) error) *MockhandlerBookUsecase_DeleteBook_Call {
+func (_c *MockhandlerBookUsecase_DeleteBook_Call) RunAndReturn(run func(context.Context, *models.DeleteBooksRequest) error) *MockhandlerBookUsecase_DeleteBook_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllBooks provides a mock function with given fields: available
-func (_m *MockhandlerBookUsecase) GetAllBooks(available bool) (*[]models.BooksSummary, error) {
-	ret := _m.Called(available)
+// ExportBooks provides a mock function with given fields: ctx, fn
+func (_m *MockhandlerBookUsecase) ExportBooks(ctx context.Context, fn func(*models.BooksExport) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportBooks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*models.BooksExport) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockhandlerBookUsecase_ExportBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportBooks'
+type MockhandlerBookUsecase_ExportBooks_Call struct {
+	*mock.Call
+}
+
+// ExportBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(*models.BooksExport) error
+func (_e *MockhandlerBookUsecase_Expecter) ExportBooks(ctx interface{}, fn interface{}) *MockhandlerBookUsecase_ExportBooks_Call {
+	return &MockhandlerBookUsecase_ExportBooks_Call{Call: _e.mock.On("ExportBooks", ctx, fn)}
+}
+
+func (_c *MockhandlerBookUsecase_ExportBooks_Call) Run(run func(ctx context.Context, fn func(*models.BooksExport) error)) *MockhandlerBookUsecase_ExportBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(*models.BooksExport) error))
+	})
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_ExportBooks_Call) Return(_a0 error) *MockhandlerBookUsecase_ExportBooks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_ExportBooks_Call) RunAndReturn(run func(context.Context, func(*models.BooksExport) error) error) *MockhandlerBookUsecase_ExportBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllBooks provides a mock function with given fields: ctx, filter, page, include
+func (_m *MockhandlerBookUsecase) GetAllBooks(ctx context.Context, filter *models.BooksFilter, page *models.PageRequest, include models.BooksInclude) (*[]models.BooksSummary, *models.PageMeta, error) {
+	ret := _m.Called(ctx, filter, page, include)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllBooks")
 	}
 
 	var r0 *[]models.BooksSummary
-	var r1 error
-	if rf, ok := ret.Get(0).(func(bool) (*[]models.BooksSummary, error)); ok {
-		return rf(available)
+	var r1 *models.PageMeta
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.BooksFilter, *models.PageRequest, models.BooksInclude) (*[]models.BooksSummary, *models.PageMeta, error)); ok {
+		return rf(ctx, filter, page, include)
 	}
-	if rf, ok := ret.Get(0).(func(bool) *[]models.BooksSummary); ok {
-		r0 = rf(available)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.BooksFilter, *models.PageRequest, models.BooksInclude) *[]models.BooksSummary); ok {
+		r0 = rf(ctx, filter, page, include)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*[]models.BooksSummary)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(bool) error); ok {
-		r1 = rf(available)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.BooksFilter, *models.PageRequest, models.BooksInclude) *models.PageMeta); ok {
+		r1 = rf(ctx, filter, page, include)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.PageMeta)
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, *models.BooksFilter, *models.PageRequest, models.BooksInclude) error); ok {
+		r2 = rf(ctx, filter, page, include)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // MockhandlerBookUsecase_GetAllBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllBooks'
@@ -161,31 +401,34 @@ type MockhandlerBookUsecase_GetAllBooks_Call struct {
 }
 
 // GetAllBooks is a helper method to define mock.On call
-//   - available bool
-func (_e *MockhandlerBookUsecase_Expecter) GetAllBooks(available interface{}) *MockhandlerBookUsecase_GetAllBooks_Call {
-	return &MockhandlerBookUsecase_GetAllBooks_Call{Call: _e.mock.On("GetAllBooks", available)}
+//   - ctx context.Context
+//   - filter *models.BooksFilter
+//   - page *models.PageRequest
+//   - include models.BooksInclude
+func (_e *MockhandlerBookUsecase_Expecter) GetAllBooks(ctx interface{}, filter interface{}, page interface{}, include interface{}) *MockhandlerBookUsecase_GetAllBooks_Call {
+	return &MockhandlerBookUsecase_GetAllBooks_Call{Call: _e.mock.On("GetAllBooks", ctx, filter, page, include)}
 }
 
-func (_c *MockhandlerBookUsecase_GetAllBooks_Call) Run(run func(available bool)) *MockhandlerBookUsecase_GetAllBooks_Call {
+func (_c *MockhandlerBookUsecase_GetAllBooks_Call) Run(run func(ctx context.Context, filter *models.BooksFilter, page *models.PageRequest, include models.BooksInclude)) *MockhandlerBookUsecase_GetAllBooks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(bool))
+		run(args[0].(context.Context), args[1].(*models.BooksFilter), args[2].(*models.PageRequest), args[3].(models.BooksInclude))
 	})
 	return _c
 }
 
-func (_c *MockhandlerBookUsecase_GetAllBooks_Call) Return(_a0 *[]models.BooksSummary, _a1 error) *MockhandlerBookUsecase_GetAllBooks_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockhandlerBookUsecase_GetAllBooks_Call) Return(_a0 *[]models.BooksSummary, _a1 *models.PageMeta, _a2 error) *MockhandlerBookUsecase_GetAllBooks_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *MockhandlerBookUsecase_GetAllBooks_Call) RunAndReturn(run func(bool) (*[]models.BooksSummary, error)) *MockhandlerBookUsecase_GetAllBooks_Call {
+func (_c *MockhandlerBookUsecase_GetAllBooks_Call) RunAndReturn(run func(context.Context, *models.BooksFilter, *models.PageRequest, models.BooksInclude) (*[]models.BooksSummary, *models.PageMeta, error)) *MockhandlerBookUsecase_GetAllBooks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBookByID provides a mock function with given fields: id
-func (_m *MockhandlerBookUsecase) GetBookByID(id int) (*models.BooksSummary, error) {
-	ret := _m.Called(id)
+// GetBookByID provides a mock function with given fields: ctx, id, include
+func (_m *MockhandlerBookUsecase) GetBookByID(ctx context.Context, id int, include models.BooksInclude) (*models.BooksSummary, error) {
+	ret := _m.Called(ctx, id, include)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBookByID")
@@ -193,19 +436,19 @@ func (_m *MockhandlerBookUsecase) GetBookByID(id int) (*models.BooksSummary, err
 
 	var r0 *models.BooksSummary
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*models.BooksSummary, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.BooksInclude) (*models.BooksSummary, error)); ok {
+		return rf(ctx, id, include)
 	}
-	if rf, ok := ret.Get(0).(func(int) *models.BooksSummary); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.BooksInclude) *models.BooksSummary); ok {
+		r0 = rf(ctx, id, include)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.BooksSummary)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int, models.BooksInclude) error); ok {
+		r1 = rf(ctx, id, include)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -219,14 +462,16 @@ type MockhandlerBookUsecase_GetBookByID_Call struct {
 }
 
 // GetBookByID is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id int
-func (_e *MockhandlerBookUsecase_Expecter) GetBookByID(id interface{}) *MockhandlerBookUsecase_GetBookByID_Call {
-	return &MockhandlerBookUsecase_GetBookByID_Call{Call: _e.mock.On("GetBookByID", id)}
+//   - include models.BooksInclude
+func (_e *MockhandlerBookUsecase_Expecter) GetBookByID(ctx interface{}, id interface{}, include interface{}) *MockhandlerBookUsecase_GetBookByID_Call {
+	return &MockhandlerBookUsecase_GetBookByID_Call{Call: _e.mock.On("GetBookByID", ctx, id, include)}
 }
 
-func (_c *MockhandlerBookUsecase_GetBookByID_Call) Run(run func(id int)) *MockhandlerBookUsecase_GetBookByID_Call {
+func (_c *MockhandlerBookUsecase_GetBookByID_Call) Run(run func(ctx context.Context, id int, include models.BooksInclude)) *MockhandlerBookUsecase_GetBookByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int))
+		run(args[0].(context.Context), args[1].(int), args[2].(models.BooksInclude))
 	})
 	return _c
 }
@@ -236,22 +481,554 @@ func (_c *MockhandlerBookUsecase_GetBookByID_Call) Return(_a0 *models.BooksSumma
 	return _c
 }
 
-func (_c *MockhandlerBookUsecase_GetBookByID_Call) RunAndReturn(run func(int) (*models.BooksSummary, error)) *MockhandlerBookUsecase_GetBookByID_Call {
+func (_c *MockhandlerBookUsecase_GetBookByID_Call) RunAndReturn(run func(context.Context, int, models.BooksInclude) (*models.BooksSummary, error)) *MockhandlerBookUsecase_GetBookByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBookByISBN provides a mock function with given fields: ctx, isbn, include
+func (_m *MockhandlerBookUsecase) GetBookByISBN(ctx context.Context, isbn string, include models.BooksInclude) (*models.BooksSummary, error) {
+	ret := _m.Called(ctx, isbn, include)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookByISBN")
+	}
+
+	var r0 *models.BooksSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.BooksInclude) (*models.BooksSummary, error)); ok {
+		return rf(ctx, isbn, include)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.BooksInclude) *models.BooksSummary); ok {
+		r0 = rf(ctx, isbn, include)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BooksSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.BooksInclude) error); ok {
+		r1 = rf(ctx, isbn, include)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerBookUsecase_GetBookByISBN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookByISBN'
+type MockhandlerBookUsecase_GetBookByISBN_Call struct {
+	*mock.Call
+}
+
+// GetBookByISBN is a helper method to define mock.On call
+//   - ctx context.Context
+//   - isbn string
+//   - include models.BooksInclude
+func (_e *MockhandlerBookUsecase_Expecter) GetBookByISBN(ctx interface{}, isbn interface{}, include interface{}) *MockhandlerBookUsecase_GetBookByISBN_Call {
+	return &MockhandlerBookUsecase_GetBookByISBN_Call{Call: _e.mock.On("GetBookByISBN", ctx, isbn, include)}
+}
+
+func (_c *MockhandlerBookUsecase_GetBookByISBN_Call) Run(run func(ctx context.Context, isbn string, include models.BooksInclude)) *MockhandlerBookUsecase_GetBookByISBN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.BooksInclude))
+	})
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_GetBookByISBN_Call) Return(_a0 *models.BooksSummary, _a1 error) *MockhandlerBookUsecase_GetBookByISBN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_GetBookByISBN_Call) RunAndReturn(run func(context.Context, string, models.BooksInclude) (*models.BooksSummary, error)) *MockhandlerBookUsecase_GetBookByISBN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStockHistory provides a mock function with given fields: ctx, id, page
+func (_m *MockhandlerBookUsecase) GetStockHistory(ctx context.Context, id int, page *models.PageRequest) (*[]models.StockMovement, *models.PageMeta, error) {
+	ret := _m.Called(ctx, id, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockHistory")
+	}
+
+	var r0 *[]models.StockMovement
+	var r1 *models.PageMeta
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *models.PageRequest) (*[]models.StockMovement, *models.PageMeta, error)); ok {
+		return rf(ctx, id, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *models.PageRequest) *[]models.StockMovement); ok {
+		r0 = rf(ctx, id, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.StockMovement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *models.PageRequest) *models.PageMeta); ok {
+		r1 = rf(ctx, id, page)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.PageMeta)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, *models.PageRequest) error); ok {
+		r2 = rf(ctx, id, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockhandlerBookUsecase_GetStockHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockHistory'
+type MockhandlerBookUsecase_GetStockHistory_Call struct {
+	*mock.Call
+}
+
+// GetStockHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - page *models.PageRequest
+func (_e *MockhandlerBookUsecase_Expecter) GetStockHistory(ctx interface{}, id interface{}, page interface{}) *MockhandlerBookUsecase_GetStockHistory_Call {
+	return &MockhandlerBookUsecase_GetStockHistory_Call{Call: _e.mock.On("GetStockHistory", ctx, id, page)}
+}
+
+func (_c *MockhandlerBookUsecase_GetStockHistory_Call) Run(run func(ctx context.Context, id int, page *models.PageRequest)) *MockhandlerBookUsecase_GetStockHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_GetStockHistory_Call) Return(_a0 *[]models.StockMovement, _a1 *models.PageMeta, _a2 error) *MockhandlerBookUsecase_GetStockHistory_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_GetStockHistory_Call) RunAndReturn(run func(context.Context, int, *models.PageRequest) (*[]models.StockMovement, *models.PageMeta, error)) *MockhandlerBookUsecase_GetStockHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTrashedBooks provides a mock function with given fields: ctx, page
+func (_m *MockhandlerBookUsecase) GetTrashedBooks(ctx context.Context, page *models.PageRequest) (*[]models.BooksSummary, *models.PageMeta, error) {
+	ret := _m.Called(ctx, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrashedBooks")
+	}
+
+	var r0 *[]models.BooksSummary
+	var r1 *models.PageMeta
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PageRequest) (*[]models.BooksSummary, *models.PageMeta, error)); ok {
+		return rf(ctx, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PageRequest) *[]models.BooksSummary); ok {
+		r0 = rf(ctx, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.BooksSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PageRequest) *models.PageMeta); ok {
+		r1 = rf(ctx, page)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.PageMeta)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.PageRequest) error); ok {
+		r2 = rf(ctx, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockhandlerBookUsecase_GetTrashedBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrashedBooks'
+type MockhandlerBookUsecase_GetTrashedBooks_Call struct {
+	*mock.Call
+}
+
+// GetTrashedBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - page *models.PageRequest
+func (_e *MockhandlerBookUsecase_Expecter) GetTrashedBooks(ctx interface{}, page interface{}) *MockhandlerBookUsecase_GetTrashedBooks_Call {
+	return &MockhandlerBookUsecase_GetTrashedBooks_Call{Call: _e.mock.On("GetTrashedBooks", ctx, page)}
+}
+
+func (_c *MockhandlerBookUsecase_GetTrashedBooks_Call) Run(run func(ctx context.Context, page *models.PageRequest)) *MockhandlerBookUsecase_GetTrashedBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_GetTrashedBooks_Call) Return(_a0 *[]models.BooksSummary, _a1 *models.PageMeta, _a2 error) *MockhandlerBookUsecase_GetTrashedBooks_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_GetTrashedBooks_Call) RunAndReturn(run func(context.Context, *models.PageRequest) (*[]models.BooksSummary, *models.PageMeta, error)) *MockhandlerBookUsecase_GetTrashedBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportBooks provides a mock function with given fields: ctx, rows, opts, report
+func (_m *MockhandlerBookUsecase) ImportBooks(ctx context.Context, rows []models.ImportRow, opts models.ImportOptions, report *models.ImportReport) error {
+	ret := _m.Called(ctx, rows, opts, report)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportBooks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.ImportRow, models.ImportOptions, *models.ImportReport) error); ok {
+		r0 = rf(ctx, rows, opts, report)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockhandlerBookUsecase_ImportBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportBooks'
+type MockhandlerBookUsecase_ImportBooks_Call struct {
+	*mock.Call
+}
+
+// ImportBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rows []models.ImportRow
+//   - opts models.ImportOptions
+//   - report *models.ImportReport
+func (_e *MockhandlerBookUsecase_Expecter) ImportBooks(ctx interface{}, rows interface{}, opts interface{}, report interface{}) *MockhandlerBookUsecase_ImportBooks_Call {
+	return &MockhandlerBookUsecase_ImportBooks_Call{Call: _e.mock.On("ImportBooks", ctx, rows, opts, report)}
+}
+
+func (_c *MockhandlerBookUsecase_ImportBooks_Call) Run(run func(ctx context.Context, rows []models.ImportRow, opts models.ImportOptions, report *models.ImportReport)) *MockhandlerBookUsecase_ImportBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.ImportRow), args[2].(models.ImportOptions), args[3].(*models.ImportReport))
+	})
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_ImportBooks_Call) Return(_a0 error) *MockhandlerBookUsecase_ImportBooks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_ImportBooks_Call) RunAndReturn(run func(context.Context, []models.ImportRow, models.ImportOptions, *models.ImportReport) error) *MockhandlerBookUsecase_ImportBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveStock provides a mock function with given fields: ctx, id, change
+func (_m *MockhandlerBookUsecase) MoveStock(ctx context.Context, id int, change models.StockChange) (*models.StockMoveResult, error) {
+	ret := _m.Called(ctx, id, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveStock")
+	}
+
+	var r0 *models.StockMoveResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.StockChange) (*models.StockMoveResult, error)); ok {
+		return rf(ctx, id, change)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.StockChange) *models.StockMoveResult); ok {
+		r0 = rf(ctx, id, change)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StockMoveResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, models.StockChange) error); ok {
+		r1 = rf(ctx, id, change)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerBookUsecase_MoveStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveStock'
+type MockhandlerBookUsecase_MoveStock_Call struct {
+	*mock.Call
+}
+
+// MoveStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - change models.StockChange
+func (_e *MockhandlerBookUsecase_Expecter) MoveStock(ctx interface{}, id interface{}, change interface{}) *MockhandlerBookUsecase_MoveStock_Call {
+	return &MockhandlerBookUsecase_MoveStock_Call{Call: _e.mock.On("MoveStock", ctx, id, change)}
+}
+
+func (_c *MockhandlerBookUsecase_MoveStock_Call) Run(run func(ctx context.Context, id int, change models.StockChange)) *MockhandlerBookUsecase_MoveStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(models.StockChange))
+	})
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_MoveStock_Call) Return(_a0 *models.StockMoveResult, _a1 error) *MockhandlerBookUsecase_MoveStock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_MoveStock_Call) RunAndReturn(run func(context.Context, int, models.StockChange) (*models.StockMoveResult, error)) *MockhandlerBookUsecase_MoveStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchBook provides a mock function with given fields: ctx, id, patch
+func (_m *MockhandlerBookUsecase) PatchBook(ctx context.Context, id int, patch *models.BooksPatch) (*models.BooksSummary, error) {
+	ret := _m.Called(ctx, id, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchBook")
+	}
+
+	var r0 *models.BooksSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *models.BooksPatch) (*models.BooksSummary, error)); ok {
+		return rf(ctx, id, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *models.BooksPatch) *models.BooksSummary); ok {
+		r0 = rf(ctx, id, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BooksSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *models.BooksPatch) error); ok {
+		r1 = rf(ctx, id, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerBookUsecase_PatchBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchBook'
+type MockhandlerBookUsecase_PatchBook_Call struct {
+	*mock.Call
+}
+
+// PatchBook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - patch *models.BooksPatch
+func (_e *MockhandlerBookUsecase_Expecter) PatchBook(ctx interface{}, id interface{}, patch interface{}) *MockhandlerBookUsecase_PatchBook_Call {
+	return &MockhandlerBookUsecase_PatchBook_Call{Call: _e.mock.On("PatchBook", ctx, id, patch)}
+}
+
+func (_c *MockhandlerBookUsecase_PatchBook_Call) Run(run func(ctx context.Context, id int, patch *models.BooksPatch)) *MockhandlerBookUsecase_PatchBook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(*models.BooksPatch))
+	})
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_PatchBook_Call) Return(_a0 *models.BooksSummary, _a1 error) *MockhandlerBookUsecase_PatchBook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_PatchBook_Call) RunAndReturn(run func(context.Context, int, *models.BooksPatch) (*models.BooksSummary, error)) *MockhandlerBookUsecase_PatchBook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeBook provides a mock function with given fields: ctx, id
+func (_m *MockhandlerBookUsecase) PurgeBook(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeBook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockhandlerBookUsecase_PurgeBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeBook'
+type MockhandlerBookUsecase_PurgeBook_Call struct {
+	*mock.Call
+}
+
+// PurgeBook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockhandlerBookUsecase_Expecter) PurgeBook(ctx interface{}, id interface{}) *MockhandlerBookUsecase_PurgeBook_Call {
+	return &MockhandlerBookUsecase_PurgeBook_Call{Call: _e.mock.On("PurgeBook", ctx, id)}
+}
+
+func (_c *MockhandlerBookUsecase_PurgeBook_Call) Run(run func(ctx context.Context, id int)) *MockhandlerBookUsecase_PurgeBook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_PurgeBook_Call) Return(_a0 error) *MockhandlerBookUsecase_PurgeBook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_PurgeBook_Call) RunAndReturn(run func(context.Context, int) error) *MockhandlerBookUsecase_PurgeBook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreBook provides a mock function with given fields: ctx, id
+func (_m *MockhandlerBookUsecase) RestoreBook(ctx context.Context, id int) (*models.BooksSummary, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreBook")
+	}
+
+	var r0 *models.BooksSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.BooksSummary, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.BooksSummary); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BooksSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerBookUsecase_RestoreBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreBook'
+type MockhandlerBookUsecase_RestoreBook_Call struct {
+	*mock.Call
+}
+
+// RestoreBook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockhandlerBookUsecase_Expecter) RestoreBook(ctx interface{}, id interface{}) *MockhandlerBookUsecase_RestoreBook_Call {
+	return &MockhandlerBookUsecase_RestoreBook_Call{Call: _e.mock.On("RestoreBook", ctx, id)}
+}
+
+func (_c *MockhandlerBookUsecase_RestoreBook_Call) Run(run func(ctx context.Context, id int)) *MockhandlerBookUsecase_RestoreBook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_RestoreBook_Call) Return(_a0 *models.BooksSummary, _a1 error) *MockhandlerBookUsecase_RestoreBook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_RestoreBook_Call) RunAndReturn(run func(context.Context, int) (*models.BooksSummary, error)) *MockhandlerBookUsecase_RestoreBook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchBooks provides a mock function with given fields: ctx, query, limit
+func (_m *MockhandlerBookUsecase) SearchBooks(ctx context.Context, query string, limit int) (*[]models.BooksSearchResult, error) {
+	ret := _m.Called(ctx, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchBooks")
+	}
+
+	var r0 *[]models.BooksSearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*[]models.BooksSearchResult, error)); ok {
+		return rf(ctx, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *[]models.BooksSearchResult); ok {
+		r0 = rf(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.BooksSearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerBookUsecase_SearchBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchBooks'
+type MockhandlerBookUsecase_SearchBooks_Call struct {
+	*mock.Call
+}
+
+// SearchBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - limit int
+func (_e *MockhandlerBookUsecase_Expecter) SearchBooks(ctx interface{}, query interface{}, limit interface{}) *MockhandlerBookUsecase_SearchBooks_Call {
+	return &MockhandlerBookUsecase_SearchBooks_Call{Call: _e.mock.On("SearchBooks", ctx, query, limit)}
+}
+
+func (_c *MockhandlerBookUsecase_SearchBooks_Call) Run(run func(ctx context.Context, query string, limit int)) *MockhandlerBookUsecase_SearchBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_SearchBooks_Call) Return(_a0 *[]models.BooksSearchResult, _a1 error) *MockhandlerBookUsecase_SearchBooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerBookUsecase_SearchBooks_Call) RunAndReturn(run func(context.Context, string, int) (*[]models.BooksSearchResult, error)) *MockhandlerBookUsecase_SearchBooks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateBook provides a mock function with given fields: book
-func (_m *MockhandlerBookUsecase) UpdateBook(book *models.UpdateBooksRequest) error {
-	ret := _m.Called(book)
+// UpdateBook provides a mock function with given fields: ctx, book
+func (_m *MockhandlerBookUsecase) UpdateBook(ctx context.Context, book *models.UpdateBooksRequest) error {
+	ret := _m.Called(ctx, book)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateBook")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.UpdateBooksRequest) error); ok {
-		r0 = rf(book)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UpdateBooksRequest) error); ok {
+		r0 = rf(ctx, book)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -265,14 +1042,15 @@ type MockhandlerBookUsecase_UpdateBook_Call struct {
 }
 
 // UpdateBook is a helper method to define mock.On call
+//   - ctx context.Context
 //   - book *models.UpdateBooksRequest
-func (_e *MockhandlerBookUsecase_Expecter) UpdateBook(book interface{}) *MockhandlerBookUsecase_UpdateBook_Call {
-	return &MockhandlerBookUsecase_UpdateBook_Call{Call: _e.mock.On("UpdateBook", book)}
+func (_e *MockhandlerBookUsecase_Expecter) UpdateBook(ctx interface{}, book interface{}) *MockhandlerBookUsecase_UpdateBook_Call {
+	return &MockhandlerBookUsecase_UpdateBook_Call{Call: _e.mock.On("UpdateBook", ctx, book)}
 }
 
-func (_c *MockhandlerBookUsecase_UpdateBook_Call) Run(run func(book *models.UpdateBooksRequest)) *MockhandlerBookUsecase_UpdateBook_Call {
+func (_c *MockhandlerBookUsecase_UpdateBook_Call) Run(run func(ctx context.Context, book *models.UpdateBooksRequest)) *MockhandlerBookUsecase_UpdateBook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*models.UpdateBooksRequest))
+		run(args[0].(context.Context), args[1].(*models.UpdateBooksRequest))
 	})
 	return _c
 }
@@ -282,7 +1060,7 @@ func (_c *MockhandlerBookUsecase_UpdateBook_Call) Return(_a0 error) *Mockhandler
 	return _c
 }
 
-func (_c *MockhandlerBookUsecase_UpdateBook_Call) RunAndReturn(run func(*models.UpdateBooksRequest) error) *MockhandlerBookUsecase_UpdateBook_Call {
+func (_c *MockhandlerBookUsecase_UpdateBook_Call) RunAndReturn(run func(context.Context, *models.UpdateBooksRequest) error) *MockhandlerBookUsecase_UpdateBook_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,439 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "crud-echo/internal/models"
+)
+
+// MockhandlerCategoryUsecase is an autogenerated mock type for the handlerCategoryUsecase type
+type MockhandlerCategoryUsecase struct {
+	mock.Mock
+}
+
+type MockhandlerCategoryUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockhandlerCategoryUsecase) EXPECT() *MockhandlerCategoryUsecase_Expecter {
+	return &MockhandlerCategoryUsecase_Expecter{mock: &_m.Mock}
+}
+
+// CreateCategory provides a mock function with given fields: ctx, category
+func (_m *MockhandlerCategoryUsecase) CreateCategory(ctx context.Context, category *models.CreateCategoriesRequest) (*models.CategoriesSummary, error) {
+	ret := _m.Called(ctx, category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCategory")
+	}
+
+	var r0 *models.CategoriesSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateCategoriesRequest) (*models.CategoriesSummary, error)); ok {
+		return rf(ctx, category)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateCategoriesRequest) *models.CategoriesSummary); ok {
+		r0 = rf(ctx, category)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CategoriesSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.CreateCategoriesRequest) error); ok {
+		r1 = rf(ctx, category)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerCategoryUsecase_CreateCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCategory'
+type MockhandlerCategoryUsecase_CreateCategory_Call struct {
+	*mock.Call
+}
+
+// CreateCategory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - category *models.CreateCategoriesRequest
+func (_e *MockhandlerCategoryUsecase_Expecter) CreateCategory(ctx interface{}, category interface{}) *MockhandlerCategoryUsecase_CreateCategory_Call {
+	return &MockhandlerCategoryUsecase_CreateCategory_Call{Call: _e.mock.On("CreateCategory", ctx, category)}
+}
+
+func (_c *MockhandlerCategoryUsecase_CreateCategory_Call) Run(run func(ctx context.Context, category *models.CreateCategoriesRequest)) *MockhandlerCategoryUsecase_CreateCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.CreateCategoriesRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerCategoryUsecase_CreateCategory_Call) Return(_a0 *models.CategoriesSummary, _a1 error) *MockhandlerCategoryUsecase_CreateCategory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerCategoryUsecase_CreateCategory_Call) RunAndReturn(run func(context.Context, *models.CreateCategoriesRequest) (*models.CategoriesSummary, error)) *MockhandlerCategoryUsecase_CreateCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCategory provides a mock function with given fields: ctx, id
+func (_m *MockhandlerCategoryUsecase) DeleteCategory(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockhandlerCategoryUsecase_DeleteCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCategory'
+type MockhandlerCategoryUsecase_DeleteCategory_Call struct {
+	*mock.Call
+}
+
+// DeleteCategory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockhandlerCategoryUsecase_Expecter) DeleteCategory(ctx interface{}, id interface{}) *MockhandlerCategoryUsecase_DeleteCategory_Call {
+	return &MockhandlerCategoryUsecase_DeleteCategory_Call{Call: _e.mock.On("DeleteCategory", ctx, id)}
+}
+
+func (_c *MockhandlerCategoryUsecase_DeleteCategory_Call) Run(run func(ctx context.Context, id int)) *MockhandlerCategoryUsecase_DeleteCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerCategoryUsecase_DeleteCategory_Call) Return(_a0 error) *MockhandlerCategoryUsecase_DeleteCategory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockhandlerCategoryUsecase_DeleteCategory_Call) RunAndReturn(run func(context.Context, int) error) *MockhandlerCategoryUsecase_DeleteCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllCategories provides a mock function with given fields: ctx
+func (_m *MockhandlerCategoryUsecase) GetAllCategories(ctx context.Context) (*[]models.CategoriesSummary, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllCategories")
+	}
+
+	var r0 *[]models.CategoriesSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*[]models.CategoriesSummary, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *[]models.CategoriesSummary); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.CategoriesSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerCategoryUsecase_GetAllCategories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllCategories'
+type MockhandlerCategoryUsecase_GetAllCategories_Call struct {
+	*mock.Call
+}
+
+// GetAllCategories is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockhandlerCategoryUsecase_Expecter) GetAllCategories(ctx interface{}) *MockhandlerCategoryUsecase_GetAllCategories_Call {
+	return &MockhandlerCategoryUsecase_GetAllCategories_Call{Call: _e.mock.On("GetAllCategories", ctx)}
+}
+
+func (_c *MockhandlerCategoryUsecase_GetAllCategories_Call) Run(run func(ctx context.Context)) *MockhandlerCategoryUsecase_GetAllCategories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockhandlerCategoryUsecase_GetAllCategories_Call) Return(_a0 *[]models.CategoriesSummary, _a1 error) *MockhandlerCategoryUsecase_GetAllCategories_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerCategoryUsecase_GetAllCategories_Call) RunAndReturn(run func(context.Context) (*[]models.CategoriesSummary, error)) *MockhandlerCategoryUsecase_GetAllCategories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBookCategories provides a mock function with given fields: ctx, bookID
+func (_m *MockhandlerCategoryUsecase) GetBookCategories(ctx context.Context, bookID int) (*[]models.CategoriesSummary, error) {
+	ret := _m.Called(ctx, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookCategories")
+	}
+
+	var r0 *[]models.CategoriesSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*[]models.CategoriesSummary, error)); ok {
+		return rf(ctx, bookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *[]models.CategoriesSummary); ok {
+		r0 = rf(ctx, bookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.CategoriesSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerCategoryUsecase_GetBookCategories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookCategories'
+type MockhandlerCategoryUsecase_GetBookCategories_Call struct {
+	*mock.Call
+}
+
+// GetBookCategories is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookID int
+func (_e *MockhandlerCategoryUsecase_Expecter) GetBookCategories(ctx interface{}, bookID interface{}) *MockhandlerCategoryUsecase_GetBookCategories_Call {
+	return &MockhandlerCategoryUsecase_GetBookCategories_Call{Call: _e.mock.On("GetBookCategories", ctx, bookID)}
+}
+
+func (_c *MockhandlerCategoryUsecase_GetBookCategories_Call) Run(run func(ctx context.Context, bookID int)) *MockhandlerCategoryUsecase_GetBookCategories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerCategoryUsecase_GetBookCategories_Call) Return(_a0 *[]models.CategoriesSummary, _a1 error) *MockhandlerCategoryUsecase_GetBookCategories_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerCategoryUsecase_GetBookCategories_Call) RunAndReturn(run func(context.Context, int) (*[]models.CategoriesSummary, error)) *MockhandlerCategoryUsecase_GetBookCategories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCategoryByID provides a mock function with given fields: ctx, id
+func (_m *MockhandlerCategoryUsecase) GetCategoryByID(ctx context.Context, id int) (*models.CategoriesSummary, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategoryByID")
+	}
+
+	var r0 *models.CategoriesSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.CategoriesSummary, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.CategoriesSummary); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CategoriesSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerCategoryUsecase_GetCategoryByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCategoryByID'
+type MockhandlerCategoryUsecase_GetCategoryByID_Call struct {
+	*mock.Call
+}
+
+// GetCategoryByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockhandlerCategoryUsecase_Expecter) GetCategoryByID(ctx interface{}, id interface{}) *MockhandlerCategoryUsecase_GetCategoryByID_Call {
+	return &MockhandlerCategoryUsecase_GetCategoryByID_Call{Call: _e.mock.On("GetCategoryByID", ctx, id)}
+}
+
+func (_c *MockhandlerCategoryUsecase_GetCategoryByID_Call) Run(run func(ctx context.Context, id int)) *MockhandlerCategoryUsecase_GetCategoryByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerCategoryUsecase_GetCategoryByID_Call) Return(_a0 *models.CategoriesSummary, _a1 error) *MockhandlerCategoryUsecase_GetCategoryByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerCategoryUsecase_GetCategoryByID_Call) RunAndReturn(run func(context.Context, int) (*models.CategoriesSummary, error)) *MockhandlerCategoryUsecase_GetCategoryByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetBookCategories provides a mock function with given fields: ctx, bookID, categoryIDs
+func (_m *MockhandlerCategoryUsecase) SetBookCategories(ctx context.Context, bookID int, categoryIDs []int) (*[]models.CategoriesSummary, error) {
+	ret := _m.Called(ctx, bookID, categoryIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetBookCategories")
+	}
+
+	var r0 *[]models.CategoriesSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) (*[]models.CategoriesSummary, error)); ok {
+		return rf(ctx, bookID, categoryIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) *[]models.CategoriesSummary); ok {
+		r0 = rf(ctx, bookID, categoryIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.CategoriesSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, []int) error); ok {
+		r1 = rf(ctx, bookID, categoryIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerCategoryUsecase_SetBookCategories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBookCategories'
+type MockhandlerCategoryUsecase_SetBookCategories_Call struct {
+	*mock.Call
+}
+
+// SetBookCategories is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookID int
+//   - categoryIDs []int
+func (_e *MockhandlerCategoryUsecase_Expecter) SetBookCategories(ctx interface{}, bookID interface{}, categoryIDs interface{}) *MockhandlerCategoryUsecase_SetBookCategories_Call {
+	return &MockhandlerCategoryUsecase_SetBookCategories_Call{Call: _e.mock.On("SetBookCategories", ctx, bookID, categoryIDs)}
+}
+
+func (_c *MockhandlerCategoryUsecase_SetBookCategories_Call) Run(run func(ctx context.Context, bookID int, categoryIDs []int)) *MockhandlerCategoryUsecase_SetBookCategories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].([]int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerCategoryUsecase_SetBookCategories_Call) Return(_a0 *[]models.CategoriesSummary, _a1 error) *MockhandlerCategoryUsecase_SetBookCategories_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerCategoryUsecase_SetBookCategories_Call) RunAndReturn(run func(context.Context, int, []int) (*[]models.CategoriesSummary, error)) *MockhandlerCategoryUsecase_SetBookCategories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCategory provides a mock function with given fields: ctx, category
+func (_m *MockhandlerCategoryUsecase) UpdateCategory(ctx context.Context, category *models.UpdateCategoriesRequest) (*models.CategoriesSummary, error) {
+	ret := _m.Called(ctx, category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCategory")
+	}
+
+	var r0 *models.CategoriesSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UpdateCategoriesRequest) (*models.CategoriesSummary, error)); ok {
+		return rf(ctx, category)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UpdateCategoriesRequest) *models.CategoriesSummary); ok {
+		r0 = rf(ctx, category)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CategoriesSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UpdateCategoriesRequest) error); ok {
+		r1 = rf(ctx, category)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerCategoryUsecase_UpdateCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCategory'
+type MockhandlerCategoryUsecase_UpdateCategory_Call struct {
+	*mock.Call
+}
+
+// UpdateCategory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - category *models.UpdateCategoriesRequest
+func (_e *MockhandlerCategoryUsecase_Expecter) UpdateCategory(ctx interface{}, category interface{}) *MockhandlerCategoryUsecase_UpdateCategory_Call {
+	return &MockhandlerCategoryUsecase_UpdateCategory_Call{Call: _e.mock.On("UpdateCategory", ctx, category)}
+}
+
+func (_c *MockhandlerCategoryUsecase_UpdateCategory_Call) Run(run func(ctx context.Context, category *models.UpdateCategoriesRequest)) *MockhandlerCategoryUsecase_UpdateCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.UpdateCategoriesRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerCategoryUsecase_UpdateCategory_Call) Return(_a0 *models.CategoriesSummary, _a1 error) *MockhandlerCategoryUsecase_UpdateCategory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerCategoryUsecase_UpdateCategory_Call) RunAndReturn(run func(context.Context, *models.UpdateCategoriesRequest) (*models.CategoriesSummary, error)) *MockhandlerCategoryUsecase_UpdateCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockhandlerCategoryUsecase creates a new instance of MockhandlerCategoryUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockhandlerCategoryUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockhandlerCategoryUsecase {
+	mock := &MockhandlerCategoryUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,203 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "crud-echo/internal/models"
+)
+
+// MockhandlerHoldUsecase is an autogenerated mock type for the handlerHoldUsecase type
+type MockhandlerHoldUsecase struct {
+	mock.Mock
+}
+
+type MockhandlerHoldUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockhandlerHoldUsecase) EXPECT() *MockhandlerHoldUsecase_Expecter {
+	return &MockhandlerHoldUsecase_Expecter{mock: &_m.Mock}
+}
+
+// CancelHold provides a mock function with given fields: ctx, id
+func (_m *MockhandlerHoldUsecase) CancelHold(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelHold")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockhandlerHoldUsecase_CancelHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelHold'
+type MockhandlerHoldUsecase_CancelHold_Call struct {
+	*mock.Call
+}
+
+// CancelHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockhandlerHoldUsecase_Expecter) CancelHold(ctx interface{}, id interface{}) *MockhandlerHoldUsecase_CancelHold_Call {
+	return &MockhandlerHoldUsecase_CancelHold_Call{Call: _e.mock.On("CancelHold", ctx, id)}
+}
+
+func (_c *MockhandlerHoldUsecase_CancelHold_Call) Run(run func(ctx context.Context, id int)) *MockhandlerHoldUsecase_CancelHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerHoldUsecase_CancelHold_Call) Return(_a0 error) *MockhandlerHoldUsecase_CancelHold_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockhandlerHoldUsecase_CancelHold_Call) RunAndReturn(run func(context.Context, int) error) *MockhandlerHoldUsecase_CancelHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHoldByID provides a mock function with given fields: ctx, id
+func (_m *MockhandlerHoldUsecase) GetHoldByID(ctx context.Context, id int) (*models.HoldsSummary, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHoldByID")
+	}
+
+	var r0 *models.HoldsSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.HoldsSummary, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.HoldsSummary); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.HoldsSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerHoldUsecase_GetHoldByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHoldByID'
+type MockhandlerHoldUsecase_GetHoldByID_Call struct {
+	*mock.Call
+}
+
+// GetHoldByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockhandlerHoldUsecase_Expecter) GetHoldByID(ctx interface{}, id interface{}) *MockhandlerHoldUsecase_GetHoldByID_Call {
+	return &MockhandlerHoldUsecase_GetHoldByID_Call{Call: _e.mock.On("GetHoldByID", ctx, id)}
+}
+
+func (_c *MockhandlerHoldUsecase_GetHoldByID_Call) Run(run func(ctx context.Context, id int)) *MockhandlerHoldUsecase_GetHoldByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerHoldUsecase_GetHoldByID_Call) Return(_a0 *models.HoldsSummary, _a1 error) *MockhandlerHoldUsecase_GetHoldByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerHoldUsecase_GetHoldByID_Call) RunAndReturn(run func(context.Context, int) (*models.HoldsSummary, error)) *MockhandlerHoldUsecase_GetHoldByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PlaceHold provides a mock function with given fields: ctx, hold
+func (_m *MockhandlerHoldUsecase) PlaceHold(ctx context.Context, hold *models.CreateHoldsRequest) (*models.HoldsSummary, error) {
+	ret := _m.Called(ctx, hold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlaceHold")
+	}
+
+	var r0 *models.HoldsSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateHoldsRequest) (*models.HoldsSummary, error)); ok {
+		return rf(ctx, hold)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateHoldsRequest) *models.HoldsSummary); ok {
+		r0 = rf(ctx, hold)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.HoldsSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.CreateHoldsRequest) error); ok {
+		r1 = rf(ctx, hold)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerHoldUsecase_PlaceHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlaceHold'
+type MockhandlerHoldUsecase_PlaceHold_Call struct {
+	*mock.Call
+}
+
+// PlaceHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hold *models.CreateHoldsRequest
+func (_e *MockhandlerHoldUsecase_Expecter) PlaceHold(ctx interface{}, hold interface{}) *MockhandlerHoldUsecase_PlaceHold_Call {
+	return &MockhandlerHoldUsecase_PlaceHold_Call{Call: _e.mock.On("PlaceHold", ctx, hold)}
+}
+
+func (_c *MockhandlerHoldUsecase_PlaceHold_Call) Run(run func(ctx context.Context, hold *models.CreateHoldsRequest)) *MockhandlerHoldUsecase_PlaceHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.CreateHoldsRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerHoldUsecase_PlaceHold_Call) Return(_a0 *models.HoldsSummary, _a1 error) *MockhandlerHoldUsecase_PlaceHold_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerHoldUsecase_PlaceHold_Call) RunAndReturn(run func(context.Context, *models.CreateHoldsRequest) (*models.HoldsSummary, error)) *MockhandlerHoldUsecase_PlaceHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockhandlerHoldUsecase creates a new instance of MockhandlerHoldUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockhandlerHoldUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockhandlerHoldUsecase {
+	mock := &MockhandlerHoldUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
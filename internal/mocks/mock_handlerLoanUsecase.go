@@ -0,0 +1,274 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "crud-echo/internal/models"
+)
+
+// MockhandlerLoanUsecase is an autogenerated mock type for the handlerLoanUsecase type
+type MockhandlerLoanUsecase struct {
+	mock.Mock
+}
+
+type MockhandlerLoanUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockhandlerLoanUsecase) EXPECT() *MockhandlerLoanUsecase_Expecter {
+	return &MockhandlerLoanUsecase_Expecter{mock: &_m.Mock}
+}
+
+// CheckoutBook provides a mock function with given fields: ctx, loan
+func (_m *MockhandlerLoanUsecase) CheckoutBook(ctx context.Context, loan *models.CreateLoansRequest) (*models.LoansSummary, error) {
+	ret := _m.Called(ctx, loan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckoutBook")
+	}
+
+	var r0 *models.LoansSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateLoansRequest) (*models.LoansSummary, error)); ok {
+		return rf(ctx, loan)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateLoansRequest) *models.LoansSummary); ok {
+		r0 = rf(ctx, loan)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoansSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.CreateLoansRequest) error); ok {
+		r1 = rf(ctx, loan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerLoanUsecase_CheckoutBook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckoutBook'
+type MockhandlerLoanUsecase_CheckoutBook_Call struct {
+	*mock.Call
+}
+
+// CheckoutBook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loan *models.CreateLoansRequest
+func (_e *MockhandlerLoanUsecase_Expecter) CheckoutBook(ctx interface{}, loan interface{}) *MockhandlerLoanUsecase_CheckoutBook_Call {
+	return &MockhandlerLoanUsecase_CheckoutBook_Call{Call: _e.mock.On("CheckoutBook", ctx, loan)}
+}
+
+func (_c *MockhandlerLoanUsecase_CheckoutBook_Call) Run(run func(ctx context.Context, loan *models.CreateLoansRequest)) *MockhandlerLoanUsecase_CheckoutBook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.CreateLoansRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerLoanUsecase_CheckoutBook_Call) Return(_a0 *models.LoansSummary, _a1 error) *MockhandlerLoanUsecase_CheckoutBook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerLoanUsecase_CheckoutBook_Call) RunAndReturn(run func(context.Context, *models.CreateLoansRequest) (*models.LoansSummary, error)) *MockhandlerLoanUsecase_CheckoutBook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanByID provides a mock function with given fields: ctx, id
+func (_m *MockhandlerLoanUsecase) GetLoanByID(ctx context.Context, id int) (*models.LoansSummary, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanByID")
+	}
+
+	var r0 *models.LoansSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.LoansSummary, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.LoansSummary); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoansSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerLoanUsecase_GetLoanByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanByID'
+type MockhandlerLoanUsecase_GetLoanByID_Call struct {
+	*mock.Call
+}
+
+// GetLoanByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockhandlerLoanUsecase_Expecter) GetLoanByID(ctx interface{}, id interface{}) *MockhandlerLoanUsecase_GetLoanByID_Call {
+	return &MockhandlerLoanUsecase_GetLoanByID_Call{Call: _e.mock.On("GetLoanByID", ctx, id)}
+}
+
+func (_c *MockhandlerLoanUsecase_GetLoanByID_Call) Run(run func(ctx context.Context, id int)) *MockhandlerLoanUsecase_GetLoanByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerLoanUsecase_GetLoanByID_Call) Return(_a0 *models.LoansSummary, _a1 error) *MockhandlerLoanUsecase_GetLoanByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerLoanUsecase_GetLoanByID_Call) RunAndReturn(run func(context.Context, int) (*models.LoansSummary, error)) *MockhandlerLoanUsecase_GetLoanByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenewLoan provides a mock function with given fields: ctx, id
+func (_m *MockhandlerLoanUsecase) RenewLoan(ctx context.Context, id int) (*models.LoansSummary, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewLoan")
+	}
+
+	var r0 *models.LoansSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.LoansSummary, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.LoansSummary); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoansSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerLoanUsecase_RenewLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewLoan'
+type MockhandlerLoanUsecase_RenewLoan_Call struct {
+	*mock.Call
+}
+
+// RenewLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockhandlerLoanUsecase_Expecter) RenewLoan(ctx interface{}, id interface{}) *MockhandlerLoanUsecase_RenewLoan_Call {
+	return &MockhandlerLoanUsecase_RenewLoan_Call{Call: _e.mock.On("RenewLoan", ctx, id)}
+}
+
+func (_c *MockhandlerLoanUsecase_RenewLoan_Call) Run(run func(ctx context.Context, id int)) *MockhandlerLoanUsecase_RenewLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerLoanUsecase_RenewLoan_Call) Return(_a0 *models.LoansSummary, _a1 error) *MockhandlerLoanUsecase_RenewLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerLoanUsecase_RenewLoan_Call) RunAndReturn(run func(context.Context, int) (*models.LoansSummary, error)) *MockhandlerLoanUsecase_RenewLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReturnLoan provides a mock function with given fields: ctx, id
+func (_m *MockhandlerLoanUsecase) ReturnLoan(ctx context.Context, id int) (*models.LoansSummary, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReturnLoan")
+	}
+
+	var r0 *models.LoansSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.LoansSummary, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.LoansSummary); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoansSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerLoanUsecase_ReturnLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReturnLoan'
+type MockhandlerLoanUsecase_ReturnLoan_Call struct {
+	*mock.Call
+}
+
+// ReturnLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockhandlerLoanUsecase_Expecter) ReturnLoan(ctx interface{}, id interface{}) *MockhandlerLoanUsecase_ReturnLoan_Call {
+	return &MockhandlerLoanUsecase_ReturnLoan_Call{Call: _e.mock.On("ReturnLoan", ctx, id)}
+}
+
+func (_c *MockhandlerLoanUsecase_ReturnLoan_Call) Run(run func(ctx context.Context, id int)) *MockhandlerLoanUsecase_ReturnLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerLoanUsecase_ReturnLoan_Call) Return(_a0 *models.LoansSummary, _a1 error) *MockhandlerLoanUsecase_ReturnLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerLoanUsecase_ReturnLoan_Call) RunAndReturn(run func(context.Context, int) (*models.LoansSummary, error)) *MockhandlerLoanUsecase_ReturnLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockhandlerLoanUsecase creates a new instance of MockhandlerLoanUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockhandlerLoanUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockhandlerLoanUsecase {
+	mock := &MockhandlerLoanUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
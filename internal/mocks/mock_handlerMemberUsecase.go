@@ -0,0 +1,354 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "crud-echo/internal/models"
+)
+
+// MockhandlerMemberUsecase is an autogenerated mock type for the handlerMemberUsecase type
+type MockhandlerMemberUsecase struct {
+	mock.Mock
+}
+
+type MockhandlerMemberUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockhandlerMemberUsecase) EXPECT() *MockhandlerMemberUsecase_Expecter {
+	return &MockhandlerMemberUsecase_Expecter{mock: &_m.Mock}
+}
+
+// CreateMember provides a mock function with given fields: ctx, member
+func (_m *MockhandlerMemberUsecase) CreateMember(ctx context.Context, member *models.CreateMembersRequest) (*models.MembersSummary, error) {
+	ret := _m.Called(ctx, member)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMember")
+	}
+
+	var r0 *models.MembersSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateMembersRequest) (*models.MembersSummary, error)); ok {
+		return rf(ctx, member)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateMembersRequest) *models.MembersSummary); ok {
+		r0 = rf(ctx, member)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MembersSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.CreateMembersRequest) error); ok {
+		r1 = rf(ctx, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerMemberUsecase_CreateMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMember'
+type MockhandlerMemberUsecase_CreateMember_Call struct {
+	*mock.Call
+}
+
+// CreateMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - member *models.CreateMembersRequest
+func (_e *MockhandlerMemberUsecase_Expecter) CreateMember(ctx interface{}, member interface{}) *MockhandlerMemberUsecase_CreateMember_Call {
+	return &MockhandlerMemberUsecase_CreateMember_Call{Call: _e.mock.On("CreateMember", ctx, member)}
+}
+
+func (_c *MockhandlerMemberUsecase_CreateMember_Call) Run(run func(ctx context.Context, member *models.CreateMembersRequest)) *MockhandlerMemberUsecase_CreateMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.CreateMembersRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerMemberUsecase_CreateMember_Call) Return(_a0 *models.MembersSummary, _a1 error) *MockhandlerMemberUsecase_CreateMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerMemberUsecase_CreateMember_Call) RunAndReturn(run func(context.Context, *models.CreateMembersRequest) (*models.MembersSummary, error)) *MockhandlerMemberUsecase_CreateMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllMembers provides a mock function with given fields: ctx, name, page
+func (_m *MockhandlerMemberUsecase) GetAllMembers(ctx context.Context, name string, page *models.PageRequest) (*[]models.MembersSummary, *models.PageMeta, error) {
+	ret := _m.Called(ctx, name, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllMembers")
+	}
+
+	var r0 *[]models.MembersSummary
+	var r1 *models.PageMeta
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PageRequest) (*[]models.MembersSummary, *models.PageMeta, error)); ok {
+		return rf(ctx, name, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PageRequest) *[]models.MembersSummary); ok {
+		r0 = rf(ctx, name, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.MembersSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.PageRequest) *models.PageMeta); ok {
+		r1 = rf(ctx, name, page)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.PageMeta)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, *models.PageRequest) error); ok {
+		r2 = rf(ctx, name, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockhandlerMemberUsecase_GetAllMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllMembers'
+type MockhandlerMemberUsecase_GetAllMembers_Call struct {
+	*mock.Call
+}
+
+// GetAllMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - page *models.PageRequest
+func (_e *MockhandlerMemberUsecase_Expecter) GetAllMembers(ctx interface{}, name interface{}, page interface{}) *MockhandlerMemberUsecase_GetAllMembers_Call {
+	return &MockhandlerMemberUsecase_GetAllMembers_Call{Call: _e.mock.On("GetAllMembers", ctx, name, page)}
+}
+
+func (_c *MockhandlerMemberUsecase_GetAllMembers_Call) Run(run func(ctx context.Context, name string, page *models.PageRequest)) *MockhandlerMemberUsecase_GetAllMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerMemberUsecase_GetAllMembers_Call) Return(_a0 *[]models.MembersSummary, _a1 *models.PageMeta, _a2 error) *MockhandlerMemberUsecase_GetAllMembers_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockhandlerMemberUsecase_GetAllMembers_Call) RunAndReturn(run func(context.Context, string, *models.PageRequest) (*[]models.MembersSummary, *models.PageMeta, error)) *MockhandlerMemberUsecase_GetAllMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMemberByID provides a mock function with given fields: ctx, id
+func (_m *MockhandlerMemberUsecase) GetMemberByID(ctx context.Context, id int) (*models.MembersSummary, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMemberByID")
+	}
+
+	var r0 *models.MembersSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.MembersSummary, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.MembersSummary); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MembersSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerMemberUsecase_GetMemberByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMemberByID'
+type MockhandlerMemberUsecase_GetMemberByID_Call struct {
+	*mock.Call
+}
+
+// GetMemberByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockhandlerMemberUsecase_Expecter) GetMemberByID(ctx interface{}, id interface{}) *MockhandlerMemberUsecase_GetMemberByID_Call {
+	return &MockhandlerMemberUsecase_GetMemberByID_Call{Call: _e.mock.On("GetMemberByID", ctx, id)}
+}
+
+func (_c *MockhandlerMemberUsecase_GetMemberByID_Call) Run(run func(ctx context.Context, id int)) *MockhandlerMemberUsecase_GetMemberByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerMemberUsecase_GetMemberByID_Call) Return(_a0 *models.MembersSummary, _a1 error) *MockhandlerMemberUsecase_GetMemberByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerMemberUsecase_GetMemberByID_Call) RunAndReturn(run func(context.Context, int) (*models.MembersSummary, error)) *MockhandlerMemberUsecase_GetMemberByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMemberLoans provides a mock function with given fields: ctx, id, status, page
+func (_m *MockhandlerMemberUsecase) GetMemberLoans(ctx context.Context, id int, status string, page *models.PageRequest) (*[]models.LoansSummary, *models.PageMeta, error) {
+	ret := _m.Called(ctx, id, status, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMemberLoans")
+	}
+
+	var r0 *[]models.LoansSummary
+	var r1 *models.PageMeta
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, *models.PageRequest) (*[]models.LoansSummary, *models.PageMeta, error)); ok {
+		return rf(ctx, id, status, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, *models.PageRequest) *[]models.LoansSummary); ok {
+		r0 = rf(ctx, id, status, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.LoansSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, *models.PageRequest) *models.PageMeta); ok {
+		r1 = rf(ctx, id, status, page)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.PageMeta)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, string, *models.PageRequest) error); ok {
+		r2 = rf(ctx, id, status, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockhandlerMemberUsecase_GetMemberLoans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMemberLoans'
+type MockhandlerMemberUsecase_GetMemberLoans_Call struct {
+	*mock.Call
+}
+
+// GetMemberLoans is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - status string
+//   - page *models.PageRequest
+func (_e *MockhandlerMemberUsecase_Expecter) GetMemberLoans(ctx interface{}, id interface{}, status interface{}, page interface{}) *MockhandlerMemberUsecase_GetMemberLoans_Call {
+	return &MockhandlerMemberUsecase_GetMemberLoans_Call{Call: _e.mock.On("GetMemberLoans", ctx, id, status, page)}
+}
+
+func (_c *MockhandlerMemberUsecase_GetMemberLoans_Call) Run(run func(ctx context.Context, id int, status string, page *models.PageRequest)) *MockhandlerMemberUsecase_GetMemberLoans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string), args[3].(*models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerMemberUsecase_GetMemberLoans_Call) Return(_a0 *[]models.LoansSummary, _a1 *models.PageMeta, _a2 error) *MockhandlerMemberUsecase_GetMemberLoans_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockhandlerMemberUsecase_GetMemberLoans_Call) RunAndReturn(run func(context.Context, int, string, *models.PageRequest) (*[]models.LoansSummary, *models.PageMeta, error)) *MockhandlerMemberUsecase_GetMemberLoans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMember provides a mock function with given fields: ctx, member
+func (_m *MockhandlerMemberUsecase) UpdateMember(ctx context.Context, member *models.UpdateMembersRequest) (*models.MembersSummary, error) {
+	ret := _m.Called(ctx, member)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMember")
+	}
+
+	var r0 *models.MembersSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UpdateMembersRequest) (*models.MembersSummary, error)); ok {
+		return rf(ctx, member)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UpdateMembersRequest) *models.MembersSummary); ok {
+		r0 = rf(ctx, member)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MembersSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UpdateMembersRequest) error); ok {
+		r1 = rf(ctx, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerMemberUsecase_UpdateMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMember'
+type MockhandlerMemberUsecase_UpdateMember_Call struct {
+	*mock.Call
+}
+
+// UpdateMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - member *models.UpdateMembersRequest
+func (_e *MockhandlerMemberUsecase_Expecter) UpdateMember(ctx interface{}, member interface{}) *MockhandlerMemberUsecase_UpdateMember_Call {
+	return &MockhandlerMemberUsecase_UpdateMember_Call{Call: _e.mock.On("UpdateMember", ctx, member)}
+}
+
+func (_c *MockhandlerMemberUsecase_UpdateMember_Call) Run(run func(ctx context.Context, member *models.UpdateMembersRequest)) *MockhandlerMemberUsecase_UpdateMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.UpdateMembersRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerMemberUsecase_UpdateMember_Call) Return(_a0 *models.MembersSummary, _a1 error) *MockhandlerMemberUsecase_UpdateMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerMemberUsecase_UpdateMember_Call) RunAndReturn(run func(context.Context, *models.UpdateMembersRequest) (*models.MembersSummary, error)) *MockhandlerMemberUsecase_UpdateMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockhandlerMemberUsecase creates a new instance of MockhandlerMemberUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockhandlerMemberUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockhandlerMemberUsecase {
+	mock := &MockhandlerMemberUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,332 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "crud-echo/internal/models"
+)
+
+// MockhandlerTagUsecase is an autogenerated mock type for the handlerTagUsecase type
+type MockhandlerTagUsecase struct {
+	mock.Mock
+}
+
+type MockhandlerTagUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockhandlerTagUsecase) EXPECT() *MockhandlerTagUsecase_Expecter {
+	return &MockhandlerTagUsecase_Expecter{mock: &_m.Mock}
+}
+
+// CreateTag provides a mock function with given fields: ctx, tag
+func (_m *MockhandlerTagUsecase) CreateTag(ctx context.Context, tag *models.CreateTagsRequest) (*models.TagsSummary, error) {
+	ret := _m.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTag")
+	}
+
+	var r0 *models.TagsSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateTagsRequest) (*models.TagsSummary, error)); ok {
+		return rf(ctx, tag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CreateTagsRequest) *models.TagsSummary); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TagsSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.CreateTagsRequest) error); ok {
+		r1 = rf(ctx, tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerTagUsecase_CreateTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTag'
+type MockhandlerTagUsecase_CreateTag_Call struct {
+	*mock.Call
+}
+
+// CreateTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tag *models.CreateTagsRequest
+func (_e *MockhandlerTagUsecase_Expecter) CreateTag(ctx interface{}, tag interface{}) *MockhandlerTagUsecase_CreateTag_Call {
+	return &MockhandlerTagUsecase_CreateTag_Call{Call: _e.mock.On("CreateTag", ctx, tag)}
+}
+
+func (_c *MockhandlerTagUsecase_CreateTag_Call) Run(run func(ctx context.Context, tag *models.CreateTagsRequest)) *MockhandlerTagUsecase_CreateTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.CreateTagsRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerTagUsecase_CreateTag_Call) Return(_a0 *models.TagsSummary, _a1 error) *MockhandlerTagUsecase_CreateTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerTagUsecase_CreateTag_Call) RunAndReturn(run func(context.Context, *models.CreateTagsRequest) (*models.TagsSummary, error)) *MockhandlerTagUsecase_CreateTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTag provides a mock function with given fields: ctx, id
+func (_m *MockhandlerTagUsecase) DeleteTag(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockhandlerTagUsecase_DeleteTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTag'
+type MockhandlerTagUsecase_DeleteTag_Call struct {
+	*mock.Call
+}
+
+// DeleteTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockhandlerTagUsecase_Expecter) DeleteTag(ctx interface{}, id interface{}) *MockhandlerTagUsecase_DeleteTag_Call {
+	return &MockhandlerTagUsecase_DeleteTag_Call{Call: _e.mock.On("DeleteTag", ctx, id)}
+}
+
+func (_c *MockhandlerTagUsecase_DeleteTag_Call) Run(run func(ctx context.Context, id int)) *MockhandlerTagUsecase_DeleteTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerTagUsecase_DeleteTag_Call) Return(_a0 error) *MockhandlerTagUsecase_DeleteTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockhandlerTagUsecase_DeleteTag_Call) RunAndReturn(run func(context.Context, int) error) *MockhandlerTagUsecase_DeleteTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllTags provides a mock function with given fields: ctx, name, page
+func (_m *MockhandlerTagUsecase) GetAllTags(ctx context.Context, name string, page *models.PageRequest) (*[]models.TagsSummary, *models.PageMeta, error) {
+	ret := _m.Called(ctx, name, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllTags")
+	}
+
+	var r0 *[]models.TagsSummary
+	var r1 *models.PageMeta
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PageRequest) (*[]models.TagsSummary, *models.PageMeta, error)); ok {
+		return rf(ctx, name, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.PageRequest) *[]models.TagsSummary); ok {
+		r0 = rf(ctx, name, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.TagsSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.PageRequest) *models.PageMeta); ok {
+		r1 = rf(ctx, name, page)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.PageMeta)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, *models.PageRequest) error); ok {
+		r2 = rf(ctx, name, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockhandlerTagUsecase_GetAllTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTags'
+type MockhandlerTagUsecase_GetAllTags_Call struct {
+	*mock.Call
+}
+
+// GetAllTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - page *models.PageRequest
+func (_e *MockhandlerTagUsecase_Expecter) GetAllTags(ctx interface{}, name interface{}, page interface{}) *MockhandlerTagUsecase_GetAllTags_Call {
+	return &MockhandlerTagUsecase_GetAllTags_Call{Call: _e.mock.On("GetAllTags", ctx, name, page)}
+}
+
+func (_c *MockhandlerTagUsecase_GetAllTags_Call) Run(run func(ctx context.Context, name string, page *models.PageRequest)) *MockhandlerTagUsecase_GetAllTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *MockhandlerTagUsecase_GetAllTags_Call) Return(_a0 *[]models.TagsSummary, _a1 *models.PageMeta, _a2 error) *MockhandlerTagUsecase_GetAllTags_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockhandlerTagUsecase_GetAllTags_Call) RunAndReturn(run func(context.Context, string, *models.PageRequest) (*[]models.TagsSummary, *models.PageMeta, error)) *MockhandlerTagUsecase_GetAllTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBookTags provides a mock function with given fields: ctx, bookID
+func (_m *MockhandlerTagUsecase) GetBookTags(ctx context.Context, bookID int) (*[]models.TagsSummary, error) {
+	ret := _m.Called(ctx, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookTags")
+	}
+
+	var r0 *[]models.TagsSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*[]models.TagsSummary, error)); ok {
+		return rf(ctx, bookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *[]models.TagsSummary); ok {
+		r0 = rf(ctx, bookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.TagsSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerTagUsecase_GetBookTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookTags'
+type MockhandlerTagUsecase_GetBookTags_Call struct {
+	*mock.Call
+}
+
+// GetBookTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookID int
+func (_e *MockhandlerTagUsecase_Expecter) GetBookTags(ctx interface{}, bookID interface{}) *MockhandlerTagUsecase_GetBookTags_Call {
+	return &MockhandlerTagUsecase_GetBookTags_Call{Call: _e.mock.On("GetBookTags", ctx, bookID)}
+}
+
+func (_c *MockhandlerTagUsecase_GetBookTags_Call) Run(run func(ctx context.Context, bookID int)) *MockhandlerTagUsecase_GetBookTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockhandlerTagUsecase_GetBookTags_Call) Return(_a0 *[]models.TagsSummary, _a1 error) *MockhandlerTagUsecase_GetBookTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerTagUsecase_GetBookTags_Call) RunAndReturn(run func(context.Context, int) (*[]models.TagsSummary, error)) *MockhandlerTagUsecase_GetBookTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetBookTags provides a mock function with given fields: ctx, bookID, names
+func (_m *MockhandlerTagUsecase) SetBookTags(ctx context.Context, bookID int, names []string) (*[]models.TagsSummary, error) {
+	ret := _m.Called(ctx, bookID, names)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetBookTags")
+	}
+
+	var r0 *[]models.TagsSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string) (*[]models.TagsSummary, error)); ok {
+		return rf(ctx, bookID, names)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string) *[]models.TagsSummary); ok {
+		r0 = rf(ctx, bookID, names)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.TagsSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, []string) error); ok {
+		r1 = rf(ctx, bookID, names)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockhandlerTagUsecase_SetBookTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBookTags'
+type MockhandlerTagUsecase_SetBookTags_Call struct {
+	*mock.Call
+}
+
+// SetBookTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookID int
+//   - names []string
+func (_e *MockhandlerTagUsecase_Expecter) SetBookTags(ctx interface{}, bookID interface{}, names interface{}) *MockhandlerTagUsecase_SetBookTags_Call {
+	return &MockhandlerTagUsecase_SetBookTags_Call{Call: _e.mock.On("SetBookTags", ctx, bookID, names)}
+}
+
+func (_c *MockhandlerTagUsecase_SetBookTags_Call) Run(run func(ctx context.Context, bookID int, names []string)) *MockhandlerTagUsecase_SetBookTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockhandlerTagUsecase_SetBookTags_Call) Return(_a0 *[]models.TagsSummary, _a1 error) *MockhandlerTagUsecase_SetBookTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockhandlerTagUsecase_SetBookTags_Call) RunAndReturn(run func(context.Context, int, []string) (*[]models.TagsSummary, error)) *MockhandlerTagUsecase_SetBookTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockhandlerTagUsecase creates a new instance of MockhandlerTagUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockhandlerTagUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockhandlerTagUsecase {
+	mock := &MockhandlerTagUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,348 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "crud-echo/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockusecaseAuthorsRepository is an autogenerated mock type for the usecaseAuthorsRepository type
+type MockusecaseAuthorsRepository struct {
+	mock.Mock
+}
+
+type MockusecaseAuthorsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockusecaseAuthorsRepository) EXPECT() *MockusecaseAuthorsRepository_Expecter {
+	return &MockusecaseAuthorsRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, author
+func (_m *MockusecaseAuthorsRepository) Create(ctx context.Context, author *models.Authors) error {
+	ret := _m.Called(ctx, author)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Authors) error); ok {
+		r0 = rf(ctx, author)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseAuthorsRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockusecaseAuthorsRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - author *models.Authors
+func (_e *MockusecaseAuthorsRepository_Expecter) Create(ctx interface{}, author interface{}) *MockusecaseAuthorsRepository_Create_Call {
+	return &MockusecaseAuthorsRepository_Create_Call{Call: _e.mock.On("Create", ctx, author)}
+}
+
+func (_c *MockusecaseAuthorsRepository_Create_Call) Run(run func(ctx context.Context, author *models.Authors)) *MockusecaseAuthorsRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Authors))
+	})
+	return _c
+}
+
+func (_c *MockusecaseAuthorsRepository_Create_Call) Return(_a0 error) *MockusecaseAuthorsRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseAuthorsRepository_Create_Call) RunAndReturn(run func(context.Context, *models.Authors) error) *MockusecaseAuthorsRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *MockusecaseAuthorsRepository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseAuthorsRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockusecaseAuthorsRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockusecaseAuthorsRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockusecaseAuthorsRepository_Delete_Call {
+	return &MockusecaseAuthorsRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockusecaseAuthorsRepository_Delete_Call) Run(run func(ctx context.Context, id int)) *MockusecaseAuthorsRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseAuthorsRepository_Delete_Call) Return(_a0 error) *MockusecaseAuthorsRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseAuthorsRepository_Delete_Call) RunAndReturn(run func(context.Context, int) error) *MockusecaseAuthorsRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBooks provides a mock function with given fields: ctx, books, authorID, page
+func (_m *MockusecaseAuthorsRepository) GetBooks(ctx context.Context, books *[]models.Books, authorID int, page *models.PageRequest) (*models.PageResult, error) {
+	ret := _m.Called(ctx, books, authorID, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBooks")
+	}
+
+	var r0 *models.PageResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Books, int, *models.PageRequest) (*models.PageResult, error)); ok {
+		return rf(ctx, books, authorID, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Books, int, *models.PageRequest) *models.PageResult); ok {
+		r0 = rf(ctx, books, authorID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PageResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *[]models.Books, int, *models.PageRequest) error); ok {
+		r1 = rf(ctx, books, authorID, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockusecaseAuthorsRepository_GetBooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBooks'
+type MockusecaseAuthorsRepository_GetBooks_Call struct {
+	*mock.Call
+}
+
+// GetBooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - books *[]models.Books
+//   - authorID int
+//   - page *models.PageRequest
+func (_e *MockusecaseAuthorsRepository_Expecter) GetBooks(ctx interface{}, books interface{}, authorID interface{}, page interface{}) *MockusecaseAuthorsRepository_GetBooks_Call {
+	return &MockusecaseAuthorsRepository_GetBooks_Call{Call: _e.mock.On("GetBooks", ctx, books, authorID, page)}
+}
+
+func (_c *MockusecaseAuthorsRepository_GetBooks_Call) Run(run func(ctx context.Context, books *[]models.Books, authorID int, page *models.PageRequest)) *MockusecaseAuthorsRepository_GetBooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*[]models.Books), args[2].(int), args[3].(*models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *MockusecaseAuthorsRepository_GetBooks_Call) Return(_a0 *models.PageResult, _a1 error) *MockusecaseAuthorsRepository_GetBooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockusecaseAuthorsRepository_GetBooks_Call) RunAndReturn(run func(context.Context, *[]models.Books, int, *models.PageRequest) (*models.PageResult, error)) *MockusecaseAuthorsRepository_GetBooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, author, id
+func (_m *MockusecaseAuthorsRepository) GetByID(ctx context.Context, author *models.Authors, id int) error {
+	ret := _m.Called(ctx, author, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Authors, int) error); ok {
+		r0 = rf(ctx, author, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseAuthorsRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockusecaseAuthorsRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - author *models.Authors
+//   - id int
+func (_e *MockusecaseAuthorsRepository_Expecter) GetByID(ctx interface{}, author interface{}, id interface{}) *MockusecaseAuthorsRepository_GetByID_Call {
+	return &MockusecaseAuthorsRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, author, id)}
+}
+
+func (_c *MockusecaseAuthorsRepository_GetByID_Call) Run(run func(ctx context.Context, author *models.Authors, id int)) *MockusecaseAuthorsRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Authors), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseAuthorsRepository_GetByID_Call) Return(_a0 error) *MockusecaseAuthorsRepository_GetByID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseAuthorsRepository_GetByID_Call) RunAndReturn(run func(context.Context, *models.Authors, int) error) *MockusecaseAuthorsRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPage provides a mock function with given fields: ctx, authors, name, page
+func (_m *MockusecaseAuthorsRepository) GetPage(ctx context.Context, authors *[]models.Authors, name string, page *models.PageRequest) (*models.PageResult, error) {
+	ret := _m.Called(ctx, authors, name, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPage")
+	}
+
+	var r0 *models.PageResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Authors, string, *models.PageRequest) (*models.PageResult, error)); ok {
+		return rf(ctx, authors, name, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Authors, string, *models.PageRequest) *models.PageResult); ok {
+		r0 = rf(ctx, authors, name, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PageResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *[]models.Authors, string, *models.PageRequest) error); ok {
+		r1 = rf(ctx, authors, name, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockusecaseAuthorsRepository_GetPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPage'
+type MockusecaseAuthorsRepository_GetPage_Call struct {
+	*mock.Call
+}
+
+// GetPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authors *[]models.Authors
+//   - name string
+//   - page *models.PageRequest
+func (_e *MockusecaseAuthorsRepository_Expecter) GetPage(ctx interface{}, authors interface{}, name interface{}, page interface{}) *MockusecaseAuthorsRepository_GetPage_Call {
+	return &MockusecaseAuthorsRepository_GetPage_Call{Call: _e.mock.On("GetPage", ctx, authors, name, page)}
+}
+
+func (_c *MockusecaseAuthorsRepository_GetPage_Call) Run(run func(ctx context.Context, authors *[]models.Authors, name string, page *models.PageRequest)) *MockusecaseAuthorsRepository_GetPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*[]models.Authors), args[2].(string), args[3].(*models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *MockusecaseAuthorsRepository_GetPage_Call) Return(_a0 *models.PageResult, _a1 error) *MockusecaseAuthorsRepository_GetPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockusecaseAuthorsRepository_GetPage_Call) RunAndReturn(run func(context.Context, *[]models.Authors, string, *models.PageRequest) (*models.PageResult, error)) *MockusecaseAuthorsRepository_GetPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, author
+func (_m *MockusecaseAuthorsRepository) Update(ctx context.Context, author *models.Authors) error {
+	ret := _m.Called(ctx, author)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Authors) error); ok {
+		r0 = rf(ctx, author)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseAuthorsRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockusecaseAuthorsRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - author *models.Authors
+func (_e *MockusecaseAuthorsRepository_Expecter) Update(ctx interface{}, author interface{}) *MockusecaseAuthorsRepository_Update_Call {
+	return &MockusecaseAuthorsRepository_Update_Call{Call: _e.mock.On("Update", ctx, author)}
+}
+
+func (_c *MockusecaseAuthorsRepository_Update_Call) Run(run func(ctx context.Context, author *models.Authors)) *MockusecaseAuthorsRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Authors))
+	})
+	return _c
+}
+
+func (_c *MockusecaseAuthorsRepository_Update_Call) Return(_a0 error) *MockusecaseAuthorsRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseAuthorsRepository_Update_Call) RunAndReturn(run func(context.Context, *models.Authors) error) *MockusecaseAuthorsRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockusecaseAuthorsRepository creates a new instance of MockusecaseAuthorsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockusecaseAuthorsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockusecaseAuthorsRepository {
+	mock := &MockusecaseAuthorsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	context "context"
 	models "crud-echo/internal/models"
 
 	mock "github.com/stretchr/testify/mock"
@@ -21,17 +22,66 @@ func (_m *MockusecaseBooksRepository) EXPECT() *MockusecaseBooksRepository_Expec
 	return &MockusecaseBooksRepository_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function with given fields: book
-func (_m *MockusecaseBooksRepository) Create(book *models.Books) error {
-	ret := _m.Called(book)
+// AddQty provides a mock function with given fields: ctx, id, delta, book
+func (_m *MockusecaseBooksRepository) AddQty(ctx context.Context, id int, delta int, book *models.Books) error {
+	ret := _m.Called(ctx, id, delta, book)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddQty")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *models.Books) error); ok {
+		r0 = rf(ctx, id, delta, book)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseBooksRepository_AddQty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddQty'
+type MockusecaseBooksRepository_AddQty_Call struct {
+	*mock.Call
+}
+
+// AddQty is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - delta int
+//   - book *models.Books
+func (_e *MockusecaseBooksRepository_Expecter) AddQty(ctx interface{}, id interface{}, delta interface{}, book interface{}) *MockusecaseBooksRepository_AddQty_Call {
+	return &MockusecaseBooksRepository_AddQty_Call{Call: _e.mock.On("AddQty", ctx, id, delta, book)}
+}
+
+func (_c *MockusecaseBooksRepository_AddQty_Call) Run(run func(ctx context.Context, id int, delta int, book *models.Books)) *MockusecaseBooksRepository_AddQty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int), args[3].(*models.Books))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_AddQty_Call) Return(_a0 error) *MockusecaseBooksRepository_AddQty_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_AddQty_Call) RunAndReturn(run func(context.Context, int, int, *models.Books) error) *MockusecaseBooksRepository_AddQty_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, book
+func (_m *MockusecaseBooksRepository) Create(ctx context.Context, book *models.Books) error {
+	ret := _m.Called(ctx, book)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Books) error); ok {
-		r0 = rf(book)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Books) error); ok {
+		r0 = rf(ctx, book)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -45,14 +95,15 @@ type MockusecaseBooksRepository_Create_Call struct {
 }
 
 // Create is a helper method to define mock.On call
+//   - ctx context.Context
 //   - book *models.Books
-func (_e *MockusecaseBooksRepository_Expecter) Create(book interface{}) *MockusecaseBooksRepository_Create_Call {
-	return &MockusecaseBooksRepository_Create_Call{Call: _e.mock.On("Create", book)}
+func (_e *MockusecaseBooksRepository_Expecter) Create(ctx interface{}, book interface{}) *MockusecaseBooksRepository_Create_Call {
+	return &MockusecaseBooksRepository_Create_Call{Call: _e.mock.On("Create", ctx, book)}
 }
 
-func (_c *MockusecaseBooksRepository_Create_Call) Run(run func(book *models.Books)) *MockusecaseBooksRepository_Create_Call {
+func (_c *MockusecaseBooksRepository_Create_Call) Run(run func(ctx context.Context, book *models.Books)) *MockusecaseBooksRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*models.Books))
+		run(args[0].(context.Context), args[1].(*models.Books))
 	})
 	return _c
 }
@@ -62,22 +113,117 @@ func (_c *MockusecaseBooksRepository_Create_Call) Return(_a0 error) *Mockusecase
 	return _c
 }
 
-func (_c *MockusecaseBooksRepository_Create_Call) RunAndReturn(run func(*models.Books) error) *MockusecaseBooksRepository_Create_Call {
+func (_c *MockusecaseBooksRepository_Create_Call) RunAndReturn(run func(context.Context, *models.Books) error) *MockusecaseBooksRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateInBatches provides a mock function with given fields: ctx, books, batchSize
+func (_m *MockusecaseBooksRepository) CreateInBatches(ctx context.Context, books []models.Books, batchSize int) error {
+	ret := _m.Called(ctx, books, batchSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInBatches")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Books, int) error); ok {
+		r0 = rf(ctx, books, batchSize)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseBooksRepository_CreateInBatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateInBatches'
+type MockusecaseBooksRepository_CreateInBatches_Call struct {
+	*mock.Call
+}
+
+// CreateInBatches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - books []models.Books
+//   - batchSize int
+func (_e *MockusecaseBooksRepository_Expecter) CreateInBatches(ctx interface{}, books interface{}, batchSize interface{}) *MockusecaseBooksRepository_CreateInBatches_Call {
+	return &MockusecaseBooksRepository_CreateInBatches_Call{Call: _e.mock.On("CreateInBatches", ctx, books, batchSize)}
+}
+
+func (_c *MockusecaseBooksRepository_CreateInBatches_Call) Run(run func(ctx context.Context, books []models.Books, batchSize int)) *MockusecaseBooksRepository_CreateInBatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.Books), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_CreateInBatches_Call) Return(_a0 error) *MockusecaseBooksRepository_CreateInBatches_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_CreateInBatches_Call) RunAndReturn(run func(context.Context, []models.Books, int) error) *MockusecaseBooksRepository_CreateInBatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateMovements provides a mock function with given fields: ctx, movements
+func (_m *MockusecaseBooksRepository) CreateMovements(ctx context.Context, movements []models.StockMovement) error {
+	ret := _m.Called(ctx, movements)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMovements")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.StockMovement) error); ok {
+		r0 = rf(ctx, movements)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseBooksRepository_CreateMovements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMovements'
+type MockusecaseBooksRepository_CreateMovements_Call struct {
+	*mock.Call
+}
+
+// CreateMovements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - movements []models.StockMovement
+func (_e *MockusecaseBooksRepository_Expecter) CreateMovements(ctx interface{}, movements interface{}) *MockusecaseBooksRepository_CreateMovements_Call {
+	return &MockusecaseBooksRepository_CreateMovements_Call{Call: _e.mock.On("CreateMovements", ctx, movements)}
+}
+
+func (_c *MockusecaseBooksRepository_CreateMovements_Call) Run(run func(ctx context.Context, movements []models.StockMovement)) *MockusecaseBooksRepository_CreateMovements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.StockMovement))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_CreateMovements_Call) Return(_a0 error) *MockusecaseBooksRepository_CreateMovements_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_CreateMovements_Call) RunAndReturn(run func(context.Context, []models.StockMovement) error) *MockusecaseBooksRepository_CreateMovements_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Delete provides a mock function with given fields: book
-func (_m *MockusecaseBooksRepository) Delete(book *models.Books) error {
-	ret := _m.Called(book)
+// Delete provides a mock function with given fields: ctx, book
+func (_m *MockusecaseBooksRepository) Delete(ctx context.Context, book *models.Books) error {
+	ret := _m.Called(ctx, book)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Books) error); ok {
-		r0 = rf(book)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Books) error); ok {
+		r0 = rf(ctx, book)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -91,14 +237,15 @@ type MockusecaseBooksRepository_Delete_Call struct {
 }
 
 // Delete is a helper method to define mock.On call
+//   - ctx context.Context
 //   - book *models.Books
-func (_e *MockusecaseBooksRepository_Expecter) Delete(book interface{}) *MockusecaseBooksRepository_Delete_Call {
-	return &MockusecaseBooksRepository_Delete_Call{Call: _e.mock.On("Delete", book)}
+func (_e *MockusecaseBooksRepository_Expecter) Delete(ctx interface{}, book interface{}) *MockusecaseBooksRepository_Delete_Call {
+	return &MockusecaseBooksRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, book)}
 }
 
-func (_c *MockusecaseBooksRepository_Delete_Call) Run(run func(book *models.Books)) *MockusecaseBooksRepository_Delete_Call {
+func (_c *MockusecaseBooksRepository_Delete_Call) Run(run func(ctx context.Context, book *models.Books)) *MockusecaseBooksRepository_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*models.Books))
+		run(args[0].(context.Context), args[1].(*models.Books))
 	})
 	return _c
 }
@@ -108,32 +255,395 @@ func (_c *MockusecaseBooksRepository_Delete_Call) Return(_a0 error) *Mockusecase
 	return _c
 }
 
-func (_c *MockusecaseBooksRepository_Delete_Call) RunAndReturn(run func(*models.Books) error) *MockusecaseBooksRepository_Delete_Call {
+func (_c *MockusecaseBooksRepository_Delete_Call) RunAndReturn(run func(context.Context, *models.Books) error) *MockusecaseBooksRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Each provides a mock function with given fields: ctx, fn
+func (_m *MockusecaseBooksRepository) Each(ctx context.Context, fn func(*models.Books) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Each")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*models.Books) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseBooksRepository_Each_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Each'
+type MockusecaseBooksRepository_Each_Call struct {
+	*mock.Call
+}
+
+// Each is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(*models.Books) error
+func (_e *MockusecaseBooksRepository_Expecter) Each(ctx interface{}, fn interface{}) *MockusecaseBooksRepository_Each_Call {
+	return &MockusecaseBooksRepository_Each_Call{Call: _e.mock.On("Each", ctx, fn)}
+}
+
+func (_c *MockusecaseBooksRepository_Each_Call) Run(run func(ctx context.Context, fn func(*models.Books) error)) *MockusecaseBooksRepository_Each_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(*models.Books) error))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_Each_Call) Return(_a0 error) *MockusecaseBooksRepository_Each_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_Each_Call) RunAndReturn(run func(context.Context, func(*models.Books) error) error) *MockusecaseBooksRepository_Each_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, book, id
+func (_m *MockusecaseBooksRepository) GetByID(ctx context.Context, book *models.Books, id int) error {
+	ret := _m.Called(ctx, book, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Books, int) error); ok {
+		r0 = rf(ctx, book, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseBooksRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockusecaseBooksRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - book *models.Books
+//   - id int
+func (_e *MockusecaseBooksRepository_Expecter) GetByID(ctx interface{}, book interface{}, id interface{}) *MockusecaseBooksRepository_GetByID_Call {
+	return &MockusecaseBooksRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, book, id)}
+}
+
+func (_c *MockusecaseBooksRepository_GetByID_Call) Run(run func(ctx context.Context, book *models.Books, id int)) *MockusecaseBooksRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Books), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_GetByID_Call) Return(_a0 error) *MockusecaseBooksRepository_GetByID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_GetByID_Call) RunAndReturn(run func(context.Context, *models.Books, int) error) *MockusecaseBooksRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDForUpdate provides a mock function with given fields: ctx, book, id
+func (_m *MockusecaseBooksRepository) GetByIDForUpdate(ctx context.Context, book *models.Books, id int) error {
+	ret := _m.Called(ctx, book, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDForUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Books, int) error); ok {
+		r0 = rf(ctx, book, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseBooksRepository_GetByIDForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDForUpdate'
+type MockusecaseBooksRepository_GetByIDForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetByIDForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - book *models.Books
+//   - id int
+func (_e *MockusecaseBooksRepository_Expecter) GetByIDForUpdate(ctx interface{}, book interface{}, id interface{}) *MockusecaseBooksRepository_GetByIDForUpdate_Call {
+	return &MockusecaseBooksRepository_GetByIDForUpdate_Call{Call: _e.mock.On("GetByIDForUpdate", ctx, book, id)}
+}
+
+func (_c *MockusecaseBooksRepository_GetByIDForUpdate_Call) Run(run func(ctx context.Context, book *models.Books, id int)) *MockusecaseBooksRepository_GetByIDForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Books), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_GetByIDForUpdate_Call) Return(_a0 error) *MockusecaseBooksRepository_GetByIDForUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_GetByIDForUpdate_Call) RunAndReturn(run func(context.Context, *models.Books, int) error) *MockusecaseBooksRepository_GetByIDForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByISBN provides a mock function with given fields: ctx, book, isbn
+func (_m *MockusecaseBooksRepository) GetByISBN(ctx context.Context, book *models.Books, isbn string) error {
+	ret := _m.Called(ctx, book, isbn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByISBN")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Books, string) error); ok {
+		r0 = rf(ctx, book, isbn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseBooksRepository_GetByISBN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByISBN'
+type MockusecaseBooksRepository_GetByISBN_Call struct {
+	*mock.Call
+}
+
+// GetByISBN is a helper method to define mock.On call
+//   - ctx context.Context
+//   - book *models.Books
+//   - isbn string
+func (_e *MockusecaseBooksRepository_Expecter) GetByISBN(ctx interface{}, book interface{}, isbn interface{}) *MockusecaseBooksRepository_GetByISBN_Call {
+	return &MockusecaseBooksRepository_GetByISBN_Call{Call: _e.mock.On("GetByISBN", ctx, book, isbn)}
+}
+
+func (_c *MockusecaseBooksRepository_GetByISBN_Call) Run(run func(ctx context.Context, book *models.Books, isbn string)) *MockusecaseBooksRepository_GetByISBN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Books), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_GetByISBN_Call) Return(_a0 error) *MockusecaseBooksRepository_GetByISBN_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_GetByISBN_Call) RunAndReturn(run func(context.Context, *models.Books, string) error) *MockusecaseBooksRepository_GetByISBN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByTitles provides a mock function with given fields: ctx, books, titles
+func (_m *MockusecaseBooksRepository) GetByTitles(ctx context.Context, books *[]models.Books, titles []string) error {
+	ret := _m.Called(ctx, books, titles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTitles")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Books, []string) error); ok {
+		r0 = rf(ctx, books, titles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseBooksRepository_GetByTitles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTitles'
+type MockusecaseBooksRepository_GetByTitles_Call struct {
+	*mock.Call
+}
+
+// GetByTitles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - books *[]models.Books
+//   - titles []string
+func (_e *MockusecaseBooksRepository_Expecter) GetByTitles(ctx interface{}, books interface{}, titles interface{}) *MockusecaseBooksRepository_GetByTitles_Call {
+	return &MockusecaseBooksRepository_GetByTitles_Call{Call: _e.mock.On("GetByTitles", ctx, books, titles)}
+}
+
+func (_c *MockusecaseBooksRepository_GetByTitles_Call) Run(run func(ctx context.Context, books *[]models.Books, titles []string)) *MockusecaseBooksRepository_GetByTitles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*[]models.Books), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_GetByTitles_Call) Return(_a0 error) *MockusecaseBooksRepository_GetByTitles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_GetByTitles_Call) RunAndReturn(run func(context.Context, *[]models.Books, []string) error) *MockusecaseBooksRepository_GetByTitles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMovements provides a mock function with given fields: ctx, movements, bookID, page
+func (_m *MockusecaseBooksRepository) GetMovements(ctx context.Context, movements *[]models.StockMovement, bookID int, page *models.PageRequest) (*models.PageResult, error) {
+	ret := _m.Called(ctx, movements, bookID, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMovements")
+	}
+
+	var r0 *models.PageResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.StockMovement, int, *models.PageRequest) (*models.PageResult, error)); ok {
+		return rf(ctx, movements, bookID, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.StockMovement, int, *models.PageRequest) *models.PageResult); ok {
+		r0 = rf(ctx, movements, bookID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PageResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *[]models.StockMovement, int, *models.PageRequest) error); ok {
+		r1 = rf(ctx, movements, bookID, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockusecaseBooksRepository_GetMovements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMovements'
+type MockusecaseBooksRepository_GetMovements_Call struct {
+	*mock.Call
+}
+
+// GetMovements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - movements *[]models.StockMovement
+//   - bookID int
+//   - page *models.PageRequest
+func (_e *MockusecaseBooksRepository_Expecter) GetMovements(ctx interface{}, movements interface{}, bookID interface{}, page interface{}) *MockusecaseBooksRepository_GetMovements_Call {
+	return &MockusecaseBooksRepository_GetMovements_Call{Call: _e.mock.On("GetMovements", ctx, movements, bookID, page)}
+}
+
+func (_c *MockusecaseBooksRepository_GetMovements_Call) Run(run func(ctx context.Context, movements *[]models.StockMovement, bookID int, page *models.PageRequest)) *MockusecaseBooksRepository_GetMovements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*[]models.StockMovement), args[2].(int), args[3].(*models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_GetMovements_Call) Return(_a0 *models.PageResult, _a1 error) *MockusecaseBooksRepository_GetMovements_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_GetMovements_Call) RunAndReturn(run func(context.Context, *[]models.StockMovement, int, *models.PageRequest) (*models.PageResult, error)) *MockusecaseBooksRepository_GetMovements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPage provides a mock function with given fields: ctx, books, filter, page
+func (_m *MockusecaseBooksRepository) GetPage(ctx context.Context, books *[]models.Books, filter *models.BooksFilter, page *models.PageRequest) (*models.PageResult, error) {
+	ret := _m.Called(ctx, books, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPage")
+	}
+
+	var r0 *models.PageResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Books, *models.BooksFilter, *models.PageRequest) (*models.PageResult, error)); ok {
+		return rf(ctx, books, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Books, *models.BooksFilter, *models.PageRequest) *models.PageResult); ok {
+		r0 = rf(ctx, books, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PageResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *[]models.Books, *models.BooksFilter, *models.PageRequest) error); ok {
+		r1 = rf(ctx, books, filter, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockusecaseBooksRepository_GetPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPage'
+type MockusecaseBooksRepository_GetPage_Call struct {
+	*mock.Call
+}
+
+// GetPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - books *[]models.Books
+//   - filter *models.BooksFilter
+//   - page *models.PageRequest
+func (_e *MockusecaseBooksRepository_Expecter) GetPage(ctx interface{}, books interface{}, filter interface{}, page interface{}) *MockusecaseBooksRepository_GetPage_Call {
+	return &MockusecaseBooksRepository_GetPage_Call{Call: _e.mock.On("GetPage", ctx, books, filter, page)}
+}
+
+func (_c *MockusecaseBooksRepository_GetPage_Call) Run(run func(ctx context.Context, books *[]models.Books, filter *models.BooksFilter, page *models.PageRequest)) *MockusecaseBooksRepository_GetPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*[]models.Books), args[2].(*models.BooksFilter), args[3].(*models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_GetPage_Call) Return(_a0 *models.PageResult, _a1 error) *MockusecaseBooksRepository_GetPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_GetPage_Call) RunAndReturn(run func(context.Context, *[]models.Books, *models.BooksFilter, *models.PageRequest) (*models.PageResult, error)) *MockusecaseBooksRepository_GetPage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ExistsByTitle provides a mock function with given fields: title
-func (_m *MockusecaseBooksRepository) ExistsByTitle(title string) (bool, error) {
-	ret := _m.Called(title)
+// GetTrash provides a mock function with given fields: ctx, books, page
+func (_m *MockusecaseBooksRepository) GetTrash(ctx context.Context, books *[]models.Books, page *models.PageRequest) (*models.PageResult, error) {
+	ret := _m.Called(ctx, books, page)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ExistsByTitle")
+		panic("no return value specified for GetTrash")
 	}
 
-	var r0 bool
+	var r0 *models.PageResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
-		return rf(title)
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Books, *models.PageRequest) (*models.PageResult, error)); ok {
+		return rf(ctx, books, page)
 	}
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(title)
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Books, *models.PageRequest) *models.PageResult); ok {
+		r0 = rf(ctx, books, page)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PageResult)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(title)
+	if rf, ok := ret.Get(1).(func(context.Context, *[]models.Books, *models.PageRequest) error); ok {
+		r1 = rf(ctx, books, page)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -141,45 +651,47 @@ func (_m *MockusecaseBooksRepository) ExistsByTitle(title string) (bool, error)
 	return r0, r1
 }
 
-// MockusecaseBooksRepository_ExistsByTitle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExistsByTitle'
-type MockusecaseBooksRepository_ExistsByTitle_Call struct {
+// MockusecaseBooksRepository_GetTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrash'
+type MockusecaseBooksRepository_GetTrash_Call struct {
 	*mock.Call
 }
 
-// ExistsByTitle is a helper method to define mock.On call
-//   - title string
-func (_e *MockusecaseBooksRepository_Expecter) ExistsByTitle(title interface{}) *MockusecaseBooksRepository_ExistsByTitle_Call {
-	return &MockusecaseBooksRepository_ExistsByTitle_Call{Call: _e.mock.On("ExistsByTitle", title)}
+// GetTrash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - books *[]models.Books
+//   - page *models.PageRequest
+func (_e *MockusecaseBooksRepository_Expecter) GetTrash(ctx interface{}, books interface{}, page interface{}) *MockusecaseBooksRepository_GetTrash_Call {
+	return &MockusecaseBooksRepository_GetTrash_Call{Call: _e.mock.On("GetTrash", ctx, books, page)}
 }
 
-func (_c *MockusecaseBooksRepository_ExistsByTitle_Call) Run(run func(title string)) *MockusecaseBooksRepository_ExistsByTitle_Call {
+func (_c *MockusecaseBooksRepository_GetTrash_Call) Run(run func(ctx context.Context, books *[]models.Books, page *models.PageRequest)) *MockusecaseBooksRepository_GetTrash_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(*[]models.Books), args[2].(*models.PageRequest))
 	})
 	return _c
 }
 
-func (_c *MockusecaseBooksRepository_ExistsByTitle_Call) Return(_a0 bool, _a1 error) *MockusecaseBooksRepository_ExistsByTitle_Call {
+func (_c *MockusecaseBooksRepository_GetTrash_Call) Return(_a0 *models.PageResult, _a1 error) *MockusecaseBooksRepository_GetTrash_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockusecaseBooksRepository_ExistsByTitle_Call) RunAndReturn(run func(string) (bool, error)) *MockusecaseBooksRepository_ExistsByTitle_Call {
+func (_c *MockusecaseBooksRepository_GetTrash_Call) RunAndReturn(run func(context.Context, *[]models.Books, *models.PageRequest) (*models.PageResult, error)) *MockusecaseBooksRepository_GetTrash_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAll provides a mock function with given fields: book
-func (_m *MockusecaseBooksRepository) GetAll(book *[]models.Books) error {
-	ret := _m.Called(book)
+// LoadAuthors provides a mock function with given fields: ctx, books
+func (_m *MockusecaseBooksRepository) LoadAuthors(ctx context.Context, books []models.Books) error {
+	ret := _m.Called(ctx, books)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAll")
+		panic("no return value specified for LoadAuthors")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*[]models.Books) error); ok {
-		r0 = rf(book)
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Books) error); ok {
+		r0 = rf(ctx, books)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -187,45 +699,46 @@ func (_m *MockusecaseBooksRepository) GetAll(book *[]models.Books) error {
 	return r0
 }
 
-// MockusecaseBooksRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
-type MockusecaseBooksRepository_GetAll_Call struct {
+// MockusecaseBooksRepository_LoadAuthors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadAuthors'
+type MockusecaseBooksRepository_LoadAuthors_Call struct {
 	*mock.Call
 }
 
-// GetAll is a helper method to define mock.On call
-//   - book *[]models.Books
-func (_e *MockusecaseBooksRepository_Expecter) GetAll(book interface{}) *MockusecaseBooksRepository_GetAll_Call {
-	return &MockusecaseBooksRepository_GetAll_Call{Call: _e.mock.On("GetAll", book)}
+// LoadAuthors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - books []models.Books
+func (_e *MockusecaseBooksRepository_Expecter) LoadAuthors(ctx interface{}, books interface{}) *MockusecaseBooksRepository_LoadAuthors_Call {
+	return &MockusecaseBooksRepository_LoadAuthors_Call{Call: _e.mock.On("LoadAuthors", ctx, books)}
 }
 
-func (_c *MockusecaseBooksRepository_GetAll_Call) Run(run func(book *[]models.Books)) *MockusecaseBooksRepository_GetAll_Call {
+func (_c *MockusecaseBooksRepository_LoadAuthors_Call) Run(run func(ctx context.Context, books []models.Books)) *MockusecaseBooksRepository_LoadAuthors_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*[]models.Books))
+		run(args[0].(context.Context), args[1].([]models.Books))
 	})
 	return _c
 }
 
-func (_c *MockusecaseBooksRepository_GetAll_Call) Return(_a0 error) *MockusecaseBooksRepository_GetAll_Call {
+func (_c *MockusecaseBooksRepository_LoadAuthors_Call) Return(_a0 error) *MockusecaseBooksRepository_LoadAuthors_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockusecaseBooksRepository_GetAll_Call) RunAndReturn(run func(*[]models.Books) error) *MockusecaseBooksRepository_GetAll_Call {
+func (_c *MockusecaseBooksRepository_LoadAuthors_Call) RunAndReturn(run func(context.Context, []models.Books) error) *MockusecaseBooksRepository_LoadAuthors_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function with given fields: book, id
-func (_m *MockusecaseBooksRepository) GetByID(book *models.Books, id int) error {
-	ret := _m.Called(book, id)
+// Patch provides a mock function with given fields: ctx, id, patch
+func (_m *MockusecaseBooksRepository) Patch(ctx context.Context, id int, patch *models.BooksPatch) error {
+	ret := _m.Called(ctx, id, patch)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for Patch")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Books, int) error); ok {
-		r0 = rf(book, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, *models.BooksPatch) error); ok {
+		r0 = rf(ctx, id, patch)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -233,46 +746,344 @@ func (_m *MockusecaseBooksRepository) GetByID(book *models.Books, id int) error
 	return r0
 }
 
-// MockusecaseBooksRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
-type MockusecaseBooksRepository_GetByID_Call struct {
+// MockusecaseBooksRepository_Patch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Patch'
+type MockusecaseBooksRepository_Patch_Call struct {
 	*mock.Call
 }
 
-// GetByID is a helper method to define mock.On call
-//   - book *models.Books
+// Patch is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id int
-func (_e *MockusecaseBooksRepository_Expecter) GetByID(book interface{}, id interface{}) *MockusecaseBooksRepository_GetByID_Call {
-	return &MockusecaseBooksRepository_GetByID_Call{Call: _e.mock.On("GetByID", book, id)}
+//   - patch *models.BooksPatch
+func (_e *MockusecaseBooksRepository_Expecter) Patch(ctx interface{}, id interface{}, patch interface{}) *MockusecaseBooksRepository_Patch_Call {
+	return &MockusecaseBooksRepository_Patch_Call{Call: _e.mock.On("Patch", ctx, id, patch)}
 }
 
-func (_c *MockusecaseBooksRepository_GetByID_Call) Run(run func(book *models.Books, id int)) *MockusecaseBooksRepository_GetByID_Call {
+func (_c *MockusecaseBooksRepository_Patch_Call) Run(run func(ctx context.Context, id int, patch *models.BooksPatch)) *MockusecaseBooksRepository_Patch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*models.Books), args[1].(int))
+		run(args[0].(context.Context), args[1].(int), args[2].(*models.BooksPatch))
 	})
 	return _c
 }
 
-func (_c *MockusecaseBooksRepository_GetByID_Call) Return(_a0 error) *MockusecaseBooksRepository_GetByID_Call {
+func (_c *MockusecaseBooksRepository_Patch_Call) Return(_a0 error) *MockusecaseBooksRepository_Patch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_Patch_Call) RunAndReturn(run func(context.Context, int, *models.BooksPatch) error) *MockusecaseBooksRepository_Patch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Purge provides a mock function with given fields: ctx, id
+func (_m *MockusecaseBooksRepository) Purge(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseBooksRepository_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type MockusecaseBooksRepository_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockusecaseBooksRepository_Expecter) Purge(ctx interface{}, id interface{}) *MockusecaseBooksRepository_Purge_Call {
+	return &MockusecaseBooksRepository_Purge_Call{Call: _e.mock.On("Purge", ctx, id)}
+}
+
+func (_c *MockusecaseBooksRepository_Purge_Call) Run(run func(ctx context.Context, id int)) *MockusecaseBooksRepository_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_Purge_Call) Return(_a0 error) *MockusecaseBooksRepository_Purge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_Purge_Call) RunAndReturn(run func(context.Context, int) error) *MockusecaseBooksRepository_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceAuthors provides a mock function with given fields: ctx, bookID, authorIDs
+func (_m *MockusecaseBooksRepository) ReplaceAuthors(ctx context.Context, bookID int, authorIDs []int) error {
+	ret := _m.Called(ctx, bookID, authorIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceAuthors")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) error); ok {
+		r0 = rf(ctx, bookID, authorIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseBooksRepository_ReplaceAuthors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceAuthors'
+type MockusecaseBooksRepository_ReplaceAuthors_Call struct {
+	*mock.Call
+}
+
+// ReplaceAuthors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookID int
+//   - authorIDs []int
+func (_e *MockusecaseBooksRepository_Expecter) ReplaceAuthors(ctx interface{}, bookID interface{}, authorIDs interface{}) *MockusecaseBooksRepository_ReplaceAuthors_Call {
+	return &MockusecaseBooksRepository_ReplaceAuthors_Call{Call: _e.mock.On("ReplaceAuthors", ctx, bookID, authorIDs)}
+}
+
+func (_c *MockusecaseBooksRepository_ReplaceAuthors_Call) Run(run func(ctx context.Context, bookID int, authorIDs []int)) *MockusecaseBooksRepository_ReplaceAuthors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].([]int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_ReplaceAuthors_Call) Return(_a0 error) *MockusecaseBooksRepository_ReplaceAuthors_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_ReplaceAuthors_Call) RunAndReturn(run func(context.Context, int, []int) error) *MockusecaseBooksRepository_ReplaceAuthors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *MockusecaseBooksRepository) Restore(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseBooksRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockusecaseBooksRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockusecaseBooksRepository_Expecter) Restore(ctx interface{}, id interface{}) *MockusecaseBooksRepository_Restore_Call {
+	return &MockusecaseBooksRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *MockusecaseBooksRepository_Restore_Call) Run(run func(ctx context.Context, id int)) *MockusecaseBooksRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_Restore_Call) Return(_a0 error) *MockusecaseBooksRepository_Restore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_Restore_Call) RunAndReturn(run func(context.Context, int) error) *MockusecaseBooksRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: ctx, results, query, limit
+func (_m *MockusecaseBooksRepository) Search(ctx context.Context, results *[]models.BooksSearchResult, query string, limit int) error {
+	ret := _m.Called(ctx, results, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.BooksSearchResult, string, int) error); ok {
+		r0 = rf(ctx, results, query, limit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseBooksRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockusecaseBooksRepository_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - results *[]models.BooksSearchResult
+//   - query string
+//   - limit int
+func (_e *MockusecaseBooksRepository_Expecter) Search(ctx interface{}, results interface{}, query interface{}, limit interface{}) *MockusecaseBooksRepository_Search_Call {
+	return &MockusecaseBooksRepository_Search_Call{Call: _e.mock.On("Search", ctx, results, query, limit)}
+}
+
+func (_c *MockusecaseBooksRepository_Search_Call) Run(run func(ctx context.Context, results *[]models.BooksSearchResult, query string, limit int)) *MockusecaseBooksRepository_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*[]models.BooksSearchResult), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_Search_Call) Return(_a0 error) *MockusecaseBooksRepository_Search_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockusecaseBooksRepository_GetByID_Call) RunAndReturn(run func(*models.Books, int) error) *MockusecaseBooksRepository_GetByID_Call {
+func (_c *MockusecaseBooksRepository_Search_Call) RunAndReturn(run func(context.Context, *[]models.BooksSearchResult, string, int) error) *MockusecaseBooksRepository_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stats provides a mock function with given fields: ctx, stats
+func (_m *MockusecaseBooksRepository) Stats(ctx context.Context, stats *models.BooksStats) error {
+	ret := _m.Called(ctx, stats)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.BooksStats) error); ok {
+		r0 = rf(ctx, stats)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseBooksRepository_Stats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stats'
+type MockusecaseBooksRepository_Stats_Call struct {
+	*mock.Call
+}
+
+// Stats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stats *models.BooksStats
+func (_e *MockusecaseBooksRepository_Expecter) Stats(ctx interface{}, stats interface{}) *MockusecaseBooksRepository_Stats_Call {
+	return &MockusecaseBooksRepository_Stats_Call{Call: _e.mock.On("Stats", ctx, stats)}
+}
+
+func (_c *MockusecaseBooksRepository_Stats_Call) Run(run func(ctx context.Context, stats *models.BooksStats)) *MockusecaseBooksRepository_Stats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.BooksStats))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_Stats_Call) Return(_a0 error) *MockusecaseBooksRepository_Stats_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_Stats_Call) RunAndReturn(run func(context.Context, *models.BooksStats) error) *MockusecaseBooksRepository_Stats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TakenTitles provides a mock function with given fields: ctx, titles
+func (_m *MockusecaseBooksRepository) TakenTitles(ctx context.Context, titles []string) ([]string, error) {
+	ret := _m.Called(ctx, titles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakenTitles")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]string, error)); ok {
+		return rf(ctx, titles)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = rf(ctx, titles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, titles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockusecaseBooksRepository_TakenTitles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TakenTitles'
+type MockusecaseBooksRepository_TakenTitles_Call struct {
+	*mock.Call
+}
+
+// TakenTitles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - titles []string
+func (_e *MockusecaseBooksRepository_Expecter) TakenTitles(ctx interface{}, titles interface{}) *MockusecaseBooksRepository_TakenTitles_Call {
+	return &MockusecaseBooksRepository_TakenTitles_Call{Call: _e.mock.On("TakenTitles", ctx, titles)}
+}
+
+func (_c *MockusecaseBooksRepository_TakenTitles_Call) Run(run func(ctx context.Context, titles []string)) *MockusecaseBooksRepository_TakenTitles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_TakenTitles_Call) Return(_a0 []string, _a1 error) *MockusecaseBooksRepository_TakenTitles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockusecaseBooksRepository_TakenTitles_Call) RunAndReturn(run func(context.Context, []string) ([]string, error)) *MockusecaseBooksRepository_TakenTitles_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function with given fields: book
-func (_m *MockusecaseBooksRepository) Update(book *models.Books) error {
-	ret := _m.Called(book)
+// Update provides a mock function with given fields: ctx, book
+func (_m *MockusecaseBooksRepository) Update(ctx context.Context, book *models.Books) error {
+	ret := _m.Called(ctx, book)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Books) error); ok {
-		r0 = rf(book)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Books) error); ok {
+		r0 = rf(ctx, book)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -286,14 +1097,15 @@ type MockusecaseBooksRepository_Update_Call struct {
 }
 
 // Update is a helper method to define mock.On call
+//   - ctx context.Context
 //   - book *models.Books
-func (_e *MockusecaseBooksRepository_Expecter) Update(book interface{}) *MockusecaseBooksRepository_Update_Call {
-	return &MockusecaseBooksRepository_Update_Call{Call: _e.mock.On("Update", book)}
+func (_e *MockusecaseBooksRepository_Expecter) Update(ctx interface{}, book interface{}) *MockusecaseBooksRepository_Update_Call {
+	return &MockusecaseBooksRepository_Update_Call{Call: _e.mock.On("Update", ctx, book)}
 }
 
-func (_c *MockusecaseBooksRepository_Update_Call) Run(run func(book *models.Books)) *MockusecaseBooksRepository_Update_Call {
+func (_c *MockusecaseBooksRepository_Update_Call) Run(run func(ctx context.Context, book *models.Books)) *MockusecaseBooksRepository_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*models.Books))
+		run(args[0].(context.Context), args[1].(*models.Books))
 	})
 	return _c
 }
@@ -303,7 +1115,7 @@ func (_c *MockusecaseBooksRepository_Update_Call) Return(_a0 error) *Mockusecase
 	return _c
 }
 
-func (_c *MockusecaseBooksRepository_Update_Call) RunAndReturn(run func(*models.Books) error) *MockusecaseBooksRepository_Update_Call {
+func (_c *MockusecaseBooksRepository_Update_Call) RunAndReturn(run func(context.Context, *models.Books) error) *MockusecaseBooksRepository_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,465 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "crud-echo/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockusecaseCategoriesRepository is an autogenerated mock type for the usecaseCategoriesRepository type
+type MockusecaseCategoriesRepository struct {
+	mock.Mock
+}
+
+type MockusecaseCategoriesRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockusecaseCategoriesRepository) EXPECT() *MockusecaseCategoriesRepository_Expecter {
+	return &MockusecaseCategoriesRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, category
+func (_m *MockusecaseCategoriesRepository) Create(ctx context.Context, category *models.Categories) error {
+	ret := _m.Called(ctx, category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Categories) error); ok {
+		r0 = rf(ctx, category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseCategoriesRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockusecaseCategoriesRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - category *models.Categories
+func (_e *MockusecaseCategoriesRepository_Expecter) Create(ctx interface{}, category interface{}) *MockusecaseCategoriesRepository_Create_Call {
+	return &MockusecaseCategoriesRepository_Create_Call{Call: _e.mock.On("Create", ctx, category)}
+}
+
+func (_c *MockusecaseCategoriesRepository_Create_Call) Run(run func(ctx context.Context, category *models.Categories)) *MockusecaseCategoriesRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Categories))
+	})
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_Create_Call) Return(_a0 error) *MockusecaseCategoriesRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_Create_Call) RunAndReturn(run func(context.Context, *models.Categories) error) *MockusecaseCategoriesRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *MockusecaseCategoriesRepository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseCategoriesRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockusecaseCategoriesRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockusecaseCategoriesRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockusecaseCategoriesRepository_Delete_Call {
+	return &MockusecaseCategoriesRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockusecaseCategoriesRepository_Delete_Call) Run(run func(ctx context.Context, id int)) *MockusecaseCategoriesRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_Delete_Call) Return(_a0 error) *MockusecaseCategoriesRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_Delete_Call) RunAndReturn(run func(context.Context, int) error) *MockusecaseCategoriesRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: ctx, categories
+func (_m *MockusecaseCategoriesRepository) GetAll(ctx context.Context, categories *[]models.Categories) error {
+	ret := _m.Called(ctx, categories)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Categories) error); ok {
+		r0 = rf(ctx, categories)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseCategoriesRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockusecaseCategoriesRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - categories *[]models.Categories
+func (_e *MockusecaseCategoriesRepository_Expecter) GetAll(ctx interface{}, categories interface{}) *MockusecaseCategoriesRepository_GetAll_Call {
+	return &MockusecaseCategoriesRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx, categories)}
+}
+
+func (_c *MockusecaseCategoriesRepository_GetAll_Call) Run(run func(ctx context.Context, categories *[]models.Categories)) *MockusecaseCategoriesRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*[]models.Categories))
+	})
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_GetAll_Call) Return(_a0 error) *MockusecaseCategoriesRepository_GetAll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_GetAll_Call) RunAndReturn(run func(context.Context, *[]models.Categories) error) *MockusecaseCategoriesRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBookCategories provides a mock function with given fields: ctx, categories, bookID
+func (_m *MockusecaseCategoriesRepository) GetBookCategories(ctx context.Context, categories *[]models.Categories, bookID int) error {
+	ret := _m.Called(ctx, categories, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookCategories")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Categories, int) error); ok {
+		r0 = rf(ctx, categories, bookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseCategoriesRepository_GetBookCategories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookCategories'
+type MockusecaseCategoriesRepository_GetBookCategories_Call struct {
+	*mock.Call
+}
+
+// GetBookCategories is a helper method to define mock.On call
+//   - ctx context.Context
+//   - categories *[]models.Categories
+//   - bookID int
+func (_e *MockusecaseCategoriesRepository_Expecter) GetBookCategories(ctx interface{}, categories interface{}, bookID interface{}) *MockusecaseCategoriesRepository_GetBookCategories_Call {
+	return &MockusecaseCategoriesRepository_GetBookCategories_Call{Call: _e.mock.On("GetBookCategories", ctx, categories, bookID)}
+}
+
+func (_c *MockusecaseCategoriesRepository_GetBookCategories_Call) Run(run func(ctx context.Context, categories *[]models.Categories, bookID int)) *MockusecaseCategoriesRepository_GetBookCategories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*[]models.Categories), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_GetBookCategories_Call) Return(_a0 error) *MockusecaseCategoriesRepository_GetBookCategories_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_GetBookCategories_Call) RunAndReturn(run func(context.Context, *[]models.Categories, int) error) *MockusecaseCategoriesRepository_GetBookCategories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, category, id
+func (_m *MockusecaseCategoriesRepository) GetByID(ctx context.Context, category *models.Categories, id int) error {
+	ret := _m.Called(ctx, category, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Categories, int) error); ok {
+		r0 = rf(ctx, category, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseCategoriesRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockusecaseCategoriesRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - category *models.Categories
+//   - id int
+func (_e *MockusecaseCategoriesRepository_Expecter) GetByID(ctx interface{}, category interface{}, id interface{}) *MockusecaseCategoriesRepository_GetByID_Call {
+	return &MockusecaseCategoriesRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, category, id)}
+}
+
+func (_c *MockusecaseCategoriesRepository_GetByID_Call) Run(run func(ctx context.Context, category *models.Categories, id int)) *MockusecaseCategoriesRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Categories), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_GetByID_Call) Return(_a0 error) *MockusecaseCategoriesRepository_GetByID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_GetByID_Call) RunAndReturn(run func(context.Context, *models.Categories, int) error) *MockusecaseCategoriesRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDForUpdate provides a mock function with given fields: ctx, category, id
+func (_m *MockusecaseCategoriesRepository) GetByIDForUpdate(ctx context.Context, category *models.Categories, id int) error {
+	ret := _m.Called(ctx, category, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDForUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Categories, int) error); ok {
+		r0 = rf(ctx, category, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseCategoriesRepository_GetByIDForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDForUpdate'
+type MockusecaseCategoriesRepository_GetByIDForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetByIDForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - category *models.Categories
+//   - id int
+func (_e *MockusecaseCategoriesRepository_Expecter) GetByIDForUpdate(ctx interface{}, category interface{}, id interface{}) *MockusecaseCategoriesRepository_GetByIDForUpdate_Call {
+	return &MockusecaseCategoriesRepository_GetByIDForUpdate_Call{Call: _e.mock.On("GetByIDForUpdate", ctx, category, id)}
+}
+
+func (_c *MockusecaseCategoriesRepository_GetByIDForUpdate_Call) Run(run func(ctx context.Context, category *models.Categories, id int)) *MockusecaseCategoriesRepository_GetByIDForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Categories), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_GetByIDForUpdate_Call) Return(_a0 error) *MockusecaseCategoriesRepository_GetByIDForUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_GetByIDForUpdate_Call) RunAndReturn(run func(context.Context, *models.Categories, int) error) *MockusecaseCategoriesRepository_GetByIDForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveSubtree provides a mock function with given fields: ctx, oldPath, newPath
+func (_m *MockusecaseCategoriesRepository) MoveSubtree(ctx context.Context, oldPath string, newPath string) error {
+	ret := _m.Called(ctx, oldPath, newPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveSubtree")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, oldPath, newPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseCategoriesRepository_MoveSubtree_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveSubtree'
+type MockusecaseCategoriesRepository_MoveSubtree_Call struct {
+	*mock.Call
+}
+
+// MoveSubtree is a helper method to define mock.On call
+//   - ctx context.Context
+//   - oldPath string
+//   - newPath string
+func (_e *MockusecaseCategoriesRepository_Expecter) MoveSubtree(ctx interface{}, oldPath interface{}, newPath interface{}) *MockusecaseCategoriesRepository_MoveSubtree_Call {
+	return &MockusecaseCategoriesRepository_MoveSubtree_Call{Call: _e.mock.On("MoveSubtree", ctx, oldPath, newPath)}
+}
+
+func (_c *MockusecaseCategoriesRepository_MoveSubtree_Call) Run(run func(ctx context.Context, oldPath string, newPath string)) *MockusecaseCategoriesRepository_MoveSubtree_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_MoveSubtree_Call) Return(_a0 error) *MockusecaseCategoriesRepository_MoveSubtree_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_MoveSubtree_Call) RunAndReturn(run func(context.Context, string, string) error) *MockusecaseCategoriesRepository_MoveSubtree_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceBookCategories provides a mock function with given fields: ctx, bookID, categoryIDs
+func (_m *MockusecaseCategoriesRepository) ReplaceBookCategories(ctx context.Context, bookID int, categoryIDs []int) error {
+	ret := _m.Called(ctx, bookID, categoryIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceBookCategories")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) error); ok {
+		r0 = rf(ctx, bookID, categoryIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseCategoriesRepository_ReplaceBookCategories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceBookCategories'
+type MockusecaseCategoriesRepository_ReplaceBookCategories_Call struct {
+	*mock.Call
+}
+
+// ReplaceBookCategories is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookID int
+//   - categoryIDs []int
+func (_e *MockusecaseCategoriesRepository_Expecter) ReplaceBookCategories(ctx interface{}, bookID interface{}, categoryIDs interface{}) *MockusecaseCategoriesRepository_ReplaceBookCategories_Call {
+	return &MockusecaseCategoriesRepository_ReplaceBookCategories_Call{Call: _e.mock.On("ReplaceBookCategories", ctx, bookID, categoryIDs)}
+}
+
+func (_c *MockusecaseCategoriesRepository_ReplaceBookCategories_Call) Run(run func(ctx context.Context, bookID int, categoryIDs []int)) *MockusecaseCategoriesRepository_ReplaceBookCategories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].([]int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_ReplaceBookCategories_Call) Return(_a0 error) *MockusecaseCategoriesRepository_ReplaceBookCategories_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_ReplaceBookCategories_Call) RunAndReturn(run func(context.Context, int, []int) error) *MockusecaseCategoriesRepository_ReplaceBookCategories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, category
+func (_m *MockusecaseCategoriesRepository) Update(ctx context.Context, category *models.Categories) error {
+	ret := _m.Called(ctx, category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Categories) error); ok {
+		r0 = rf(ctx, category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseCategoriesRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockusecaseCategoriesRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - category *models.Categories
+func (_e *MockusecaseCategoriesRepository_Expecter) Update(ctx interface{}, category interface{}) *MockusecaseCategoriesRepository_Update_Call {
+	return &MockusecaseCategoriesRepository_Update_Call{Call: _e.mock.On("Update", ctx, category)}
+}
+
+func (_c *MockusecaseCategoriesRepository_Update_Call) Run(run func(ctx context.Context, category *models.Categories)) *MockusecaseCategoriesRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Categories))
+	})
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_Update_Call) Return(_a0 error) *MockusecaseCategoriesRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseCategoriesRepository_Update_Call) RunAndReturn(run func(context.Context, *models.Categories) error) *MockusecaseCategoriesRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockusecaseCategoriesRepository creates a new instance of MockusecaseCategoriesRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockusecaseCategoriesRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockusecaseCategoriesRepository {
+	mock := &MockusecaseCategoriesRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
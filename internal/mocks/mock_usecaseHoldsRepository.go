@@ -0,0 +1,432 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "crud-echo/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockusecaseHoldsRepository is an autogenerated mock type for the usecaseHoldsRepository type
+type MockusecaseHoldsRepository struct {
+	mock.Mock
+}
+
+type MockusecaseHoldsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockusecaseHoldsRepository) EXPECT() *MockusecaseHoldsRepository_Expecter {
+	return &MockusecaseHoldsRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, hold
+func (_m *MockusecaseHoldsRepository) Create(ctx context.Context, hold *models.Holds) error {
+	ret := _m.Called(ctx, hold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Holds) error); ok {
+		r0 = rf(ctx, hold)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseHoldsRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockusecaseHoldsRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hold *models.Holds
+func (_e *MockusecaseHoldsRepository_Expecter) Create(ctx interface{}, hold interface{}) *MockusecaseHoldsRepository_Create_Call {
+	return &MockusecaseHoldsRepository_Create_Call{Call: _e.mock.On("Create", ctx, hold)}
+}
+
+func (_c *MockusecaseHoldsRepository_Create_Call) Run(run func(ctx context.Context, hold *models.Holds)) *MockusecaseHoldsRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Holds))
+	})
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_Create_Call) Return(_a0 error) *MockusecaseHoldsRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_Create_Call) RunAndReturn(run func(context.Context, *models.Holds) error) *MockusecaseHoldsRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, hold, id
+func (_m *MockusecaseHoldsRepository) GetByID(ctx context.Context, hold *models.Holds, id int) error {
+	ret := _m.Called(ctx, hold, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Holds, int) error); ok {
+		r0 = rf(ctx, hold, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseHoldsRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockusecaseHoldsRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hold *models.Holds
+//   - id int
+func (_e *MockusecaseHoldsRepository_Expecter) GetByID(ctx interface{}, hold interface{}, id interface{}) *MockusecaseHoldsRepository_GetByID_Call {
+	return &MockusecaseHoldsRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, hold, id)}
+}
+
+func (_c *MockusecaseHoldsRepository_GetByID_Call) Run(run func(ctx context.Context, hold *models.Holds, id int)) *MockusecaseHoldsRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Holds), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_GetByID_Call) Return(_a0 error) *MockusecaseHoldsRepository_GetByID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_GetByID_Call) RunAndReturn(run func(context.Context, *models.Holds, int) error) *MockusecaseHoldsRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExpired provides a mock function with given fields: ctx, holds, bookID, at
+func (_m *MockusecaseHoldsRepository) GetExpired(ctx context.Context, holds *[]models.Holds, bookID int, at time.Time) error {
+	ret := _m.Called(ctx, holds, bookID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpired")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Holds, int, time.Time) error); ok {
+		r0 = rf(ctx, holds, bookID, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseHoldsRepository_GetExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpired'
+type MockusecaseHoldsRepository_GetExpired_Call struct {
+	*mock.Call
+}
+
+// GetExpired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - holds *[]models.Holds
+//   - bookID int
+//   - at time.Time
+func (_e *MockusecaseHoldsRepository_Expecter) GetExpired(ctx interface{}, holds interface{}, bookID interface{}, at interface{}) *MockusecaseHoldsRepository_GetExpired_Call {
+	return &MockusecaseHoldsRepository_GetExpired_Call{Call: _e.mock.On("GetExpired", ctx, holds, bookID, at)}
+}
+
+func (_c *MockusecaseHoldsRepository_GetExpired_Call) Run(run func(ctx context.Context, holds *[]models.Holds, bookID int, at time.Time)) *MockusecaseHoldsRepository_GetExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*[]models.Holds), args[2].(int), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_GetExpired_Call) Return(_a0 error) *MockusecaseHoldsRepository_GetExpired_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_GetExpired_Call) RunAndReturn(run func(context.Context, *[]models.Holds, int, time.Time) error) *MockusecaseHoldsRepository_GetExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetForUpdate provides a mock function with given fields: ctx, hold, id
+func (_m *MockusecaseHoldsRepository) GetForUpdate(ctx context.Context, hold *models.Holds, id int) error {
+	ret := _m.Called(ctx, hold, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Holds, int) error); ok {
+		r0 = rf(ctx, hold, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseHoldsRepository_GetForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetForUpdate'
+type MockusecaseHoldsRepository_GetForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hold *models.Holds
+//   - id int
+func (_e *MockusecaseHoldsRepository_Expecter) GetForUpdate(ctx interface{}, hold interface{}, id interface{}) *MockusecaseHoldsRepository_GetForUpdate_Call {
+	return &MockusecaseHoldsRepository_GetForUpdate_Call{Call: _e.mock.On("GetForUpdate", ctx, hold, id)}
+}
+
+func (_c *MockusecaseHoldsRepository_GetForUpdate_Call) Run(run func(ctx context.Context, hold *models.Holds, id int)) *MockusecaseHoldsRepository_GetForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Holds), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_GetForUpdate_Call) Return(_a0 error) *MockusecaseHoldsRepository_GetForUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_GetForUpdate_Call) RunAndReturn(run func(context.Context, *models.Holds, int) error) *MockusecaseHoldsRepository_GetForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenForMember provides a mock function with given fields: ctx, hold, bookID, memberID
+func (_m *MockusecaseHoldsRepository) GetOpenForMember(ctx context.Context, hold *models.Holds, bookID int, memberID int) error {
+	ret := _m.Called(ctx, hold, bookID, memberID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenForMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Holds, int, int) error); ok {
+		r0 = rf(ctx, hold, bookID, memberID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseHoldsRepository_GetOpenForMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenForMember'
+type MockusecaseHoldsRepository_GetOpenForMember_Call struct {
+	*mock.Call
+}
+
+// GetOpenForMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hold *models.Holds
+//   - bookID int
+//   - memberID int
+func (_e *MockusecaseHoldsRepository_Expecter) GetOpenForMember(ctx interface{}, hold interface{}, bookID interface{}, memberID interface{}) *MockusecaseHoldsRepository_GetOpenForMember_Call {
+	return &MockusecaseHoldsRepository_GetOpenForMember_Call{Call: _e.mock.On("GetOpenForMember", ctx, hold, bookID, memberID)}
+}
+
+func (_c *MockusecaseHoldsRepository_GetOpenForMember_Call) Run(run func(ctx context.Context, hold *models.Holds, bookID int, memberID int)) *MockusecaseHoldsRepository_GetOpenForMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Holds), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_GetOpenForMember_Call) Return(_a0 error) *MockusecaseHoldsRepository_GetOpenForMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_GetOpenForMember_Call) RunAndReturn(run func(context.Context, *models.Holds, int, int) error) *MockusecaseHoldsRepository_GetOpenForMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NextWaiting provides a mock function with given fields: ctx, hold, bookID
+func (_m *MockusecaseHoldsRepository) NextWaiting(ctx context.Context, hold *models.Holds, bookID int) error {
+	ret := _m.Called(ctx, hold, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NextWaiting")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Holds, int) error); ok {
+		r0 = rf(ctx, hold, bookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseHoldsRepository_NextWaiting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NextWaiting'
+type MockusecaseHoldsRepository_NextWaiting_Call struct {
+	*mock.Call
+}
+
+// NextWaiting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hold *models.Holds
+//   - bookID int
+func (_e *MockusecaseHoldsRepository_Expecter) NextWaiting(ctx interface{}, hold interface{}, bookID interface{}) *MockusecaseHoldsRepository_NextWaiting_Call {
+	return &MockusecaseHoldsRepository_NextWaiting_Call{Call: _e.mock.On("NextWaiting", ctx, hold, bookID)}
+}
+
+func (_c *MockusecaseHoldsRepository_NextWaiting_Call) Run(run func(ctx context.Context, hold *models.Holds, bookID int)) *MockusecaseHoldsRepository_NextWaiting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Holds), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_NextWaiting_Call) Return(_a0 error) *MockusecaseHoldsRepository_NextWaiting_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_NextWaiting_Call) RunAndReturn(run func(context.Context, *models.Holds, int) error) *MockusecaseHoldsRepository_NextWaiting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Position provides a mock function with given fields: ctx, hold
+func (_m *MockusecaseHoldsRepository) Position(ctx context.Context, hold *models.Holds) (int, error) {
+	ret := _m.Called(ctx, hold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Position")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Holds) (int, error)); ok {
+		return rf(ctx, hold)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Holds) int); ok {
+		r0 = rf(ctx, hold)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Holds) error); ok {
+		r1 = rf(ctx, hold)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockusecaseHoldsRepository_Position_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Position'
+type MockusecaseHoldsRepository_Position_Call struct {
+	*mock.Call
+}
+
+// Position is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hold *models.Holds
+func (_e *MockusecaseHoldsRepository_Expecter) Position(ctx interface{}, hold interface{}) *MockusecaseHoldsRepository_Position_Call {
+	return &MockusecaseHoldsRepository_Position_Call{Call: _e.mock.On("Position", ctx, hold)}
+}
+
+func (_c *MockusecaseHoldsRepository_Position_Call) Run(run func(ctx context.Context, hold *models.Holds)) *MockusecaseHoldsRepository_Position_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Holds))
+	})
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_Position_Call) Return(_a0 int, _a1 error) *MockusecaseHoldsRepository_Position_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_Position_Call) RunAndReturn(run func(context.Context, *models.Holds) (int, error)) *MockusecaseHoldsRepository_Position_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, hold
+func (_m *MockusecaseHoldsRepository) Update(ctx context.Context, hold *models.Holds) error {
+	ret := _m.Called(ctx, hold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Holds) error); ok {
+		r0 = rf(ctx, hold)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseHoldsRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockusecaseHoldsRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hold *models.Holds
+func (_e *MockusecaseHoldsRepository_Expecter) Update(ctx interface{}, hold interface{}) *MockusecaseHoldsRepository_Update_Call {
+	return &MockusecaseHoldsRepository_Update_Call{Call: _e.mock.On("Update", ctx, hold)}
+}
+
+func (_c *MockusecaseHoldsRepository_Update_Call) Run(run func(ctx context.Context, hold *models.Holds)) *MockusecaseHoldsRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Holds))
+	})
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_Update_Call) Return(_a0 error) *MockusecaseHoldsRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseHoldsRepository_Update_Call) RunAndReturn(run func(context.Context, *models.Holds) error) *MockusecaseHoldsRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockusecaseHoldsRepository creates a new instance of MockusecaseHoldsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockusecaseHoldsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockusecaseHoldsRepository {
+	mock := &MockusecaseHoldsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,227 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "crud-echo/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockusecaseLoansRepository is an autogenerated mock type for the usecaseLoansRepository type
+type MockusecaseLoansRepository struct {
+	mock.Mock
+}
+
+type MockusecaseLoansRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockusecaseLoansRepository) EXPECT() *MockusecaseLoansRepository_Expecter {
+	return &MockusecaseLoansRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, loan
+func (_m *MockusecaseLoansRepository) Create(ctx context.Context, loan *models.Loans) error {
+	ret := _m.Called(ctx, loan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Loans) error); ok {
+		r0 = rf(ctx, loan)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseLoansRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockusecaseLoansRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loan *models.Loans
+func (_e *MockusecaseLoansRepository_Expecter) Create(ctx interface{}, loan interface{}) *MockusecaseLoansRepository_Create_Call {
+	return &MockusecaseLoansRepository_Create_Call{Call: _e.mock.On("Create", ctx, loan)}
+}
+
+func (_c *MockusecaseLoansRepository_Create_Call) Run(run func(ctx context.Context, loan *models.Loans)) *MockusecaseLoansRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Loans))
+	})
+	return _c
+}
+
+func (_c *MockusecaseLoansRepository_Create_Call) Return(_a0 error) *MockusecaseLoansRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseLoansRepository_Create_Call) RunAndReturn(run func(context.Context, *models.Loans) error) *MockusecaseLoansRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, loan, id
+func (_m *MockusecaseLoansRepository) GetByID(ctx context.Context, loan *models.Loans, id int) error {
+	ret := _m.Called(ctx, loan, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Loans, int) error); ok {
+		r0 = rf(ctx, loan, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseLoansRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockusecaseLoansRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loan *models.Loans
+//   - id int
+func (_e *MockusecaseLoansRepository_Expecter) GetByID(ctx interface{}, loan interface{}, id interface{}) *MockusecaseLoansRepository_GetByID_Call {
+	return &MockusecaseLoansRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, loan, id)}
+}
+
+func (_c *MockusecaseLoansRepository_GetByID_Call) Run(run func(ctx context.Context, loan *models.Loans, id int)) *MockusecaseLoansRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Loans), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseLoansRepository_GetByID_Call) Return(_a0 error) *MockusecaseLoansRepository_GetByID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseLoansRepository_GetByID_Call) RunAndReturn(run func(context.Context, *models.Loans, int) error) *MockusecaseLoansRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetForUpdate provides a mock function with given fields: ctx, loan, id
+func (_m *MockusecaseLoansRepository) GetForUpdate(ctx context.Context, loan *models.Loans, id int) error {
+	ret := _m.Called(ctx, loan, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Loans, int) error); ok {
+		r0 = rf(ctx, loan, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseLoansRepository_GetForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetForUpdate'
+type MockusecaseLoansRepository_GetForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loan *models.Loans
+//   - id int
+func (_e *MockusecaseLoansRepository_Expecter) GetForUpdate(ctx interface{}, loan interface{}, id interface{}) *MockusecaseLoansRepository_GetForUpdate_Call {
+	return &MockusecaseLoansRepository_GetForUpdate_Call{Call: _e.mock.On("GetForUpdate", ctx, loan, id)}
+}
+
+func (_c *MockusecaseLoansRepository_GetForUpdate_Call) Run(run func(ctx context.Context, loan *models.Loans, id int)) *MockusecaseLoansRepository_GetForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Loans), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseLoansRepository_GetForUpdate_Call) Return(_a0 error) *MockusecaseLoansRepository_GetForUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseLoansRepository_GetForUpdate_Call) RunAndReturn(run func(context.Context, *models.Loans, int) error) *MockusecaseLoansRepository_GetForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, loan
+func (_m *MockusecaseLoansRepository) Update(ctx context.Context, loan *models.Loans) error {
+	ret := _m.Called(ctx, loan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Loans) error); ok {
+		r0 = rf(ctx, loan)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseLoansRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockusecaseLoansRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loan *models.Loans
+func (_e *MockusecaseLoansRepository_Expecter) Update(ctx interface{}, loan interface{}) *MockusecaseLoansRepository_Update_Call {
+	return &MockusecaseLoansRepository_Update_Call{Call: _e.mock.On("Update", ctx, loan)}
+}
+
+func (_c *MockusecaseLoansRepository_Update_Call) Run(run func(ctx context.Context, loan *models.Loans)) *MockusecaseLoansRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Loans))
+	})
+	return _c
+}
+
+func (_c *MockusecaseLoansRepository_Update_Call) Return(_a0 error) *MockusecaseLoansRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseLoansRepository_Update_Call) RunAndReturn(run func(context.Context, *models.Loans) error) *MockusecaseLoansRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockusecaseLoansRepository creates a new instance of MockusecaseLoansRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockusecaseLoansRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockusecaseLoansRepository {
+	mock := &MockusecaseLoansRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,302 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "crud-echo/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockusecaseMembersRepository is an autogenerated mock type for the usecaseMembersRepository type
+type MockusecaseMembersRepository struct {
+	mock.Mock
+}
+
+type MockusecaseMembersRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockusecaseMembersRepository) EXPECT() *MockusecaseMembersRepository_Expecter {
+	return &MockusecaseMembersRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, member
+func (_m *MockusecaseMembersRepository) Create(ctx context.Context, member *models.Members) error {
+	ret := _m.Called(ctx, member)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Members) error); ok {
+		r0 = rf(ctx, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseMembersRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockusecaseMembersRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - member *models.Members
+func (_e *MockusecaseMembersRepository_Expecter) Create(ctx interface{}, member interface{}) *MockusecaseMembersRepository_Create_Call {
+	return &MockusecaseMembersRepository_Create_Call{Call: _e.mock.On("Create", ctx, member)}
+}
+
+func (_c *MockusecaseMembersRepository_Create_Call) Run(run func(ctx context.Context, member *models.Members)) *MockusecaseMembersRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Members))
+	})
+	return _c
+}
+
+func (_c *MockusecaseMembersRepository_Create_Call) Return(_a0 error) *MockusecaseMembersRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseMembersRepository_Create_Call) RunAndReturn(run func(context.Context, *models.Members) error) *MockusecaseMembersRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, member, id
+func (_m *MockusecaseMembersRepository) GetByID(ctx context.Context, member *models.Members, id int) error {
+	ret := _m.Called(ctx, member, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Members, int) error); ok {
+		r0 = rf(ctx, member, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseMembersRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockusecaseMembersRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - member *models.Members
+//   - id int
+func (_e *MockusecaseMembersRepository_Expecter) GetByID(ctx interface{}, member interface{}, id interface{}) *MockusecaseMembersRepository_GetByID_Call {
+	return &MockusecaseMembersRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, member, id)}
+}
+
+func (_c *MockusecaseMembersRepository_GetByID_Call) Run(run func(ctx context.Context, member *models.Members, id int)) *MockusecaseMembersRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Members), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseMembersRepository_GetByID_Call) Return(_a0 error) *MockusecaseMembersRepository_GetByID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseMembersRepository_GetByID_Call) RunAndReturn(run func(context.Context, *models.Members, int) error) *MockusecaseMembersRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoans provides a mock function with given fields: ctx, loans, memberID, filter, page
+func (_m *MockusecaseMembersRepository) GetLoans(ctx context.Context, loans *[]models.Loans, memberID int, filter *models.LoansFilter, page *models.PageRequest) (*models.PageResult, error) {
+	ret := _m.Called(ctx, loans, memberID, filter, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoans")
+	}
+
+	var r0 *models.PageResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Loans, int, *models.LoansFilter, *models.PageRequest) (*models.PageResult, error)); ok {
+		return rf(ctx, loans, memberID, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Loans, int, *models.LoansFilter, *models.PageRequest) *models.PageResult); ok {
+		r0 = rf(ctx, loans, memberID, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PageResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *[]models.Loans, int, *models.LoansFilter, *models.PageRequest) error); ok {
+		r1 = rf(ctx, loans, memberID, filter, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockusecaseMembersRepository_GetLoans_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoans'
+type MockusecaseMembersRepository_GetLoans_Call struct {
+	*mock.Call
+}
+
+// GetLoans is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loans *[]models.Loans
+//   - memberID int
+//   - filter *models.LoansFilter
+//   - page *models.PageRequest
+func (_e *MockusecaseMembersRepository_Expecter) GetLoans(ctx interface{}, loans interface{}, memberID interface{}, filter interface{}, page interface{}) *MockusecaseMembersRepository_GetLoans_Call {
+	return &MockusecaseMembersRepository_GetLoans_Call{Call: _e.mock.On("GetLoans", ctx, loans, memberID, filter, page)}
+}
+
+func (_c *MockusecaseMembersRepository_GetLoans_Call) Run(run func(ctx context.Context, loans *[]models.Loans, memberID int, filter *models.LoansFilter, page *models.PageRequest)) *MockusecaseMembersRepository_GetLoans_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*[]models.Loans), args[2].(int), args[3].(*models.LoansFilter), args[4].(*models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *MockusecaseMembersRepository_GetLoans_Call) Return(_a0 *models.PageResult, _a1 error) *MockusecaseMembersRepository_GetLoans_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockusecaseMembersRepository_GetLoans_Call) RunAndReturn(run func(context.Context, *[]models.Loans, int, *models.LoansFilter, *models.PageRequest) (*models.PageResult, error)) *MockusecaseMembersRepository_GetLoans_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPage provides a mock function with given fields: ctx, members, name, page
+func (_m *MockusecaseMembersRepository) GetPage(ctx context.Context, members *[]models.Members, name string, page *models.PageRequest) (*models.PageResult, error) {
+	ret := _m.Called(ctx, members, name, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPage")
+	}
+
+	var r0 *models.PageResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Members, string, *models.PageRequest) (*models.PageResult, error)); ok {
+		return rf(ctx, members, name, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Members, string, *models.PageRequest) *models.PageResult); ok {
+		r0 = rf(ctx, members, name, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PageResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *[]models.Members, string, *models.PageRequest) error); ok {
+		r1 = rf(ctx, members, name, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockusecaseMembersRepository_GetPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPage'
+type MockusecaseMembersRepository_GetPage_Call struct {
+	*mock.Call
+}
+
+// GetPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - members *[]models.Members
+//   - name string
+//   - page *models.PageRequest
+func (_e *MockusecaseMembersRepository_Expecter) GetPage(ctx interface{}, members interface{}, name interface{}, page interface{}) *MockusecaseMembersRepository_GetPage_Call {
+	return &MockusecaseMembersRepository_GetPage_Call{Call: _e.mock.On("GetPage", ctx, members, name, page)}
+}
+
+func (_c *MockusecaseMembersRepository_GetPage_Call) Run(run func(ctx context.Context, members *[]models.Members, name string, page *models.PageRequest)) *MockusecaseMembersRepository_GetPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*[]models.Members), args[2].(string), args[3].(*models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *MockusecaseMembersRepository_GetPage_Call) Return(_a0 *models.PageResult, _a1 error) *MockusecaseMembersRepository_GetPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockusecaseMembersRepository_GetPage_Call) RunAndReturn(run func(context.Context, *[]models.Members, string, *models.PageRequest) (*models.PageResult, error)) *MockusecaseMembersRepository_GetPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, member
+func (_m *MockusecaseMembersRepository) Update(ctx context.Context, member *models.Members) error {
+	ret := _m.Called(ctx, member)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Members) error); ok {
+		r0 = rf(ctx, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseMembersRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockusecaseMembersRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - member *models.Members
+func (_e *MockusecaseMembersRepository_Expecter) Update(ctx interface{}, member interface{}) *MockusecaseMembersRepository_Update_Call {
+	return &MockusecaseMembersRepository_Update_Call{Call: _e.mock.On("Update", ctx, member)}
+}
+
+func (_c *MockusecaseMembersRepository_Update_Call) Run(run func(ctx context.Context, member *models.Members)) *MockusecaseMembersRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Members))
+	})
+	return _c
+}
+
+func (_c *MockusecaseMembersRepository_Update_Call) Return(_a0 error) *MockusecaseMembersRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseMembersRepository_Update_Call) RunAndReturn(run func(context.Context, *models.Members) error) *MockusecaseMembersRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockusecaseMembersRepository creates a new instance of MockusecaseMembersRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockusecaseMembersRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockusecaseMembersRepository {
+	mock := &MockusecaseMembersRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,181 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "crud-echo/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockusecaseStockRepository is an autogenerated mock type for the usecaseStockRepository type
+type MockusecaseStockRepository struct {
+	mock.Mock
+}
+
+type MockusecaseStockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockusecaseStockRepository) EXPECT() *MockusecaseStockRepository_Expecter {
+	return &MockusecaseStockRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddQty provides a mock function with given fields: ctx, id, delta, book
+func (_m *MockusecaseStockRepository) AddQty(ctx context.Context, id int, delta int, book *models.Books) error {
+	ret := _m.Called(ctx, id, delta, book)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddQty")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *models.Books) error); ok {
+		r0 = rf(ctx, id, delta, book)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseStockRepository_AddQty_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddQty'
+type MockusecaseStockRepository_AddQty_Call struct {
+	*mock.Call
+}
+
+// AddQty is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - delta int
+//   - book *models.Books
+func (_e *MockusecaseStockRepository_Expecter) AddQty(ctx interface{}, id interface{}, delta interface{}, book interface{}) *MockusecaseStockRepository_AddQty_Call {
+	return &MockusecaseStockRepository_AddQty_Call{Call: _e.mock.On("AddQty", ctx, id, delta, book)}
+}
+
+func (_c *MockusecaseStockRepository_AddQty_Call) Run(run func(ctx context.Context, id int, delta int, book *models.Books)) *MockusecaseStockRepository_AddQty_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int), args[3].(*models.Books))
+	})
+	return _c
+}
+
+func (_c *MockusecaseStockRepository_AddQty_Call) Return(_a0 error) *MockusecaseStockRepository_AddQty_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseStockRepository_AddQty_Call) RunAndReturn(run func(context.Context, int, int, *models.Books) error) *MockusecaseStockRepository_AddQty_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateMovements provides a mock function with given fields: ctx, movements
+func (_m *MockusecaseStockRepository) CreateMovements(ctx context.Context, movements []models.StockMovement) error {
+	ret := _m.Called(ctx, movements)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMovements")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.StockMovement) error); ok {
+		r0 = rf(ctx, movements)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseStockRepository_CreateMovements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMovements'
+type MockusecaseStockRepository_CreateMovements_Call struct {
+	*mock.Call
+}
+
+// CreateMovements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - movements []models.StockMovement
+func (_e *MockusecaseStockRepository_Expecter) CreateMovements(ctx interface{}, movements interface{}) *MockusecaseStockRepository_CreateMovements_Call {
+	return &MockusecaseStockRepository_CreateMovements_Call{Call: _e.mock.On("CreateMovements", ctx, movements)}
+}
+
+func (_c *MockusecaseStockRepository_CreateMovements_Call) Run(run func(ctx context.Context, movements []models.StockMovement)) *MockusecaseStockRepository_CreateMovements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.StockMovement))
+	})
+	return _c
+}
+
+func (_c *MockusecaseStockRepository_CreateMovements_Call) Return(_a0 error) *MockusecaseStockRepository_CreateMovements_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseStockRepository_CreateMovements_Call) RunAndReturn(run func(context.Context, []models.StockMovement) error) *MockusecaseStockRepository_CreateMovements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDForUpdate provides a mock function with given fields: ctx, book, id
+func (_m *MockusecaseStockRepository) GetByIDForUpdate(ctx context.Context, book *models.Books, id int) error {
+	ret := _m.Called(ctx, book, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDForUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Books, int) error); ok {
+		r0 = rf(ctx, book, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseStockRepository_GetByIDForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDForUpdate'
+type MockusecaseStockRepository_GetByIDForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetByIDForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - book *models.Books
+//   - id int
+func (_e *MockusecaseStockRepository_Expecter) GetByIDForUpdate(ctx interface{}, book interface{}, id interface{}) *MockusecaseStockRepository_GetByIDForUpdate_Call {
+	return &MockusecaseStockRepository_GetByIDForUpdate_Call{Call: _e.mock.On("GetByIDForUpdate", ctx, book, id)}
+}
+
+func (_c *MockusecaseStockRepository_GetByIDForUpdate_Call) Run(run func(ctx context.Context, book *models.Books, id int)) *MockusecaseStockRepository_GetByIDForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Books), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseStockRepository_GetByIDForUpdate_Call) Return(_a0 error) *MockusecaseStockRepository_GetByIDForUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseStockRepository_GetByIDForUpdate_Call) RunAndReturn(run func(context.Context, *models.Books, int) error) *MockusecaseStockRepository_GetByIDForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockusecaseStockRepository creates a new instance of MockusecaseStockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockusecaseStockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockusecaseStockRepository {
+	mock := &MockusecaseStockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,288 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "crud-echo/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockusecaseTagsRepository is an autogenerated mock type for the usecaseTagsRepository type
+type MockusecaseTagsRepository struct {
+	mock.Mock
+}
+
+type MockusecaseTagsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockusecaseTagsRepository) EXPECT() *MockusecaseTagsRepository_Expecter {
+	return &MockusecaseTagsRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, tag
+func (_m *MockusecaseTagsRepository) Create(ctx context.Context, tag *models.Tags) error {
+	ret := _m.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Tags) error); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseTagsRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockusecaseTagsRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tag *models.Tags
+func (_e *MockusecaseTagsRepository_Expecter) Create(ctx interface{}, tag interface{}) *MockusecaseTagsRepository_Create_Call {
+	return &MockusecaseTagsRepository_Create_Call{Call: _e.mock.On("Create", ctx, tag)}
+}
+
+func (_c *MockusecaseTagsRepository_Create_Call) Run(run func(ctx context.Context, tag *models.Tags)) *MockusecaseTagsRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Tags))
+	})
+	return _c
+}
+
+func (_c *MockusecaseTagsRepository_Create_Call) Return(_a0 error) *MockusecaseTagsRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseTagsRepository_Create_Call) RunAndReturn(run func(context.Context, *models.Tags) error) *MockusecaseTagsRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *MockusecaseTagsRepository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseTagsRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockusecaseTagsRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockusecaseTagsRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockusecaseTagsRepository_Delete_Call {
+	return &MockusecaseTagsRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockusecaseTagsRepository_Delete_Call) Run(run func(ctx context.Context, id int)) *MockusecaseTagsRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseTagsRepository_Delete_Call) Return(_a0 error) *MockusecaseTagsRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseTagsRepository_Delete_Call) RunAndReturn(run func(context.Context, int) error) *MockusecaseTagsRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBookTags provides a mock function with given fields: ctx, tags, bookID
+func (_m *MockusecaseTagsRepository) GetBookTags(ctx context.Context, tags *[]models.Tags, bookID int) error {
+	ret := _m.Called(ctx, tags, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Tags, int) error); ok {
+		r0 = rf(ctx, tags, bookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseTagsRepository_GetBookTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookTags'
+type MockusecaseTagsRepository_GetBookTags_Call struct {
+	*mock.Call
+}
+
+// GetBookTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tags *[]models.Tags
+//   - bookID int
+func (_e *MockusecaseTagsRepository_Expecter) GetBookTags(ctx interface{}, tags interface{}, bookID interface{}) *MockusecaseTagsRepository_GetBookTags_Call {
+	return &MockusecaseTagsRepository_GetBookTags_Call{Call: _e.mock.On("GetBookTags", ctx, tags, bookID)}
+}
+
+func (_c *MockusecaseTagsRepository_GetBookTags_Call) Run(run func(ctx context.Context, tags *[]models.Tags, bookID int)) *MockusecaseTagsRepository_GetBookTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*[]models.Tags), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockusecaseTagsRepository_GetBookTags_Call) Return(_a0 error) *MockusecaseTagsRepository_GetBookTags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseTagsRepository_GetBookTags_Call) RunAndReturn(run func(context.Context, *[]models.Tags, int) error) *MockusecaseTagsRepository_GetBookTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPage provides a mock function with given fields: ctx, tags, name, page
+func (_m *MockusecaseTagsRepository) GetPage(ctx context.Context, tags *[]models.Tags, name string, page *models.PageRequest) (*models.PageResult, error) {
+	ret := _m.Called(ctx, tags, name, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPage")
+	}
+
+	var r0 *models.PageResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Tags, string, *models.PageRequest) (*models.PageResult, error)); ok {
+		return rf(ctx, tags, name, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *[]models.Tags, string, *models.PageRequest) *models.PageResult); ok {
+		r0 = rf(ctx, tags, name, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PageResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *[]models.Tags, string, *models.PageRequest) error); ok {
+		r1 = rf(ctx, tags, name, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockusecaseTagsRepository_GetPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPage'
+type MockusecaseTagsRepository_GetPage_Call struct {
+	*mock.Call
+}
+
+// GetPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tags *[]models.Tags
+//   - name string
+//   - page *models.PageRequest
+func (_e *MockusecaseTagsRepository_Expecter) GetPage(ctx interface{}, tags interface{}, name interface{}, page interface{}) *MockusecaseTagsRepository_GetPage_Call {
+	return &MockusecaseTagsRepository_GetPage_Call{Call: _e.mock.On("GetPage", ctx, tags, name, page)}
+}
+
+func (_c *MockusecaseTagsRepository_GetPage_Call) Run(run func(ctx context.Context, tags *[]models.Tags, name string, page *models.PageRequest)) *MockusecaseTagsRepository_GetPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*[]models.Tags), args[2].(string), args[3].(*models.PageRequest))
+	})
+	return _c
+}
+
+func (_c *MockusecaseTagsRepository_GetPage_Call) Return(_a0 *models.PageResult, _a1 error) *MockusecaseTagsRepository_GetPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockusecaseTagsRepository_GetPage_Call) RunAndReturn(run func(context.Context, *[]models.Tags, string, *models.PageRequest) (*models.PageResult, error)) *MockusecaseTagsRepository_GetPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceBookTags provides a mock function with given fields: ctx, bookID, names
+func (_m *MockusecaseTagsRepository) ReplaceBookTags(ctx context.Context, bookID int, names []string) error {
+	ret := _m.Called(ctx, bookID, names)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceBookTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string) error); ok {
+		r0 = rf(ctx, bookID, names)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockusecaseTagsRepository_ReplaceBookTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceBookTags'
+type MockusecaseTagsRepository_ReplaceBookTags_Call struct {
+	*mock.Call
+}
+
+// ReplaceBookTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookID int
+//   - names []string
+func (_e *MockusecaseTagsRepository_Expecter) ReplaceBookTags(ctx interface{}, bookID interface{}, names interface{}) *MockusecaseTagsRepository_ReplaceBookTags_Call {
+	return &MockusecaseTagsRepository_ReplaceBookTags_Call{Call: _e.mock.On("ReplaceBookTags", ctx, bookID, names)}
+}
+
+func (_c *MockusecaseTagsRepository_ReplaceBookTags_Call) Run(run func(ctx context.Context, bookID int, names []string)) *MockusecaseTagsRepository_ReplaceBookTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockusecaseTagsRepository_ReplaceBookTags_Call) Return(_a0 error) *MockusecaseTagsRepository_ReplaceBookTags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockusecaseTagsRepository_ReplaceBookTags_Call) RunAndReturn(run func(context.Context, int, []string) error) *MockusecaseTagsRepository_ReplaceBookTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockusecaseTagsRepository creates a new instance of MockusecaseTagsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockusecaseTagsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockusecaseTagsRepository {
+	mock := &MockusecaseTagsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+const (
+	HeaderActor = "X-Actor"
+
+	// who changed something when the request didn't say
+	DefaultActor = "anonymous"
+	MaxActorLen  = 100
+)
+
+type actorKey struct{}
+
+// WithActor stores who the request acts for, as the client reported it.
+// Invalid UTF-8 becomes U+FFFD, control characters are dropped and the rest
+// is cut to MaxActorLen characters, what the ledger column holds
+func WithActor(ctx context.Context, actor string) context.Context {
+	actor = strings.ToValidUTF8(actor, "\uFFFD")
+	actor = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, actor)
+	actor = strings.TrimSpace(actor)
+	if runes := []rune(actor); len(runes) > MaxActorLen {
+		actor = string(runes[:MaxActorLen])
+	}
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return DefaultActor
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Authors struct {
+	ID        int       `gorm:"primaryKey;autoIncrement;not null"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	Bio       string    `gorm:"type:varchar(1000);not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime;type:timestamptz;not null"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime;type:timestamptz"`
+}
+
+// BookAuthors is a row of the book_authors join table
+type BookAuthors struct {
+	BookID   int `gorm:"primaryKey"`
+	AuthorID int `gorm:"primaryKey"`
+}
+
+func (BookAuthors) TableName() string {
+	return "book_authors"
+}
+
+type CreateAuthorsRequest struct {
+	Name string `json:"name" validate:"required,no_leading_space,min=2,max=100"`
+	Bio  string `json:"bio" validate:"max=1000"`
+}
+
+type UpdateAuthorsRequest struct {
+	ID   int    `param:"id" json:"-" validate:"required,gte=1"`
+	Name string `json:"name" validate:"required,no_leading_space,min=2,max=100"`
+	Bio  string `json:"bio" validate:"max=1000"`
+}
+
+type GetAllAuthorsRequest struct {
+	Name   string `query:"name" validate:"omitempty,max=100"`
+	Limit  int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Offset int    `query:"offset" validate:"gte=0"`
+}
+
+type GetAuthorBooksRequest struct {
+	Limit  int `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Offset int `query:"offset" validate:"gte=0"`
+}
+
+type AuthorsSummary struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Bio  string `json:"bio,omitempty"`
+}
+
+func (a Authors) ToAuthorsSummary() *AuthorsSummary {
+	return &AuthorsSummary{
+		ID:   a.ID,
+		Name: a.Name,
+		Bio:  a.Bio,
+	}
+}
+
+// AuthorRefs turns author IDs into Authors carrying just the ID, enough for
+// GORM to write the book_authors rows of a book
+func AuthorRefs(ids []int) []Authors {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	authors := make([]Authors, len(ids))
+	for i, id := range ids {
+		authors[i] = Authors{ID: id}
+	}
+	return authors
+}
+
+const IncludeAuthors = "authors"
+
+// BooksInclude lists what to load along with books, see ?include=
+type BooksInclude struct {
+	Authors bool
+}
+
+// ParseBooksInclude reads "authors" style comma separated lists, unknown
+// names are rejected
+func ParseBooksInclude(raw string) (BooksInclude, error) {
+	var include BooksInclude
+	if raw == "" {
+		return include, nil
+	}
+
+	for _, name := range strings.Split(raw, ",") {
+		switch strings.TrimSpace(name) {
+		case IncludeAuthors:
+			include.Authors = true
+		default:
+			return include, fmt.Errorf("unknown include %q", name)
+		}
+	}
+
+	return include, nil
+}
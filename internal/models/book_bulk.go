@@ -0,0 +1,104 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	MaxBulkItems = 5000
+	// rows per INSERT when creating in bulk
+	BulkBatchSize = 100
+)
+
+// BulkUpdateBooksItem carries its version in the body since one If-Match
+// header can't cover many books
+type BulkUpdateBooksItem struct {
+	ID          int    `json:"id" validate:"required,gte=1"`
+	Title       string `json:"title" validate:"required,no_leading_space,min=3,max=50"`
+	Description string `json:"description" validate:"required,min=3,max=255"`
+	Qty         int    `json:"qty" validate:"required,gte=0,lte=100"`
+	Version     int    `json:"version" validate:"required,gte=1"`
+
+	BooksBibliography
+}
+
+type BulkDeleteBooksItem struct {
+	ID      int `json:"id" validate:"required,gte=1"`
+	Version int `json:"version" validate:"required,gte=1"`
+}
+
+// BulkResult is the outcome of one item, Index is its position in the
+// request. ID is set when the item went through, Code otherwise
+type BulkResult struct {
+	Index   int               `json:"index"`
+	ID      int               `json:"id,omitempty"`
+	Code    string            `json:"code,omitempty"`
+	Message string            `json:"message,omitempty"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+type BulkSummary struct {
+	Atomic    bool `json:"atomic"`
+	Total     int  `json:"total"`
+	Succeeded int  `json:"succeeded"`
+	Failed    int  `json:"failed"`
+}
+
+func (r *BulkResult) Failed() bool {
+	return r.Code != ""
+}
+
+// Fail records err on the item, with the same code and message the error
+// handler would have used for a single book
+func (r *BulkResult) Fail(err error) {
+	r.ID = 0
+
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		r.Code = ErrValidationError.Code
+		r.Message = ve.Message
+		r.Errors = ve.Errors
+		return
+	}
+
+	de := DomainErrorFor(err)
+	r.Code = de.Code
+	r.Message = de.Message
+}
+
+// NewBulkResults returns one pending result per item
+func NewBulkResults(n int) []BulkResult {
+	results := make([]BulkResult, n)
+	for i := range results {
+		results[i].Index = i
+	}
+	return results
+}
+
+// RollBackBulk marks every item that hasn't failed as rolled back
+func RollBackBulk(results []BulkResult) {
+	for i := range results {
+		if !results[i].Failed() {
+			results[i].Fail(ErrBulkRolledBack)
+		}
+	}
+}
+
+func SummarizeBulk(results []BulkResult, atomic bool) BulkSummary {
+	summary := BulkSummary{Atomic: atomic, Total: len(results)}
+	for _, r := range results {
+		if r.Failed() {
+			summary.Failed++
+		} else {
+			summary.Succeeded++
+		}
+	}
+	return summary
+}
+
+// NormalizeTitle is how titles are compared for uniqueness, the same
+// lower(btrim(title)) the unique index uses
+func NormalizeTitle(title string) string {
+	return strings.ToLower(strings.Trim(title, " "))
+}
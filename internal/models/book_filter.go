@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// BooksSortableColumns maps the names accepted by ?sort= to books columns,
+// anything not listed here is rejected before it gets near a query
+var BooksSortableColumns = map[string]string{
+	"id":         "id",
+	"title":      "title",
+	"qty":        "qty",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+type BooksFilter struct {
+	Title         string // case-insensitive contains
+	QtyMin        *int
+	QtyMax        *int
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Available     *bool    // true means qty > 0, false means qty = 0
+	Category      string   // slug, books in the category or any below it
+	Tags          []string // normalized names, books carrying all of them
+}
+
+type SortField struct {
+	Column string
+	Desc   bool
+}
@@ -2,39 +2,124 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Books struct {
-	ID          int       `gorm:"primaryKey;autoIncrement;not null"`
-	Title       string    `gorm:"type:varchar(50);not null"`
-	Description string    `gorm:"type:varchar(255);not null"`
-	Qty         int       `gorm:"not null"`
-	CreatedAt   time.Time `gorm:"autoCreateTime;type:timestamptz;not null"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime;type:timestamptz"`
+	ID          int            `gorm:"primaryKey;autoIncrement;not null"`
+	Title       string         `gorm:"type:varchar(50);not null"`
+	Description string         `gorm:"type:varchar(255);not null"`
+	Qty         int            `gorm:"not null"`
+	Version     int            `gorm:"not null;default:1"` // bumped on every write, exposed as the ETag
+	CreatedAt   time.Time      `gorm:"autoCreateTime;type:timestamptz;not null"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime;type:timestamptz"`
+	DeletedAt   gorm.DeletedAt `gorm:"index;type:timestamptz"` // set while the book sits in the trash
+
+	BooksBibliography
+
+	// only loaded when asked for, see BooksInclude
+	Authors []Authors `gorm:"many2many:book_authors;joinForeignKey:BookID;joinReferences:AuthorID"`
+}
+
+// BooksBibliography is what identifies a book outside the shop, all of it is
+// optional. The ISBN is stored as the ISBN-13 NormalizeISBN returns
+type BooksBibliography struct {
+	ISBN            string `json:"isbn,omitempty" gorm:"column:isbn;type:varchar(13);not null" validate:"omitempty,isbn"`
+	Publisher       string `json:"publisher,omitempty" gorm:"type:varchar(100);not null" validate:"omitempty,no_leading_space,max=100"`
+	PublicationYear *int   `json:"publication_year,omitempty" gorm:"type:smallint" validate:"omitempty,gte=1,lte=9999"`
+	Language        string `json:"language,omitempty" gorm:"type:varchar(35);not null" validate:"omitempty,bcp47_language_tag,max=35"`
+	PageCount       *int   `json:"page_count,omitempty" validate:"omitempty,gte=1,lte=100000"`
+	Edition         string `json:"edition,omitempty" gorm:"type:varchar(50);not null" validate:"omitempty,no_leading_space,max=50"`
+}
+
+// Normalized returns b with its ISBN in the stored form, b has to have been
+// validated already
+func (b BooksBibliography) Normalized() BooksBibliography {
+	if isbn, err := NormalizeISBN(b.ISBN); err == nil {
+		b.ISBN = isbn
+	}
+	return b
 }
 
 type CreateBooksRequest struct {
-	Title       string `json:"title" validate:"required,min=3,max=50"`
+	Title       string `json:"title" validate:"required,no_leading_space,min=3,max=50"`
 	Description string `json:"description" validate:"required,min=3,max=255"`
 	Qty         int    `json:"qty" validate:"required,gte=0,lte=100"`
+	AuthorIDs   []int  `json:"author_ids,omitempty" validate:"omitempty,max=20,unique,dive,gte=1"`
+
+	BooksBibliography
 }
 
 type UpdateBooksRequest struct {
 	ID          int    `json:"id" validate:"required,gte=1"`
-	Title       string `json:"title" validate:"required,min=3,max=50"`
+	Title       string `json:"title" validate:"required,no_leading_space,min=3,max=50"`
 	Description string `json:"description" validate:"required,min=3,max=255"`
 	Qty         int    `json:"qty" validate:"required,gte=0,lte=100"`
+	Version     int    `json:"-"` // from If-Match
+
+	// replaced as a whole, leaving a field out clears it
+	BooksBibliography
+
+	// replaces the authors of the book when set, leaves them alone otherwise
+	AuthorIDs *[]int `json:"author_ids,omitempty" validate:"omitempty,max=20,unique,dive,gte=1"`
 }
 
 type DeleteBooksRequest struct {
-	ID int `json:"id" validate:"required,gte=1"`
+	ID      int `json:"id" validate:"required,gte=1"`
+	Version int `json:"-"` // from If-Match
+}
+
+type GetAllBooksRequest struct {
+	Title         string     `query:"title" validate:"omitempty,max=50"`
+	QtyMin        *int       `query:"qty_min" validate:"omitempty,gte=0"`
+	QtyMax        *int       `query:"qty_max" validate:"omitempty,gte=0"`
+	CreatedAfter  *time.Time `query:"created_after"`
+	CreatedBefore *time.Time `query:"created_before"`
+	Available     *bool      `query:"available"`
+	Category      string     `query:"category" validate:"omitempty,slug,max=100"`
+	Tags          string     `query:"tags" validate:"omitempty,max=500"`
+	Sort          string     `query:"sort" validate:"omitempty,max=100"`
+	Limit         int        `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Offset        int        `query:"offset" validate:"gte=0"`
+	Cursor        string     `query:"cursor" validate:"excluded_with=Offset Sort"`
+	Include       string     `query:"include" validate:"omitempty,max=100"`
+}
+
+type GetTrashedBooksRequest struct {
+	Limit  int `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Offset int `query:"offset" validate:"gte=0"`
+}
+
+type SearchBooksRequest struct {
+	Q     string `query:"q" validate:"required,min=2,max=100"`
+	Limit int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
 }
 
 type BooksSummary struct {
-	ID          int    `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Qty         int    `json:"qty"`
+	ID          int        `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Qty         int        `json:"qty"`
+	Version     int        `json:"version,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	BooksBibliography
+
+	Authors []AuthorsSummary `json:"authors,omitempty" gorm:"-"` // search results are scanned into summaries
+}
+
+// BooksSearchResult is a summary ranked by relevance, Snippet has the
+// matched words wrapped in <mark> tags
+type BooksSearchResult struct {
+	BooksSummary
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// BooksStats covers the books that aren't in the trash
+type BooksStats struct {
+	Total    int64
+	TotalQty int64
 }
 
 func (b Books) ToBooksSummary() *BooksSummary {
@@ -43,5 +128,37 @@ func (b Books) ToBooksSummary() *BooksSummary {
 		Title:       b.Title,
 		Description: b.Description,
 		Qty:         b.Qty,
+		Version:     b.Version,
+		DeletedAt:   deletedAt(b.DeletedAt),
+		Authors:     authorsSummary(b.Authors),
+
+		BooksBibliography: b.BooksBibliography,
+	}
+}
+
+func authorsSummary(authors []Authors) []AuthorsSummary {
+	if authors == nil {
+		return nil
+	}
+
+	summaries := make([]AuthorsSummary, len(authors))
+	for i, author := range authors {
+		summaries[i] = *author.ToAuthorsSummary()
+	}
+	return summaries
+}
+
+func deletedAt(d gorm.DeletedAt) *time.Time {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Time
+}
+
+func (b Books) ToCursor(direction string) Cursor {
+	return Cursor{
+		ID:        b.ID,
+		CreatedAt: b.CreatedAt,
+		Direction: direction,
 	}
 }
@@ -0,0 +1,160 @@
+package models
+
+const (
+	MIMEApplicationMergePatch = "application/merge-patch+json"
+	MIMEApplicationJSONPatch  = "application/json-patch+json"
+)
+
+// BooksPatchDocument is the part of a book a PATCH may touch, patches are
+// applied to its JSON form so only these keys can ever be addressed. The
+// bibliography is spelled out without omitempty so a JSON Patch can replace
+// a field the book doesn't have yet
+type BooksPatchDocument struct {
+	Title       string `json:"title" validate:"required,no_leading_space,min=3,max=50"`
+	Description string `json:"description" validate:"required,min=3,max=255"`
+	Qty         int    `json:"qty" validate:"gte=0,lte=100"`
+
+	ISBN            string `json:"isbn" validate:"omitempty,isbn"`
+	Publisher       string `json:"publisher" validate:"omitempty,no_leading_space,max=100"`
+	PublicationYear *int   `json:"publication_year" validate:"omitempty,gte=1,lte=9999"`
+	Language        string `json:"language" validate:"omitempty,bcp47_language_tag,max=35"`
+	PageCount       *int   `json:"page_count" validate:"omitempty,gte=1,lte=100000"`
+	Edition         string `json:"edition" validate:"omitempty,no_leading_space,max=50"`
+}
+
+// BooksPatch holds only the fields a patch changed, nil means untouched.
+// PublicationYear and PageCount can be cleared, so they point at the new
+// value which is nil for NULL. Version is the one the patch was applied to,
+// not a field to change
+type BooksPatch struct {
+	Title       *string
+	Description *string
+	Qty         *int
+	Version     int
+
+	ISBN            *string
+	Publisher       *string
+	PublicationYear **int
+	Language        *string
+	PageCount       **int
+	Edition         *string
+}
+
+func (b BooksSummary) ToBooksPatchDocument() *BooksPatchDocument {
+	return &BooksPatchDocument{
+		Title:       b.Title,
+		Description: b.Description,
+		Qty:         b.Qty,
+
+		ISBN:            b.ISBN,
+		Publisher:       b.Publisher,
+		PublicationYear: b.PublicationYear,
+		Language:        b.Language,
+		PageCount:       b.PageCount,
+		Edition:         b.Edition,
+	}
+}
+
+// Diff returns what changed from d to patched along with the Go field names,
+// the latter is what validator.StructPartial expects
+func (d BooksPatchDocument) Diff(patched BooksPatchDocument) (*BooksPatch, []string) {
+	var patch BooksPatch
+	var fields []string
+
+	if patched.Title != d.Title {
+		patch.Title = &patched.Title
+		fields = append(fields, "Title")
+	}
+	if patched.Description != d.Description {
+		patch.Description = &patched.Description
+		fields = append(fields, "Description")
+	}
+	if patched.Qty != d.Qty {
+		patch.Qty = &patched.Qty
+		fields = append(fields, "Qty")
+	}
+	if patched.ISBN != d.ISBN {
+		patch.ISBN = &patched.ISBN
+		fields = append(fields, "ISBN")
+	}
+	if patched.Publisher != d.Publisher {
+		patch.Publisher = &patched.Publisher
+		fields = append(fields, "Publisher")
+	}
+	if !sameInt(patched.PublicationYear, d.PublicationYear) {
+		patch.PublicationYear = &patched.PublicationYear
+		fields = append(fields, "PublicationYear")
+	}
+	if patched.Language != d.Language {
+		patch.Language = &patched.Language
+		fields = append(fields, "Language")
+	}
+	if !sameInt(patched.PageCount, d.PageCount) {
+		patch.PageCount = &patched.PageCount
+		fields = append(fields, "PageCount")
+	}
+	if patched.Edition != d.Edition {
+		patch.Edition = &patched.Edition
+		fields = append(fields, "Edition")
+	}
+
+	return &patch, fields
+}
+
+func sameInt(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (p BooksPatch) IsEmpty() bool {
+	return p.Title == nil && p.Description == nil && p.Qty == nil &&
+		p.ISBN == nil && p.Publisher == nil && p.PublicationYear == nil &&
+		p.Language == nil && p.PageCount == nil && p.Edition == nil
+}
+
+// Normalized returns p with the ISBN in the stored form, p has to have been
+// validated already
+func (p BooksPatch) Normalized() *BooksPatch {
+	if p.ISBN != nil {
+		if isbn, err := NormalizeISBN(*p.ISBN); err == nil {
+			p.ISBN = &isbn
+		}
+	}
+	return &p
+}
+
+// Columns maps the patch to column updates, unlike a struct a map keeps zero values
+func (p BooksPatch) Columns() map[string]any {
+	columns := make(map[string]any)
+	if p.Title != nil {
+		columns["title"] = *p.Title
+	}
+	if p.Description != nil {
+		columns["description"] = *p.Description
+	}
+	if p.Qty != nil {
+		columns["qty"] = *p.Qty
+	}
+	if p.ISBN != nil {
+		columns["isbn"] = *p.ISBN
+	}
+	if p.Publisher != nil {
+		columns["publisher"] = *p.Publisher
+	}
+	if p.PublicationYear != nil {
+		columns["publication_year"] = *p.PublicationYear
+	}
+	if p.Language != nil {
+		columns["language"] = *p.Language
+	}
+	if p.PageCount != nil {
+		columns["page_count"] = *p.PageCount
+	}
+	if p.Edition != nil {
+		columns["edition"] = *p.Edition
+	}
+
+	return columns
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"time"
+)
+
+const (
+	ExportFormatCSV   = "csv"
+	ExportFormatJSONL = "jsonl"
+
+	// what to do with an imported row whose title is already taken
+	DuplicateSkip   = "skip"
+	DuplicateUpdate = "update"
+	DuplicateFail   = "fail"
+
+	MaxImportRows = 50000
+)
+
+// BooksExportColumns is the CSV header of an export, and the names an import
+// maps its columns to
+var BooksExportColumns = []string{
+	"id", "title", "description", "qty", "version", "created_at", "updated_at",
+	"isbn", "publisher", "publication_year", "language", "page_count", "edition",
+}
+
+// BooksImportColumns are the columns an import can fill, title is required
+var BooksImportColumns = []string{
+	"title", "description", "qty",
+	"isbn", "publisher", "publication_year", "language", "page_count", "edition",
+}
+
+// BooksExport is one exported book, trashed books are left out
+type BooksExport struct {
+	ID          int       `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Qty         int       `json:"qty"`
+	Version     int       `json:"version"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	BooksBibliography
+}
+
+func (b Books) ToBooksExport() *BooksExport {
+	return &BooksExport{
+		ID:          b.ID,
+		Title:       b.Title,
+		Description: b.Description,
+		Qty:         b.Qty,
+		Version:     b.Version,
+		CreatedAt:   b.CreatedAt,
+		UpdatedAt:   b.UpdatedAt,
+
+		BooksBibliography: b.BooksBibliography,
+	}
+}
+
+// ImportRow is a parsed and validated row, Line is where it sits in the file
+// (the header is line 1 in a CSV) so errors can point back at it. The ISBN
+// is already normalized
+type ImportRow struct {
+	Line int
+	Book CreateBooksRequest
+}
+
+// ImportBooksRequest holds the query params of POST /books/import, the file
+// and the column mapping come as multipart fields
+type ImportBooksRequest struct {
+	Format      string `query:"format" validate:"omitempty,oneof=csv jsonl"`
+	DryRun      bool   `query:"dry_run"`
+	OnDuplicate string `query:"on_duplicate" validate:"omitempty,oneof=skip update fail"`
+	Report      string `query:"report" validate:"omitempty,oneof=json csv"`
+}
+
+type ImportOptions struct {
+	DryRun      bool
+	OnDuplicate string
+}
+
+// ImportRowError is one line of the error report, Field is empty when the
+// whole row failed
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ImportReport says what an import did, or with DryRun what it would do
+type ImportReport struct {
+	DryRun  bool             `json:"dry_run"`
+	Total   int              `json:"total"`
+	Created int              `json:"created"`
+	Updated int              `json:"updated"`
+	Skipped int              `json:"skipped"`
+	Failed  int              `json:"failed"`
+	Errors  []ImportRowError `json:"errors"`
+}
+
+// AddError records err against the row on line, a validation error gives one
+// report line per field
+func (r *ImportReport) AddError(line int, err error) {
+	r.Failed++
+
+	var ve *ValidationError
+	if errors.As(err, &ve) && len(ve.Errors) > 0 {
+		fields := slices.Sorted(maps.Keys(ve.Errors))
+		for _, field := range fields {
+			r.Errors = append(r.Errors, ImportRowError{
+				Line:    line,
+				Field:   field,
+				Code:    ErrValidationError.Code,
+				Message: ve.Errors[field],
+			})
+		}
+		return
+	}
+
+	if ve != nil {
+		r.Errors = append(r.Errors, ImportRowError{Line: line, Code: ErrValidationError.Code, Message: ve.Message})
+		return
+	}
+
+	de := DomainErrorFor(err)
+	r.Errors = append(r.Errors, ImportRowError{Line: line, Code: de.Code, Message: de.Message})
+}
+
+// SortErrors orders the report by line, rows are checked in more than one
+// pass so their errors don't come out in order
+func (r *ImportReport) SortErrors() {
+	slices.SortStableFunc(r.Errors, func(a, b ImportRowError) int {
+		return a.Line - b.Line
+	})
+}
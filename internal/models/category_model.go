@@ -0,0 +1,78 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CategoryRootPath is the path roots hang off
+const CategoryRootPath = "/"
+
+// Categories form a tree. Path lists the ids from the root down to the
+// category itself, "/1/4/", so a subtree is every path with that prefix
+type Categories struct {
+	ID        int       `gorm:"primaryKey;autoIncrement;not null"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	Slug      string    `gorm:"type:varchar(100);not null"`
+	ParentID  *int      `gorm:"type:bigint"`
+	Path      string    `gorm:"type:varchar(1000);not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime;type:timestamptz;not null"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime;type:timestamptz"`
+}
+
+// BookCategories is a row of the book_categories join table
+type BookCategories struct {
+	BookID     int `gorm:"primaryKey"`
+	CategoryID int `gorm:"primaryKey"`
+}
+
+func (BookCategories) TableName() string {
+	return "book_categories"
+}
+
+// CategoryPath is the path of the category id placed under parentPath
+func CategoryPath(parentPath string, id int) string {
+	return parentPath + strconv.Itoa(id) + "/"
+}
+
+// Contains reports whether other is c or one of its descendants
+func (c Categories) Contains(other Categories) bool {
+	return strings.HasPrefix(other.Path, c.Path)
+}
+
+type CreateCategoriesRequest struct {
+	Name     string `json:"name" validate:"required,no_leading_space,min=2,max=100"`
+	Slug     string `json:"slug" validate:"required,slug,max=100"`
+	ParentID *int   `json:"parent_id" validate:"omitempty,gte=1"`
+}
+
+// UpdateCategoriesRequest replaces name, slug and parent, a different parent
+// moves the whole subtree along
+type UpdateCategoriesRequest struct {
+	ID       int    `param:"id" json:"-" validate:"required,gte=1"`
+	Name     string `json:"name" validate:"required,no_leading_space,min=2,max=100"`
+	Slug     string `json:"slug" validate:"required,slug,max=100"`
+	ParentID *int   `json:"parent_id" validate:"omitempty,gte=1"`
+}
+
+type SetBookCategoriesRequest struct {
+	BookID      int   `param:"id" json:"-" validate:"required,gte=1"`
+	CategoryIDs []int `json:"category_ids" validate:"max=20,unique,dive,gte=1"`
+}
+
+type CategoriesSummary struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	ParentID *int   `json:"parent_id,omitempty"`
+}
+
+func (c Categories) ToCategoriesSummary() *CategoriesSummary {
+	return &CategoriesSummary{
+		ID:       c.ID,
+		Name:     c.Name,
+		Slug:     c.Slug,
+		ParentID: c.ParentID,
+	}
+}
@@ -2,66 +2,203 @@ package models
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
 )
 
 const (
 	InternalServerError  = "internal server error"
 	BadRequest           = "bad request"
 	NotFound             = "record not found"
-	EmptyTable           = "table is empty"
 	ResourceAlreadyExist = "resource already exist"
 	InvalidParam         = "invalid parameter"
-	ValidationError      = "validation error"
+	ValidationFailed     = "validation error"
+	UnsupportedMediaType = "unsupported media type"
+	PreconditionFailed   = "resource has been modified"
+	PreconditionRequired = "if-match header is required"
+	Unauthorized         = "unauthorized"
 )
 
+// DomainError is an error the API knows how to report: a stable code clients
+// can switch on, the HTTP status and a message safe to show them. Cause is
+// whatever went wrong underneath, it's for logs only
+type DomainError struct {
+	Code    string
+	Status  int
+	Message string
+	Cause   error
+
+	// the more general error this one is a kind of, so a BOOK_NOT_FOUND
+	// still matches errors.Is(err, ErrNotFound)
+	parent *DomainError
+}
+
+func (e *DomainError) Error() string {
+	if e.Cause != nil {
+		return e.Message + ": " + e.Cause.Error()
+	}
+	return e.Message
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.Cause
+}
+
+// Is matches the error itself and every error it was derived or wrapped from
+func (e *DomainError) Is(target error) bool {
+	t, ok := target.(*DomainError)
+	if !ok {
+		return false
+	}
+	for k := e; k != nil; k = k.parent {
+		if k == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Wrap returns a copy of e carrying cause, it still matches e
+func (e *DomainError) Wrap(cause error) *DomainError {
+	return &DomainError{
+		Code:    e.Code,
+		Status:  e.Status,
+		Message: e.Message,
+		Cause:   cause,
+		parent:  e,
+	}
+}
+
+// registry holds every DomainError by code, codes are part of the API and
+// must stay unique
+var registry = map[string]*DomainError{}
+
+func register(code string, status int, message string, parent *DomainError) *DomainError {
+	if _, ok := registry[code]; ok {
+		panic(fmt.Sprintf("models: error code %s registered twice", code))
+	}
+
+	e := &DomainError{Code: code, Status: status, Message: message, parent: parent}
+	registry[code] = e
+	return e
+}
+
 var (
-	ErrInternalServerError  = errors.New("internal server error")
-	ErrBadRequest           = errors.New("bad request")
-	ErrNotFound             = errors.New("record not found")
-	ErrRecordNotCreated     = errors.New("record not created")
-	ErrEmptyTable           = errors.New("table is empty")
-	ErrResourceAlreadyExist = errors.New("resource already exist")
-	ErrInvalidParam         = errors.New("invalid parameter")
-	ErrValidationError      = errors.New("validation error")
+	ErrInternalServerError  = register("INTERNAL_SERVER_ERROR", http.StatusInternalServerError, InternalServerError, nil)
+	ErrBadRequest           = register("BAD_REQUEST", http.StatusBadRequest, BadRequest, nil)
+	ErrNotFound             = register("NOT_FOUND", http.StatusNotFound, NotFound, nil)
+	ErrResourceAlreadyExist = register("RESOURCE_ALREADY_EXIST", http.StatusConflict, ResourceAlreadyExist, nil)
+	ErrInvalidParam         = register("INVALID_PARAMETER", http.StatusBadRequest, InvalidParam, nil)
+	ErrValidationError      = register("VALIDATION_ERROR", http.StatusUnprocessableEntity, ValidationFailed, nil)
+	ErrUnsupportedMediaType = register("UNSUPPORTED_MEDIA_TYPE", http.StatusUnsupportedMediaType, UnsupportedMediaType, nil)
+	ErrPreconditionFailed   = register("PRECONDITION_FAILED", http.StatusPreconditionFailed, PreconditionFailed, nil)
+	ErrPreconditionRequired = register("PRECONDITION_REQUIRED", http.StatusPreconditionRequired, PreconditionRequired, nil)
+	ErrUnauthorized         = register("UNAUTHORIZED", http.StatusUnauthorized, Unauthorized, nil)
+
+	// what the database refused, see database.translateError
+	ErrReferenceViolation  = register("REFERENCE_VIOLATION", http.StatusConflict, "resource is referenced by or refers to a missing resource", nil)
+	ErrConstraintViolation = register("CONSTRAINT_VIOLATION", http.StatusUnprocessableEntity, "value breaks a constraint", ErrValidationError)
+	ErrConcurrentUpdate    = register("CONCURRENT_UPDATE", http.StatusConflict, "conflicting concurrent update, try again", nil)
+
+	ErrBookNotFound   = register("BOOK_NOT_FOUND", http.StatusNotFound, "book not found", ErrNotFound)
+	ErrBookTitleTaken = register("BOOK_TITLE_TAKEN", http.StatusConflict, "a book with this title already exists", ErrResourceAlreadyExist)
+	ErrBookISBNTaken  = register("BOOK_ISBN_TAKEN", http.StatusConflict, "a book with this ISBN already exists", ErrResourceAlreadyExist)
+
+	ErrAuthorNotFound = register("AUTHOR_NOT_FOUND", http.StatusNotFound, "author not found", ErrNotFound)
+	// a book refers to an author that doesn't exist
+	ErrUnknownAuthor = register("UNKNOWN_AUTHOR", http.StatusUnprocessableEntity, "no author with this id", ErrReferenceViolation)
+
+	ErrCategoryNotFound  = register("CATEGORY_NOT_FOUND", http.StatusNotFound, "category not found", ErrNotFound)
+	ErrCategorySlugTaken = register("CATEGORY_SLUG_TAKEN", http.StatusConflict, "a category with this slug already exists", ErrResourceAlreadyExist)
+	// a book or a parent refers to a category that doesn't exist
+	ErrUnknownCategory     = register("UNKNOWN_CATEGORY", http.StatusUnprocessableEntity, "no category with this id", ErrReferenceViolation)
+	ErrCategoryHasChildren = register("CATEGORY_HAS_CHILDREN", http.StatusConflict, "category still has subcategories", ErrReferenceViolation)
+	ErrCategoryCycle       = register("CATEGORY_CYCLE", http.StatusUnprocessableEntity, "a category can't be moved below itself", nil)
+
+	ErrTagNotFound  = register("TAG_NOT_FOUND", http.StatusNotFound, "tag not found", ErrNotFound)
+	ErrTagNameTaken = register("TAG_NAME_TAKEN", http.StatusConflict, "a tag with this name already exists", ErrResourceAlreadyExist)
+
+	ErrMemberNotFound   = register("MEMBER_NOT_FOUND", http.StatusNotFound, "member not found", ErrNotFound)
+	ErrMemberEmailTaken = register("MEMBER_EMAIL_TAKEN", http.StatusConflict, "a member with this email already exists", ErrResourceAlreadyExist)
+
+	ErrLoanNotFound        = register("LOAN_NOT_FOUND", http.StatusNotFound, "loan not found", ErrNotFound)
+	ErrLoanAlreadyReturned = register("LOAN_ALREADY_RETURNED", http.StatusConflict, "loan has been returned already", nil)
+	ErrLoanOverdue         = register("LOAN_OVERDUE", http.StatusConflict, "an overdue loan can't be renewed, return the book instead", nil)
+	ErrLoanRenewalLimit    = register("LOAN_RENEWAL_LIMIT", http.StatusConflict, "loan has been renewed as often as allowed", nil)
+	// a book can't be purged while copies of it are lent out
+	ErrBookHasOpenLoans = register("BOOK_HAS_OPEN_LOANS", http.StatusConflict, "copies of the book are still out on loan", ErrReferenceViolation)
+
+	ErrHoldNotFound      = register("HOLD_NOT_FOUND", http.StatusNotFound, "hold not found", ErrNotFound)
+	ErrHoldAlreadyExists = register("HOLD_ALREADY_EXISTS", http.StatusConflict, "the member already holds this book", ErrResourceAlreadyExist)
+	ErrHoldClosed        = register("HOLD_CLOSED", http.StatusConflict, "hold has been fulfilled, cancelled or has expired already", nil)
+	// holds are for books out of copies, otherwise the member can check one out
+	ErrCopiesAvailable = register("COPIES_AVAILABLE", http.StatusConflict, "copies of the book are available, check one out instead", nil)
+
+	// books.qty can't go below zero, see the books_qty_non_negative constraint
+	ErrInsufficientStock = register("INSUFFICIENT_STOCK", http.StatusConflict, "not enough stock", nil)
+	// a checkout found every copy of the book lent out or sold
+	ErrNoCopiesAvailable = register("NO_COPIES_AVAILABLE", http.StatusConflict, "no copies of the book are available", ErrInsufficientStock)
+
+	// an item of an atomic bulk request that was fine on its own but got
+	// rolled back with the rest
+	ErrBulkRolledBack = register("ROLLED_BACK", http.StatusConflict, "not applied, another item of the request failed", nil)
 )
 
-func GetErrorHTTPStatusCode(err error) int {
-	switch {
-	case errors.Is(err, ErrEmptyTable):
-		return 200
-	case errors.Is(err, ErrInternalServerError):
-		return 500
-	case errors.Is(err, ErrBadRequest), errors.Is(err, ErrInvalidParam):
-		return 400
-	case errors.Is(err, ErrNotFound):
-		return 404
-	case errors.Is(err, ErrResourceAlreadyExist):
-		return 409
-	case errors.Is(err, ErrValidationError):
-		return 422
-	default:
-		return 500
+// DomainErrorFor finds the DomainError in err's chain, anything else is
+// reported as an internal error with the original kept as the cause
+func DomainErrorFor(err error) *DomainError {
+	var de *DomainError
+	if errors.As(err, &de) {
+		return de
+	}
+	return ErrInternalServerError.Wrap(err)
+}
+
+func LookupDomainError(code string) (*DomainError, bool) {
+	e, ok := registry[code]
+	return e, ok
+}
+
+// DomainErrors lists everything registered ordered by code, for docs and
+// tests that want to check every code is handled
+func DomainErrors() []*DomainError {
+	all := make([]*DomainError, 0, len(registry))
+	for _, e := range registry {
+		all = append(all, e)
 	}
+	slices.SortFunc(all, func(a, b *DomainError) int { return strings.Compare(a.Code, b.Code) })
+	return all
+}
+
+// ValidationError says what is wrong with each field, keyed by the name the
+// client sent it under (json or query), it matches ErrValidationError
+type ValidationError struct {
+	Message string
+	Errors  map[string]string
 }
 
-// for generic/human-readable message
-func GetErrorHTTPStatusMessage(err error) string {
-	switch {
-	case errors.Is(err, ErrInternalServerError):
-		return InternalServerError
-	case errors.Is(err, ErrBadRequest):
-		return BadRequest
-	case errors.Is(err, ErrNotFound):
-		return NotFound
-	case errors.Is(err, ErrEmptyTable):
-		return EmptyTable
-	case errors.Is(err, ErrResourceAlreadyExist):
-		return ResourceAlreadyExist
-	case errors.Is(err, ErrInvalidParam):
-		return InvalidParam
-	case errors.Is(err, ErrValidationError):
-		return ValidationError
-	default:
-		return InternalServerError
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+
+	var b strings.Builder
+	b.WriteString(e.Message)
+	for i, field := range fields {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString(", ")
+		}
+		b.WriteString(field + " " + e.Errors[field])
 	}
+	return b.String()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidationError
 }
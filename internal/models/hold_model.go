@@ -0,0 +1,88 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	// waiting in line for a copy
+	HoldStatusWaiting = "waiting"
+	// a returned copy is set aside for the member until ExpiresAt
+	HoldStatusReady = "ready"
+	// closed, the member checked the book out
+	HoldStatusFulfilled = "fulfilled"
+	// closed by the member
+	HoldStatusCancelled = "cancelled"
+	// closed, the copy wasn't picked up in time
+	HoldStatusExpired = "expired"
+)
+
+// Holds is the place of a member in the queue of a book. The copy of a
+// ready hold is off books.qty, so nobody else can check it out
+type Holds struct {
+	ID        int        `gorm:"primaryKey;autoIncrement;not null"`
+	BookID    int        `gorm:"not null"`
+	MemberID  int        `gorm:"not null"`
+	Status    string     `gorm:"type:varchar(10);not null"`
+	CreatedAt time.Time  `gorm:"type:timestamptz;not null"`
+	ReadyAt   *time.Time `gorm:"type:timestamptz"`
+	ExpiresAt *time.Time `gorm:"type:timestamptz"`
+	UpdatedAt time.Time  `gorm:"autoUpdateTime;type:timestamptz"`
+}
+
+// CreateHoldsRequest is the body of POST /book/:id/holds
+type CreateHoldsRequest struct {
+	BookID   int `param:"id" json:"-" validate:"required,gte=1"`
+	MemberID int `json:"member_id" validate:"required,gte=1"`
+}
+
+type HoldsSummary struct {
+	ID       int    `json:"id"`
+	BookID   int    `json:"book_id"`
+	MemberID int    `json:"member_id"`
+	Status   string `json:"status"`
+	// 1 for the next in line, only set while waiting
+	Position  int        `json:"position,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ReadyAt   *time.Time `json:"ready_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Open tells whether the hold still has a place in the queue
+func (h Holds) Open() bool {
+	return h.Status == HoldStatusWaiting || h.Status == HoldStatusReady
+}
+
+// Expired tells whether the hold was ready but not picked up before now
+func (h Holds) Expired(now time.Time) bool {
+	return h.Status == HoldStatusReady && h.ExpiresAt != nil && now.After(*h.ExpiresAt)
+}
+
+// StatusAt is where the hold stands at now, a ready hold past its expiry is
+// only closed once the queue of the book moves again
+func (h Holds) StatusAt(now time.Time) string {
+	if h.Expired(now) {
+		return HoldStatusExpired
+	}
+	return h.Status
+}
+
+func (h Holds) ToHoldsSummary(position int, now time.Time) *HoldsSummary {
+	return &HoldsSummary{
+		ID:        h.ID,
+		BookID:    h.BookID,
+		MemberID:  h.MemberID,
+		Status:    h.StatusAt(now),
+		Position:  position,
+		CreatedAt: h.CreatedAt,
+		ReadyAt:   h.ReadyAt,
+		ExpiresAt: h.ExpiresAt,
+	}
+}
+
+// StockReason is what the stock ledger says about the copy of a hold going
+// back on the shelf
+func (h Holds) StockReason() string {
+	return "hold " + strconv.Itoa(h.ID)
+}
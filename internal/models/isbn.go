@@ -0,0 +1,83 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ISBN13Prefix is the EAN prefix every ISBN-10 maps to
+const ISBN13Prefix = "978"
+
+var isbnSeparators = strings.NewReplacer("-", "", " ", "")
+
+// NormalizeISBN strips hyphens and spaces, checks the check digit and turns
+// an ISBN-10 into the ISBN-13 it is known by, which is how ISBNs are stored
+// and looked up
+func NormalizeISBN(raw string) (string, error) {
+	s := strings.ToUpper(isbnSeparators.Replace(raw))
+
+	switch len(s) {
+	case 10:
+		if !validISBN10(s) {
+			return "", fmt.Errorf("%q is not a valid ISBN-10", raw)
+		}
+		s = ISBN13Prefix + s[:9]
+		return s + isbn13CheckDigit(s), nil
+	case 13:
+		if !validISBN13(s) {
+			return "", fmt.Errorf("%q is not a valid ISBN-13", raw)
+		}
+		return s, nil
+	default:
+		return "", fmt.Errorf("%q is neither an ISBN-10 nor an ISBN-13", raw)
+	}
+}
+
+// validISBN10 weighs the digits 10 down to 1, X stands for 10 and only as
+// the check digit
+func validISBN10(s string) bool {
+	sum := 0
+	for i, r := range s {
+		var d int
+		switch {
+		case r >= '0' && r <= '9':
+			d = int(r - '0')
+		case r == 'X' && i == 9:
+			d = 10
+		default:
+			return false
+		}
+		sum += d * (10 - i)
+	}
+	return sum%11 == 0
+}
+
+// validISBN13 weighs the digits alternately 1 and 3
+func validISBN13(s string) bool {
+	if !isDigits(s) {
+		return false
+	}
+	return isbn13CheckDigit(s[:12]) == s[12:]
+}
+
+// isbn13CheckDigit is the digit that makes the first 12 add up
+func isbn13CheckDigit(s string) string {
+	sum := 0
+	for i, r := range s[:12] {
+		d := int(r - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return string(rune('0' + (10-sum%10)%10))
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	LoanStatusActive   = "active"
+	LoanStatusOverdue  = "overdue"
+	LoanStatusReturned = "returned"
+)
+
+// Loans is one copy of a book lent to a member, ReturnedAt stays nil while
+// the member has it
+type Loans struct {
+	ID         int        `gorm:"primaryKey;autoIncrement;not null"`
+	BookID     int        `gorm:"not null"`
+	MemberID   int        `gorm:"not null"`
+	LoanedAt   time.Time  `gorm:"type:timestamptz;not null"`
+	DueAt      time.Time  `gorm:"type:timestamptz;not null"`
+	ReturnedAt *time.Time `gorm:"type:timestamptz"`
+	Renewals   int        `gorm:"not null"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime;type:timestamptz"`
+}
+
+// LoanPolicy is how long a loan runs and how often it can be extended, each
+// renewal adds another Period to the due date
+type LoanPolicy struct {
+	Period      time.Duration
+	MaxRenewals int
+}
+
+// CreateLoansRequest is the body of POST /loans
+type CreateLoansRequest struct {
+	BookID   int `json:"book_id" validate:"required,gte=1"`
+	MemberID int `json:"member_id" validate:"required,gte=1"`
+}
+
+type GetMemberLoansRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=active overdue returned"`
+	Limit  int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Offset int    `query:"offset" validate:"gte=0"`
+}
+
+// LoansFilter narrows the loans of a member, At is the time overdue is
+// judged at
+type LoansFilter struct {
+	Status string
+	At     time.Time
+}
+
+type LoansSummary struct {
+	ID         int        `json:"id"`
+	BookID     int        `json:"book_id"`
+	MemberID   int        `json:"member_id"`
+	Status     string     `json:"status"`
+	LoanedAt   time.Time  `json:"loaned_at"`
+	DueAt      time.Time  `json:"due_at"`
+	ReturnedAt *time.Time `json:"returned_at,omitempty"`
+	Renewals   int        `json:"renewals"`
+}
+
+// Status is where the loan stands at now
+func (l Loans) Status(now time.Time) string {
+	switch {
+	case l.ReturnedAt != nil:
+		return LoanStatusReturned
+	case now.After(l.DueAt):
+		return LoanStatusOverdue
+	default:
+		return LoanStatusActive
+	}
+}
+
+func (l Loans) ToLoansSummary(now time.Time) *LoansSummary {
+	return &LoansSummary{
+		ID:         l.ID,
+		BookID:     l.BookID,
+		MemberID:   l.MemberID,
+		Status:     l.Status(now),
+		LoanedAt:   l.LoanedAt,
+		DueAt:      l.DueAt,
+		ReturnedAt: l.ReturnedAt,
+		Renewals:   l.Renewals,
+	}
+}
+
+// StockReason is what the stock ledger says about the copies a loan moves
+func (l Loans) StockReason() string {
+	return "loan " + strconv.Itoa(l.ID)
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+type Members struct {
+	ID        int       `gorm:"primaryKey;autoIncrement;not null"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	Email     string    `gorm:"type:varchar(255);not null"` // lower case, see NormalizeEmail
+	CreatedAt time.Time `gorm:"autoCreateTime;type:timestamptz;not null"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime;type:timestamptz"`
+}
+
+type CreateMembersRequest struct {
+	Name  string `json:"name" validate:"required,no_leading_space,min=2,max=100"`
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+type UpdateMembersRequest struct {
+	ID    int    `param:"id" json:"-" validate:"required,gte=1"`
+	Name  string `json:"name" validate:"required,no_leading_space,min=2,max=100"`
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+type GetAllMembersRequest struct {
+	Name   string `query:"name" validate:"omitempty,max=100"`
+	Limit  int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Offset int    `query:"offset" validate:"gte=0"`
+}
+
+type MembersSummary struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func (m Members) ToMembersSummary() *MembersSummary {
+	return &MembersSummary{
+		ID:    m.ID,
+		Name:  m.Name,
+		Email: m.Email,
+	}
+}
+
+// NormalizeEmail is how emails are stored and compared for uniqueness
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
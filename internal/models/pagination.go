@@ -0,0 +1,77 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+
+	CursorDirectionNext = "next"
+	CursorDirectionPrev = "prev"
+)
+
+// Cursor is the keyset position of a row, handed to clients as an opaque token
+type Cursor struct {
+	ID        int       `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Direction string    `json:"dir"`
+}
+
+type PageRequest struct {
+	Limit  int
+	Offset int
+	Cursor *Cursor // nil means offset pagination
+	Sort   []SortField
+}
+
+type PageResult struct {
+	Total   int64
+	HasMore bool // more rows exist in the direction of travel
+}
+
+type PageMeta struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	Total      int64  `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset,omitempty"`
+}
+
+func (c Cursor) Encode() string {
+	// marshalling a struct of plain fields can't fail
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (c Cursor) IsBackward() bool {
+	return c.Direction == CursorDirectionPrev
+}
+
+// cursors are keyed on (created_at, id), so they only make sense in the default order
+func (p PageRequest) SupportsCursor() bool {
+	return len(p.Sort) == 0
+}
+
+func DecodeCursor(token string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidParam
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, ErrInvalidParam
+	}
+	if c.ID < 1 || c.CreatedAt.IsZero() {
+		return nil, ErrInvalidParam
+	}
+	if c.Direction != CursorDirectionNext && c.Direction != CursorDirectionPrev {
+		return nil, ErrInvalidParam
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,30 @@
+package models
+
+import "strings"
+
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// ProblemTypeBase prefixes the type member of problem+json responses, the
+// slug after it is the DomainError code
+const ProblemTypeBase = "/problems/"
+
+// Problem is an RFC 7807 problem detail, Code, Errors, Results, Summary and
+// RequestID are extension members. Results and Summary come with a rolled
+// back atomic bulk request
+type Problem struct {
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Status    int               `json:"status"`
+	Detail    string            `json:"detail,omitempty"`
+	Instance  string            `json:"instance,omitempty"`
+	Code      string            `json:"code,omitempty"`
+	Errors    map[string]string `json:"errors,omitempty"`
+	Results   []BulkResult      `json:"results,omitempty"`
+	Summary   *BulkSummary      `json:"summary,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+}
+
+// ProblemType turns BOOK_NOT_FOUND into /problems/book-not-found
+func ProblemType(code string) string {
+	return ProblemTypeBase + strings.ReplaceAll(strings.ToLower(code), "_", "-")
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+const (
+	StockReceive = "receive"
+	StockSell    = "sell"
+	StockLend    = "lend"
+	StockReturn  = "return"
+	StockAdjust  = "adjust"
+)
+
+// StockMovement is one line of a book's stock ledger. Quantity is signed,
+// Balance is the book's qty right after the movement
+type StockMovement struct {
+	ID        int       `gorm:"primaryKey;autoIncrement;not null" json:"id"`
+	BookID    int       `gorm:"not null;index" json:"book_id"`
+	Kind      string    `gorm:"type:varchar(16);not null" json:"kind"`
+	Quantity  int       `gorm:"not null" json:"quantity"`
+	Balance   int       `gorm:"not null" json:"balance"`
+	Reason    string    `gorm:"type:varchar(255);not null" json:"reason,omitempty"`
+	Actor     string    `gorm:"type:varchar(100);not null" json:"actor"`
+	CreatedAt time.Time `gorm:"autoCreateTime;type:timestamptz;not null" json:"created_at"`
+}
+
+// IncreaseStockRequest is the body of POST /book/:id/stock/increase, Kind
+// defaults to receive
+type IncreaseStockRequest struct {
+	Kind     string `json:"kind" validate:"omitempty,oneof=receive return adjust"`
+	Quantity int    `json:"quantity" validate:"required,gte=1"`
+	Reason   string `json:"reason" validate:"max=255"`
+}
+
+// DecreaseStockRequest is the body of POST /book/:id/stock/decrease, Kind
+// defaults to sell
+type DecreaseStockRequest struct {
+	Kind     string `json:"kind" validate:"omitempty,oneof=sell lend adjust"`
+	Quantity int    `json:"quantity" validate:"required,gte=1"`
+	Reason   string `json:"reason" validate:"max=255"`
+}
+
+type GetStockHistoryRequest struct {
+	Limit  int `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Offset int `query:"offset" validate:"gte=0"`
+}
+
+// StockChange is a movement about to happen, Quantity is signed
+type StockChange struct {
+	Kind     string
+	Quantity int
+	Reason   string
+}
+
+// StockMoveResult is the book as the movement left it, along with the movement
+type StockMoveResult struct {
+	Book     *BooksSummary  `json:"book"`
+	Movement *StockMovement `json:"movement"`
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxTagLen is how long a tag name can be, in characters
+const MaxTagLen = 50
+
+// Tags are free-form labels, names are stored lower case and are unique
+type Tags struct {
+	ID        int       `gorm:"primaryKey;autoIncrement;not null"`
+	Name      string    `gorm:"type:varchar(50);not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime;type:timestamptz;not null"`
+}
+
+// BookTags is a row of the book_tags join table
+type BookTags struct {
+	BookID int `gorm:"primaryKey"`
+	TagID  int `gorm:"primaryKey"`
+}
+
+func (BookTags) TableName() string {
+	return "book_tags"
+}
+
+// commas separate tags in ?tags=, so they can't be part of a name
+type CreateTagsRequest struct {
+	Name string `json:"name" validate:"required,no_leading_space,max=50,excludesall=0x2C"`
+}
+
+type GetAllTagsRequest struct {
+	Name   string `query:"name" validate:"omitempty,max=50"`
+	Limit  int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Offset int    `query:"offset" validate:"gte=0"`
+}
+
+// SetBookTagsRequest names the tags of a book, tags that don't exist yet
+// are created
+type SetBookTagsRequest struct {
+	BookID int      `param:"id" json:"-" validate:"required,gte=1"`
+	Tags   []string `json:"tags" validate:"max=20,dive,required,no_leading_space,max=50,excludesall=0x2C"`
+}
+
+type TagsSummary struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func (t Tags) ToTagsSummary() *TagsSummary {
+	return &TagsSummary{ID: t.ID, Name: t.Name}
+}
+
+// NormalizeTag is the form tag names are stored and looked up in
+func NormalizeTag(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NormalizeTags normalizes names and drops repeats, keeping the first
+// occurrence order
+func NormalizeTags(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeTag(name)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		tags = append(tags, name)
+	}
+	return tags
+}
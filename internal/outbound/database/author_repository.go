@@ -0,0 +1,124 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type AuthorsRepository struct {
+	rdc RepositoryDBConn
+}
+
+func NewAuthorsRepository(repoDBConn RepositoryDBConn) *AuthorsRepository {
+	return &AuthorsRepository{rdc: repoDBConn}
+}
+
+func (r *AuthorsRepository) Create(ctx context.Context, author *models.Authors) error {
+	result := conn(ctx, r.rdc).Create(author)
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if author.ID == 0 {
+		return models.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (r *AuthorsRepository) GetByID(ctx context.Context, author *models.Authors, id int) error {
+	result := conn(ctx, r.rdc).First(author, id)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return models.ErrAuthorNotFound
+		}
+		return translateError(result.Error)
+	}
+
+	return nil
+}
+
+// GetPage lists authors by name, name filters on a case-insensitive contains
+func (r *AuthorsRepository) GetPage(ctx context.Context, authors *[]models.Authors, name string, page *models.PageRequest) (*models.PageResult, error) {
+	scope := func(db *gorm.DB) *gorm.DB {
+		if name != "" {
+			db = db.Where("name ILIKE ?", "%"+escapeLike(name)+"%")
+		}
+		return db
+	}
+
+	var total int64
+	if err := conn(ctx, r.rdc).Model(&models.Authors{}).Scopes(scope).Count(&total).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	result := conn(ctx, r.rdc).Scopes(scope).
+		Order("name ASC, id ASC").
+		Offset(page.Offset).
+		Limit(page.Limit).
+		Find(authors)
+	if result.Error != nil {
+		return nil, translateError(result.Error)
+	}
+
+	return &models.PageResult{
+		Total:   total,
+		HasMore: int64(page.Offset+len(*authors)) < total,
+	}, nil
+}
+
+func (r *AuthorsRepository) Update(ctx context.Context, author *models.Authors) error {
+	result := conn(ctx, r.rdc).Model(author).Select("name", "bio").Updates(author)
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if result.RowsAffected < 1 {
+		return models.ErrAuthorNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the author for good, the books it wrote stay and just lose
+// the link
+func (r *AuthorsRepository) Delete(ctx context.Context, id int) error {
+	result := conn(ctx, r.rdc).Delete(&models.Authors{ID: id})
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if result.RowsAffected < 1 {
+		return models.ErrAuthorNotFound
+	}
+
+	return nil
+}
+
+// GetBooks lists the books of an author outside the trash, by title
+func (r *AuthorsRepository) GetBooks(ctx context.Context, books *[]models.Books, authorID int, page *models.PageRequest) (*models.PageResult, error) {
+	scope := func(db *gorm.DB) *gorm.DB {
+		return db.Joins("JOIN book_authors ON book_authors.book_id = books.id").
+			Where("book_authors.author_id = ?", authorID)
+	}
+
+	var total int64
+	if err := conn(ctx, r.rdc).Model(&models.Books{}).Scopes(scope).Count(&total).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	result := conn(ctx, r.rdc).Scopes(scope).
+		Order("books.title ASC, books.id ASC").
+		Offset(page.Offset).
+		Limit(page.Limit).
+		Find(books)
+	if result.Error != nil {
+		return nil, translateError(result.Error)
+	}
+
+	return &models.PageResult{
+		Total:   total,
+		HasMore: int64(page.Offset+len(*books)) < total,
+	}, nil
+}
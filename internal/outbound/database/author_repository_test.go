@@ -0,0 +1,398 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateAuthor(t *testing.T) {
+	tests := []struct {
+		name       string
+		author     *models.Authors
+		expectedID int
+		mock       func(mock sqlmock.Sqlmock)
+		wantErr    bool
+		errType    error
+	}{
+		{
+			name:       "Success create author",
+			author:     &models.Authors{Name: "Frank Herbert", Bio: "Wrote Dune"},
+			expectedID: 1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "authors" \("name","bio","created_at","updated_at"\) VALUES \(\$1,\$2,\$3,\$4\) RETURNING "id"`).
+					WithArgs("Frank Herbert", "Wrote Dune", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Database error during create author",
+			author: &models.Authors{Name: "Frank Herbert"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "authors" (.+) VALUES (.+)`).
+					WillReturnError(gorm.ErrInvalidDB)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewAuthorsRepository(gdb)
+
+			err := repo.Create(context.Background(), tt.author)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedID, tt.author.ID)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetAuthorByID(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		id             int
+		mock           func(mock sqlmock.Sqlmock)
+		expectedAuthor models.Authors
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name: "Success get author",
+			id:   1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "authors" WHERE "authors"."id" = \$1 ORDER BY "authors"."id" LIMIT \$2`).
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "bio", "created_at", "updated_at"}).
+						AddRow(1, "Frank Herbert", "Wrote Dune", now, now))
+			},
+			expectedAuthor: models.Authors{ID: 1, Name: "Frank Herbert", Bio: "Wrote Dune", CreatedAt: now, UpdatedAt: now},
+		},
+		{
+			name: "Author not found",
+			id:   99,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "authors" WHERE "authors"."id" = \$1`).
+					WithArgs(99, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantErr: true,
+			errType: models.ErrAuthorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewAuthorsRepository(gdb)
+
+			var author models.Authors
+			err := repo.GetByID(context.Background(), &author, tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAuthor, author)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetAuthorsPage(t *testing.T) {
+	tests := []struct {
+		name           string
+		filter         string
+		page           *models.PageRequest
+		mock           func(mock sqlmock.Sqlmock)
+		expectedResult *models.PageResult
+		expectedLen    int
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name: "Success list authors",
+			page: &models.PageRequest{Limit: 1},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "authors"$`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectQuery(`SELECT \* FROM "authors" ORDER BY name ASC, id ASC LIMIT \$1`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Frank Herbert"))
+			},
+			expectedResult: &models.PageResult{Total: 2, HasMore: true},
+			expectedLen:    1,
+		},
+		{
+			name:   "Success list authors by name",
+			filter: "50%",
+			page:   &models.PageRequest{Limit: 10},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "authors" WHERE name ILIKE \$1`).
+					WithArgs(`%50\%%`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery(`SELECT \* FROM "authors" WHERE name ILIKE \$1 ORDER BY name ASC, id ASC LIMIT \$2`).
+					WithArgs(`%50\%%`, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+			},
+			expectedResult: &models.PageResult{Total: 0},
+		},
+		{
+			name: "Database error during list authors",
+			page: &models.PageRequest{Limit: 10},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "authors"`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewAuthorsRepository(gdb)
+
+			var authors []models.Authors
+			result, err := repo.GetPage(context.Background(), &authors, tt.filter, tt.page)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+				assert.Len(t, authors, tt.expectedLen)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestUpdateAuthor(t *testing.T) {
+	tests := []struct {
+		name    string
+		author  *models.Authors
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errType error
+	}{
+		{
+			name:   "Success update author, bio can be cleared",
+			author: &models.Authors{ID: 1, Name: "Frank Herbert"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "authors" SET "name"=\$1,"bio"=\$2,"updated_at"=\$3 WHERE "id" = \$4`).
+					WithArgs("Frank Herbert", "", sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Author not found during update",
+			author: &models.Authors{ID: 99, Name: "Frank Herbert"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "authors"`).
+					WithArgs("Frank Herbert", "", sqlmock.AnyArg(), 99).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			wantErr: true,
+			errType: models.ErrAuthorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewAuthorsRepository(gdb)
+
+			err := repo.Update(context.Background(), tt.author)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDeleteAuthor(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "Success delete author",
+			id:   1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "authors" WHERE "authors"."id" = \$1`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Author not found during delete",
+			id:   99,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "authors" WHERE "authors"."id" = \$1`).
+					WithArgs(99).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			wantErr: true,
+			errType: models.ErrAuthorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewAuthorsRepository(gdb)
+
+			err := repo.Delete(context.Background(), tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetAuthorBooks(t *testing.T) {
+	tests := []struct {
+		name           string
+		authorID       int
+		page           *models.PageRequest
+		mock           func(mock sqlmock.Sqlmock)
+		expectedResult *models.PageResult
+		expectedLen    int
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name:     "Success list books of an author",
+			authorID: 7,
+			page:     &models.PageRequest{Limit: 10},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" JOIN book_authors ON book_authors.book_id = books.id WHERE book_authors.author_id = \$1 AND "books"."deleted_at" IS NULL`).
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectQuery(`SELECT "books"."id",(.+) FROM "books" JOIN book_authors ON book_authors.book_id = books.id WHERE book_authors.author_id = \$1 AND "books"."deleted_at" IS NULL ORDER BY books.title ASC, books.id ASC LIMIT \$2`).
+					WithArgs(7, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "Children of Dune").AddRow(2, "Dune"))
+			},
+			expectedResult: &models.PageResult{Total: 2},
+			expectedLen:    2,
+		},
+		{
+			name:     "Database error during list books of an author",
+			authorID: 7,
+			page:     &models.PageRequest{Limit: 10},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewAuthorsRepository(gdb)
+
+			var books []models.Books
+			result, err := repo.GetBooks(context.Background(), &books, tt.authorID, tt.page)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+				assert.Len(t, books, tt.expectedLen)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
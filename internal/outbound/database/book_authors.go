@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+
+	"gorm.io/gorm/clause"
+)
+
+// ReplaceAuthors makes authorIDs the authors of the book, links already in
+// place are left alone. Run it in a unit of work along with the book update
+func (r *BooksRepository) ReplaceAuthors(ctx context.Context, bookID int, authorIDs []int) error {
+	db := conn(ctx, r.rdc)
+
+	stale := db.Where("book_id = ?", bookID)
+	if len(authorIDs) > 0 {
+		stale = stale.Where("author_id NOT IN ?", authorIDs)
+	}
+	if err := stale.Delete(&models.BookAuthors{}).Error; err != nil {
+		return translateError(err)
+	}
+
+	if len(authorIDs) == 0 {
+		return nil
+	}
+
+	links := make([]models.BookAuthors, len(authorIDs))
+	for i, id := range authorIDs {
+		links[i] = models.BookAuthors{BookID: bookID, AuthorID: id}
+	}
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&links)
+
+	return translateError(result.Error)
+}
+
+// bookAuthor is an author along with the book it was found through
+type bookAuthor struct {
+	BookID         int
+	models.Authors `gorm:"embedded"`
+}
+
+// LoadAuthors fills in the authors of every book with a single query, so a
+// page of books costs two queries and not one per book. Books without
+// authors get an empty list
+func (r *BooksRepository) LoadAuthors(ctx context.Context, books []models.Books) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(books))
+	for i, book := range books {
+		ids[i] = book.ID
+	}
+
+	var rows []bookAuthor
+	result := conn(ctx, r.rdc).Table("authors").
+		Select("authors.*, book_authors.book_id").
+		Joins("JOIN book_authors ON book_authors.author_id = authors.id").
+		Where("book_authors.book_id IN ?", ids).
+		Order("authors.name ASC, authors.id ASC").
+		Scan(&rows)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+
+	byBook := make(map[int][]models.Authors, len(books))
+	for _, row := range rows {
+		byBook[row.BookID] = append(byBook[row.BookID], row.Authors)
+	}
+	for i := range books {
+		books[i].Authors = byBook[books[i].ID]
+		if books[i].Authors == nil {
+			books[i].Authors = []models.Authors{}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// what Postgres reports when a book is linked to an author that doesn't exist
+var errUnknownAuthor = &pgconn.PgError{Code: pgForeignKeyViolation, ConstraintName: fkBookAuthorsAuthorID}
+
+func TestCreateWithAuthors(t *testing.T) {
+	tests := []struct {
+		name    string
+		book    *models.Books
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "Success create book linked to authors",
+			book: &models.Books{Title: "Test Title", Description: "Test Description", Qty: 10, Authors: models.AuthorRefs([]int{3, 4})},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "books" (.+) VALUES (.+)`).
+					WithArgs("Test Title", "Test Description", 10, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "", "", nil, "", nil, "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec(`INSERT INTO "book_authors" \("book_id","author_id"\) VALUES \(\$1,\$2\),\(\$3,\$4\) ON CONFLICT DO NOTHING`).
+					WithArgs(1, 3, 1, 4).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Unknown author during create",
+			book: &models.Books{Title: "Test Title", Description: "Test Description", Qty: 10, Authors: models.AuthorRefs([]int{99})},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "books" (.+) VALUES (.+)`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec(`INSERT INTO "book_authors"`).
+					WithArgs(1, 99).
+					WillReturnError(errUnknownAuthor)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrUnknownAuthor.Wrap(errUnknownAuthor),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			err := repo.Create(context.Background(), tt.book)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestReplaceAuthors(t *testing.T) {
+	tests := []struct {
+		name      string
+		bookID    int
+		authorIDs []int
+		mock      func(mock sqlmock.Sqlmock)
+		wantErr   bool
+		errType   error
+	}{
+		{
+			name:      "Success replace authors",
+			bookID:    1,
+			authorIDs: []int{3, 4},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "book_authors" WHERE book_id = \$1 AND author_id NOT IN \(\$2,\$3\)`).
+					WithArgs(1, 3, 4).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "book_authors" \("book_id","author_id"\) VALUES \(\$1,\$2\),\(\$3,\$4\) ON CONFLICT DO NOTHING`).
+					WithArgs(1, 3, 1, 4).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:      "Success remove every author",
+			bookID:    1,
+			authorIDs: []int{},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "book_authors" WHERE book_id = \$1$`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:      "Unknown author during replace",
+			bookID:    1,
+			authorIDs: []int{99},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "book_authors"`).
+					WithArgs(1, 99).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "book_authors"`).
+					WithArgs(1, 99).
+					WillReturnError(errUnknownAuthor)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrUnknownAuthor.Wrap(errUnknownAuthor),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			err := repo.ReplaceAuthors(context.Background(), tt.bookID, tt.authorIDs)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestLoadAuthors(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name            string
+		books           []models.Books
+		mock            func(mock sqlmock.Sqlmock)
+		expectedAuthors [][]models.Authors
+		wantErr         bool
+		errType         error
+	}{
+		{
+			name:  "Success load authors in one query",
+			books: []models.Books{{ID: 1}, {ID: 2}, {ID: 3}},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT authors.\*, book_authors.book_id FROM "authors" JOIN book_authors ON book_authors.author_id = authors.id WHERE book_authors.book_id IN \(\$1,\$2,\$3\) ORDER BY authors.name ASC, authors.id ASC`).
+					WithArgs(1, 2, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "bio", "created_at", "updated_at", "book_id"}).
+						AddRow(7, "Frank Herbert", "", now, now, 1).
+						AddRow(8, "Kevin J. Anderson", "", now, now, 1).
+						AddRow(7, "Frank Herbert", "", now, now, 3))
+			},
+			expectedAuthors: [][]models.Authors{
+				{{ID: 7, Name: "Frank Herbert", CreatedAt: now, UpdatedAt: now}, {ID: 8, Name: "Kevin J. Anderson", CreatedAt: now, UpdatedAt: now}},
+				{},
+				{{ID: 7, Name: "Frank Herbert", CreatedAt: now, UpdatedAt: now}},
+			},
+		},
+		{
+			name:  "Nothing to load",
+			mock:  func(mock sqlmock.Sqlmock) {},
+			books: []models.Books{},
+		},
+		{
+			name:  "Database error during load authors",
+			books: []models.Books{{ID: 1}},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT authors.\*, book_authors.book_id FROM "authors"`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			err := repo.LoadAuthors(context.Background(), tt.books)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				for i, book := range tt.books {
+					assert.Equal(t, tt.expectedAuthors[i], book.Authors)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
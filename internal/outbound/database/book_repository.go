@@ -1,16 +1,32 @@
 package database
 
 import (
+	"context"
 	"crud-echo/internal/models"
+	"errors"
+	"slices"
+	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type RepositoryDBConn interface {
 	Migrate() error
 	GetDB() *gorm.DB
+	Close() error
 }
 
+// updateColumns are what Update writes, updated_at is set on top of them
+var updateColumns = []string{
+	"title", "description", "qty", "version",
+	"isbn", "publisher", "publication_year", "language", "page_count", "edition",
+}
+
+// omitAuthors keeps GORM from upserting the authors of a book it writes,
+// the book_authors rows still go in
+const omitAuthors = "Authors.*"
+
 type BooksRepository struct {
 	rdc RepositoryDBConn
 }
@@ -19,11 +35,13 @@ func NewBooksRepository(repoDBConn RepositoryDBConn) *BooksRepository {
 	return &BooksRepository{rdc: repoDBConn}
 }
 
-func (r *BooksRepository) Create(book *models.Books) error {
-	result := r.rdc.GetDB().Create(&book)
+// Create links the book to the authors in book.Authors, only their IDs are
+// looked at. An unknown author fails the insert with ErrUnknownAuthor
+func (r *BooksRepository) Create(ctx context.Context, book *models.Books) error {
+	result := conn(ctx, r.rdc).Omit(omitAuthors).Create(&book)
 
 	if result.Error != nil {
-		return result.Error
+		return translateError(result.Error)
 	} else if book.ID == 0 {
 		return models.ErrInternalServerError
 	}
@@ -31,66 +49,393 @@ func (r *BooksRepository) Create(book *models.Books) error {
 	return nil
 }
 
-func (r *BooksRepository) GetByID(book *models.Books, id int) error {
-	result := r.rdc.GetDB().First(&book, id)
+// CreateInBatches inserts batchSize rows per statement, the IDs are set on
+// books. Run it inside a unit of work when all batches have to go in together
+func (r *BooksRepository) CreateInBatches(ctx context.Context, books []models.Books, batchSize int) error {
+	result := conn(ctx, r.rdc).Session(&gorm.Session{SkipDefaultTransaction: true}).Omit(omitAuthors).CreateInBatches(books, batchSize)
+
+	return translateError(result.Error)
+}
+
+func (r *BooksRepository) GetByID(ctx context.Context, book *models.Books, id int) error {
+	result := conn(ctx, r.rdc).First(&book, id)
 
 	if result.Error != nil {
-		if result.Error == gorm.ErrRecordNotFound {
-			return models.ErrNotFound
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return models.ErrBookNotFound
 		}
-		return result.Error
+		return translateError(result.Error)
 	}
 
 	return nil
 }
 
-func (r *BooksRepository) GetAll(books *[]models.Books) error {
-	result := r.rdc.GetDB().Find(&books)
+// GetByISBN finds the live book with isbn, which has to be normalized already
+func (r *BooksRepository) GetByISBN(ctx context.Context, book *models.Books, isbn string) error {
+	result := conn(ctx, r.rdc).Where("isbn = ?", isbn).First(&book)
 
 	if result.Error != nil {
-		return result.Error
-	} else if result.RowsAffected < 1 { // maybe only need to do one check
-		return models.ErrEmptyTable
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return models.ErrBookNotFound
+		}
+		return translateError(result.Error)
 	}
 
 	return nil
 }
 
-func (r *BooksRepository) Update(book *models.Books) error {
-	result := r.rdc.GetDB().Model(&book).Updates(models.Books{
+// GetPage fetches one page of books matching the filter, an empty table is
+// an empty page. Without an explicit sort the page is ordered by
+// (created_at, id) and a keyset cursor can be used, otherwise it falls back
+// to limit/offset
+func (r *BooksRepository) GetPage(ctx context.Context, books *[]models.Books, filter *models.BooksFilter, page *models.PageRequest) (*models.PageResult, error) {
+	var total int64
+	if err := conn(ctx, r.rdc).Model(&models.Books{}).Scopes(booksFilterScope(filter)).Count(&total).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	// one extra row tells us whether there is anything past this page
+	query := conn(ctx, r.rdc).Scopes(booksFilterScope(filter)).Limit(page.Limit + 1)
+	switch {
+	case len(page.Sort) > 0:
+		query = query.Scopes(booksSortScope(page.Sort)).Offset(page.Offset)
+	case page.Cursor == nil:
+		query = query.Order("created_at ASC, id ASC").Offset(page.Offset)
+	case page.Cursor.IsBackward():
+		query = query.Where("(created_at, id) < (?, ?)", page.Cursor.CreatedAt, page.Cursor.ID).
+			Order("created_at DESC, id DESC")
+	default:
+		query = query.Where("(created_at, id) > (?, ?)", page.Cursor.CreatedAt, page.Cursor.ID).
+			Order("created_at ASC, id ASC")
+	}
+
+	if err := query.Find(books).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	result := &models.PageResult{
+		Total:   total,
+		HasMore: len(*books) > page.Limit,
+	}
+	if result.HasMore {
+		*books = (*books)[:page.Limit]
+	}
+	if page.Cursor != nil && page.Cursor.IsBackward() {
+		slices.Reverse(*books)
+	}
+
+	return result, nil
+}
+
+// Update only goes through while the row is still at the version the caller
+// read, bumping it so anyone else holding the old version loses. The
+// bibliographic fields are written even when empty, that's how they get cleared
+func (r *BooksRepository) Update(ctx context.Context, book *models.Books) error {
+	result := conn(ctx, r.rdc).Model(book).Where("version = ?", book.Version).Select(updateColumns).Updates(models.Books{
 		Title:       book.Title,
 		Description: book.Description,
 		Qty:         book.Qty,
+		Version:     book.Version + 1,
+
+		BooksBibliography: book.BooksBibliography,
 	})
 
 	if result.Error != nil {
-		return result.Error
+		return translateError(result.Error)
 	} else if result.RowsAffected < 1 {
-		return models.ErrNotFound
+		return r.notFoundOrModified(ctx, book.ID)
 	}
 
 	return nil
 }
 
-func (r *BooksRepository) Delete(book *models.Books) error {
-	result := r.rdc.GetDB().Delete(&book)
+// Patch updates only the columns set in the patch, including zero values
+// that Update would skip, under the same version check as Update
+func (r *BooksRepository) Patch(ctx context.Context, id int, patch *models.BooksPatch) error {
+	columns := patch.Columns()
+	columns["version"] = gorm.Expr("version + 1")
+
+	result := conn(ctx, r.rdc).Model(&models.Books{ID: id}).Where("version = ?", patch.Version).Updates(columns)
 
 	if result.Error != nil {
-		return result.Error
+		return translateError(result.Error)
 	} else if result.RowsAffected < 1 {
-		return models.ErrNotFound
+		return r.notFoundOrModified(ctx, id)
 	}
 
 	return nil
 }
 
-func (r *BooksRepository) ExistsByTitle(title string) (bool, error) {
+// Delete moves the book to the trash, the row stays around until it is purged
+func (r *BooksRepository) Delete(ctx context.Context, book *models.Books) error {
+	result := conn(ctx, r.rdc).Where("version = ?", book.Version).Delete(book)
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if result.RowsAffected < 1 {
+		return r.notFoundOrModified(ctx, book.ID)
+	}
+
+	return nil
+}
+
+// notFoundOrModified tells apart the two reasons a versioned write can touch
+// no rows: the book is gone, or someone else changed it first
+func (r *BooksRepository) notFoundOrModified(ctx context.Context, id int) error {
+	var count int64
+	if err := conn(ctx, r.rdc).Model(&models.Books{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return translateError(err)
+	}
+
+	if count > 0 {
+		return models.ErrPreconditionFailed
+	}
+	return models.ErrBookNotFound
+}
+
+// requireBook fails with ErrBookNotFound unless the book is there and out of
+// the trash, for repositories that only touch rows linked to a book
+func requireBook(db *gorm.DB, id int) error {
 	var count int64
-	result := r.rdc.GetDB().Model(&models.Books{}).Where("title = ?", title).Count(&count)
+	if err := db.Model(&models.Books{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return translateError(err)
+	} else if count < 1 {
+		return models.ErrBookNotFound
+	}
+
+	return nil
+}
 
+// GetTrash lists soft deleted books, most recently deleted first
+func (r *BooksRepository) GetTrash(ctx context.Context, books *[]models.Books, page *models.PageRequest) (*models.PageResult, error) {
+	var total int64
+	if err := conn(ctx, r.rdc).Model(&models.Books{}).Scopes(trashedScope).Count(&total).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	result := conn(ctx, r.rdc).Scopes(trashedScope).
+		Order("deleted_at DESC, id DESC").
+		Offset(page.Offset).
+		Limit(page.Limit).
+		Find(books)
 	if result.Error != nil {
-		return false, result.Error
+		return nil, translateError(result.Error)
 	}
 
-	return count > 0, nil
+	return &models.PageResult{
+		Total:   total,
+		HasMore: int64(page.Offset+len(*books)) < total,
+	}, nil
+}
+
+// Restore takes a book out of the trash, bumping the version since any ETag
+// handed out before the delete should not be reused
+func (r *BooksRepository) Restore(ctx context.Context, id int) error {
+	result := conn(ctx, r.rdc).Unscoped().Model(&models.Books{ID: id}).
+		Where("deleted_at IS NOT NULL").
+		Updates(map[string]any{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		})
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if result.RowsAffected < 1 {
+		return models.ErrBookNotFound
+	}
+
+	return nil
+}
+
+// Purge removes the row for good, trashed or not, along with the history of
+// returned loans. Loans still out keep the book, see
+// models.ErrBookHasOpenLoans. Run it in a unit of work
+func (r *BooksRepository) Purge(ctx context.Context, id int) error {
+	db := conn(ctx, r.rdc)
+
+	if err := db.Where("book_id = ? AND returned_at IS NOT NULL", id).Delete(&models.Loans{}).Error; err != nil {
+		return translateError(err)
+	}
+
+	result := db.Unscoped().Delete(&models.Books{ID: id})
+
+	if result.Error != nil {
+		// fkLoansBookID maps to ErrBookNotFound for a loan of a missing
+		// book, here it's the loans left pointing at this one
+		if violates(result.Error, fkLoansBookID) {
+			return models.ErrBookHasOpenLoans.Wrap(result.Error)
+		}
+		return translateError(result.Error)
+	} else if result.RowsAffected < 1 {
+		return models.ErrBookNotFound
+	}
+
+	return nil
+}
+
+func (r *BooksRepository) Stats(ctx context.Context, stats *models.BooksStats) error {
+	err := conn(ctx, r.rdc).Model(&models.Books{}).
+		Select("count(*) AS total, coalesce(sum(qty), 0) AS total_qty").
+		Scan(stats).Error
+	return translateError(err)
+}
+
+// TakenTitles returns which of titles are used by books outside the trash,
+// normalized like models.NormalizeTitle
+func (r *BooksRepository) TakenTitles(ctx context.Context, titles []string) ([]string, error) {
+	normalized := make([]string, len(titles))
+	for i, title := range titles {
+		normalized[i] = models.NormalizeTitle(title)
+	}
+
+	var taken []string
+	result := conn(ctx, r.rdc).Model(&models.Books{}).
+		Where("lower(btrim(title)) IN ?", normalized).
+		Pluck("lower(btrim(title))", &taken)
+	if result.Error != nil {
+		return nil, translateError(result.Error)
+	}
+
+	return taken, nil
+}
+
+// GetByTitles finds the books outside the trash using any of titles,
+// compared like the unique index does
+func (r *BooksRepository) GetByTitles(ctx context.Context, books *[]models.Books, titles []string) error {
+	normalized := make([]string, len(titles))
+	for i, title := range titles {
+		normalized[i] = models.NormalizeTitle(title)
+	}
+
+	result := conn(ctx, r.rdc).Where("lower(btrim(title)) IN ?", normalized).Find(books)
+
+	return translateError(result.Error)
+}
+
+// Each calls fn for every book outside the trash in id order, rows are read
+// off the cursor one at a time so the whole table is never held in memory.
+// An error from fn stops the walk and is returned as is
+func (r *BooksRepository) Each(ctx context.Context, fn func(book *models.Books) error) error {
+	db := conn(ctx, r.rdc)
+
+	rows, err := db.Model(&models.Books{}).Order("id ASC").Rows()
+	if err != nil {
+		return translateError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var book models.Books
+		if err := db.ScanRows(rows, &book); err != nil {
+			return translateError(err)
+		}
+		if err := fn(&book); err != nil {
+			return err
+		}
+	}
+
+	return translateError(rows.Err())
+}
+
+// Search ranks books by how well title and description match the query using
+// the search_vector column, databases without it get plain ILIKE matching
+func (r *BooksRepository) Search(ctx context.Context, results *[]models.BooksSearchResult, query string, limit int) error {
+	db := conn(ctx, r.rdc)
+	if db.Dialector.Name() != "postgres" {
+		pattern := "%" + escapeLike(query) + "%"
+		err := db.Model(&models.Books{}).
+			Select("id, title, description, qty, 0 AS rank, description AS snippet").
+			Where("title ILIKE ? OR description ILIKE ?", pattern, pattern).
+			Order("id ASC").
+			Limit(limit).
+			Scan(results).Error
+		return translateError(err)
+	}
+
+	err := db.Raw(`SELECT id, title, description, qty,
+			ts_rank(search_vector, q) AS rank,
+			ts_headline('english', title || ' - ' || description, q,
+				'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5') AS snippet
+		FROM books, websearch_to_tsquery('english', ?) AS q
+		WHERE search_vector @@ q AND deleted_at IS NULL
+		ORDER BY rank DESC, id ASC
+		LIMIT ?`, query, limit).
+		Scan(results).Error
+	return translateError(err)
+}
+
+// every value goes through a placeholder, so user input never becomes SQL
+func booksFilterScope(filter *models.BooksFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter == nil {
+			return db
+		}
+		if filter.Title != "" {
+			db = db.Where("title ILIKE ?", "%"+escapeLike(filter.Title)+"%")
+		}
+		if filter.QtyMin != nil {
+			db = db.Where("qty >= ?", *filter.QtyMin)
+		}
+		if filter.QtyMax != nil {
+			db = db.Where("qty <= ?", *filter.QtyMax)
+		}
+		if filter.CreatedAfter != nil {
+			db = db.Where("created_at > ?", *filter.CreatedAfter)
+		}
+		if filter.CreatedBefore != nil {
+			db = db.Where("created_at < ?", *filter.CreatedBefore)
+		}
+		if filter.Available != nil {
+			if *filter.Available {
+				db = db.Where("qty > 0")
+			} else {
+				db = db.Where("qty = 0")
+			}
+		}
+		// paths are digits and slashes, nothing in them needs escaping for LIKE
+		if filter.Category != "" {
+			db = db.Where(`EXISTS (SELECT 1 FROM book_categories bc
+				JOIN categories c ON c.id = bc.category_id
+				JOIN categories root ON c.path LIKE root.path || '%'
+				WHERE bc.book_id = books.id AND root.slug = ?)`, filter.Category)
+		}
+		// a book matches when it carries as many of the tags as were asked
+		// for, the names have to be distinct for that to work
+		if len(filter.Tags) > 0 {
+			db = db.Where(`books.id IN (SELECT bt.book_id FROM book_tags bt
+				JOIN tags t ON t.id = bt.tag_id
+				WHERE t.name IN ?
+				GROUP BY bt.book_id HAVING COUNT(*) = ?)`, filter.Tags, len(filter.Tags))
+		}
+		return db
+	}
+}
+
+// column names are looked up in the whitelist again and quoted by GORM,
+// id is appended as a tiebreaker so offsets stay stable
+func booksSortScope(sort []models.SortField) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		hasID := false
+		for _, s := range sort {
+			column, ok := models.BooksSortableColumns[s.Column]
+			if !ok {
+				continue
+			}
+			hasID = hasID || column == "id"
+			db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: s.Desc})
+		}
+		if !hasID {
+			db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}})
+		}
+		return db
+	}
+}
+
+// trashedScope drops GORM's deleted_at IS NULL default and keeps only the trash
+func trashedScope(db *gorm.DB) *gorm.DB {
+	return db.Unscoped().Where("deleted_at IS NOT NULL")
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
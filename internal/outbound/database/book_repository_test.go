@@ -1,18 +1,26 @@
 package database
 
 import (
+	"context"
 	"crud-echo/internal/models"
 	psgr "crud-echo/pkg/postgres"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+// what Postgres reports when idx_books_title_unique refuses a write
+var errTitleTaken = &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: idxBooksTitleUnique}
+
+var errISBNTaken = &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: idxBooksISBNUnique}
+
 func setupTestDB(t *testing.T) (*psgr.PostgresDB, sqlmock.Sqlmock, func()) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -43,6 +51,8 @@ func setupTestDB(t *testing.T) (*psgr.PostgresDB, sqlmock.Sqlmock, func()) {
 }
 
 func TestCreate(t *testing.T) {
+	year, pages := 1990, 541
+
 	tests := []struct {
 		name        string
 		bookRequest *models.Books
@@ -62,7 +72,7 @@ func TestCreate(t *testing.T) {
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
 				mock.ExpectQuery(`INSERT INTO "books" (.+) VALUES (.+)`).
-					WithArgs("Test Title", "Test Description", 10, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs("Test Title", "Test Description", 10, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "", "", nil, "", nil, "").
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).
 						AddRow(1))
 				mock.ExpectCommit()
@@ -79,13 +89,55 @@ func TestCreate(t *testing.T) {
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
 				mock.ExpectQuery(`INSERT INTO "books" (.+) VALUES (.+)`).
-					WithArgs("Test Title", "Test Description", 10, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs("Test Title", "Test Description", 10, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "", "", nil, "", nil, "").
 					WillReturnError(gorm.ErrInvalidDB)
 				mock.ExpectRollback()
 			},
 			wantErr: true,
 			errType: gorm.ErrInvalidDB,
 		},
+		{
+			name: "Unique violation during create",
+			bookRequest: &models.Books{
+				Title:       "Test Title",
+				Description: "Test Description",
+				Qty:         10,
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "books" (.+) VALUES (.+)`).
+					WithArgs("Test Title", "Test Description", 10, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "", "", nil, "", nil, "").
+					WillReturnError(&pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "books_pkey"})
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrResourceAlreadyExist.Wrap(&pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "books_pkey"}),
+		},
+		{
+			name: "ISBN taken during create",
+			bookRequest: &models.Books{
+				Title:       "Test Title",
+				Description: "Test Description",
+				Qty:         10,
+				BooksBibliography: models.BooksBibliography{
+					ISBN:            "9780441172719",
+					Publisher:       "Ace",
+					PublicationYear: &year,
+					Language:        "en",
+					PageCount:       &pages,
+					Edition:         "Reissue",
+				},
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "books" (.+) VALUES (.+)`).
+					WithArgs("Test Title", "Test Description", 10, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "9780441172719", "Ace", 1990, "en", 541, "Reissue").
+					WillReturnError(errISBNTaken)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrBookISBNTaken.Wrap(errISBNTaken),
+		},
 	}
 
 	for _, tt := range tests {
@@ -97,7 +149,7 @@ func TestCreate(t *testing.T) {
 
 			repo := NewBooksRepository(gdb)
 
-			err := repo.Create(tt.bookRequest)
+			err := repo.Create(context.Background(), tt.bookRequest)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -115,6 +167,9 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+// TestCreateConcurrentSameTitle has every insert but the first refused by
+// idx_books_title_unique, as Postgres does with concurrent inserts of one
+// title, and checks each refusal comes back as ErrBookTitleTaken
 func TestGetByID(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -154,7 +209,7 @@ func TestGetByID(t *testing.T) {
 					WillReturnError(gorm.ErrRecordNotFound)
 			},
 			wantErr: true,
-			errType: models.ErrNotFound,
+			errType: models.ErrBookNotFound,
 		},
 		{
 			name:        "Database error during get",
@@ -179,7 +234,7 @@ func TestGetByID(t *testing.T) {
 
 			repo := NewBooksRepository(gdb)
 
-			err := repo.GetByID(tt.bookRequest, tt.id)
+			err := repo.GetByID(context.Background(), tt.bookRequest, tt.id)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -199,56 +254,228 @@ func TestGetByID(t *testing.T) {
 	}
 }
 
-func TestGetAll(t *testing.T) {
+func TestGetByISBN(t *testing.T) {
+	pages := 541
+
 	tests := []struct {
-		name          string
-		books         *[]models.Books
-		expectedBooks *[]models.Books
-		mock          func(mock sqlmock.Sqlmock)
-		wantErr       bool
-		errType       error
+		name         string
+		isbn         string
+		expectedBook *models.Books
+		mock         func(mock sqlmock.Sqlmock)
+		wantErr      bool
+		errType      error
 	}{
 		{
-			name:  "Success get all books",
-			books: &[]models.Books{},
-			expectedBooks: &[]models.Books{
-				{
-					ID:          1,
-					Title:       "Test Title 1",
-					Description: "Test Description 1",
-					Qty:         10,
-				},
-				{
-					ID:          2,
-					Title:       "Test Title 2",
-					Description: "Test Description 2",
-					Qty:         20,
-				},
+			name: "Success get book by ISBN",
+			isbn: "9780441172719",
+			expectedBook: &models.Books{
+				ID:                1,
+				Title:             "Dune",
+				BooksBibliography: models.BooksBibliography{ISBN: "9780441172719", PageCount: &pages},
 			},
 			mock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "title", "description", "qty", "created_at", "updated_at"}).
-					AddRow(1, "Test Title 1", "Test Description 1", 10, time.Now(), time.Now()).
-					AddRow(2, "Test Title 2", "Test Description 2", 20, time.Now(), time.Now())
-				mock.ExpectQuery(`SELECT \* FROM "books"`).
+				rows := sqlmock.NewRows([]string{"id", "title", "isbn", "page_count"}).
+					AddRow(1, "Dune", "9780441172719", 541)
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE isbn = \$1 AND "books"."deleted_at" IS NULL ORDER BY "books"."id" LIMIT \$2`).
+					WithArgs("9780441172719", 1).
 					WillReturnRows(rows)
 			},
-			wantErr: false,
 		},
 		{
-			name:  "No books found in database",
-			books: &[]models.Books{},
+			name: "No live book with the ISBN",
+			isbn: "9780441172719",
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT \* FROM "books"`).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "qty", "created_at", "updated_at"}))
+				mock.ExpectQuery(`SELECT (.+) FROM "books" WHERE isbn = (.+)`).
+					WithArgs("9780441172719", 1).
+					WillReturnError(gorm.ErrRecordNotFound)
 			},
 			wantErr: true,
-			errType: models.ErrEmptyTable,
+			errType: models.ErrBookNotFound,
 		},
 		{
-			name:  "Database error during get all",
-			books: &[]models.Books{},
+			name: "Database error during get by ISBN",
+			isbn: "9780441172719",
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT \* FROM "books"`).
+				mock.ExpectQuery(`SELECT (.+) FROM "books" WHERE isbn = (.+)`).
+					WithArgs("9780441172719", 1).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			var book models.Books
+			err := repo.GetByISBN(context.Background(), &book, tt.isbn)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBook.ID, book.ID)
+				assert.Equal(t, tt.expectedBook.Title, book.Title)
+				assert.Equal(t, tt.expectedBook.BooksBibliography, book.BooksBibliography)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetPage(t *testing.T) {
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	columns := []string{"id", "title", "description", "qty", "created_at", "updated_at"}
+	available := true
+	qtyMin := 1000
+
+	tests := []struct {
+		name           string
+		filter         models.BooksFilter
+		page           *models.PageRequest
+		expectedIDs    []int
+		expectedResult *models.PageResult
+		mock           func(mock sqlmock.Sqlmock)
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name:           "Success get first page with offset",
+			page:           &models.PageRequest{Limit: 2},
+			expectedIDs:    []int{1, 2},
+			expectedResult: &models.PageResult{Total: 3, HasMore: true},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE "books"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC LIMIT \$1`).
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "Test Title 1", "Test Description 1", 10, createdAt, createdAt).
+						AddRow(2, "Test Title 2", "Test Description 2", 20, createdAt, createdAt).
+						AddRow(3, "Test Title 3", "Test Description 3", 30, createdAt, createdAt))
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Success get page after cursor",
+			page:           &models.PageRequest{Limit: 2, Cursor: &models.Cursor{ID: 2, CreatedAt: createdAt, Direction: models.CursorDirectionNext}},
+			expectedIDs:    []int{3},
+			expectedResult: &models.PageResult{Total: 3, HasMore: false},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE \(created_at, id\) > \(\$1, \$2\) AND "books"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC LIMIT \$3`).
+					WithArgs(createdAt, 2, 3).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(3, "Test Title 3", "Test Description 3", 30, createdAt, createdAt))
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Success get page before cursor in ascending order",
+			page:           &models.PageRequest{Limit: 2, Cursor: &models.Cursor{ID: 3, CreatedAt: createdAt, Direction: models.CursorDirectionPrev}},
+			expectedIDs:    []int{1, 2},
+			expectedResult: &models.PageResult{Total: 3, HasMore: false},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE \(created_at, id\) < \(\$1, \$2\) AND "books"."deleted_at" IS NULL ORDER BY created_at DESC, id DESC LIMIT \$3`).
+					WithArgs(createdAt, 3, 3).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(2, "Test Title 2", "Test Description 2", 20, createdAt, createdAt).
+						AddRow(1, "Test Title 1", "Test Description 1", 10, createdAt, createdAt))
+			},
+			wantErr: false,
+		},
+		{
+			name: "Success get filtered and sorted page",
+			filter: models.BooksFilter{
+				Title:     "50%_off",
+				Available: &available,
+			},
+			page:           &models.PageRequest{Limit: 2, Sort: []models.SortField{{Column: "title", Desc: true}}},
+			expectedIDs:    []int{4},
+			expectedResult: &models.PageResult{Total: 1, HasMore: false},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE title ILIKE \$1 AND qty > 0`).
+					WithArgs(`%50\%\_off%`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE title ILIKE \$1 AND qty > 0 AND "books"."deleted_at" IS NULL ORDER BY "title" DESC,"id" LIMIT \$2`).
+					WithArgs(`%50\%\_off%`, 3).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(4, "50%_off", "Test Description 4", 40, createdAt, createdAt))
+			},
+			wantErr: false,
+		},
+		{
+			name: "Success get page filtered by category and tags",
+			filter: models.BooksFilter{
+				Category: "fiction",
+				Tags:     []string{"classic", "award"},
+			},
+			page:           &models.PageRequest{Limit: 2},
+			expectedIDs:    []int{5},
+			expectedResult: &models.PageResult{Total: 1, HasMore: false},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE \(EXISTS \(SELECT 1 FROM book_categories bc .+ root.slug = \$1\)\) AND books.id IN \(SELECT bt.book_id FROM book_tags bt .+ t.name IN \(\$2,\$3\) .+ HAVING COUNT\(\*\) = \$4\)`).
+					WithArgs("fiction", "classic", "award", 2).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE \(EXISTS \(.+\)\) AND books.id IN \(.+\) AND "books"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC LIMIT \$5`).
+					WithArgs("fiction", "classic", "award", 2, 3).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(5, "Test Title 5", "Test Description 5", 50, createdAt, createdAt))
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Filter matching nothing is not an empty table",
+			filter:         models.BooksFilter{QtyMin: &qtyMin},
+			page:           &models.PageRequest{Limit: 2},
+			expectedIDs:    []int{},
+			expectedResult: &models.PageResult{Total: 0, HasMore: false},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE qty >= \$1`).
+					WithArgs(1000).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE qty >= \$1 AND "books"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC LIMIT \$2`).
+					WithArgs(1000, 3).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			wantErr: false,
+		},
+		{
+			name:           "Success get empty page of an empty table",
+			page:           &models.PageRequest{Limit: 2},
+			expectedIDs:    []int{},
+			expectedResult: &models.PageResult{Total: 0, HasMore: false},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE "books"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC LIMIT \$1`).
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			wantErr: false,
+		},
+		{
+			name: "Database error during get page",
+			page: &models.PageRequest{Limit: 2},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE "books"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC LIMIT \$1`).
+					WithArgs(3).
 					WillReturnError(gorm.ErrInvalidDB)
 			},
 			wantErr: true,
@@ -265,20 +492,18 @@ func TestGetAll(t *testing.T) {
 
 			repo := NewBooksRepository(gdb)
 
-			err := repo.GetAll(tt.books)
+			var books []models.Books
+			result, err := repo.GetPage(context.Background(), &books, &tt.filter, tt.page)
 
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Equal(t, tt.errType, err)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, len(*tt.expectedBooks), len(*tt.books))
-				for i, expected := range *tt.expectedBooks {
-					actual := (*tt.books)[i]
-					assert.Equal(t, expected.ID, actual.ID)
-					assert.Equal(t, expected.Title, actual.Title)
-					assert.Equal(t, expected.Description, actual.Description)
-					assert.Equal(t, expected.Qty, actual.Qty)
+				assert.Equal(t, tt.expectedResult, result)
+				assert.Equal(t, len(tt.expectedIDs), len(books))
+				for i, id := range tt.expectedIDs {
+					assert.Equal(t, id, books[i].ID)
 				}
 			}
 
@@ -305,12 +530,13 @@ func TestUpdate(t *testing.T) {
 				Title:       "Updated Title",
 				Description: "Updated Description",
 				Qty:         15,
+				Version:     2,
 			},
 			// expectedRowsAffected: 1,
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec(`UPDATE "books" SET "title"=\$1,"description"=\$2,"qty"=\$3,"updated_at"=\$4 WHERE "id" = \$5`).
-					WithArgs("Updated Title", "Updated Description", 15, sqlmock.AnyArg(), 1).
+				mock.ExpectExec(`UPDATE "books" SET "title"=\$1,"description"=\$2,"qty"=\$3,"version"=\$4,"updated_at"=\$5,"isbn"=\$6,"publisher"=\$7,"publication_year"=\$8,"language"=\$9,"page_count"=\$10,"edition"=\$11 WHERE version = \$12 AND "books"."deleted_at" IS NULL AND "id" = \$13`).
+					WithArgs("Updated Title", "Updated Description", 15, 3, sqlmock.AnyArg(), "", "", nil, "", nil, "", 2, 1).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
@@ -323,17 +549,85 @@ func TestUpdate(t *testing.T) {
 				Title:       "Updated Title",
 				Description: "Updated Description",
 				Qty:         15,
+				Version:     2,
 			},
 			// expectedRowsAffected: 1,
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec(`UPDATE "books" SET "title"=\$1,"description"=\$2,"qty"=\$3,"updated_at"=\$4 WHERE "id" = \$5`).
-					WithArgs("Updated Title", "Updated Description", 15, sqlmock.AnyArg(), 99).
+				mock.ExpectExec(`UPDATE "books" SET "title"=\$1,"description"=\$2,"qty"=\$3,"version"=\$4,"updated_at"=\$5,"isbn"=\$6,"publisher"=\$7,"publication_year"=\$8,"language"=\$9,"page_count"=\$10,"edition"=\$11 WHERE version = \$12 AND "books"."deleted_at" IS NULL AND "id" = \$13`).
+					WithArgs("Updated Title", "Updated Description", 15, 3, sqlmock.AnyArg(), "", "", nil, "", nil, "", 2, 99).
 					WillReturnResult(sqlmock.NewResult(99, 0))
 				mock.ExpectCommit()
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE id = \$1`).
+					WithArgs(99).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			wantErr: true,
+			errType: models.ErrBookNotFound,
+		},
+		{
+			name: "Book modified since it was read during update",
+			book: &models.Books{
+				ID:          1,
+				Title:       "Updated Title",
+				Description: "Updated Description",
+				Qty:         15,
+				Version:     2,
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "title"=\$1,"description"=\$2,"qty"=\$3,"version"=\$4,"updated_at"=\$5,"isbn"=\$6,"publisher"=\$7,"publication_year"=\$8,"language"=\$9,"page_count"=\$10,"edition"=\$11 WHERE version = \$12 AND "books"."deleted_at" IS NULL AND "id" = \$13`).
+					WithArgs("Updated Title", "Updated Description", 15, 3, sqlmock.AnyArg(), "", "", nil, "", nil, "", 2, 1).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectCommit()
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE id = \$1`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			wantErr: true,
+			errType: models.ErrPreconditionFailed,
+		},
+		{
+			name: "Title taken by another book during update",
+			book: &models.Books{
+				ID:          1,
+				Title:       "Taken Title",
+				Description: "Updated Description",
+				Qty:         15,
+				Version:     2,
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "title"=\$1,"description"=\$2,"qty"=\$3,"version"=\$4,"updated_at"=\$5,"isbn"=\$6,"publisher"=\$7,"publication_year"=\$8,"language"=\$9,"page_count"=\$10,"edition"=\$11 WHERE version = \$12 AND "books"."deleted_at" IS NULL AND "id" = \$13`).
+					WithArgs("Taken Title", "Updated Description", 15, 3, sqlmock.AnyArg(), "", "", nil, "", nil, "", 2, 1).
+					WillReturnError(errTitleTaken)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrBookTitleTaken.Wrap(errTitleTaken),
+		},
+		{
+			name: "ISBN taken by another book during update",
+			book: &models.Books{
+				ID:          1,
+				Title:       "Updated Title",
+				Description: "Updated Description",
+				Qty:         15,
+				Version:     2,
+				BooksBibliography: models.BooksBibliography{
+					ISBN:     "9780441172719",
+					Language: "en",
+				},
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET (.+) WHERE version = (.+)`).
+					WithArgs("Updated Title", "Updated Description", 15, 3, sqlmock.AnyArg(), "9780441172719", "", nil, "en", nil, "", 2, 1).
+					WillReturnError(errISBNTaken)
+				mock.ExpectRollback()
 			},
 			wantErr: true,
-			errType: models.ErrNotFound,
+			errType: models.ErrBookISBNTaken.Wrap(errISBNTaken),
 		},
 		{
 			name: "Database error during update",
@@ -342,12 +636,13 @@ func TestUpdate(t *testing.T) {
 				Title:       "Updated Title",
 				Description: "Updated Description",
 				Qty:         15,
+				Version:     2,
 			},
 			// expectedRowsAffected: 1,
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec(`UPDATE "books" SET "title"=\$1,"description"=\$2,"qty"=\$3,"updated_at"=\$4 WHERE "id" = \$5`).
-					WithArgs("Updated Title", "Updated Description", 15, sqlmock.AnyArg(), 1).
+				mock.ExpectExec(`UPDATE "books" SET "title"=\$1,"description"=\$2,"qty"=\$3,"version"=\$4,"updated_at"=\$5,"isbn"=\$6,"publisher"=\$7,"publication_year"=\$8,"language"=\$9,"page_count"=\$10,"edition"=\$11 WHERE version = \$12 AND "books"."deleted_at" IS NULL AND "id" = \$13`).
+					WithArgs("Updated Title", "Updated Description", 15, 3, sqlmock.AnyArg(), "", "", nil, "", nil, "", 2, 1).
 					WillReturnError(gorm.ErrInvalidDB)
 				mock.ExpectRollback()
 			},
@@ -365,7 +660,7 @@ func TestUpdate(t *testing.T) {
 
 			repo := NewBooksRepository(gdb)
 
-			err := repo.Update(tt.book)
+			err := repo.Update(context.Background(), tt.book)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -382,52 +677,101 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
-func TestDelete(t *testing.T) {
+func TestPatch(t *testing.T) {
+	qty := 0
+	title := "Patched Title"
+	isbn := "9780441172719"
+	var noYear *int
+
 	tests := []struct {
 		name    string
-		book    *models.Books
+		id      int
+		patch   *models.BooksPatch
 		mock    func(mock sqlmock.Sqlmock)
 		wantErr bool
 		errType error
 	}{
 		{
-			name: "Success delete existing book",
-			book: &models.Books{
-				ID: 1,
+			name:  "Success patch qty to zero",
+			id:    1,
+			patch: &models.BooksPatch{Qty: &qty, Version: 2},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "qty"=\$1,"version"=version \+ 1,"updated_at"=\$2 WHERE version = \$3 AND "books"."deleted_at" IS NULL AND "id" = \$4`).
+					WithArgs(0, sqlmock.AnyArg(), 2, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			},
+			wantErr: false,
+		},
+		{
+			name:  "Success patch title and qty",
+			id:    1,
+			patch: &models.BooksPatch{Title: &title, Qty: &qty, Version: 2},
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec(`DELETE FROM "books" WHERE "books"."id" = \$1`).
-					WithArgs(1).
+				mock.ExpectExec(`UPDATE "books" SET "qty"=\$1,"title"=\$2,"version"=version \+ 1,"updated_at"=\$3 WHERE version = \$4 AND "books"."deleted_at" IS NULL AND "id" = \$5`).
+					WithArgs(0, "Patched Title", sqlmock.AnyArg(), 2, 1).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
 			wantErr: false,
 		},
 		{
-			name: "Book not found during delete",
-			book: &models.Books{
-				ID: 99,
+			name:  "Success patch isbn and clear publication year",
+			id:    1,
+			patch: &models.BooksPatch{ISBN: &isbn, PublicationYear: &noYear, Version: 2},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "isbn"=\$1,"publication_year"=\$2,"version"=version \+ 1,"updated_at"=\$3 WHERE version = \$4 AND "books"."deleted_at" IS NULL AND "id" = \$5`).
+					WithArgs("9780441172719", nil, sqlmock.AnyArg(), 2, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			},
+			wantErr: false,
+		},
+		{
+			name:  "Book not found during patch",
+			id:    99,
+			patch: &models.BooksPatch{Qty: &qty, Version: 2},
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec(`DELETE FROM "books" WHERE "books"."id" = \$1`).
-					WithArgs(99).
+				mock.ExpectExec(`UPDATE "books" SET "qty"=\$1,"version"=version \+ 1,"updated_at"=\$2 WHERE version = \$3 AND "books"."deleted_at" IS NULL AND "id" = \$4`).
+					WithArgs(0, sqlmock.AnyArg(), 2, 99).
 					WillReturnResult(sqlmock.NewResult(99, 0))
 				mock.ExpectCommit()
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE id = \$1`).
+					WithArgs(99).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 			},
 			wantErr: true,
-			errType: models.ErrNotFound,
+			errType: models.ErrBookNotFound,
 		},
 		{
-			name: "Database error during delete",
-			book: &models.Books{
-				ID: 1,
-			},
+			name:  "Book modified since it was read during patch",
+			id:    1,
+			patch: &models.BooksPatch{Qty: &qty, Version: 2},
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec(`DELETE FROM "books" WHERE "books"."id" = \$1`).
+				mock.ExpectExec(`UPDATE "books" SET "qty"=\$1,"version"=version \+ 1,"updated_at"=\$2 WHERE version = \$3 AND "books"."deleted_at" IS NULL AND "id" = \$4`).
+					WithArgs(0, sqlmock.AnyArg(), 2, 1).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectCommit()
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE id = \$1`).
 					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			wantErr: true,
+			errType: models.ErrPreconditionFailed,
+		},
+		{
+			name:  "Database error during patch",
+			id:    1,
+			patch: &models.BooksPatch{Qty: &qty, Version: 2},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "qty"=\$1,"version"=version \+ 1,"updated_at"=\$2 WHERE version = \$3 AND "books"."deleted_at" IS NULL AND "id" = \$4`).
+					WithArgs(0, sqlmock.AnyArg(), 2, 1).
 					WillReturnError(gorm.ErrInvalidDB)
 				mock.ExpectRollback()
 			},
@@ -445,7 +789,7 @@ func TestDelete(t *testing.T) {
 
 			repo := NewBooksRepository(gdb)
 
-			err := repo.Delete(tt.book)
+			err := repo.Patch(context.Background(), tt.id, tt.patch)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -461,46 +805,737 @@ func TestDelete(t *testing.T) {
 	}
 }
 
-func TestExistsByTitle(t *testing.T) {
+func TestDelete(t *testing.T) {
 	tests := []struct {
-		name       string
-		title      string
-		mock       func(mock sqlmock.Sqlmock)
-		wantExists bool
-		wantErr    bool
-		errType    error
+		name    string
+		book    *models.Books
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errType error
 	}{
 		{
-			name:       "Book exists with given title",
-			title:      "Test Title",
-			wantExists: true,
+			name: "Success delete existing book",
+			book: &models.Books{
+				ID:      1,
+				Version: 2,
+			},
 			mock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"count"}).AddRow(1)
-				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE title = \$1`).
-					WithArgs("Test Title").
-					WillReturnRows(rows)
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "deleted_at"=\$1 WHERE version = \$2 AND "books"."id" = \$3 AND "books"."deleted_at" IS NULL`).
+					WithArgs(sqlmock.AnyArg(), 2, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			},
 			wantErr: false,
 		},
 		{
-			name:       "Book does not exist with given title",
-			title:      "Non-existent Title",
-			wantExists: false,
+			name: "Book not found during delete",
+			book: &models.Books{
+				ID:      99,
+				Version: 2,
+			},
 			mock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"count"}).AddRow(0)
-				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE title = \$1`).
-					WithArgs("Non-existent Title").
-					WillReturnRows(rows)
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "deleted_at"=\$1 WHERE version = \$2 AND "books"."id" = \$3 AND "books"."deleted_at" IS NULL`).
+					WithArgs(sqlmock.AnyArg(), 2, 99).
+					WillReturnResult(sqlmock.NewResult(99, 0))
+				mock.ExpectCommit()
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE id = \$1`).
+					WithArgs(99).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 			},
-			wantErr: false,
+			wantErr: true,
+			errType: models.ErrBookNotFound,
+		},
+		{
+			name: "Book modified since it was read during delete",
+			book: &models.Books{
+				ID:      1,
+				Version: 2,
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "deleted_at"=\$1 WHERE version = \$2 AND "books"."id" = \$3 AND "books"."deleted_at" IS NULL`).
+					WithArgs(sqlmock.AnyArg(), 2, 1).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectCommit()
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE id = \$1`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			wantErr: true,
+			errType: models.ErrPreconditionFailed,
 		},
 		{
-			name:       "Database error during check",
-			title:      "Test Title",
-			wantExists: false,
+			name: "Database error during delete",
+			book: &models.Books{
+				ID:      1,
+				Version: 2,
+			},
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE title = \$1`).
-					WithArgs("Test Title").
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "deleted_at"=\$1 WHERE version = \$2 AND "books"."id" = \$3 AND "books"."deleted_at" IS NULL`).
+					WithArgs(sqlmock.AnyArg(), 2, 1).
+					WillReturnError(gorm.ErrInvalidDB)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			err := repo.Delete(context.Background(), tt.book)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetTrash(t *testing.T) {
+	deletedAt := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		page           *models.PageRequest
+		mock           func(mock sqlmock.Sqlmock)
+		expectedResult *models.PageResult
+		expectedIDs    []int
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name: "Success get trashed books",
+			page: &models.PageRequest{Limit: 2},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE deleted_at IS NOT NULL`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC LIMIT \$1`).
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "qty", "deleted_at"}).
+						AddRow(3, "Title 3", "Description 3", 1, deletedAt).
+						AddRow(2, "Title 2", "Description 2", 1, deletedAt))
+			},
+			expectedResult: &models.PageResult{Total: 3, HasMore: true},
+			expectedIDs:    []int{3, 2},
+		},
+		{
+			name: "Success get empty trash",
+			page: &models.PageRequest{Limit: 20},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE deleted_at IS NOT NULL`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id DESC LIMIT \$1`).
+					WithArgs(20).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "qty", "deleted_at"}))
+			},
+			expectedResult: &models.PageResult{Total: 0, HasMore: false},
+			expectedIDs:    []int{},
+		},
+		{
+			name: "Database error during get trash",
+			page: &models.PageRequest{Limit: 20},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE deleted_at IS NOT NULL`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			var books []models.Books
+			result, err := repo.GetTrash(context.Background(), &books, tt.page)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+				ids := make([]int, 0, len(books))
+				for _, b := range books {
+					ids = append(ids, b.ID)
+					assert.True(t, b.DeletedAt.Valid)
+				}
+				assert.Equal(t, tt.expectedIDs, ids)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "Success restore trashed book",
+			id:   1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "deleted_at"=\$1,"version"=version \+ 1,"updated_at"=\$2 WHERE deleted_at IS NOT NULL AND "id" = \$3`).
+					WithArgs(nil, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Book not in trash during restore",
+			id:   99,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "deleted_at"=\$1,"version"=version \+ 1,"updated_at"=\$2 WHERE deleted_at IS NOT NULL AND "id" = \$3`).
+					WithArgs(nil, sqlmock.AnyArg(), 99).
+					WillReturnResult(sqlmock.NewResult(99, 0))
+				mock.ExpectCommit()
+			},
+			wantErr: true,
+			errType: models.ErrBookNotFound,
+		},
+		{
+			name: "Database error during restore",
+			id:   1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "deleted_at"=\$1,"version"=version \+ 1,"updated_at"=\$2 WHERE deleted_at IS NOT NULL AND "id" = \$3`).
+					WithArgs(nil, sqlmock.AnyArg(), 1).
+					WillReturnError(gorm.ErrInvalidDB)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+		{
+			name: "Title taken by a live book during restore",
+			id:   1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "deleted_at"=\$1,"version"=version \+ 1,"updated_at"=\$2 WHERE deleted_at IS NOT NULL AND "id" = \$3`).
+					WithArgs(nil, sqlmock.AnyArg(), 1).
+					WillReturnError(errTitleTaken)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrBookTitleTaken.Wrap(errTitleTaken),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			err := repo.Restore(context.Background(), tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestPurge(t *testing.T) {
+	// what Postgres reports for a book that still has loans out
+	errOpenLoans := &pgconn.PgError{Code: pgForeignKeyViolation, ConstraintName: fkLoansBookID}
+	returnedLoans := func(mock sqlmock.Sqlmock, id int) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "loans" WHERE book_id = \$1 AND returned_at IS NOT NULL`).
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+	}
+
+	tests := []struct {
+		name    string
+		id      int
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "Success purge book",
+			id:   1,
+			mock: func(mock sqlmock.Sqlmock) {
+				returnedLoans(mock, 1)
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "books" WHERE "books"."id" = \$1`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Book not found during purge",
+			id:   99,
+			mock: func(mock sqlmock.Sqlmock) {
+				returnedLoans(mock, 99)
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "books" WHERE "books"."id" = \$1`).
+					WithArgs(99).
+					WillReturnResult(sqlmock.NewResult(99, 0))
+				mock.ExpectCommit()
+			},
+			wantErr: true,
+			errType: models.ErrBookNotFound,
+		},
+		{
+			name: "Book with open loans during purge",
+			id:   1,
+			mock: func(mock sqlmock.Sqlmock) {
+				returnedLoans(mock, 1)
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "books" WHERE "books"."id" = \$1`).
+					WithArgs(1).
+					WillReturnError(errOpenLoans)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrBookHasOpenLoans.Wrap(errOpenLoans),
+		},
+		{
+			name: "Database error during clearing the returned loans",
+			id:   1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "loans"`).
+					WithArgs(1).
+					WillReturnError(gorm.ErrInvalidDB)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+		{
+			name: "Database error during purge",
+			id:   1,
+			mock: func(mock sqlmock.Sqlmock) {
+				returnedLoans(mock, 1)
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "books" WHERE "books"."id" = \$1`).
+					WithArgs(1).
+					WillReturnError(gorm.ErrInvalidDB)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			err := repo.Purge(context.Background(), tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		mock      func(mock sqlmock.Sqlmock)
+		wantStats models.BooksStats
+		wantErr   bool
+		errType   error
+	}{
+		{
+			name: "Success get stats",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"total", "total_qty"}).AddRow(3, 17)
+				mock.ExpectQuery(`SELECT count\(\*\) AS total, coalesce\(sum\(qty\), 0\) AS total_qty FROM "books" WHERE "books"."deleted_at" IS NULL`).
+					WillReturnRows(rows)
+			},
+			wantStats: models.BooksStats{Total: 3, TotalQty: 17},
+		},
+		{
+			name: "Database error during stats",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) AS total`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			var stats models.BooksStats
+			err := repo.Stats(context.Background(), &stats)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantStats, stats)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name            string
+		query           string
+		limit           int
+		expectedResults []models.BooksSearchResult
+		mock            func(mock sqlmock.Sqlmock)
+		wantErr         bool
+		errType         error
+	}{
+		{
+			name:  "Success search books ranked by relevance",
+			query: "dune",
+			limit: 10,
+			expectedResults: []models.BooksSearchResult{
+				{
+					BooksSummary: models.BooksSummary{ID: 2, Title: "Dune", Description: "Desert planet", Qty: 3},
+					Rank:         0.6,
+					Snippet:      "<mark>Dune</mark> - Desert planet",
+				},
+				{
+					BooksSummary: models.BooksSummary{ID: 1, Title: "Dune Messiah", Description: "Sequel", Qty: 1},
+					Rank:         0.3,
+					Snippet:      "<mark>Dune</mark> Messiah - Sequel",
+				},
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "qty", "rank", "snippet"}).
+					AddRow(2, "Dune", "Desert planet", 3, 0.6, "<mark>Dune</mark> - Desert planet").
+					AddRow(1, "Dune Messiah", "Sequel", 1, 0.3, "<mark>Dune</mark> Messiah - Sequel")
+				mock.ExpectQuery(`SELECT (.+) FROM books, websearch_to_tsquery\('english', \$1\) AS q WHERE search_vector @@ q AND deleted_at IS NULL ORDER BY rank DESC, id ASC LIMIT \$2`).
+					WithArgs("dune", 10).
+					WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name:            "No books match the query",
+			query:           "nothing",
+			limit:           10,
+			expectedResults: []models.BooksSearchResult{},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT (.+) FROM books, websearch_to_tsquery\('english', \$1\) AS q WHERE search_vector @@ q`).
+					WithArgs("nothing", 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "qty", "rank", "snippet"}))
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Database error during search",
+			query: "dune",
+			limit: 10,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT (.+) FROM books, websearch_to_tsquery\('english', \$1\) AS q WHERE search_vector @@ q`).
+					WithArgs("dune", 10).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+		{
+			name:  "Deadlock during search",
+			query: "dune",
+			limit: 10,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT (.+) FROM books, websearch_to_tsquery\('english', \$1\) AS q WHERE search_vector @@ q`).
+					WithArgs("dune", 10).
+					WillReturnError(&pgconn.PgError{Code: pgDeadlockDetected})
+			},
+			wantErr: true,
+			errType: models.ErrConcurrentUpdate.Wrap(&pgconn.PgError{Code: pgDeadlockDetected}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			results := []models.BooksSearchResult{}
+			err := repo.Search(context.Background(), &results, tt.query, tt.limit)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResults, results)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestCreateInBatches(t *testing.T) {
+	tests := []struct {
+		name        string
+		books       []models.Books
+		batchSize   int
+		expectedIDs []int
+		mock        func(mock sqlmock.Sqlmock)
+		wantErr     bool
+		errType     error
+	}{
+		{
+			name: "Success create books in two batches",
+			books: []models.Books{
+				{Title: "Title A", Description: "Description A", Qty: 1},
+				{Title: "Title B", Description: "Description B", Qty: 2},
+				{Title: "Title C", Description: "Description C", Qty: 3},
+			},
+			batchSize:   2,
+			expectedIDs: []int{1, 2, 3},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO "books" (.+) VALUES \(.+\),\(.+\) RETURNING "id"`).
+					WithArgs(
+						"Title A", "Description A", 1, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "", "", nil, "", nil, "",
+						"Title B", "Description B", 2, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "", "", nil, "", nil, "",
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+				mock.ExpectQuery(`INSERT INTO "books" (.+) VALUES \([^)]+\) RETURNING "id"`).
+					WithArgs("Title C", "Description C", 3, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "", "", nil, "", nil, "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+			},
+		},
+		{
+			name: "Title taken during create in batches",
+			books: []models.Books{
+				{Title: "Title A", Description: "Description A", Qty: 1},
+			},
+			batchSize: 2,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO "books" (.+) VALUES (.+)`).
+					WithArgs("Title A", "Description A", 1, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "", "", nil, "", nil, "").
+					WillReturnError(errTitleTaken)
+			},
+			wantErr: true,
+			errType: models.ErrBookTitleTaken.Wrap(errTitleTaken),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			err := repo.CreateInBatches(context.Background(), tt.books, tt.batchSize)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				for i, book := range tt.books {
+					assert.Equal(t, tt.expectedIDs[i], book.ID)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestTakenTitles(t *testing.T) {
+	tests := []struct {
+		name          string
+		titles        []string
+		mock          func(mock sqlmock.Sqlmock)
+		expectedTaken []string
+		wantErr       bool
+		errType       error
+	}{
+		{
+			name:   "Success find taken titles",
+			titles: []string{" Title A", "TITLE B"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT lower\(btrim\(title\)\) FROM "books" WHERE lower\(btrim\(title\)\) IN \(\$1,\$2\) AND "books"."deleted_at" IS NULL`).
+					WithArgs("title a", "title b").
+					WillReturnRows(sqlmock.NewRows([]string{"title"}).AddRow("title b"))
+			},
+			expectedTaken: []string{"title b"},
+		},
+		{
+			name:   "Database error during title lookup",
+			titles: []string{"Title A"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT lower\(btrim\(title\)\) FROM "books"`).
+					WithArgs("title a").
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			taken, err := repo.TakenTitles(context.Background(), tt.titles)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTaken, taken)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetByTitles(t *testing.T) {
+	gdb, mock, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`SELECT \* FROM "books" WHERE lower\(btrim\(title\)\) IN \(\$1,\$2\) AND "books"."deleted_at" IS NULL`).
+		WithArgs("title a", "title b").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "version"}).AddRow(2, "Title B", 4))
+
+	repo := NewBooksRepository(gdb)
+
+	var books []models.Books
+	err := repo.GetByTitles(context.Background(), &books, []string{"Title A ", "TITLE B"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Books{{ID: 2, Title: "Title B", Version: 4}}, books)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestEach(t *testing.T) {
+	errStop := errors.New("stop")
+
+	tests := []struct {
+		name        string
+		mock        func(mock sqlmock.Sqlmock)
+		fnErr       error
+		expectedIDs []int
+		wantErr     bool
+		errType     error
+	}{
+		{
+			name: "Success walk every book",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE "books"."deleted_at" IS NULL ORDER BY id ASC`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "Title A").AddRow(2, "Title B"))
+			},
+			expectedIDs: []int{1, 2},
+		},
+		{
+			name: "Error from fn stops the walk",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "books" WHERE "books"."deleted_at" IS NULL ORDER BY id ASC`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "Title A").AddRow(2, "Title B"))
+			},
+			fnErr:       errStop,
+			expectedIDs: []int{1},
+			wantErr:     true,
+			errType:     errStop,
+		},
+		{
+			name: "Database error during walk",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "books"`).
 					WillReturnError(gorm.ErrInvalidDB)
 			},
 			wantErr: true,
@@ -517,15 +1552,19 @@ func TestExistsByTitle(t *testing.T) {
 
 			repo := NewBooksRepository(gdb)
 
-			exists, err := repo.ExistsByTitle(tt.title)
+			var ids []int
+			err := repo.Each(context.Background(), func(book *models.Books) error {
+				ids = append(ids, book.ID)
+				return tt.fnErr
+			})
 
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Equal(t, tt.errType, err)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.wantExists, exists)
 			}
+			assert.Equal(t, tt.expectedIDs, ids)
 
 			if err := mock.ExpectationsWereMet(); err != nil {
 				t.Errorf("there were unfulfilled expectations: %s", err)
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetByIDForUpdate is GetByID locking the row until the transaction in ctx
+// ends. Checkouts, returns and holds of a book take it first, so each sees
+// the qty the one before it left
+func (r *BooksRepository) GetByIDForUpdate(ctx context.Context, book *models.Books, id int) error {
+	result := conn(ctx, r.rdc).Clauses(clause.Locking{Strength: "UPDATE"}).First(book, id)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return models.ErrBookNotFound
+		}
+		return translateError(result.Error)
+	}
+
+	return nil
+}
+
+// AddQty moves qty by delta in a single statement, so concurrent changes
+// can't overwrite each other, and reads the book back into book. Going
+// below zero trips books_qty_non_negative and comes back as
+// ErrInsufficientStock
+func (r *BooksRepository) AddQty(ctx context.Context, id int, delta int, book *models.Books) error {
+	result := conn(ctx, r.rdc).Model(book).Clauses(clause.Returning{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"qty":     gorm.Expr("qty + ?", delta),
+			"version": gorm.Expr("version + 1"),
+		})
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if result.RowsAffected < 1 {
+		return models.ErrBookNotFound
+	}
+
+	return nil
+}
+
+func (r *BooksRepository) CreateMovements(ctx context.Context, movements []models.StockMovement) error {
+	if len(movements) == 0 {
+		return nil
+	}
+
+	result := conn(ctx, r.rdc).Session(&gorm.Session{SkipDefaultTransaction: true}).CreateInBatches(movements, models.BulkBatchSize)
+
+	return translateError(result.Error)
+}
+
+// GetMovements lists the ledger of a book, newest first
+func (r *BooksRepository) GetMovements(ctx context.Context, movements *[]models.StockMovement, bookID int, page *models.PageRequest) (*models.PageResult, error) {
+	var total int64
+	if err := conn(ctx, r.rdc).Model(&models.StockMovement{}).Where("book_id = ?", bookID).Count(&total).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	result := conn(ctx, r.rdc).Where("book_id = ?", bookID).
+		Order("id DESC").
+		Offset(page.Offset).
+		Limit(page.Limit).
+		Find(movements)
+	if result.Error != nil {
+		return nil, translateError(result.Error)
+	}
+
+	return &models.PageResult{
+		Total:   total,
+		HasMore: int64(page.Offset+len(*movements)) < total,
+	}, nil
+}
@@ -0,0 +1,329 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// what Postgres reports when books_qty_non_negative refuses a write
+var errQtyNegative = &pgconn.PgError{Code: pgCheckViolation, ConstraintName: chkBooksQtyNonNegative}
+
+func TestGetByIDForUpdate(t *testing.T) {
+	const lockQuery = `SELECT \* FROM "books" WHERE "books"."id" = \$1 AND "books"."deleted_at" IS NULL ORDER BY "books"."id" LIMIT \$2 FOR UPDATE`
+
+	tests := []struct {
+		name    string
+		id      int
+		mock    func(mock sqlmock.Sqlmock)
+		wantQty int
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "Success lock book",
+			id:   1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(lockQuery).
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "qty"}).AddRow(1, "Test Title", 4))
+			},
+			wantQty: 4,
+		},
+		{
+			name: "Book not found during lock",
+			id:   99,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(lockQuery).
+					WithArgs(99, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "qty"}))
+			},
+			wantErr: true,
+			errType: models.ErrBookNotFound,
+		},
+		{
+			name: "Database error during lock",
+			id:   1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(lockQuery).
+					WithArgs(1, 1).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			var book models.Books
+			err := repo.GetByIDForUpdate(context.Background(), &book, tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantQty, book.Qty)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestAddQty(t *testing.T) {
+	const addQtyQuery = `UPDATE "books" SET "qty"=qty \+ \$1,"version"=version \+ 1,"updated_at"=\$2 WHERE id = \$3 AND "books"."deleted_at" IS NULL RETURNING \*`
+
+	tests := []struct {
+		name         string
+		id           int
+		delta        int
+		mock         func(mock sqlmock.Sqlmock)
+		expectedBook models.Books
+		wantErr      bool
+		errType      error
+	}{
+		{
+			name:  "Success add to qty",
+			id:    1,
+			delta: 5,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(addQtyQuery).
+					WithArgs(5, sqlmock.AnyArg(), 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "qty", "version"}).
+						AddRow(1, "Test Title", 15, 3))
+				mock.ExpectCommit()
+			},
+			expectedBook: models.Books{ID: 1, Qty: 15, Version: 3},
+		},
+		{
+			name:  "Book not found during add qty",
+			id:    99,
+			delta: 5,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(addQtyQuery).
+					WithArgs(5, sqlmock.AnyArg(), 99).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "qty", "version"}))
+				mock.ExpectCommit()
+			},
+			wantErr: true,
+			errType: models.ErrBookNotFound,
+		},
+		{
+			name:  "Qty would go below zero",
+			id:    1,
+			delta: -20,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(addQtyQuery).
+					WithArgs(-20, sqlmock.AnyArg(), 1).
+					WillReturnError(errQtyNegative)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrInsufficientStock.Wrap(errQtyNegative),
+		},
+		{
+			name:  "Database error during add qty",
+			id:    1,
+			delta: 5,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(addQtyQuery).
+					WithArgs(5, sqlmock.AnyArg(), 1).
+					WillReturnError(gorm.ErrInvalidDB)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			var book models.Books
+			err := repo.AddQty(context.Background(), tt.id, tt.delta, &book)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBook.ID, book.ID)
+				assert.Equal(t, tt.expectedBook.Qty, book.Qty)
+				assert.Equal(t, tt.expectedBook.Version, book.Version)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestCreateMovements(t *testing.T) {
+	tests := []struct {
+		name        string
+		movements   []models.StockMovement
+		expectedIDs []int
+		mock        func(mock sqlmock.Sqlmock)
+		wantErr     bool
+		errType     error
+	}{
+		{
+			name: "Success create movements",
+			movements: []models.StockMovement{
+				{BookID: 1, Kind: models.StockReceive, Quantity: 10, Balance: 10, Reason: "initial stock", Actor: "alice"},
+				{BookID: 2, Kind: models.StockSell, Quantity: -1, Balance: 4, Actor: "bob"},
+			},
+			expectedIDs: []int{1, 2},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO "stock_movements" (.+) VALUES \(.+\),\(.+\) RETURNING "id"`).
+					WithArgs(
+						1, models.StockReceive, 10, 10, "initial stock", "alice", sqlmock.AnyArg(),
+						2, models.StockSell, -1, 4, "", "bob", sqlmock.AnyArg(),
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+			},
+		},
+		{
+			name: "Nothing to create",
+			mock: func(mock sqlmock.Sqlmock) {},
+		},
+		{
+			name: "Book gone during create movements",
+			movements: []models.StockMovement{
+				{BookID: 99, Kind: models.StockReceive, Quantity: 1, Balance: 1, Actor: "alice"},
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO "stock_movements" (.+) VALUES (.+)`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			err := repo.CreateMovements(context.Background(), tt.movements)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				for i, movement := range tt.movements {
+					assert.Equal(t, tt.expectedIDs[i], movement.ID)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetMovements(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		bookID         int
+		page           *models.PageRequest
+		mock           func(mock sqlmock.Sqlmock)
+		expectedResult *models.PageResult
+		expectedLen    int
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name:   "Success get movements newest first",
+			bookID: 1,
+			page:   &models.PageRequest{Limit: 2},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "stock_movements" WHERE book_id = \$1`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectQuery(`SELECT \* FROM "stock_movements" WHERE book_id = \$1 ORDER BY id DESC LIMIT \$2`).
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "book_id", "kind", "quantity", "balance", "created_at"}).
+						AddRow(3, 1, models.StockSell, -1, 9, now).
+						AddRow(2, 1, models.StockReceive, 5, 10, now))
+			},
+			expectedResult: &models.PageResult{Total: 3, HasMore: true},
+			expectedLen:    2,
+		},
+		{
+			name:   "Database error during get movements",
+			bookID: 1,
+			page:   &models.PageRequest{Limit: 2},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "stock_movements"`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewBooksRepository(gdb)
+
+			var movements []models.StockMovement
+			result, err := repo.GetMovements(context.Background(), &movements, tt.bookID, tt.page)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+				assert.Len(t, movements, tt.expectedLen)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
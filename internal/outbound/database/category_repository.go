@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CategoriesRepository struct {
+	rdc RepositoryDBConn
+}
+
+func NewCategoriesRepository(repoDBConn RepositoryDBConn) *CategoriesRepository {
+	return &CategoriesRepository{rdc: repoDBConn}
+}
+
+// Create puts the category under its parent, or at the root without one. The
+// parent row stays locked until the transaction in ctx ends, so a move of the
+// parent either waits for the new child or hands it the moved path. The id is
+// taken from the sequence up front since the path ends with it
+func (r *CategoriesRepository) Create(ctx context.Context, category *models.Categories) error {
+	db := conn(ctx, r.rdc)
+
+	parentPath := models.CategoryRootPath
+	if category.ParentID != nil {
+		var parent models.Categories
+		if err := r.GetByIDForUpdate(ctx, &parent, *category.ParentID); err != nil {
+			// a parent that doesn't exist is the client's mistake, not a 404
+			if errors.Is(err, models.ErrCategoryNotFound) {
+				return models.ErrUnknownCategory.Wrap(err)
+			}
+			return err
+		}
+		parentPath = parent.Path
+	}
+
+	var id int
+	if err := db.Raw("SELECT nextval(pg_get_serial_sequence('categories', 'id'))").Scan(&id).Error; err != nil {
+		return translateError(err)
+	} else if id == 0 {
+		return models.ErrInternalServerError
+	}
+
+	category.ID = id
+	category.Path = models.CategoryPath(parentPath, id)
+
+	return translateError(db.Create(category).Error)
+}
+
+func (r *CategoriesRepository) GetByID(ctx context.Context, category *models.Categories, id int) error {
+	result := conn(ctx, r.rdc).First(category, id)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return models.ErrCategoryNotFound
+		}
+		return translateError(result.Error)
+	}
+
+	return nil
+}
+
+// GetByIDForUpdate is GetByID locking the row until the transaction in ctx
+// ends. Moves take the category and its new parent first, creates the parent
+func (r *CategoriesRepository) GetByIDForUpdate(ctx context.Context, category *models.Categories, id int) error {
+	result := conn(ctx, r.rdc).Clauses(clause.Locking{Strength: "UPDATE"}).First(category, id)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return models.ErrCategoryNotFound
+		}
+		return translateError(result.Error)
+	}
+
+	return nil
+}
+
+// GetAll lists the whole tree depth first, every category comes right
+// before its subtree
+func (r *CategoriesRepository) GetAll(ctx context.Context, categories *[]models.Categories) error {
+	result := conn(ctx, r.rdc).Order("path ASC").Find(categories)
+
+	return translateError(result.Error)
+}
+
+// Update writes name, slug and parent, the paths are left to MoveSubtree
+func (r *CategoriesRepository) Update(ctx context.Context, category *models.Categories) error {
+	result := conn(ctx, r.rdc).Model(category).Select("name", "slug", "parent_id").Updates(category)
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if result.RowsAffected < 1 {
+		return models.ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+// MoveSubtree swaps the oldPath prefix for newPath on the category at
+// oldPath and everything below it. The subtree is locked by a statement of
+// its own first, that waits out creates under any of its categories and lets
+// the update see the children they added
+func (r *CategoriesRepository) MoveSubtree(ctx context.Context, oldPath, newPath string) error {
+	db := conn(ctx, r.rdc)
+	subtree := escapeLike(oldPath) + "%"
+
+	var ids []int
+	if err := db.Model(&models.Categories{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("path LIKE ?", subtree).Pluck("id", &ids).Error; err != nil {
+		return translateError(err)
+	}
+
+	result := db.Model(&models.Categories{}).
+		Where("path LIKE ?", subtree).
+		Update("path", gorm.Expr("? || substr(path, ?)", newPath, len(oldPath)+1))
+
+	return translateError(result.Error)
+}
+
+// Delete removes the category and its links to books, a category that still
+// has children fails with ErrCategoryHasChildren
+func (r *CategoriesRepository) Delete(ctx context.Context, id int) error {
+	result := conn(ctx, r.rdc).Delete(&models.Categories{ID: id})
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if result.RowsAffected < 1 {
+		return models.ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+// ReplaceBookCategories makes categoryIDs the categories of the book, links
+// already in place are left alone. Run it in a unit of work
+func (r *CategoriesRepository) ReplaceBookCategories(ctx context.Context, bookID int, categoryIDs []int) error {
+	db := conn(ctx, r.rdc)
+
+	if err := requireBook(db, bookID); err != nil {
+		return err
+	}
+
+	stale := db.Where("book_id = ?", bookID)
+	if len(categoryIDs) > 0 {
+		stale = stale.Where("category_id NOT IN ?", categoryIDs)
+	}
+	if err := stale.Delete(&models.BookCategories{}).Error; err != nil {
+		return translateError(err)
+	}
+
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	links := make([]models.BookCategories, len(categoryIDs))
+	for i, id := range categoryIDs {
+		links[i] = models.BookCategories{BookID: bookID, CategoryID: id}
+	}
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&links)
+
+	return translateError(result.Error)
+}
+
+// GetBookCategories lists the categories the book was put in, in tree order
+func (r *CategoriesRepository) GetBookCategories(ctx context.Context, categories *[]models.Categories, bookID int) error {
+	db := conn(ctx, r.rdc)
+
+	if err := requireBook(db, bookID); err != nil {
+		return err
+	}
+
+	result := db.Joins("JOIN book_categories ON book_categories.category_id = categories.id").
+		Where("book_categories.book_id = ?", bookID).
+		Order("categories.path ASC").
+		Find(categories)
+
+	return translateError(result.Error)
+}
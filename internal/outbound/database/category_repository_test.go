@@ -0,0 +1,553 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var (
+	// what Postgres reports for a second category with the same slug
+	errSlugTaken = &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: ukCategoriesSlug}
+	// and for deleting a category other categories still point at
+	errHasChildren = &pgconn.PgError{Code: pgForeignKeyViolation, ConstraintName: fkCategoriesParentID}
+)
+
+func TestCreateCategory(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name         string
+		category     *models.Categories
+		expectedPath string
+		mock         func(mock sqlmock.Sqlmock)
+		wantErr      bool
+		errType      error
+	}{
+		{
+			name:         "Success create root category",
+			category:     &models.Categories{Name: "Fiction", Slug: "fiction"},
+			expectedPath: "/1/",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT nextval\(pg_get_serial_sequence\('categories', 'id'\)\)`).
+					WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(1))
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "categories" \("name","slug","parent_id","path","created_at","updated_at","id"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7\) RETURNING "id"`).
+					WithArgs("Fiction", "fiction", nil, "/1/", sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:         "Success create child category",
+			category:     &models.Categories{Name: "Fantasy", Slug: "fantasy", ParentID: &parentID},
+			expectedPath: "/1/4/",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "categories" WHERE "categories"."id" = \$1 ORDER BY "categories"."id" LIMIT \$2 FOR UPDATE`).
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "path"}).AddRow(1, "/1/"))
+				mock.ExpectQuery(`SELECT nextval`).
+					WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(4))
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "categories" (.+) VALUES (.+)`).
+					WithArgs("Fantasy", "fantasy", &parentID, "/1/4/", sqlmock.AnyArg(), sqlmock.AnyArg(), 4).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "Unknown parent during create category",
+			category: &models.Categories{Name: "Fantasy", Slug: "fantasy", ParentID: &parentID},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "categories" (.+) FOR UPDATE`).
+					WithArgs(1, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantErr: true,
+			errType: models.ErrUnknownCategory.Wrap(models.ErrCategoryNotFound),
+		},
+		{
+			name:     "Slug taken during create category",
+			category: &models.Categories{Name: "Fiction", Slug: "fiction"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT nextval`).
+					WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(2))
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "categories" (.+) VALUES (.+)`).
+					WillReturnError(errSlugTaken)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrCategorySlugTaken.Wrap(errSlugTaken),
+		},
+		{
+			name:     "Database error during reserving the id",
+			category: &models.Categories{Name: "Fiction", Slug: "fiction"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT nextval`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewCategoriesRepository(gdb)
+
+			err := repo.Create(context.Background(), tt.category)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPath, tt.category.Path)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetCategoryByID(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               int
+		expectedCategory models.Categories
+		mock             func(mock sqlmock.Sqlmock)
+		wantErr          bool
+		errType          error
+	}{
+		{
+			name:             "Success get category",
+			id:               4,
+			expectedCategory: models.Categories{ID: 4, Name: "Fantasy", Slug: "fantasy", Path: "/1/4/"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "categories" WHERE "categories"."id" = \$1 ORDER BY "categories"."id" LIMIT \$2`).
+					WithArgs(4, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug", "path"}).AddRow(4, "Fantasy", "fantasy", "/1/4/"))
+			},
+		},
+		{
+			name: "Category not found",
+			id:   99,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "categories"`).
+					WithArgs(99, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantErr: true,
+			errType: models.ErrCategoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewCategoriesRepository(gdb)
+
+			var category models.Categories
+			err := repo.GetByID(context.Background(), &category, tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCategory, category)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetCategoryByIDForUpdate(t *testing.T) {
+	const lockQuery = `SELECT \* FROM "categories" WHERE "categories"."id" = \$1 ORDER BY "categories"."id" LIMIT \$2 FOR UPDATE`
+
+	tests := []struct {
+		name             string
+		id               int
+		expectedCategory models.Categories
+		mock             func(mock sqlmock.Sqlmock)
+		wantErr          bool
+		errType          error
+	}{
+		{
+			name:             "Success lock category",
+			id:               4,
+			expectedCategory: models.Categories{ID: 4, Name: "Fantasy", Slug: "fantasy", Path: "/1/4/"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(lockQuery).
+					WithArgs(4, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug", "path"}).AddRow(4, "Fantasy", "fantasy", "/1/4/"))
+			},
+		},
+		{
+			name: "Category not found",
+			id:   99,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(lockQuery).
+					WithArgs(99, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantErr: true,
+			errType: models.ErrCategoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewCategoriesRepository(gdb)
+
+			var category models.Categories
+			err := repo.GetByIDForUpdate(context.Background(), &category, tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCategory, category)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestMoveSubtree(t *testing.T) {
+	const lockQuery = `SELECT "id" FROM "categories" WHERE path LIKE \$1 FOR UPDATE`
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "Success move subtree",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(lockQuery).
+					WithArgs("/1/4/%").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4).AddRow(5).AddRow(6))
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "categories" SET "path"=\$1 \|\| substr\(path, \$2\),"updated_at"=\$3 WHERE path LIKE \$4`).
+					WithArgs("/2/4/", 6, sqlmock.AnyArg(), "/1/4/%").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Database error during locking the subtree",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(lockQuery).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+		{
+			name: "Database error during move subtree",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(lockQuery).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "categories"`).
+					WillReturnError(gorm.ErrInvalidDB)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewCategoriesRepository(gdb)
+
+			err := repo.MoveSubtree(context.Background(), "/1/4/", "/2/4/")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDeleteCategory(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "Success delete category",
+			id:   4,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "categories" WHERE "categories"."id" = \$1`).
+					WithArgs(4).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Category with children",
+			id:   1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "categories"`).
+					WithArgs(1).
+					WillReturnError(errHasChildren)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrCategoryHasChildren.Wrap(errHasChildren),
+		},
+		{
+			name: "Category not found",
+			id:   99,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "categories"`).
+					WithArgs(99).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			wantErr: true,
+			errType: models.ErrCategoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewCategoriesRepository(gdb)
+
+			err := repo.Delete(context.Background(), tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestReplaceBookCategories(t *testing.T) {
+	errUnknownCategory := &pgconn.PgError{Code: pgForeignKeyViolation, ConstraintName: fkBookCategoriesCategoryID}
+
+	tests := []struct {
+		name        string
+		categoryIDs []int
+		mock        func(mock sqlmock.Sqlmock)
+		wantErr     bool
+		errType     error
+	}{
+		{
+			name:        "Success replace categories of a book",
+			categoryIDs: []int{4, 5},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE id = \$1 AND "books"."deleted_at" IS NULL`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "book_categories" WHERE book_id = \$1 AND category_id NOT IN \(\$2,\$3\)`).
+					WithArgs(1, 4, 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "book_categories" \("book_id","category_id"\) VALUES \(\$1,\$2\),\(\$3,\$4\) ON CONFLICT DO NOTHING`).
+					WithArgs(1, 4, 1, 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "Success clear categories of a book",
+			categoryIDs: []int{},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "book_categories" WHERE book_id = \$1$`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "Book not found",
+			categoryIDs: []int{4},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			wantErr: true,
+			errType: models.ErrBookNotFound,
+		},
+		{
+			name:        "Unknown category",
+			categoryIDs: []int{99},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "book_categories"`).
+					WithArgs(1, 99).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "book_categories"`).
+					WithArgs(1, 99).
+					WillReturnError(errUnknownCategory)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrUnknownCategory.Wrap(errUnknownCategory),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewCategoriesRepository(gdb)
+
+			err := repo.ReplaceBookCategories(context.Background(), 1, tt.categoryIDs)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetBookCategories(t *testing.T) {
+	tests := []struct {
+		name        string
+		expectedIDs []int
+		mock        func(mock sqlmock.Sqlmock)
+		wantErr     bool
+		errType     error
+	}{
+		{
+			name:        "Success get categories of a book",
+			expectedIDs: []int{1, 4},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery(`SELECT "categories"."id",(.+) FROM "categories" JOIN book_categories ON book_categories.category_id = categories.id WHERE book_categories.book_id = \$1 ORDER BY categories.path ASC`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "slug", "path"}).
+						AddRow(1, "Fiction", "fiction", "/1/").
+						AddRow(4, "Fantasy", "fantasy", "/1/4/"))
+			},
+		},
+		{
+			name: "Book not found",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			wantErr: true,
+			errType: models.ErrBookNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewCategoriesRepository(gdb)
+
+			var categories []models.Categories
+			err := repo.GetBookCategories(context.Background(), &categories, 1)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, len(tt.expectedIDs), len(categories))
+				for i, id := range tt.expectedIDs {
+					assert.Equal(t, id, categories[i].ID)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
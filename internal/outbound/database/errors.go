@@ -0,0 +1,108 @@
+package database
+
+import (
+	"crud-echo/internal/models"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SQLSTATE codes the repositories translate, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation      = "23505"
+	pgForeignKeyViolation  = "23503"
+	pgCheckViolation       = "23514"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+const (
+	// created by migration 0003
+	idxBooksTitleUnique = "idx_books_title_unique"
+	// created by migration 0004
+	chkBooksQtyNonNegative = "books_qty_non_negative"
+	// created by migration 0005
+	fkBookAuthorsBookID   = "book_authors_book_id_fkey"
+	fkBookAuthorsAuthorID = "book_authors_author_id_fkey"
+	// created by migration 0006
+	ukCategoriesSlug           = "categories_slug_key"
+	fkCategoriesParentID       = "categories_parent_id_fkey"
+	fkBookCategoriesBookID     = "book_categories_book_id_fkey"
+	fkBookCategoriesCategoryID = "book_categories_category_id_fkey"
+	ukTagsName                 = "tags_name_key"
+	fkBookTagsBookID           = "book_tags_book_id_fkey"
+	// created by migration 0007
+	idxBooksISBNUnique = "idx_books_isbn_unique"
+	// created by migration 0008, on delete restrict since 0010
+	ukMembersEmail  = "members_email_key"
+	fkLoansBookID   = "loans_book_id_fkey"
+	fkLoansMemberID = "loans_member_id_fkey"
+	// created by migration 0009
+	fkHoldsBookID      = "holds_book_id_fkey"
+	fkHoldsMemberID    = "holds_member_id_fkey"
+	idxHoldsOpenUnique = "idx_holds_open_unique"
+)
+
+// constraintErrors maps constraint (or index) names to a more specific error
+// than the one their SQLSTATE gets
+var constraintErrors = map[string]*models.DomainError{
+	idxBooksTitleUnique:    models.ErrBookTitleTaken,
+	chkBooksQtyNonNegative: models.ErrInsufficientStock,
+	fkBookAuthorsBookID:    models.ErrBookNotFound,
+	fkBookAuthorsAuthorID:  models.ErrUnknownAuthor,
+	ukCategoriesSlug:       models.ErrCategorySlugTaken,
+	// the parent is looked up before a category is written, so the key is
+	// expected to trip when a category with children gets deleted
+	fkCategoriesParentID:       models.ErrCategoryHasChildren,
+	fkBookCategoriesBookID:     models.ErrBookNotFound,
+	fkBookCategoriesCategoryID: models.ErrUnknownCategory,
+	ukTagsName:                 models.ErrTagNameTaken,
+	fkBookTagsBookID:           models.ErrBookNotFound,
+	idxBooksISBNUnique:         models.ErrBookISBNTaken,
+	ukMembersEmail:             models.ErrMemberEmailTaken,
+	fkLoansBookID:              models.ErrBookNotFound,
+	fkLoansMemberID:            models.ErrMemberNotFound,
+	fkHoldsBookID:              models.ErrBookNotFound,
+	fkHoldsMemberID:            models.ErrMemberNotFound,
+	idxHoldsOpenUnique:         models.ErrHoldAlreadyExists,
+}
+
+// violates reports whether Postgres refused err over constraint, for the
+// keys whose meaning depends on the side that tripped them
+func violates(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.ConstraintName == constraint
+}
+
+// translateError turns what Postgres refused into a DomainError, keeping the
+// driver error as the cause. Anything else, including errors translated
+// already, is returned as is
+func translateError(err error) error {
+	var de *models.DomainError
+	if errors.As(err, &de) {
+		return err
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	if de, ok := constraintErrors[pgErr.ConstraintName]; ok {
+		return de.Wrap(err)
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return models.ErrResourceAlreadyExist.Wrap(err)
+	case pgForeignKeyViolation:
+		return models.ErrReferenceViolation.Wrap(err)
+	case pgCheckViolation:
+		return models.ErrConstraintViolation.Wrap(err)
+	case pgSerializationFailure, pgDeadlockDetected:
+		return models.ErrConcurrentUpdate.Wrap(err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"crud-echo/internal/models"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "Unique violation",
+			err:  &pgconn.PgError{Code: pgUniqueViolation},
+			want: models.ErrResourceAlreadyExist,
+		},
+		{
+			name: "Foreign key violation",
+			err:  &pgconn.PgError{Code: pgForeignKeyViolation},
+			want: models.ErrReferenceViolation,
+		},
+		{
+			name: "Check violation",
+			err:  &pgconn.PgError{Code: pgCheckViolation},
+			want: models.ErrConstraintViolation,
+		},
+		{
+			name: "Serialization failure",
+			err:  &pgconn.PgError{Code: pgSerializationFailure},
+			want: models.ErrConcurrentUpdate,
+		},
+		{
+			name: "Deadlock detected",
+			err:  &pgconn.PgError{Code: pgDeadlockDetected},
+			want: models.ErrConcurrentUpdate,
+		},
+		{
+			name: "Wrapped Postgres error",
+			err:  fmt.Errorf("insert: %w", &pgconn.PgError{Code: pgUniqueViolation}),
+			want: models.ErrResourceAlreadyExist,
+		},
+		{
+			name: "Unhandled SQLSTATE is left alone",
+			err:  &pgconn.PgError{Code: "42P01"},
+			want: &pgconn.PgError{Code: "42P01"},
+		},
+		{
+			name: "Non Postgres error is left alone",
+			err:  gorm.ErrInvalidDB,
+			want: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := translateError(tt.err)
+
+			var de *models.DomainError
+			if errors.As(tt.want, &de) {
+				assert.ErrorIs(t, err, tt.want)
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
+
+func TestTranslateErrorConstraint(t *testing.T) {
+	constraintErrors["books_title_key"] = models.ErrBookTitleTaken
+	defer delete(constraintErrors, "books_title_key")
+
+	err := translateError(&pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "books_title_key"})
+
+	assert.ErrorIs(t, err, models.ErrBookTitleTaken)
+	assert.ErrorIs(t, err, models.ErrResourceAlreadyExist)
+}
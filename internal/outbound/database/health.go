@@ -0,0 +1,18 @@
+package database
+
+import (
+	"context"
+	"crud-echo/pkg/health"
+)
+
+// NewPingChecker reports the database as ready while it answers a ping
+func NewPingChecker(conn RepositoryDBConn) health.Checker {
+	return health.NewChecker("database", func(ctx context.Context) error {
+		sqlDB, err := conn.GetDB().DB()
+		if err != nil {
+			return err
+		}
+
+		return sqlDB.PingContext(ctx)
+	})
+}
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type HoldsRepository struct {
+	rdc RepositoryDBConn
+}
+
+func NewHoldsRepository(repoDBConn RepositoryDBConn) *HoldsRepository {
+	return &HoldsRepository{rdc: repoDBConn}
+}
+
+// Create fails with ErrHoldAlreadyExists when the member has an open hold
+// on the book already, and with ErrBookNotFound or ErrMemberNotFound when
+// either doesn't exist
+func (r *HoldsRepository) Create(ctx context.Context, hold *models.Holds) error {
+	result := conn(ctx, r.rdc).Create(hold)
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if hold.ID == 0 {
+		return models.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (r *HoldsRepository) GetByID(ctx context.Context, hold *models.Holds, id int) error {
+	return r.first(conn(ctx, r.rdc), hold, id)
+}
+
+// GetForUpdate is GetByID locking the row until the transaction in ctx ends
+func (r *HoldsRepository) GetForUpdate(ctx context.Context, hold *models.Holds, id int) error {
+	return r.first(conn(ctx, r.rdc).Clauses(clause.Locking{Strength: "UPDATE"}), hold, id)
+}
+
+// GetOpenForMember locks the waiting or ready hold of the member on the book,
+// ErrHoldNotFound says there is none
+func (r *HoldsRepository) GetOpenForMember(ctx context.Context, hold *models.Holds, bookID, memberID int) error {
+	db := conn(ctx, r.rdc).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("book_id = ? AND member_id = ? AND status IN ?", bookID, memberID,
+			[]string{models.HoldStatusWaiting, models.HoldStatusReady})
+
+	return r.first(db, hold)
+}
+
+// NextWaiting locks the oldest waiting hold on the book, ErrHoldNotFound says
+// nobody is in line. Every caller takes the book lock first, that is what
+// hands copies coming in at once to different holds, so SKIP LOCKED has
+// nothing to skip and only matters to a caller that doesn't
+func (r *HoldsRepository) NextWaiting(ctx context.Context, hold *models.Holds, bookID int) error {
+	db := conn(ctx, r.rdc).Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("book_id = ? AND status = ?", bookID, models.HoldStatusWaiting).
+		Order("created_at ASC, id ASC")
+
+	return r.first(db, hold)
+}
+
+// GetExpired locks the ready holds on the book whose pickup window ended
+// before at
+func (r *HoldsRepository) GetExpired(ctx context.Context, holds *[]models.Holds, bookID int, at time.Time) error {
+	result := conn(ctx, r.rdc).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("book_id = ? AND status = ? AND expires_at < ?", bookID, models.HoldStatusReady, at).
+		Order("expires_at ASC, id ASC").
+		Find(holds)
+
+	return translateError(result.Error)
+}
+
+// Position counts the waiting holds on the book up to and including hold,
+// the next in line is 1
+func (r *HoldsRepository) Position(ctx context.Context, hold *models.Holds) (int, error) {
+	var position int64
+	result := conn(ctx, r.rdc).Model(&models.Holds{}).
+		Where("book_id = ? AND status = ? AND (created_at, id) <= (?, ?)",
+			hold.BookID, models.HoldStatusWaiting, hold.CreatedAt, hold.ID).
+		Count(&position)
+
+	if result.Error != nil {
+		return 0, translateError(result.Error)
+	}
+
+	return int(position), nil
+}
+
+// Update writes what moves a hold along the queue: its status and the
+// pickup window
+func (r *HoldsRepository) Update(ctx context.Context, hold *models.Holds) error {
+	result := conn(ctx, r.rdc).Model(hold).Select("status", "ready_at", "expires_at").Updates(hold)
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if result.RowsAffected < 1 {
+		return models.ErrHoldNotFound
+	}
+
+	return nil
+}
+
+func (r *HoldsRepository) first(db *gorm.DB, hold *models.Holds, conds ...any) error {
+	result := db.First(hold, conds...)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return models.ErrHoldNotFound
+		}
+		return translateError(result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,317 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateHold(t *testing.T) {
+	createdAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	errHeld := &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: idxHoldsOpenUnique}
+	errNoMember := &pgconn.PgError{Code: pgForeignKeyViolation, ConstraintName: fkHoldsMemberID}
+
+	tests := []struct {
+		name       string
+		hold       *models.Holds
+		expectedID int
+		mock       func(mock sqlmock.Sqlmock)
+		wantErr    bool
+		errType    error
+	}{
+		{
+			name:       "Success create hold",
+			hold:       &models.Holds{BookID: 1, MemberID: 7, Status: models.HoldStatusWaiting, CreatedAt: createdAt},
+			expectedID: 1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "holds" \("book_id","member_id","status","created_at","ready_at","expires_at","updated_at"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7\) RETURNING "id"`).
+					WithArgs(1, 7, models.HoldStatusWaiting, createdAt, nil, nil, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Member holds the book already",
+			hold: &models.Holds{BookID: 1, MemberID: 7, Status: models.HoldStatusWaiting, CreatedAt: createdAt},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "holds" (.+) VALUES (.+)`).
+					WillReturnError(errHeld)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrHoldAlreadyExists.Wrap(errHeld),
+		},
+		{
+			name: "Member not found during create hold",
+			hold: &models.Holds{BookID: 1, MemberID: 99, Status: models.HoldStatusWaiting, CreatedAt: createdAt},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "holds" (.+) VALUES (.+)`).
+					WillReturnError(errNoMember)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrMemberNotFound.Wrap(errNoMember),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewHoldsRepository(gdb)
+
+			err := repo.Create(context.Background(), tt.hold)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedID, tt.hold.ID)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetHold(t *testing.T) {
+	tests := []struct {
+		name         string
+		get          func(repo *HoldsRepository, hold *models.Holds) error
+		mock         func(mock sqlmock.Sqlmock)
+		expectedHold *models.Holds
+		wantErr      bool
+		errType      error
+	}{
+		{
+			name: "Success get hold by ID",
+			get: func(repo *HoldsRepository, hold *models.Holds) error {
+				return repo.GetByID(context.Background(), hold, 3)
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "holds" WHERE "holds"."id" = \$1 ORDER BY "holds"."id" LIMIT \$2$`).
+					WithArgs(3, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "book_id", "member_id", "status"}).AddRow(3, 1, 7, models.HoldStatusWaiting))
+			},
+			expectedHold: &models.Holds{ID: 3, BookID: 1, MemberID: 7, Status: models.HoldStatusWaiting},
+		},
+		{
+			name: "Success get open hold of member",
+			get: func(repo *HoldsRepository, hold *models.Holds) error {
+				return repo.GetOpenForMember(context.Background(), hold, 1, 7)
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "holds" WHERE book_id = \$1 AND member_id = \$2 AND status IN \(\$3,\$4\) ORDER BY "holds"."id" LIMIT \$5 FOR UPDATE`).
+					WithArgs(1, 7, models.HoldStatusWaiting, models.HoldStatusReady, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "book_id", "member_id", "status"}).AddRow(3, 1, 7, models.HoldStatusReady))
+			},
+			expectedHold: &models.Holds{ID: 3, BookID: 1, MemberID: 7, Status: models.HoldStatusReady},
+		},
+		{
+			name: "Success get next waiting hold",
+			get: func(repo *HoldsRepository, hold *models.Holds) error {
+				return repo.NextWaiting(context.Background(), hold, 1)
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "holds" WHERE book_id = \$1 AND status = \$2 ORDER BY created_at ASC, id ASC,"holds"."id" LIMIT \$3 FOR UPDATE SKIP LOCKED`).
+					WithArgs(1, models.HoldStatusWaiting, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "book_id", "member_id", "status"}).AddRow(2, 1, 8, models.HoldStatusWaiting))
+			},
+			expectedHold: &models.Holds{ID: 2, BookID: 1, MemberID: 8, Status: models.HoldStatusWaiting},
+		},
+		{
+			name: "Nobody waiting",
+			get: func(repo *HoldsRepository, hold *models.Holds) error {
+				return repo.NextWaiting(context.Background(), hold, 1)
+			},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "holds"`).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantErr: true,
+			errType: models.ErrHoldNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewHoldsRepository(gdb)
+
+			var hold models.Holds
+			err := tt.get(repo, &hold)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedHold, &hold)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetExpiredHolds(t *testing.T) {
+	at := time.Date(2026, 10, 5, 12, 0, 0, 0, time.UTC)
+
+	gdb, mock, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`SELECT \* FROM "holds" WHERE book_id = \$1 AND status = \$2 AND expires_at < \$3 ORDER BY expires_at ASC, id ASC FOR UPDATE`).
+		WithArgs(1, models.HoldStatusReady, at).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "book_id", "status"}).AddRow(4, 1, models.HoldStatusReady))
+
+	repo := NewHoldsRepository(gdb)
+
+	var holds []models.Holds
+	err := repo.GetExpired(context.Background(), &holds, 1, at)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Holds{{ID: 4, BookID: 1, Status: models.HoldStatusReady}}, holds)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestHoldPosition(t *testing.T) {
+	createdAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		mock             func(mock sqlmock.Sqlmock)
+		expectedPosition int
+		wantErr          bool
+		errType          error
+	}{
+		{
+			name: "Success count holds ahead",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "holds" WHERE book_id = \$1 AND status = \$2 AND \(created_at, id\) <= \(\$3, \$4\)`).
+					WithArgs(1, models.HoldStatusWaiting, createdAt, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			},
+			expectedPosition: 2,
+		},
+		{
+			name: "Database error during count",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "holds"`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewHoldsRepository(gdb)
+
+			position, err := repo.Position(context.Background(), &models.Holds{ID: 3, BookID: 1, CreatedAt: createdAt})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPosition, position)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestUpdateHold(t *testing.T) {
+	readyAt := time.Date(2026, 10, 5, 12, 0, 0, 0, time.UTC)
+	expiresAt := readyAt.Add(72 * time.Hour)
+
+	tests := []struct {
+		name    string
+		hold    *models.Holds
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "Success mark hold ready",
+			hold: &models.Holds{ID: 3, Status: models.HoldStatusReady, ReadyAt: &readyAt, ExpiresAt: &expiresAt},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "holds" SET "status"=\$1,"ready_at"=\$2,"expires_at"=\$3,"updated_at"=\$4 WHERE "id" = \$5`).
+					WithArgs(models.HoldStatusReady, &readyAt, &expiresAt, sqlmock.AnyArg(), 3).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Hold not found during update",
+			hold: &models.Holds{ID: 99, Status: models.HoldStatusCancelled},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "holds"`).
+					WithArgs(models.HoldStatusCancelled, nil, nil, sqlmock.AnyArg(), 99).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			wantErr: true,
+			errType: models.ErrHoldNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewHoldsRepository(gdb)
+
+			err := repo.Update(context.Background(), tt.hold)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
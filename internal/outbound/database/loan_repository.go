@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoansRepository struct {
+	rdc RepositoryDBConn
+}
+
+func NewLoansRepository(repoDBConn RepositoryDBConn) *LoansRepository {
+	return &LoansRepository{rdc: repoDBConn}
+}
+
+// Create fails with ErrBookNotFound or ErrMemberNotFound when either side
+// of the loan doesn't exist
+func (r *LoansRepository) Create(ctx context.Context, loan *models.Loans) error {
+	result := conn(ctx, r.rdc).Create(loan)
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if loan.ID == 0 {
+		return models.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (r *LoansRepository) GetByID(ctx context.Context, loan *models.Loans, id int) error {
+	return r.first(conn(ctx, r.rdc), loan, id)
+}
+
+// GetForUpdate is GetByID locking the row until the transaction in ctx
+// ends, so a loan can't be returned or renewed twice at once
+func (r *LoansRepository) GetForUpdate(ctx context.Context, loan *models.Loans, id int) error {
+	return r.first(conn(ctx, r.rdc).Clauses(clause.Locking{Strength: "UPDATE"}), loan, id)
+}
+
+// Update writes what can change once a loan is out: the due date, the
+// renewals and the return
+func (r *LoansRepository) Update(ctx context.Context, loan *models.Loans) error {
+	result := conn(ctx, r.rdc).Model(loan).Select("due_at", "renewals", "returned_at").Updates(loan)
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if result.RowsAffected < 1 {
+		return models.ErrLoanNotFound
+	}
+
+	return nil
+}
+
+func (r *LoansRepository) first(db *gorm.DB, loan *models.Loans, id int) error {
+	result := db.First(loan, id)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return models.ErrLoanNotFound
+		}
+		return translateError(result.Error)
+	}
+
+	return nil
+}
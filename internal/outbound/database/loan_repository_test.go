@@ -0,0 +1,229 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateLoan(t *testing.T) {
+	loanedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	dueAt := loanedAt.Add(14 * 24 * time.Hour)
+	errNoMember := &pgconn.PgError{Code: pgForeignKeyViolation, ConstraintName: fkLoansMemberID}
+	errNoBook := &pgconn.PgError{Code: pgForeignKeyViolation, ConstraintName: fkLoansBookID}
+
+	tests := []struct {
+		name       string
+		loan       *models.Loans
+		expectedID int
+		mock       func(mock sqlmock.Sqlmock)
+		wantErr    bool
+		errType    error
+	}{
+		{
+			name:       "Success create loan",
+			loan:       &models.Loans{BookID: 1, MemberID: 7, LoanedAt: loanedAt, DueAt: dueAt},
+			expectedID: 1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "loans" \("book_id","member_id","loaned_at","due_at","returned_at","renewals","updated_at"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7\) RETURNING "id"`).
+					WithArgs(1, 7, loanedAt, dueAt, nil, 0, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Member not found during create loan",
+			loan: &models.Loans{BookID: 1, MemberID: 99, LoanedAt: loanedAt, DueAt: dueAt},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "loans" (.+) VALUES (.+)`).
+					WillReturnError(errNoMember)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrMemberNotFound.Wrap(errNoMember),
+		},
+		{
+			name: "Book not found during create loan",
+			loan: &models.Loans{BookID: 99, MemberID: 7, LoanedAt: loanedAt, DueAt: dueAt},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "loans" (.+) VALUES (.+)`).
+					WillReturnError(errNoBook)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrBookNotFound.Wrap(errNoBook),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewLoansRepository(gdb)
+
+			err := repo.Create(context.Background(), tt.loan)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedID, tt.loan.ID)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetLoanByID(t *testing.T) {
+	tests := []struct {
+		name         string
+		id           int
+		forUpdate    bool
+		mock         func(mock sqlmock.Sqlmock)
+		expectedLoan *models.Loans
+		wantErr      bool
+		errType      error
+	}{
+		{
+			name: "Success get loan",
+			id:   1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "loans" WHERE "loans"."id" = \$1 ORDER BY "loans"."id" LIMIT \$2$`).
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "book_id", "member_id", "renewals"}).AddRow(1, 2, 7, 1))
+			},
+			expectedLoan: &models.Loans{ID: 1, BookID: 2, MemberID: 7, Renewals: 1},
+		},
+		{
+			name:      "Success get loan for update",
+			id:        1,
+			forUpdate: true,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "loans" WHERE "loans"."id" = \$1 ORDER BY "loans"."id" LIMIT \$2 FOR UPDATE`).
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "book_id", "member_id"}).AddRow(1, 2, 7))
+			},
+			expectedLoan: &models.Loans{ID: 1, BookID: 2, MemberID: 7},
+		},
+		{
+			name: "Loan not found",
+			id:   99,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "loans"`).
+					WithArgs(99, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantErr: true,
+			errType: models.ErrLoanNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewLoansRepository(gdb)
+
+			var loan models.Loans
+			var err error
+			if tt.forUpdate {
+				err = repo.GetForUpdate(context.Background(), &loan, tt.id)
+			} else {
+				err = repo.GetByID(context.Background(), &loan, tt.id)
+			}
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedLoan, &loan)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestUpdateLoan(t *testing.T) {
+	dueAt := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	returnedAt := time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		loan    *models.Loans
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "Success return loan",
+			loan: &models.Loans{ID: 1, DueAt: dueAt, ReturnedAt: &returnedAt},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "loans" SET "due_at"=\$1,"returned_at"=\$2,"renewals"=\$3,"updated_at"=\$4 WHERE "id" = \$5`).
+					WithArgs(dueAt, &returnedAt, 0, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Loan not found during update",
+			loan: &models.Loans{ID: 99, DueAt: dueAt, Renewals: 1},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "loans"`).
+					WithArgs(dueAt, nil, 1, sqlmock.AnyArg(), 99).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			wantErr: true,
+			errType: models.ErrLoanNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewLoansRepository(gdb)
+
+			err := repo.Update(context.Background(), tt.loan)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type MembersRepository struct {
+	rdc RepositoryDBConn
+}
+
+func NewMembersRepository(repoDBConn RepositoryDBConn) *MembersRepository {
+	return &MembersRepository{rdc: repoDBConn}
+}
+
+func (r *MembersRepository) Create(ctx context.Context, member *models.Members) error {
+	result := conn(ctx, r.rdc).Create(member)
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if member.ID == 0 {
+		return models.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (r *MembersRepository) GetByID(ctx context.Context, member *models.Members, id int) error {
+	result := conn(ctx, r.rdc).First(member, id)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return models.ErrMemberNotFound
+		}
+		return translateError(result.Error)
+	}
+
+	return nil
+}
+
+// GetPage lists members by name, name filters on a case-insensitive contains
+func (r *MembersRepository) GetPage(ctx context.Context, members *[]models.Members, name string, page *models.PageRequest) (*models.PageResult, error) {
+	scope := func(db *gorm.DB) *gorm.DB {
+		if name != "" {
+			db = db.Where("name ILIKE ?", "%"+escapeLike(name)+"%")
+		}
+		return db
+	}
+
+	var total int64
+	if err := conn(ctx, r.rdc).Model(&models.Members{}).Scopes(scope).Count(&total).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	result := conn(ctx, r.rdc).Scopes(scope).
+		Order("name ASC, id ASC").
+		Offset(page.Offset).
+		Limit(page.Limit).
+		Find(members)
+	if result.Error != nil {
+		return nil, translateError(result.Error)
+	}
+
+	return &models.PageResult{
+		Total:   total,
+		HasMore: int64(page.Offset+len(*members)) < total,
+	}, nil
+}
+
+func (r *MembersRepository) Update(ctx context.Context, member *models.Members) error {
+	result := conn(ctx, r.rdc).Model(member).Select("name", "email").Updates(member)
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if result.RowsAffected < 1 {
+		return models.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+// GetLoans lists the loans of a member, latest first
+func (r *MembersRepository) GetLoans(ctx context.Context, loans *[]models.Loans, memberID int, filter *models.LoansFilter, page *models.PageRequest) (*models.PageResult, error) {
+	scope := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("member_id = ?", memberID)
+		switch filter.Status {
+		case models.LoanStatusActive:
+			db = db.Where("returned_at IS NULL AND due_at >= ?", filter.At)
+		case models.LoanStatusOverdue:
+			db = db.Where("returned_at IS NULL AND due_at < ?", filter.At)
+		case models.LoanStatusReturned:
+			db = db.Where("returned_at IS NOT NULL")
+		}
+		return db
+	}
+
+	var total int64
+	if err := conn(ctx, r.rdc).Model(&models.Loans{}).Scopes(scope).Count(&total).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	result := conn(ctx, r.rdc).Scopes(scope).
+		Order("loaned_at DESC, id DESC").
+		Offset(page.Offset).
+		Limit(page.Limit).
+		Find(loans)
+	if result.Error != nil {
+		return nil, translateError(result.Error)
+	}
+
+	return &models.PageResult{
+		Total:   total,
+		HasMore: int64(page.Offset+len(*loans)) < total,
+	}, nil
+}
@@ -0,0 +1,372 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateMember(t *testing.T) {
+	errEmailTaken := &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: ukMembersEmail}
+
+	tests := []struct {
+		name       string
+		member     *models.Members
+		expectedID int
+		mock       func(mock sqlmock.Sqlmock)
+		wantErr    bool
+		errType    error
+	}{
+		{
+			name:       "Success create member",
+			member:     &models.Members{Name: "Paul Atreides", Email: "paul@arrakis.org"},
+			expectedID: 1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "members" \("name","email","created_at","updated_at"\) VALUES \(\$1,\$2,\$3,\$4\) RETURNING "id"`).
+					WithArgs("Paul Atreides", "paul@arrakis.org", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Email taken during create member",
+			member: &models.Members{Name: "Paul Atreides", Email: "paul@arrakis.org"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "members" (.+) VALUES (.+)`).
+					WillReturnError(errEmailTaken)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrMemberEmailTaken.Wrap(errEmailTaken),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewMembersRepository(gdb)
+
+			err := repo.Create(context.Background(), tt.member)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedID, tt.member.ID)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetMemberByID(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             int
+		mock           func(mock sqlmock.Sqlmock)
+		expectedMember *models.Members
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name: "Success get member",
+			id:   1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "members" WHERE "members"."id" = \$1 ORDER BY "members"."id" LIMIT \$2`).
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).AddRow(1, "Paul Atreides", "paul@arrakis.org"))
+			},
+			expectedMember: &models.Members{ID: 1, Name: "Paul Atreides", Email: "paul@arrakis.org"},
+		},
+		{
+			name: "Member not found",
+			id:   99,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "members"`).
+					WithArgs(99, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantErr: true,
+			errType: models.ErrMemberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewMembersRepository(gdb)
+
+			var member models.Members
+			err := repo.GetByID(context.Background(), &member, tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedMember, &member)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetMembersPage(t *testing.T) {
+	tests := []struct {
+		name           string
+		filter         string
+		page           *models.PageRequest
+		mock           func(mock sqlmock.Sqlmock)
+		expectedResult *models.PageResult
+		expectedLen    int
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name:   "Success list members by name",
+			filter: "paul",
+			page:   &models.PageRequest{Limit: 1},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "members" WHERE name ILIKE \$1`).
+					WithArgs("%paul%").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectQuery(`SELECT \* FROM "members" WHERE name ILIKE \$1 ORDER BY name ASC, id ASC LIMIT \$2`).
+					WithArgs("%paul%", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Paul Atreides"))
+			},
+			expectedResult: &models.PageResult{Total: 2, HasMore: true},
+			expectedLen:    1,
+		},
+		{
+			name: "Database error during list members",
+			page: &models.PageRequest{Limit: 10},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "members"`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewMembersRepository(gdb)
+
+			var members []models.Members
+			result, err := repo.GetPage(context.Background(), &members, tt.filter, tt.page)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+				assert.Len(t, members, tt.expectedLen)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestUpdateMember(t *testing.T) {
+	tests := []struct {
+		name    string
+		member  *models.Members
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errType error
+	}{
+		{
+			name:   "Success update member",
+			member: &models.Members{ID: 1, Name: "Paul Atreides", Email: "muaddib@arrakis.org"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "members" SET "name"=\$1,"email"=\$2,"updated_at"=\$3 WHERE "id" = \$4`).
+					WithArgs("Paul Atreides", "muaddib@arrakis.org", sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Member not found during update",
+			member: &models.Members{ID: 99, Name: "Paul Atreides", Email: "paul@arrakis.org"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "members"`).
+					WithArgs("Paul Atreides", "paul@arrakis.org", sqlmock.AnyArg(), 99).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			wantErr: true,
+			errType: models.ErrMemberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewMembersRepository(gdb)
+
+			err := repo.Update(context.Background(), tt.member)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetMemberLoans(t *testing.T) {
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		filter         *models.LoansFilter
+		page           *models.PageRequest
+		mock           func(mock sqlmock.Sqlmock)
+		expectedResult *models.PageResult
+		expectedLen    int
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name:   "Success list every loan of a member",
+			filter: &models.LoansFilter{At: at},
+			page:   &models.PageRequest{Limit: 10},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "loans" WHERE member_id = \$1$`).
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectQuery(`SELECT \* FROM "loans" WHERE member_id = \$1 ORDER BY loaned_at DESC, id DESC LIMIT \$2`).
+					WithArgs(7, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "book_id", "member_id"}).AddRow(2, 1, 7).AddRow(1, 1, 7))
+			},
+			expectedResult: &models.PageResult{Total: 2},
+			expectedLen:    2,
+		},
+		{
+			name:   "Success list active loans",
+			filter: &models.LoansFilter{Status: models.LoanStatusActive, At: at},
+			page:   &models.PageRequest{Limit: 10},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "loans" WHERE member_id = \$1 AND \(returned_at IS NULL AND due_at >= \$2\)`).
+					WithArgs(7, at).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery(`SELECT \* FROM "loans" WHERE member_id = \$1 AND \(returned_at IS NULL AND due_at >= \$2\) ORDER BY`).
+					WithArgs(7, at, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			expectedResult: &models.PageResult{Total: 0},
+		},
+		{
+			name:   "Success list overdue loans",
+			filter: &models.LoansFilter{Status: models.LoanStatusOverdue, At: at},
+			page:   &models.PageRequest{Limit: 10},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "loans" WHERE member_id = \$1 AND \(returned_at IS NULL AND due_at < \$2\)`).
+					WithArgs(7, at).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery(`SELECT \* FROM "loans" WHERE member_id = \$1 AND \(returned_at IS NULL AND due_at < \$2\) ORDER BY`).
+					WithArgs(7, at, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+			},
+			expectedResult: &models.PageResult{Total: 1},
+			expectedLen:    1,
+		},
+		{
+			name:   "Success list returned loans",
+			filter: &models.LoansFilter{Status: models.LoanStatusReturned, At: at},
+			page:   &models.PageRequest{Limit: 10},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "loans" WHERE member_id = \$1 AND returned_at IS NOT NULL`).
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery(`SELECT \* FROM "loans" WHERE member_id = \$1 AND returned_at IS NOT NULL ORDER BY`).
+					WithArgs(7, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			expectedResult: &models.PageResult{Total: 0},
+		},
+		{
+			name:   "Database error during list loans",
+			filter: &models.LoansFilter{At: at},
+			page:   &models.PageRequest{Limit: 10},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "loans"`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewMembersRepository(gdb)
+
+			var loans []models.Loans
+			result, err := repo.GetLoans(context.Background(), &loans, 7, tt.filter, tt.page)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+				assert.Len(t, loans, tt.expectedLen)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
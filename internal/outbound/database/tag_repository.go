@@ -0,0 +1,147 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagsRepository struct {
+	rdc RepositoryDBConn
+}
+
+func NewTagsRepository(repoDBConn RepositoryDBConn) *TagsRepository {
+	return &TagsRepository{rdc: repoDBConn}
+}
+
+func (r *TagsRepository) Create(ctx context.Context, tag *models.Tags) error {
+	result := conn(ctx, r.rdc).Create(tag)
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if tag.ID == 0 {
+		return models.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (r *TagsRepository) GetByID(ctx context.Context, tag *models.Tags, id int) error {
+	result := conn(ctx, r.rdc).First(tag, id)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return models.ErrTagNotFound
+		}
+		return translateError(result.Error)
+	}
+
+	return nil
+}
+
+// GetPage lists tags by name, name filters on a prefix
+func (r *TagsRepository) GetPage(ctx context.Context, tags *[]models.Tags, name string, page *models.PageRequest) (*models.PageResult, error) {
+	scope := func(db *gorm.DB) *gorm.DB {
+		if name != "" {
+			db = db.Where("name LIKE ?", escapeLike(name)+"%")
+		}
+		return db
+	}
+
+	var total int64
+	if err := conn(ctx, r.rdc).Model(&models.Tags{}).Scopes(scope).Count(&total).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	result := conn(ctx, r.rdc).Scopes(scope).
+		Order("name ASC").
+		Offset(page.Offset).
+		Limit(page.Limit).
+		Find(tags)
+	if result.Error != nil {
+		return nil, translateError(result.Error)
+	}
+
+	return &models.PageResult{
+		Total:   total,
+		HasMore: int64(page.Offset+len(*tags)) < total,
+	}, nil
+}
+
+// Delete removes the tag from every book carrying it
+func (r *TagsRepository) Delete(ctx context.Context, id int) error {
+	result := conn(ctx, r.rdc).Delete(&models.Tags{ID: id})
+
+	if result.Error != nil {
+		return translateError(result.Error)
+	} else if result.RowsAffected < 1 {
+		return models.ErrTagNotFound
+	}
+
+	return nil
+}
+
+// ReplaceBookTags makes names, normalized already, the tags of the book and
+// creates the ones that don't exist yet. Run it in a unit of work
+func (r *TagsRepository) ReplaceBookTags(ctx context.Context, bookID int, names []string) error {
+	db := conn(ctx, r.rdc)
+
+	if err := requireBook(db, bookID); err != nil {
+		return err
+	}
+
+	var ids []int
+	if len(names) > 0 {
+		tags := make([]models.Tags, len(names))
+		for i, name := range names {
+			tags[i] = models.Tags{Name: name}
+		}
+		// the IDs of tags that were there already don't come back, so they
+		// are looked up along with the new ones
+		if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error; err != nil {
+			return translateError(err)
+		}
+		if err := db.Model(&models.Tags{}).Where("name IN ?", names).Pluck("id", &ids).Error; err != nil {
+			return translateError(err)
+		}
+	}
+
+	stale := db.Where("book_id = ?", bookID)
+	if len(ids) > 0 {
+		stale = stale.Where("tag_id NOT IN ?", ids)
+	}
+	if err := stale.Delete(&models.BookTags{}).Error; err != nil {
+		return translateError(err)
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	links := make([]models.BookTags, len(ids))
+	for i, id := range ids {
+		links[i] = models.BookTags{BookID: bookID, TagID: id}
+	}
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&links)
+
+	return translateError(result.Error)
+}
+
+// GetBookTags lists the tags of the book by name
+func (r *TagsRepository) GetBookTags(ctx context.Context, tags *[]models.Tags, bookID int) error {
+	db := conn(ctx, r.rdc)
+
+	if err := requireBook(db, bookID); err != nil {
+		return err
+	}
+
+	result := db.Joins("JOIN book_tags ON book_tags.tag_id = tags.id").
+		Where("book_tags.book_id = ?", bookID).
+		Order("tags.name ASC").
+		Find(tags)
+
+	return translateError(result.Error)
+}
@@ -0,0 +1,311 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateTag(t *testing.T) {
+	errNameTaken := &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: ukTagsName}
+
+	tests := []struct {
+		name       string
+		tag        *models.Tags
+		expectedID int
+		mock       func(mock sqlmock.Sqlmock)
+		wantErr    bool
+		errType    error
+	}{
+		{
+			name:       "Success create tag",
+			tag:        &models.Tags{Name: "classic"},
+			expectedID: 1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "tags" \("name","created_at"\) VALUES \(\$1,\$2\) RETURNING "id"`).
+					WithArgs("classic", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Name taken during create tag",
+			tag:  &models.Tags{Name: "classic"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "tags" (.+) VALUES (.+)`).
+					WillReturnError(errNameTaken)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+			errType: models.ErrTagNameTaken.Wrap(errNameTaken),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewTagsRepository(gdb)
+
+			err := repo.Create(context.Background(), tt.tag)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedID, tt.tag.ID)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetTagsPage(t *testing.T) {
+	tests := []struct {
+		name           string
+		prefix         string
+		expectedIDs    []int
+		expectedResult *models.PageResult
+		mock           func(mock sqlmock.Sqlmock)
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name:           "Success get tags by prefix",
+			prefix:         "c",
+			expectedIDs:    []int{1, 3},
+			expectedResult: &models.PageResult{Total: 3, HasMore: true},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "tags" WHERE name LIKE \$1`).
+					WithArgs("c%").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectQuery(`SELECT \* FROM "tags" WHERE name LIKE \$1 ORDER BY name ASC LIMIT \$2`).
+					WithArgs("c%", 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "classic").AddRow(3, "cozy"))
+			},
+		},
+		{
+			name: "Database error during get tags",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "tags"`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewTagsRepository(gdb)
+
+			var tags []models.Tags
+			result, err := repo.GetPage(context.Background(), &tags, tt.prefix, &models.PageRequest{Limit: 2})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+				assert.Equal(t, len(tt.expectedIDs), len(tags))
+				for i, id := range tt.expectedIDs {
+					assert.Equal(t, id, tags[i].ID)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "Success delete tag",
+			id:   1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "tags" WHERE "tags"."id" = \$1`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Tag not found",
+			id:   99,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "tags"`).
+					WithArgs(99).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			wantErr: true,
+			errType: models.ErrTagNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewTagsRepository(gdb)
+
+			err := repo.Delete(context.Background(), tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestReplaceBookTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr bool
+		errType error
+	}{
+		{
+			name:  "Success replace tags of a book",
+			names: []string{"classic", "award"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books" WHERE id = \$1`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "tags" \("name","created_at"\) VALUES \(\$1,\$2\),\(\$3,\$4\) ON CONFLICT DO NOTHING RETURNING "id"`).
+					WithArgs("classic", sqlmock.AnyArg(), "award", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectCommit()
+				mock.ExpectQuery(`SELECT "id" FROM "tags" WHERE name IN \(\$1,\$2\)`).
+					WithArgs("classic", "award").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "book_tags" WHERE book_id = \$1 AND tag_id NOT IN \(\$2,\$3\)`).
+					WithArgs(1, 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "book_tags" \("book_id","tag_id"\) VALUES \(\$1,\$2\),\(\$3,\$4\) ON CONFLICT DO NOTHING`).
+					WithArgs(1, 1, 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Success clear tags of a book",
+			names: []string{},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "book_tags" WHERE book_id = \$1$`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Book not found",
+			names: []string{"classic"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			wantErr: true,
+			errType: models.ErrBookNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gdb, mock, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := NewTagsRepository(gdb)
+
+			err := repo.ReplaceBookTags(context.Background(), 1, tt.names)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGetBookTags(t *testing.T) {
+	gdb, mock, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "books"`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT "tags"."id","tags"."name","tags"."created_at" FROM "tags" JOIN book_tags ON book_tags.tag_id = tags.id WHERE book_tags.book_id = \$1 ORDER BY tags.name ASC`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "award").AddRow(1, "classic"))
+
+	repo := NewTagsRepository(gdb)
+
+	var tags []models.Tags
+	err := repo.GetBookTags(context.Background(), &tags, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Tags{{ID: 2, Name: "award"}, {ID: 1, Name: "classic"}}, tags)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
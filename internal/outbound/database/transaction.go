@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/config"
+	"crud-echo/internal/models"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// retryBackoff is the base wait before retrying a transaction that lost a
+// serialization conflict, doubled on every attempt with some jitter so the
+// losers don't collide again
+const retryBackoff = 10 * time.Millisecond
+
+type txKey struct{}
+
+// TxManager runs a unit of work in one transaction. Repositories called with
+// the context it hands out join that transaction instead of using the pool
+type TxManager struct {
+	rdc        RepositoryDBConn
+	isolation  sql.IsolationLevel
+	maxRetries int
+}
+
+func NewTxManager(rdc RepositoryDBConn, cfg *config.Config) (*TxManager, error) {
+	isolation, err := parseIsolation(cfg.Database.TxIsolation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TxManager{
+		rdc:        rdc,
+		isolation:  isolation,
+		maxRetries: max(cfg.Database.TxMaxRetries, 0),
+	}, nil
+}
+
+// WithinTx commits when fn returns nil and rolls back otherwise. Calls nested
+// in fn join the outer transaction, so only the outermost one commits and
+// retries: a serialization failure or deadlock reruns fn from the start in a
+// fresh transaction, fn must therefore be safe to run more than once
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := m.rdc.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, txKey{}, tx))
+		}, &sql.TxOptions{Isolation: m.isolation})
+
+		err = translateError(err)
+		if err == nil || !errors.Is(err, models.ErrConcurrentUpdate) || attempt >= m.maxRetries {
+			return err
+		}
+
+		wait := retryBackoff << attempt
+		wait += rand.N(wait)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+	}
+}
+
+func txFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok
+}
+
+// conn is what repositories run their statements on, the ambient transaction
+// when there is one and the pool otherwise
+func conn(ctx context.Context, rdc RepositoryDBConn) *gorm.DB {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return rdc.GetDB().WithContext(ctx)
+}
+
+func parseIsolation(level string) (sql.IsolationLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "", "default":
+		return sql.LevelDefault, nil
+	case "read committed":
+		return sql.LevelReadCommitted, nil
+	case "repeatable read":
+		return sql.LevelRepeatableRead, nil
+	case "serializable":
+		return sql.LevelSerializable, nil
+	default:
+		return 0, fmt.Errorf("unknown transaction isolation level %q", level)
+	}
+}
@@ -0,0 +1,263 @@
+package database
+
+import (
+	"context"
+	"crud-echo/internal/config"
+	"crud-echo/internal/models"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func newTestTxManager(t *testing.T, maxRetries int) (*TxManager, *BooksRepository, sqlmock.Sqlmock, func()) {
+	gdb, mock, cleanup := setupTestDB(t)
+
+	txm, err := NewTxManager(gdb, &config.Config{Database: &config.Database{
+		TxIsolation:  "read committed",
+		TxMaxRetries: maxRetries,
+	}})
+	if err != nil {
+		t.Fatalf("Failed to create transaction manager: %v", err)
+	}
+
+	return txm, NewBooksRepository(gdb), mock, cleanup
+}
+
+func expectRestoreAndGet(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(`UPDATE "books" SET "deleted_at"=\$1,"version"=version \+ 1,"updated_at"=\$2 WHERE deleted_at IS NOT NULL AND "id" = \$3`).
+		WithArgs(nil, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`SELECT (.+) FROM "books" WHERE "books"."id" = (.+) ORDER BY "books"."id" LIMIT (.+)`).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "Test Title"))
+}
+
+func TestWithinTx(t *testing.T) {
+	serializationFailure := &pgconn.PgError{Code: pgSerializationFailure}
+
+	tests := []struct {
+		name       string
+		maxRetries int
+		mock       func(mock sqlmock.Sqlmock)
+		fn         func(repo *BooksRepository) func(ctx context.Context) error
+		wantCalls  int
+		wantErr    bool
+		errType    error
+	}{
+		{
+			name: "Success commit when every step succeeds",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectRestoreAndGet(mock)
+				mock.ExpectCommit()
+			},
+			fn: func(repo *BooksRepository) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := repo.Restore(ctx, 1); err != nil {
+						return err
+					}
+					return repo.GetByID(ctx, &models.Books{}, 1)
+				}
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "Rollback when a step fails",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books" SET "deleted_at"=\$1,"version"=version \+ 1,"updated_at"=\$2 WHERE deleted_at IS NOT NULL AND "id" = \$3`).
+					WithArgs(nil, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectRollback()
+			},
+			fn: func(repo *BooksRepository) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					return repo.Restore(ctx, 1)
+				}
+			},
+			wantCalls: 1,
+			wantErr:   true,
+			errType:   models.ErrBookNotFound,
+		},
+		{
+			name: "Nested unit of work joins the outer transaction",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectRestoreAndGet(mock)
+				mock.ExpectCommit()
+			},
+			fn: func(repo *BooksRepository) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := repo.Restore(ctx, 1); err != nil {
+						return err
+					}
+					txm := &TxManager{}
+					return txm.WithinTx(ctx, func(ctx context.Context) error {
+						return repo.GetByID(ctx, &models.Books{}, 1)
+					})
+				}
+			},
+			wantCalls: 1,
+		},
+		{
+			name:       "Retry after a serialization failure",
+			maxRetries: 2,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books"`).WillReturnError(serializationFailure)
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				expectRestoreAndGet(mock)
+				mock.ExpectCommit()
+			},
+			fn: func(repo *BooksRepository) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := repo.Restore(ctx, 1); err != nil {
+						return err
+					}
+					return repo.GetByID(ctx, &models.Books{}, 1)
+				}
+			},
+			wantCalls: 2,
+		},
+		{
+			name:       "Retry after a serialization failure at commit",
+			maxRetries: 1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectRestoreAndGet(mock)
+				mock.ExpectCommit().WillReturnError(serializationFailure)
+				mock.ExpectBegin()
+				expectRestoreAndGet(mock)
+				mock.ExpectCommit()
+			},
+			fn: func(repo *BooksRepository) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := repo.Restore(ctx, 1); err != nil {
+						return err
+					}
+					return repo.GetByID(ctx, &models.Books{}, 1)
+				}
+			},
+			wantCalls: 2,
+		},
+		{
+			name:       "Give up once retries run out",
+			maxRetries: 1,
+			mock: func(mock sqlmock.Sqlmock) {
+				for range 2 {
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE "books"`).WillReturnError(serializationFailure)
+					mock.ExpectRollback()
+				}
+			},
+			fn: func(repo *BooksRepository) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					return repo.Restore(ctx, 1)
+				}
+			},
+			wantCalls: 2,
+			wantErr:   true,
+			errType:   models.ErrConcurrentUpdate.Wrap(serializationFailure),
+		},
+		{
+			name:       "No retry for other errors",
+			maxRetries: 3,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "books"`).WillReturnError(gorm.ErrInvalidDB)
+				mock.ExpectRollback()
+			},
+			fn: func(repo *BooksRepository) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					return repo.Restore(ctx, 1)
+				}
+			},
+			wantCalls: 1,
+			wantErr:   true,
+			errType:   gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txm, repo, mock, cleanup := newTestTxManager(t, tt.maxRetries)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			var calls int
+			fn := tt.fn(repo)
+			err := txm.WithinTx(context.Background(), func(ctx context.Context) error {
+				calls++
+				return fn(ctx)
+			})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestWithinTxStopsRetryingOnCancel(t *testing.T) {
+	txm, repo, mock, cleanup := newTestTxManager(t, 5)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "books"`).WillReturnError(&pgconn.PgError{Code: pgDeadlockDetected})
+	mock.ExpectRollback()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls int
+	err := txm.WithinTx(ctx, func(txCtx context.Context) error {
+		calls++
+		err := repo.Restore(txCtx, 1)
+		cancel()
+		return err
+	})
+
+	assert.ErrorIs(t, err, models.ErrConcurrentUpdate)
+	assert.Equal(t, 1, calls)
+}
+
+func TestParseIsolation(t *testing.T) {
+	tests := []struct {
+		level   string
+		want    sql.IsolationLevel
+		wantErr bool
+	}{
+		{level: "", want: sql.LevelDefault},
+		{level: "read committed", want: sql.LevelReadCommitted},
+		{level: "Repeatable Read", want: sql.LevelRepeatableRead},
+		{level: " serializable ", want: sql.LevelSerializable},
+		{level: "snapshot", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			got, err := parseIsolation(tt.level)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"crud-echo/pkg/logger"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+type UsecaseAuthorsRepository interface {
+	Create(ctx context.Context, author *models.Authors) error
+	GetByID(ctx context.Context, author *models.Authors, id int) error
+	GetPage(ctx context.Context, authors *[]models.Authors, name string, page *models.PageRequest) (*models.PageResult, error)
+	Update(ctx context.Context, author *models.Authors) error
+	Delete(ctx context.Context, id int) error
+	GetBooks(ctx context.Context, books *[]models.Books, authorID int, page *models.PageRequest) (*models.PageResult, error)
+}
+
+type AuthorsUseCase struct {
+	authorRepo UsecaseAuthorsRepository
+}
+
+func NewAuthorsUseCase(repo UsecaseAuthorsRepository) *AuthorsUseCase {
+	return &AuthorsUseCase{authorRepo: repo}
+}
+
+func (uc *AuthorsUseCase) CreateAuthor(ctx context.Context, authorRequest *models.CreateAuthorsRequest) (*models.AuthorsSummary, error) {
+	ctx, span := tracer.Start(ctx, "AuthorsUseCase.CreateAuthor")
+	defer span.End()
+
+	author := &models.Authors{
+		Name: authorRequest.Name,
+		Bio:  authorRequest.Bio,
+	}
+
+	if err := uc.authorRepo.Create(ctx, author); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return author.ToAuthorsSummary(), nil
+}
+
+func (uc *AuthorsUseCase) GetAuthorByID(ctx context.Context, id int) (*models.AuthorsSummary, error) {
+	ctx, span := tracer.Start(ctx, "AuthorsUseCase.GetAuthorByID")
+	defer span.End()
+
+	var author models.Authors
+	if err := uc.authorRepo.GetByID(ctx, &author, id); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return author.ToAuthorsSummary(), nil
+}
+
+func (uc *AuthorsUseCase) GetAllAuthors(ctx context.Context, name string, page *models.PageRequest) (*[]models.AuthorsSummary, *models.PageMeta, error) {
+	ctx, span := tracer.Start(ctx, "AuthorsUseCase.GetAllAuthors")
+	defer span.End()
+
+	var authors []models.Authors
+
+	result, err := uc.authorRepo.GetPage(ctx, &authors, name, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	authorsList := make([]models.AuthorsSummary, 0, len(authors))
+	for _, author := range authors {
+		authorsList = append(authorsList, *author.ToAuthorsSummary())
+	}
+
+	return &authorsList, &models.PageMeta{
+		Total:  result.Total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}, nil
+}
+
+func (uc *AuthorsUseCase) UpdateAuthor(ctx context.Context, authorRequest *models.UpdateAuthorsRequest) (*models.AuthorsSummary, error) {
+	ctx, span := tracer.Start(ctx, "AuthorsUseCase.UpdateAuthor")
+	defer span.End()
+
+	author := &models.Authors{
+		ID:   authorRequest.ID,
+		Name: authorRequest.Name,
+		Bio:  authorRequest.Bio,
+	}
+
+	if err := uc.authorRepo.Update(ctx, author); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return author.ToAuthorsSummary(), nil
+}
+
+func (uc *AuthorsUseCase) DeleteAuthor(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "AuthorsUseCase.DeleteAuthor")
+	defer span.End()
+
+	if err := uc.authorRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+	// the links to its books go with it
+	logger.FromContext(ctx).Info("author deleted", zap.Int("id", id))
+
+	return nil
+}
+
+func (uc *AuthorsUseCase) GetAuthorBooks(ctx context.Context, id int, page *models.PageRequest) (*[]models.BooksSummary, *models.PageMeta, error) {
+	ctx, span := tracer.Start(ctx, "AuthorsUseCase.GetAuthorBooks")
+	defer span.End()
+
+	// an unknown author is a 404, not an empty list
+	var author models.Authors
+	if err := uc.authorRepo.GetByID(ctx, &author, id); err != nil {
+		return nil, nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	var books []models.Books
+	result, err := uc.authorRepo.GetBooks(ctx, &books, id, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	booksList := make([]models.BooksSummary, 0, len(books))
+	for _, book := range books {
+		booksList = append(booksList, *book.ToBooksSummary())
+	}
+
+	return &booksList, &models.PageMeta{
+		Total:  result.Total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}, nil
+}
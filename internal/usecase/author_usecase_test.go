@@ -0,0 +1,331 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateAuthor(t *testing.T) {
+	tests := []struct {
+		name           string
+		authorRequest  *models.CreateAuthorsRequest
+		mock           func(mock *mocks.MockusecaseAuthorsRepository)
+		expectedAuthor *models.AuthorsSummary
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name:          "Success create author",
+			authorRequest: &models.CreateAuthorsRequest{Name: "Frank Herbert", Bio: "Wrote Dune"},
+			mock: func(mock *mocks.MockusecaseAuthorsRepository) {
+				mock.EXPECT().Create(anyCtx, &models.Authors{Name: "Frank Herbert", Bio: "Wrote Dune"}).
+					RunAndReturn(func(_ context.Context, author *models.Authors) error {
+						author.ID = 1
+						return nil
+					})
+			},
+			expectedAuthor: &models.AuthorsSummary{ID: 1, Name: "Frank Herbert", Bio: "Wrote Dune"},
+		},
+		{
+			name:          "Failed create author due to invalid DB",
+			authorRequest: &models.CreateAuthorsRequest{Name: "Frank Herbert"},
+			mock: func(mock *mocks.MockusecaseAuthorsRepository) {
+				mock.EXPECT().Create(anyCtx, &models.Authors{Name: "Frank Herbert"}).Return(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", gorm.ErrInvalidDB),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseAuthorsRepository(t)
+			tt.mock(mock)
+
+			uc := NewAuthorsUseCase(mock)
+
+			author, err := uc.CreateAuthor(context.Background(), tt.authorRequest)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAuthor, author)
+			}
+		})
+	}
+}
+
+func TestGetAuthorByID(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             int
+		mock           func(mock *mocks.MockusecaseAuthorsRepository)
+		expectedAuthor *models.AuthorsSummary
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name: "Success get author",
+			id:   1,
+			mock: func(mock *mocks.MockusecaseAuthorsRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Authors{}, 1).
+					RunAndReturn(func(_ context.Context, author *models.Authors, id int) error {
+						*author = models.Authors{ID: id, Name: "Frank Herbert"}
+						return nil
+					})
+			},
+			expectedAuthor: &models.AuthorsSummary{ID: 1, Name: "Frank Herbert"},
+		},
+		{
+			name: "Failed get author due to author not found",
+			id:   99,
+			mock: func(mock *mocks.MockusecaseAuthorsRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Authors{}, 99).Return(models.ErrAuthorNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrAuthorNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseAuthorsRepository(t)
+			tt.mock(mock)
+
+			uc := NewAuthorsUseCase(mock)
+
+			author, err := uc.GetAuthorByID(context.Background(), tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAuthor, author)
+			}
+		})
+	}
+}
+
+func TestGetAllAuthors(t *testing.T) {
+	page := &models.PageRequest{Limit: 2, Offset: 2}
+
+	tests := []struct {
+		name            string
+		mock            func(mock *mocks.MockusecaseAuthorsRepository)
+		expectedAuthors *[]models.AuthorsSummary
+		expectedMeta    *models.PageMeta
+		wantErr         bool
+		errType         error
+	}{
+		{
+			name: "Success get authors",
+			mock: func(mock *mocks.MockusecaseAuthorsRepository) {
+				mock.EXPECT().GetPage(anyCtx, new([]models.Authors), "herbert", page).
+					RunAndReturn(func(_ context.Context, authors *[]models.Authors, _ string, _ *models.PageRequest) (*models.PageResult, error) {
+						*authors = []models.Authors{{ID: 3, Name: "Brian Herbert"}}
+						return &models.PageResult{Total: 3}, nil
+					})
+			},
+			expectedAuthors: &[]models.AuthorsSummary{{ID: 3, Name: "Brian Herbert"}},
+			expectedMeta:    &models.PageMeta{Total: 3, Limit: 2, Offset: 2},
+		},
+		{
+			name: "Failed get authors due to invalid DB",
+			mock: func(mock *mocks.MockusecaseAuthorsRepository) {
+				mock.EXPECT().GetPage(anyCtx, new([]models.Authors), "herbert", page).Return(nil, gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", gorm.ErrInvalidDB),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseAuthorsRepository(t)
+			tt.mock(mock)
+
+			uc := NewAuthorsUseCase(mock)
+
+			authors, meta, err := uc.GetAllAuthors(context.Background(), "herbert", page)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAuthors, authors)
+				assert.Equal(t, tt.expectedMeta, meta)
+			}
+		})
+	}
+}
+
+func TestUpdateAuthor(t *testing.T) {
+	tests := []struct {
+		name           string
+		authorRequest  *models.UpdateAuthorsRequest
+		mock           func(mock *mocks.MockusecaseAuthorsRepository)
+		expectedAuthor *models.AuthorsSummary
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name:          "Success update author",
+			authorRequest: &models.UpdateAuthorsRequest{ID: 1, Name: "Frank Herbert", Bio: "Wrote Dune"},
+			mock: func(mock *mocks.MockusecaseAuthorsRepository) {
+				mock.EXPECT().Update(anyCtx, &models.Authors{ID: 1, Name: "Frank Herbert", Bio: "Wrote Dune"}).Return(nil)
+			},
+			expectedAuthor: &models.AuthorsSummary{ID: 1, Name: "Frank Herbert", Bio: "Wrote Dune"},
+		},
+		{
+			name:          "Failed update author due to author not found",
+			authorRequest: &models.UpdateAuthorsRequest{ID: 99, Name: "Frank Herbert"},
+			mock: func(mock *mocks.MockusecaseAuthorsRepository) {
+				mock.EXPECT().Update(anyCtx, &models.Authors{ID: 99, Name: "Frank Herbert"}).Return(models.ErrAuthorNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrAuthorNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseAuthorsRepository(t)
+			tt.mock(mock)
+
+			uc := NewAuthorsUseCase(mock)
+
+			author, err := uc.UpdateAuthor(context.Background(), tt.authorRequest)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAuthor, author)
+			}
+		})
+	}
+}
+
+func TestDeleteAuthor(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		mock    func(mock *mocks.MockusecaseAuthorsRepository)
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "Success delete author",
+			id:   1,
+			mock: func(mock *mocks.MockusecaseAuthorsRepository) {
+				mock.EXPECT().Delete(anyCtx, 1).Return(nil)
+			},
+		},
+		{
+			name: "Failed delete author due to author not found",
+			id:   99,
+			mock: func(mock *mocks.MockusecaseAuthorsRepository) {
+				mock.EXPECT().Delete(anyCtx, 99).Return(models.ErrAuthorNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrAuthorNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseAuthorsRepository(t)
+			tt.mock(mock)
+
+			uc := NewAuthorsUseCase(mock)
+
+			err := uc.DeleteAuthor(context.Background(), tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetAuthorBooks(t *testing.T) {
+	page := &models.PageRequest{Limit: 10}
+
+	tests := []struct {
+		name          string
+		id            int
+		mock          func(mock *mocks.MockusecaseAuthorsRepository)
+		expectedBooks *[]models.BooksSummary
+		expectedMeta  *models.PageMeta
+		wantErr       bool
+		errType       error
+	}{
+		{
+			name: "Success get books of an author",
+			id:   7,
+			mock: func(mock *mocks.MockusecaseAuthorsRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Authors{}, 7).Return(nil)
+				mock.EXPECT().GetBooks(anyCtx, new([]models.Books), 7, page).
+					RunAndReturn(func(_ context.Context, books *[]models.Books, _ int, _ *models.PageRequest) (*models.PageResult, error) {
+						*books = []models.Books{{ID: 1, Title: "Dune", Version: 2}}
+						return &models.PageResult{Total: 1}, nil
+					})
+			},
+			expectedBooks: &[]models.BooksSummary{{ID: 1, Title: "Dune", Version: 2}},
+			expectedMeta:  &models.PageMeta{Total: 1, Limit: 10},
+		},
+		{
+			name: "Success get books of an author without any",
+			id:   7,
+			mock: func(mock *mocks.MockusecaseAuthorsRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Authors{}, 7).Return(nil)
+				mock.EXPECT().GetBooks(anyCtx, new([]models.Books), 7, page).Return(&models.PageResult{}, nil)
+			},
+			expectedBooks: &[]models.BooksSummary{},
+			expectedMeta:  &models.PageMeta{Limit: 10},
+		},
+		{
+			name: "Failed get books due to author not found",
+			id:   99,
+			mock: func(mock *mocks.MockusecaseAuthorsRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Authors{}, 99).Return(models.ErrAuthorNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrAuthorNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseAuthorsRepository(t)
+			tt.mock(mock)
+
+			uc := NewAuthorsUseCase(mock)
+
+			books, meta, err := uc.GetAuthorBooks(context.Background(), tt.id, page)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBooks, books)
+				assert.Equal(t, tt.expectedMeta, meta)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGetBookByIDWithAuthors(t *testing.T) {
+	tests := []struct {
+		name         string
+		include      models.BooksInclude
+		mock         func(mock *mocks.MockusecaseBooksRepository)
+		expectedBook *models.BooksSummary
+		wantErr      bool
+		errType      error
+	}{
+		{
+			name:    "Success get book with its authors",
+			include: models.BooksInclude{Authors: true},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(10))
+				mock.EXPECT().LoadAuthors(anyCtx, []models.Books{{ID: 1, Qty: 10}}).
+					RunAndReturn(func(_ context.Context, books []models.Books) error {
+						books[0].Authors = []models.Authors{{ID: 7, Name: "Frank Herbert"}}
+						return nil
+					})
+			},
+			expectedBook: &models.BooksSummary{ID: 1, Qty: 10, Authors: []models.AuthorsSummary{{ID: 7, Name: "Frank Herbert"}}},
+		},
+		{
+			name: "Success get book without asking for authors",
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(10))
+			},
+			expectedBook: &models.BooksSummary{ID: 1, Qty: 10},
+		},
+		{
+			name:    "Failed get book due to authors lookup",
+			include: models.BooksInclude{Authors: true},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(10))
+				mock.EXPECT().LoadAuthors(anyCtx, []models.Books{{ID: 1, Qty: 10}}).Return(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", gorm.ErrInvalidDB),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseBooksRepository(t)
+			tt.mock(mock)
+
+			uc := newTestBooksUseCase(t, mock)
+
+			book, err := uc.GetBookByID(context.Background(), 1, tt.include)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBook, book)
+			}
+		})
+	}
+}
+
+func TestGetAllBooksWithAuthors(t *testing.T) {
+	page := &models.PageRequest{Limit: 10, Sort: []models.SortField{{Column: "title"}}}
+
+	mock := mocks.NewMockusecaseBooksRepository(t)
+	mock.EXPECT().GetPage(anyCtx, new([]models.Books), &models.BooksFilter{}, page).
+		RunAndReturn(func(_ context.Context, books *[]models.Books, _ *models.BooksFilter, _ *models.PageRequest) (*models.PageResult, error) {
+			*books = []models.Books{{ID: 1}, {ID: 2}}
+			return &models.PageResult{Total: 2}, nil
+		})
+	// one call for the whole page
+	mock.EXPECT().LoadAuthors(anyCtx, []models.Books{{ID: 1}, {ID: 2}}).
+		RunAndReturn(func(_ context.Context, books []models.Books) error {
+			books[0].Authors = []models.Authors{{ID: 7, Name: "Frank Herbert"}}
+			books[1].Authors = []models.Authors{}
+			return nil
+		}).Once()
+
+	uc := newTestBooksUseCase(t, mock)
+
+	books, _, err := uc.GetAllBooks(context.Background(), &models.BooksFilter{}, page, models.BooksInclude{Authors: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &[]models.BooksSummary{
+		{ID: 1, Authors: []models.AuthorsSummary{{ID: 7, Name: "Frank Herbert"}}},
+		{ID: 2, Authors: []models.AuthorsSummary{}},
+	}, books)
+}
+
+func TestCreateBookWithAuthors(t *testing.T) {
+	mock := mocks.NewMockusecaseBooksRepository(t)
+	mock.EXPECT().Create(anyCtx, &models.Books{
+		Title:       "Test Title",
+		Description: "Test Description",
+		Qty:         10,
+		Authors:     []models.Authors{{ID: 7}, {ID: 8}},
+	}).Return(models.ErrUnknownAuthor)
+
+	uc := newTestBooksUseCase(t, mock)
+
+	_, err := uc.CreateBook(context.Background(), &models.CreateBooksRequest{
+		Title:       "Test Title",
+		Description: "Test Description",
+		Qty:         10,
+		AuthorIDs:   []int{7, 8},
+	})
+
+	assert.Equal(t, fmt.Errorf("repository error: %w", models.ErrUnknownAuthor), err)
+}
+
+func TestUpdateBookAuthors(t *testing.T) {
+	updated := &models.Books{ID: 1, Title: "Updated Title", Description: "Updated Description", Qty: 10}
+
+	tests := []struct {
+		name      string
+		authorIDs *[]int
+		mock      func(mock *mocks.MockusecaseBooksRepository)
+		wantErr   bool
+		errType   error
+	}{
+		{
+			name:      "Success replace authors",
+			authorIDs: &[]int{7, 8},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().ReplaceAuthors(anyCtx, 1, []int{7, 8}).Return(nil)
+			},
+		},
+		{
+			name:      "Success remove every author",
+			authorIDs: &[]int{},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().ReplaceAuthors(anyCtx, 1, []int{}).Return(nil)
+			},
+		},
+		{
+			name: "Success leave authors alone",
+			mock: func(mock *mocks.MockusecaseBooksRepository) {},
+		},
+		{
+			name:      "Failed update due to unknown author",
+			authorIDs: &[]int{99},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().ReplaceAuthors(anyCtx, 1, []int{99}).Return(models.ErrUnknownAuthor)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrUnknownAuthor),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseBooksRepository(t)
+			mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(10))
+			mock.EXPECT().Update(anyCtx, updated).Return(nil)
+			tt.mock(mock)
+
+			uc := newTestBooksUseCase(t, mock)
+
+			err := uc.UpdateBook(context.Background(), &models.UpdateBooksRequest{
+				ID:          1,
+				Title:       "Updated Title",
+				Description: "Updated Description",
+				Qty:         10,
+				AuthorIDs:   tt.authorIDs,
+			})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"fmt"
+)
+
+// BulkCreateBooks creates the books in batches. Atomic requests go all in or
+// not at all, the error returned is what failed them. Otherwise every item
+// stands alone and the error is only for when nothing could be attempted
+func (uc *BooksUseCase) BulkCreateBooks(ctx context.Context, books []models.CreateBooksRequest, atomic bool) ([]models.BulkResult, error) {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.BulkCreateBooks")
+	defer span.End()
+
+	results := models.NewBulkResults(len(books))
+	if len(books) == 0 {
+		return results, nil
+	}
+
+	// titles clashing within the request or with existing books are caught
+	// here so they fail on their own, the unique index still has the last word
+	titles := make([]string, len(books))
+	for i, book := range books {
+		titles[i] = book.Title
+	}
+	taken, err := uc.bookRepo.TakenTitles(ctx, titles)
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	seen := make(map[string]bool, len(books)+len(taken))
+	for _, title := range taken {
+		seen[title] = true
+	}
+	for i, title := range titles {
+		key := models.NormalizeTitle(title)
+		if seen[key] {
+			results[i].Fail(models.ErrBookTitleTaken)
+		}
+		seen[key] = true
+	}
+
+	pending := make([]models.Books, 0, len(books))
+	indexes := make([]int, 0, len(books))
+	for i, book := range books {
+		if results[i].Failed() {
+			continue
+		}
+		pending = append(pending, models.Books{
+			Title:       book.Title,
+			Description: book.Description,
+			Qty:         book.Qty,
+			Authors:     models.AuthorRefs(book.AuthorIDs),
+
+			BooksBibliography: book.Normalized(),
+		})
+		indexes = append(indexes, i)
+	}
+
+	if atomic {
+		if err := firstFailure(results); err != nil {
+			models.RollBackBulk(results)
+			return results, err
+		}
+
+		if err := uc.createInBatches(ctx, pending); err != nil {
+			// a single statement failed, there's no telling which row did it
+			for _, i := range indexes {
+				results[i].Fail(err)
+			}
+			return results, fmt.Errorf("repository error: %w", err)
+		}
+		for j, i := range indexes {
+			results[i].ID = pending[j].ID
+		}
+		return results, nil
+	}
+
+	for j, err := range uc.createEach(ctx, pending) {
+		if err != nil {
+			results[indexes[j]].Fail(err)
+			continue
+		}
+		results[indexes[j]].ID = pending[j].ID
+	}
+
+	return results, nil
+}
+
+// createEach creates books in batches, each committing on its own, and
+// returns the error of every book (nil once it has its ID)
+func (uc *BooksUseCase) createEach(ctx context.Context, books []models.Books) []error {
+	errs := make([]error, len(books))
+
+	for start := 0; start < len(books); start += models.BulkBatchSize {
+		end := min(start+models.BulkBatchSize, len(books))
+		batch := books[start:end]
+
+		if err := uc.createInBatches(ctx, batch); err == nil {
+			continue
+		}
+
+		// one bad row fails the whole INSERT, go one by one to find it
+		for j := range batch {
+			batch[j].ID = 0
+			errs[start+j] = uc.create(ctx, &batch[j])
+		}
+	}
+
+	return errs
+}
+
+func (uc *BooksUseCase) BulkUpdateBooks(ctx context.Context, books []models.BulkUpdateBooksItem, atomic bool) ([]models.BulkResult, error) {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.BulkUpdateBooks")
+	defer span.End()
+
+	return uc.bulkApply(ctx, len(books), atomic, func(ctx context.Context, i int) (int, error) {
+		book := &models.Books{
+			ID:          books[i].ID,
+			Title:       books[i].Title,
+			Description: books[i].Description,
+			Qty:         books[i].Qty,
+			Version:     books[i].Version,
+
+			BooksBibliography: books[i].Normalized(),
+		}
+		return book.ID, uc.update(ctx, book)
+	})
+}
+
+func (uc *BooksUseCase) BulkDeleteBooks(ctx context.Context, books []models.BulkDeleteBooksItem, atomic bool) ([]models.BulkResult, error) {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.BulkDeleteBooks")
+	defer span.End()
+
+	return uc.bulkApply(ctx, len(books), atomic, func(ctx context.Context, i int) (int, error) {
+		book := &models.Books{
+			ID:      books[i].ID,
+			Version: books[i].Version,
+		}
+		return book.ID, uc.bookRepo.Delete(ctx, book)
+	})
+}
+
+// bulkApply runs apply for each of the n items. Atomic requests share one
+// transaction and stop at the first failure, otherwise each item commits on
+// its own
+func (uc *BooksUseCase) bulkApply(ctx context.Context, n int, atomic bool, apply func(ctx context.Context, i int) (int, error)) ([]models.BulkResult, error) {
+	if !atomic {
+		results := models.NewBulkResults(n)
+		for i := range results {
+			id, err := apply(ctx, i)
+			if err != nil {
+				results[i].Fail(err)
+				continue
+			}
+			results[i].ID = id
+		}
+		return results, nil
+	}
+
+	var results []models.BulkResult
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// a retried transaction starts over with a clean slate
+		results = models.NewBulkResults(n)
+		for i := range results {
+			id, err := apply(ctx, i)
+			if err != nil {
+				results[i].Fail(err)
+				return err
+			}
+			results[i].ID = id
+		}
+		return nil
+	})
+	if err != nil {
+		models.RollBackBulk(results)
+		return results, fmt.Errorf("repository error: %w", err)
+	}
+
+	return results, nil
+}
+
+// firstFailure returns the error behind the first failed result, nil when
+// none failed
+func firstFailure(results []models.BulkResult) error {
+	for _, r := range results {
+		if !r.Failed() {
+			continue
+		}
+		if de, ok := models.LookupDomainError(r.Code); ok {
+			return de
+		}
+		return models.ErrInternalServerError
+	}
+	return nil
+}
@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func bulkCreateRequests(titles ...string) []models.CreateBooksRequest {
+	books := make([]models.CreateBooksRequest, len(titles))
+	for i, title := range titles {
+		books[i] = models.CreateBooksRequest{Title: title, Description: "Test Description", Qty: 10}
+	}
+	return books
+}
+
+func bulkBooks(titles ...string) []models.Books {
+	books := make([]models.Books, len(titles))
+	for i, title := range titles {
+		books[i] = models.Books{Title: title, Description: "Test Description", Qty: 10}
+	}
+	return books
+}
+
+// assignIDs stands in for the INSERT ... RETURNING "id"
+func assignIDs(first int) func(context.Context, []models.Books, int) error {
+	return func(_ context.Context, books []models.Books, _ int) error {
+		for i := range books {
+			books[i].ID = first + i
+		}
+		return nil
+	}
+}
+
+func failed(index int, de *models.DomainError) models.BulkResult {
+	return models.BulkResult{Index: index, Code: de.Code, Message: de.Message}
+}
+
+func TestBulkCreateBooks(t *testing.T) {
+	tests := []struct {
+		name            string
+		books           []models.CreateBooksRequest
+		atomic          bool
+		mock            func(mock *mocks.MockusecaseBooksRepository)
+		expectedResults []models.BulkResult
+		wantErr         bool
+		errType         error
+	}{
+		{
+			name:   "Success create books best effort",
+			books:  bulkCreateRequests("Title A", "Title B"),
+			atomic: false,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().TakenTitles(anyCtx, []string{"Title A", "Title B"}).Return(nil, nil)
+				mock.EXPECT().CreateInBatches(anyCtx, bulkBooks("Title A", "Title B"), models.BulkBatchSize).
+					RunAndReturn(assignIDs(1))
+				mock.EXPECT().CreateMovements(anyCtx, opening(1, 2)).Return(nil)
+			},
+			expectedResults: []models.BulkResult{{Index: 0, ID: 1}, {Index: 1, ID: 2}},
+		},
+		{
+			name:   "Titles taken or repeated fail on their own best effort",
+			books:  bulkCreateRequests("Title A", "Title B", "title a ", "Title C"),
+			atomic: false,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().TakenTitles(anyCtx, []string{"Title A", "Title B", "title a ", "Title C"}).
+					Return([]string{"title b"}, nil)
+				mock.EXPECT().CreateInBatches(anyCtx, bulkBooks("Title A", "Title C"), models.BulkBatchSize).
+					RunAndReturn(assignIDs(1))
+				mock.EXPECT().CreateMovements(anyCtx, opening(1, 2)).Return(nil)
+			},
+			expectedResults: []models.BulkResult{
+				{Index: 0, ID: 1},
+				failed(1, models.ErrBookTitleTaken),
+				failed(2, models.ErrBookTitleTaken),
+				{Index: 3, ID: 2},
+			},
+		},
+		{
+			name:   "Failed batch falls back to one by one best effort",
+			books:  bulkCreateRequests("Title A", "Title B"),
+			atomic: false,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().TakenTitles(anyCtx, []string{"Title A", "Title B"}).Return(nil, nil)
+				mock.EXPECT().CreateInBatches(anyCtx, bulkBooks("Title A", "Title B"), models.BulkBatchSize).
+					Return(models.ErrBookTitleTaken)
+				mock.EXPECT().Create(anyCtx, &models.Books{Title: "Title A", Description: "Test Description", Qty: 10}).
+					Return(models.ErrBookTitleTaken)
+				mock.EXPECT().Create(anyCtx, &models.Books{Title: "Title B", Description: "Test Description", Qty: 10}).
+					RunAndReturn(func(_ context.Context, book *models.Books) error {
+						book.ID = 7
+						return nil
+					})
+				mock.EXPECT().CreateMovements(anyCtx, opening(7)).Return(nil)
+			},
+			expectedResults: []models.BulkResult{
+				failed(0, models.ErrBookTitleTaken),
+				{Index: 1, ID: 7},
+			},
+		},
+		{
+			name:   "Success create books atomic",
+			books:  bulkCreateRequests("Title A", "Title B"),
+			atomic: true,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().TakenTitles(anyCtx, []string{"Title A", "Title B"}).Return(nil, nil)
+				mock.EXPECT().CreateInBatches(anyCtx, bulkBooks("Title A", "Title B"), models.BulkBatchSize).
+					RunAndReturn(assignIDs(5))
+				mock.EXPECT().CreateMovements(anyCtx, opening(5, 6)).Return(nil)
+			},
+			expectedResults: []models.BulkResult{{Index: 0, ID: 5}, {Index: 1, ID: 6}},
+		},
+		{
+			name:   "Taken title rolls back everything atomic",
+			books:  bulkCreateRequests("Title A", "Title B"),
+			atomic: true,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().TakenTitles(anyCtx, []string{"Title A", "Title B"}).Return([]string{"title b"}, nil)
+			},
+			expectedResults: []models.BulkResult{
+				failed(0, models.ErrBulkRolledBack),
+				failed(1, models.ErrBookTitleTaken),
+			},
+			wantErr: true,
+			errType: models.ErrBookTitleTaken,
+		},
+		{
+			name:   "Failed insert fails every item atomic",
+			books:  bulkCreateRequests("Title A", "Title B"),
+			atomic: true,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().TakenTitles(anyCtx, []string{"Title A", "Title B"}).Return(nil, nil)
+				mock.EXPECT().CreateInBatches(anyCtx, bulkBooks("Title A", "Title B"), models.BulkBatchSize).
+					Return(models.ErrBookTitleTaken)
+			},
+			expectedResults: []models.BulkResult{
+				failed(0, models.ErrBookTitleTaken),
+				failed(1, models.ErrBookTitleTaken),
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrBookTitleTaken),
+		},
+		{
+			name:   "Failed title lookup",
+			books:  bulkCreateRequests("Title A"),
+			atomic: false,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().TakenTitles(anyCtx, []string{"Title A"}).Return(nil, gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", gorm.ErrInvalidDB),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseBooksRepository(t)
+			tt.mock(mock)
+
+			uc := newTestBooksUseCase(t, mock)
+
+			results, err := uc.BulkCreateBooks(context.Background(), tt.books, tt.atomic)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedResults, results)
+		})
+	}
+}
+
+func TestBulkUpdateBooks(t *testing.T) {
+	books := []models.BulkUpdateBooksItem{
+		{ID: 1, Title: "Title A", Description: "Test Description", Qty: 10, Version: 2},
+		{ID: 2, Title: "Title B", Description: "Test Description", Qty: 10, Version: 3},
+	}
+	book := func(i int) *models.Books {
+		return &models.Books{ID: books[i].ID, Title: books[i].Title, Description: books[i].Description, Qty: books[i].Qty, Version: books[i].Version}
+	}
+
+	tests := []struct {
+		name            string
+		atomic          bool
+		mock            func(mock *mocks.MockusecaseBooksRepository)
+		expectedResults []models.BulkResult
+		wantErr         bool
+		errType         error
+	}{
+		{
+			name:   "Stale item fails on its own best effort",
+			atomic: false,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(10))
+				mock.EXPECT().Update(anyCtx, book(0)).Return(models.ErrPreconditionFailed)
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 2).RunAndReturn(withQty(4))
+				mock.EXPECT().Update(anyCtx, book(1)).Return(nil)
+				mock.EXPECT().CreateMovements(anyCtx, []models.StockMovement{{
+					BookID:   2,
+					Kind:     models.StockAdjust,
+					Quantity: 6,
+					Balance:  10,
+					Reason:   reasonEdited,
+					Actor:    models.DefaultActor,
+				}}).Return(nil)
+			},
+			expectedResults: []models.BulkResult{
+				failed(0, models.ErrPreconditionFailed),
+				{Index: 1, ID: 2},
+			},
+		},
+		{
+			name:   "Success update books atomic",
+			atomic: true,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(10))
+				mock.EXPECT().Update(anyCtx, book(0)).Return(nil)
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 2).RunAndReturn(withQty(10))
+				mock.EXPECT().Update(anyCtx, book(1)).Return(nil)
+			},
+			expectedResults: []models.BulkResult{{Index: 0, ID: 1}, {Index: 1, ID: 2}},
+		},
+		{
+			name:   "Stale item rolls back everything atomic",
+			atomic: true,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(10))
+				mock.EXPECT().Update(anyCtx, book(0)).Return(nil)
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 2).RunAndReturn(withQty(10))
+				mock.EXPECT().Update(anyCtx, book(1)).Return(models.ErrPreconditionFailed)
+			},
+			expectedResults: []models.BulkResult{
+				failed(0, models.ErrBulkRolledBack),
+				failed(1, models.ErrPreconditionFailed),
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrPreconditionFailed),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseBooksRepository(t)
+			tt.mock(mock)
+
+			uc := newTestBooksUseCase(t, mock)
+
+			results, err := uc.BulkUpdateBooks(context.Background(), books, tt.atomic)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedResults, results)
+		})
+	}
+}
+
+func TestBulkDeleteBooks(t *testing.T) {
+	books := []models.BulkDeleteBooksItem{{ID: 1, Version: 2}, {ID: 99, Version: 1}}
+
+	tests := []struct {
+		name            string
+		atomic          bool
+		mock            func(mock *mocks.MockusecaseBooksRepository)
+		expectedResults []models.BulkResult
+		wantErr         bool
+		errType         error
+	}{
+		{
+			name:   "Missing book fails on its own best effort",
+			atomic: false,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Delete(anyCtx, &models.Books{ID: 1, Version: 2}).Return(nil)
+				mock.EXPECT().Delete(anyCtx, &models.Books{ID: 99, Version: 1}).Return(models.ErrBookNotFound)
+			},
+			expectedResults: []models.BulkResult{
+				{Index: 0, ID: 1},
+				failed(1, models.ErrBookNotFound),
+			},
+		},
+		{
+			name:   "Missing book rolls back everything atomic",
+			atomic: true,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Delete(anyCtx, &models.Books{ID: 1, Version: 2}).Return(nil)
+				mock.EXPECT().Delete(anyCtx, &models.Books{ID: 99, Version: 1}).Return(models.ErrBookNotFound)
+			},
+			expectedResults: []models.BulkResult{
+				failed(0, models.ErrBulkRolledBack),
+				failed(1, models.ErrBookNotFound),
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrBookNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseBooksRepository(t)
+			tt.mock(mock)
+
+			uc := newTestBooksUseCase(t, mock)
+
+			results, err := uc.BulkDeleteBooks(context.Background(), books, tt.atomic)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedResults, results)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"crud-echo/pkg/logger"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// reasons for the movements the usecase records on its own
+const (
+	reasonInitialStock = "initial stock"
+	reasonEdited       = "edited"
+)
+
+// MoveStock changes the qty of a book by change.Quantity and records the
+// movement, both or neither happen. Copies coming in are set aside for the
+// holds waiting for the book first, the book returned is what the shelf has
+// left after that
+func (uc *BooksUseCase) MoveStock(ctx context.Context, id int, change models.StockChange) (*models.StockMoveResult, error) {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.MoveStock")
+	defer span.End()
+
+	if change.Quantity == 0 {
+		return nil, models.ErrBadRequest
+	}
+
+	now := uc.now()
+	var book models.Books
+	var movement models.StockMovement
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// the queue only moves under the book lock, the order checkouts and
+		// returns take it in. Taking copies away leaves the queue alone
+		incoming := change.Quantity > 0
+		if incoming {
+			if err := uc.bookRepo.GetByIDForUpdate(ctx, &models.Books{}, id); err != nil {
+				return err
+			}
+			if err := uc.queue.expire(ctx, id, now); err != nil {
+				return err
+			}
+		}
+
+		book = models.Books{}
+		if err := uc.bookRepo.AddQty(ctx, id, change.Quantity, &book); err != nil {
+			return err
+		}
+		movement = newMovement(ctx, id, change, book.Qty)
+		if err := uc.bookRepo.CreateMovements(ctx, []models.StockMovement{movement}); err != nil {
+			return err
+		}
+		if !incoming {
+			return nil
+		}
+		return uc.queue.serveShelf(ctx, &book, now)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	logger.FromContext(ctx).Info("stock moved",
+		zap.Int("id", id),
+		zap.String("kind", change.Kind),
+		zap.Int("quantity", change.Quantity),
+		zap.Int("balance", book.Qty),
+	)
+
+	return &models.StockMoveResult{
+		Book:     book.ToBooksSummary(),
+		Movement: &movement,
+	}, nil
+}
+
+func (uc *BooksUseCase) GetStockHistory(ctx context.Context, id int, page *models.PageRequest) (*[]models.StockMovement, *models.PageMeta, error) {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.GetStockHistory")
+	defer span.End()
+
+	// an unknown book is a 404, not an empty history
+	var book models.Books
+	if err := uc.bookRepo.GetByID(ctx, &book, id); err != nil {
+		return nil, nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	movements := make([]models.StockMovement, 0)
+	result, err := uc.bookRepo.GetMovements(ctx, &movements, id, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return &movements, &models.PageMeta{
+		Total:  result.Total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}, nil
+}
+
+// the write paths below keep the ledger of a book adding up to its qty,
+// each runs in a transaction of its own or joins the one in ctx
+
+// create inserts book along with its opening movement
+func (uc *BooksUseCase) create(ctx context.Context, book *models.Books) error {
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.bookRepo.Create(ctx, book); err != nil {
+			return err
+		}
+		return uc.bookRepo.CreateMovements(ctx, openingMovements(ctx, []models.Books{*book}))
+	})
+}
+
+// createInBatches is create for many books at once
+func (uc *BooksUseCase) createInBatches(ctx context.Context, books []models.Books) error {
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.bookRepo.CreateInBatches(ctx, books, models.BulkBatchSize); err != nil {
+			return err
+		}
+		return uc.bookRepo.CreateMovements(ctx, openingMovements(ctx, books))
+	})
+}
+
+// update writes book and records what it did to the qty as an adjustment.
+// The version check makes sure the qty read first is still the one replaced
+func (uc *BooksUseCase) update(ctx context.Context, book *models.Books) error {
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var current models.Books
+		if err := uc.bookRepo.GetByID(ctx, &current, book.ID); err != nil {
+			return err
+		}
+		if err := uc.bookRepo.Update(ctx, book); err != nil {
+			return err
+		}
+		return uc.adjust(ctx, book.ID, current.Qty, book.Qty)
+	})
+}
+
+// patch is update for a patch, one leaving qty alone needs no ledger
+func (uc *BooksUseCase) patch(ctx context.Context, id int, patch *models.BooksPatch) error {
+	if patch.Qty == nil {
+		return uc.bookRepo.Patch(ctx, id, patch)
+	}
+
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var current models.Books
+		if err := uc.bookRepo.GetByID(ctx, &current, id); err != nil {
+			return err
+		}
+		if err := uc.bookRepo.Patch(ctx, id, patch); err != nil {
+			return err
+		}
+		return uc.adjust(ctx, id, current.Qty, *patch.Qty)
+	})
+}
+
+func (uc *BooksUseCase) adjust(ctx context.Context, id int, from, to int) error {
+	if from == to {
+		return nil
+	}
+
+	change := models.StockChange{Kind: models.StockAdjust, Quantity: to - from, Reason: reasonEdited}
+	return uc.bookRepo.CreateMovements(ctx, []models.StockMovement{newMovement(ctx, id, change, to)})
+}
+
+func newMovement(ctx context.Context, bookID int, change models.StockChange, balance int) models.StockMovement {
+	return models.StockMovement{
+		BookID:   bookID,
+		Kind:     change.Kind,
+		Quantity: change.Quantity,
+		Balance:  balance,
+		Reason:   change.Reason,
+		Actor:    models.ActorFromContext(ctx),
+	}
+}
+
+// openingMovements receives the initial qty of books just created, books
+// starting out empty have nothing to record
+func openingMovements(ctx context.Context, books []models.Books) []models.StockMovement {
+	var movements []models.StockMovement
+	for _, book := range books {
+		if book.Qty == 0 {
+			continue
+		}
+		change := models.StockChange{Kind: models.StockReceive, Quantity: book.Qty, Reason: reasonInitialStock}
+		movements = append(movements, newMovement(ctx, book.ID, change, book.Qty))
+	}
+	return movements
+}
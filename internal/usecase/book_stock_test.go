@@ -0,0 +1,308 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// withQty stands in for GetByID finding the book with qty in stock
+func withQty(qty int) func(context.Context, *models.Books, int) error {
+	return func(_ context.Context, book *models.Books, id int) error {
+		book.ID = id
+		book.Qty = qty
+		return nil
+	}
+}
+
+// opening is the ledger of books made by bulkBooks once they got ids
+func opening(ids ...int) []models.StockMovement {
+	movements := make([]models.StockMovement, len(ids))
+	for i, id := range ids {
+		movements[i] = models.StockMovement{
+			BookID:   id,
+			Kind:     models.StockReceive,
+			Quantity: 10,
+			Balance:  10,
+			Reason:   reasonInitialStock,
+			Actor:    models.DefaultActor,
+		}
+	}
+	return movements
+}
+
+func TestMoveStock(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(holdPickupWindow)
+	waitingHold := models.Holds{ID: 7, BookID: 1, MemberID: 3, Status: models.HoldStatusWaiting}
+	readyHold := models.Holds{ID: 7, BookID: 1, MemberID: 3, Status: models.HoldStatusReady, ReadyAt: &now, ExpiresAt: &expiresAt}
+	// movement is a ledger row of book 1 written for alice
+	movement := func(kind string, delta, balance int, reason string) models.StockMovement {
+		return models.StockMovement{BookID: 1, Kind: kind, Quantity: delta, Balance: balance, Reason: reason, Actor: "alice"}
+	}
+	nobodyWaiting := func(holdRepo *mocks.MockusecaseHoldsRepository) {
+		holdRepo.EXPECT().NextWaiting(anyCtx, &models.Holds{}, 1).Return(models.ErrHoldNotFound).Once()
+	}
+
+	tests := []struct {
+		name           string
+		id             int
+		change         models.StockChange
+		mock           func(bookRepo *mocks.MockusecaseBooksRepository, holdRepo *mocks.MockusecaseHoldsRepository)
+		expectedResult *models.StockMoveResult
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name:   "Success receive stock",
+			id:     1,
+			change: models.StockChange{Kind: models.StockReceive, Quantity: 5, Reason: "delivery"},
+			mock: func(bookRepo *mocks.MockusecaseBooksRepository, holdRepo *mocks.MockusecaseHoldsRepository) {
+				bookRepo.EXPECT().GetByIDForUpdate(anyCtx, &models.Books{}, 1).Return(nil)
+				bookRepo.EXPECT().AddQty(anyCtx, 1, 5, &models.Books{}).
+					RunAndReturn(func(_ context.Context, id int, delta int, book *models.Books) error {
+						*book = models.Books{ID: id, Title: "Test Title", Qty: 15, Version: 4}
+						return nil
+					})
+				bookRepo.EXPECT().CreateMovements(anyCtx, []models.StockMovement{
+					movement(models.StockReceive, 5, 15, "delivery"),
+				}).Return(nil)
+				noExpiredHolds(holdRepo, now)
+				nobodyWaiting(holdRepo)
+			},
+			expectedResult: &models.StockMoveResult{
+				Book: &models.BooksSummary{ID: 1, Title: "Test Title", Qty: 15, Version: 4},
+				Movement: &models.StockMovement{
+					BookID:   1,
+					Kind:     models.StockReceive,
+					Quantity: 5,
+					Balance:  15,
+					Reason:   "delivery",
+					Actor:    "alice",
+				},
+			},
+		},
+		{
+			name:   "Success receive stock sets a copy aside for the waiting hold",
+			id:     1,
+			change: models.StockChange{Kind: models.StockReceive, Quantity: 2, Reason: "delivery"},
+			mock: func(bookRepo *mocks.MockusecaseBooksRepository, holdRepo *mocks.MockusecaseHoldsRepository) {
+				bookRepo.EXPECT().GetByIDForUpdate(anyCtx, &models.Books{}, 1).Return(nil)
+				bookRepo.EXPECT().AddQty(anyCtx, 1, 2, &models.Books{}).RunAndReturn(withBalance(2))
+				bookRepo.EXPECT().CreateMovements(anyCtx, []models.StockMovement{
+					movement(models.StockReceive, 2, 2, "delivery"),
+				}).Return(nil)
+				noExpiredHolds(holdRepo, now)
+				holdRepo.EXPECT().NextWaiting(anyCtx, &models.Holds{}, 1).RunAndReturn(withHold(waitingHold)).Once()
+				holdRepo.EXPECT().Update(anyCtx, &readyHold).Return(nil)
+				bookRepo.EXPECT().AddQty(anyCtx, 1, -1, &models.Books{ID: 1, Qty: 2}).RunAndReturn(withBalance(1))
+				bookRepo.EXPECT().CreateMovements(anyCtx, []models.StockMovement{
+					movement(models.StockLend, -1, 1, "hold 7"),
+				}).Return(nil)
+				nobodyWaiting(holdRepo)
+			},
+			expectedResult: &models.StockMoveResult{
+				Book: &models.BooksSummary{ID: 1, Qty: 1},
+				Movement: &models.StockMovement{
+					BookID:   1,
+					Kind:     models.StockReceive,
+					Quantity: 2,
+					Balance:  2,
+					Reason:   "delivery",
+					Actor:    "alice",
+				},
+			},
+		},
+		{
+			name:   "Success receive stock runs out before the queue does",
+			id:     1,
+			change: models.StockChange{Kind: models.StockReturn, Quantity: 1},
+			mock: func(bookRepo *mocks.MockusecaseBooksRepository, holdRepo *mocks.MockusecaseHoldsRepository) {
+				bookRepo.EXPECT().GetByIDForUpdate(anyCtx, &models.Books{}, 1).Return(nil)
+				bookRepo.EXPECT().AddQty(anyCtx, 1, 1, &models.Books{}).RunAndReturn(withBalance(1))
+				bookRepo.EXPECT().CreateMovements(anyCtx, []models.StockMovement{
+					movement(models.StockReturn, 1, 1, ""),
+				}).Return(nil)
+				noExpiredHolds(holdRepo, now)
+				holdRepo.EXPECT().NextWaiting(anyCtx, &models.Holds{}, 1).RunAndReturn(withHold(waitingHold)).Once()
+				holdRepo.EXPECT().Update(anyCtx, &readyHold).Return(nil)
+				bookRepo.EXPECT().AddQty(anyCtx, 1, -1, &models.Books{ID: 1, Qty: 1}).RunAndReturn(withBalance(0))
+				bookRepo.EXPECT().CreateMovements(anyCtx, []models.StockMovement{
+					movement(models.StockLend, -1, 0, "hold 7"),
+				}).Return(nil)
+			},
+			expectedResult: &models.StockMoveResult{
+				Book: &models.BooksSummary{ID: 1},
+				Movement: &models.StockMovement{
+					BookID:   1,
+					Kind:     models.StockReturn,
+					Quantity: 1,
+					Balance:  1,
+					Actor:    "alice",
+				},
+			},
+		},
+		{
+			name:   "Failed sell due to insufficient stock",
+			id:     1,
+			change: models.StockChange{Kind: models.StockSell, Quantity: -50},
+			mock: func(bookRepo *mocks.MockusecaseBooksRepository, holdRepo *mocks.MockusecaseHoldsRepository) {
+				bookRepo.EXPECT().AddQty(anyCtx, 1, -50, &models.Books{}).Return(models.ErrInsufficientStock)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrInsufficientStock),
+		},
+		{
+			name:   "Failed move due to book not found",
+			id:     99,
+			change: models.StockChange{Kind: models.StockReceive, Quantity: 1},
+			mock: func(bookRepo *mocks.MockusecaseBooksRepository, holdRepo *mocks.MockusecaseHoldsRepository) {
+				bookRepo.EXPECT().GetByIDForUpdate(anyCtx, &models.Books{}, 99).Return(models.ErrBookNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrBookNotFound),
+		},
+		{
+			name:   "Failed move due to ledger write",
+			id:     1,
+			change: models.StockChange{Kind: models.StockLend, Quantity: -1},
+			mock: func(bookRepo *mocks.MockusecaseBooksRepository, holdRepo *mocks.MockusecaseHoldsRepository) {
+				bookRepo.EXPECT().AddQty(anyCtx, 1, -1, &models.Books{}).Return(nil)
+				bookRepo.EXPECT().CreateMovements(anyCtx, []models.StockMovement{{
+					BookID:   1,
+					Kind:     models.StockLend,
+					Quantity: -1,
+					Actor:    "alice",
+				}}).Return(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", gorm.ErrInvalidDB),
+		},
+		{
+			name:   "Failed receive due to hold update",
+			id:     1,
+			change: models.StockChange{Kind: models.StockReceive, Quantity: 1},
+			mock: func(bookRepo *mocks.MockusecaseBooksRepository, holdRepo *mocks.MockusecaseHoldsRepository) {
+				bookRepo.EXPECT().GetByIDForUpdate(anyCtx, &models.Books{}, 1).Return(nil)
+				bookRepo.EXPECT().AddQty(anyCtx, 1, 1, &models.Books{}).RunAndReturn(withBalance(1))
+				bookRepo.EXPECT().CreateMovements(anyCtx, []models.StockMovement{
+					movement(models.StockReceive, 1, 1, ""),
+				}).Return(nil)
+				noExpiredHolds(holdRepo, now)
+				holdRepo.EXPECT().NextWaiting(anyCtx, &models.Holds{}, 1).RunAndReturn(withHold(waitingHold)).Once()
+				holdRepo.EXPECT().Update(anyCtx, &readyHold).Return(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", gorm.ErrInvalidDB),
+		},
+		{
+			name:    "Failed move of nothing",
+			id:      1,
+			change:  models.StockChange{Kind: models.StockAdjust},
+			mock:    func(bookRepo *mocks.MockusecaseBooksRepository, holdRepo *mocks.MockusecaseHoldsRepository) {},
+			wantErr: true,
+			errType: models.ErrBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookRepo := mocks.NewMockusecaseBooksRepository(t)
+			holdRepo := mocks.NewMockusecaseHoldsRepository(t)
+			tt.mock(bookRepo, holdRepo)
+
+			uc := newTestBooksUseCaseWithHolds(t, bookRepo, holdRepo, now)
+
+			ctx := models.WithActor(context.Background(), "alice")
+			result, err := uc.MoveStock(ctx, tt.id, tt.change)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestGetStockHistory(t *testing.T) {
+	page := &models.PageRequest{Limit: 2, Offset: 0}
+
+	tests := []struct {
+		name              string
+		id                int
+		mock              func(mock *mocks.MockusecaseBooksRepository)
+		expectedMovements *[]models.StockMovement
+		expectedMeta      *models.PageMeta
+		wantErr           bool
+		errType           error
+	}{
+		{
+			name: "Success get stock history",
+			id:   1,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(9))
+				mock.EXPECT().GetMovements(anyCtx, &[]models.StockMovement{}, 1, page).
+					RunAndReturn(func(_ context.Context, movements *[]models.StockMovement, _ int, _ *models.PageRequest) (*models.PageResult, error) {
+						*movements = []models.StockMovement{
+							{ID: 2, BookID: 1, Kind: models.StockSell, Quantity: -1, Balance: 9},
+							{ID: 1, BookID: 1, Kind: models.StockReceive, Quantity: 10, Balance: 10},
+						}
+						return &models.PageResult{Total: 2}, nil
+					})
+			},
+			expectedMovements: &[]models.StockMovement{
+				{ID: 2, BookID: 1, Kind: models.StockSell, Quantity: -1, Balance: 9},
+				{ID: 1, BookID: 1, Kind: models.StockReceive, Quantity: 10, Balance: 10},
+			},
+			expectedMeta: &models.PageMeta{Total: 2, Limit: 2},
+		},
+		{
+			name: "Failed get stock history due to book not found",
+			id:   99,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 99).Return(models.ErrBookNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrBookNotFound),
+		},
+		{
+			name: "Failed get stock history due to invalid DB",
+			id:   1,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(9))
+				mock.EXPECT().GetMovements(anyCtx, &[]models.StockMovement{}, 1, page).Return(nil, gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", gorm.ErrInvalidDB),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseBooksRepository(t)
+			tt.mock(mock)
+
+			uc := newTestBooksUseCase(t, mock)
+
+			movements, meta, err := uc.GetStockHistory(context.Background(), tt.id, page)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedMovements, movements)
+				assert.Equal(t, tt.expectedMeta, meta)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"fmt"
+)
+
+// ExportBooks hands every book outside the trash to fn as it comes off the
+// database, an error from fn stops the export and is returned as is
+func (uc *BooksUseCase) ExportBooks(ctx context.Context, fn func(book *models.BooksExport) error) error {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.ExportBooks")
+	defer span.End()
+
+	return uc.bookRepo.Each(ctx, func(book *models.Books) error {
+		return fn(book.ToBooksExport())
+	})
+}
+
+// ImportBooks creates the rows whose title is free and applies the
+// duplicate policy to the others, every row stands alone. A dry run only
+// works out the report. Rows that failed parsing are already in report
+func (uc *BooksUseCase) ImportBooks(ctx context.Context, rows []models.ImportRow, opts models.ImportOptions, report *models.ImportReport) error {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.ImportBooks")
+	defer span.End()
+
+	report.DryRun = opts.DryRun
+	if len(rows) == 0 {
+		return nil
+	}
+
+	titles := make([]string, len(rows))
+	for i, row := range rows {
+		titles[i] = row.Book.Title
+	}
+	var existing []models.Books
+	if err := uc.bookRepo.GetByTitles(ctx, &existing, titles); err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+	byTitle := make(map[string]models.Books, len(existing))
+	for _, book := range existing {
+		byTitle[models.NormalizeTitle(book.Title)] = book
+	}
+
+	var (
+		creates   []models.Books
+		createdAt []int // line of each book in creates
+		updates   []models.Books
+		updatedAt []int
+		seen      = make(map[string]int, len(rows))
+	)
+	for _, row := range rows {
+		key := models.NormalizeTitle(row.Book.Title)
+		if line, ok := seen[key]; ok {
+			report.AddError(row.Line, fmt.Errorf("same title as line %d: %w", line, models.ErrBookTitleTaken))
+			continue
+		}
+		seen[key] = row.Line
+
+		current, taken := byTitle[key]
+		switch {
+		case !taken:
+			creates = append(creates, models.Books{
+				Title:       row.Book.Title,
+				Description: row.Book.Description,
+				Qty:         row.Book.Qty,
+				Authors:     models.AuthorRefs(row.Book.AuthorIDs),
+
+				BooksBibliography: row.Book.Normalized(),
+			})
+			createdAt = append(createdAt, row.Line)
+		case opts.OnDuplicate == models.DuplicateUpdate:
+			updates = append(updates, models.Books{
+				ID:          current.ID,
+				Title:       row.Book.Title,
+				Description: row.Book.Description,
+				Qty:         row.Book.Qty,
+				Version:     current.Version,
+
+				// replaced as a whole like PUT /book does, an empty cell clears it
+				BooksBibliography: row.Book.Normalized(),
+			})
+			updatedAt = append(updatedAt, row.Line)
+		case opts.OnDuplicate == models.DuplicateSkip:
+			report.Skipped++
+		default:
+			report.AddError(row.Line, models.ErrBookTitleTaken)
+		}
+	}
+
+	if opts.DryRun {
+		report.Created += len(creates)
+		report.Updated += len(updates)
+		return nil
+	}
+
+	for i, err := range uc.createEach(ctx, creates) {
+		if err != nil {
+			report.AddError(createdAt[i], err)
+			continue
+		}
+		report.Created++
+	}
+
+	for i := range updates {
+		if err := uc.update(ctx, &updates[i]); err != nil {
+			report.AddError(updatedAt[i], err)
+			continue
+		}
+		report.Updated++
+	}
+
+	return nil
+}
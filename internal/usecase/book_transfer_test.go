@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestExportBooks(t *testing.T) {
+	repo := mocks.NewMockusecaseBooksRepository(t)
+	repo.EXPECT().Each(anyCtx, mock.Anything).RunAndReturn(func(_ context.Context, fn func(*models.Books) error) error {
+		for _, book := range []models.Books{{ID: 1, Title: "Title A", Version: 2}, {ID: 2, Title: "Title B", Version: 1}} {
+			if err := fn(&book); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	uc := newTestBooksUseCase(t, repo)
+
+	var exported []models.BooksExport
+	err := uc.ExportBooks(context.Background(), func(book *models.BooksExport) error {
+		exported = append(exported, *book)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.BooksExport{{ID: 1, Title: "Title A", Version: 2}, {ID: 2, Title: "Title B", Version: 1}}, exported)
+}
+
+func importRows(titles ...string) []models.ImportRow {
+	rows := make([]models.ImportRow, len(titles))
+	for i, title := range titles {
+		rows[i] = models.ImportRow{
+			Line: i + 2,
+			Book: models.CreateBooksRequest{Title: title, Description: "Test Description", Qty: 10},
+		}
+	}
+	return rows
+}
+
+func TestImportBooks(t *testing.T) {
+	existing := []models.Books{{ID: 7, Title: "Title B", Description: "Old Description", Qty: 1, Version: 3}}
+	titleTaken := func(line int) models.ImportRowError {
+		return models.ImportRowError{Line: line, Code: models.ErrBookTitleTaken.Code, Message: models.ErrBookTitleTaken.Message}
+	}
+
+	tests := []struct {
+		name           string
+		rows           []models.ImportRow
+		opts           models.ImportOptions
+		mock           func(mock *mocks.MockusecaseBooksRepository)
+		expectedReport *models.ImportReport
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name: "Taken title fails with the fail policy",
+			rows: importRows("Title A", "Title B"),
+			opts: models.ImportOptions{OnDuplicate: models.DuplicateFail},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByTitles(anyCtx, new([]models.Books), []string{"Title A", "Title B"}).
+					Run(func(_ context.Context, books *[]models.Books, _ []string) { *books = existing }).
+					Return(nil)
+				mock.EXPECT().CreateInBatches(anyCtx, bulkBooks("Title A"), models.BulkBatchSize).RunAndReturn(assignIDs(1))
+				mock.EXPECT().CreateMovements(anyCtx, opening(1)).Return(nil)
+			},
+			expectedReport: &models.ImportReport{Created: 1, Failed: 1, Errors: []models.ImportRowError{titleTaken(3)}},
+		},
+		{
+			name: "Taken title is skipped with the skip policy",
+			rows: importRows("Title A", "title b"),
+			opts: models.ImportOptions{OnDuplicate: models.DuplicateSkip},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByTitles(anyCtx, new([]models.Books), []string{"Title A", "title b"}).
+					Run(func(_ context.Context, books *[]models.Books, _ []string) { *books = existing }).
+					Return(nil)
+				mock.EXPECT().CreateInBatches(anyCtx, bulkBooks("Title A"), models.BulkBatchSize).RunAndReturn(assignIDs(1))
+				mock.EXPECT().CreateMovements(anyCtx, opening(1)).Return(nil)
+			},
+			expectedReport: &models.ImportReport{Created: 1, Skipped: 1},
+		},
+		{
+			name: "Taken title updates the book with the update policy",
+			rows: importRows("Title B"),
+			opts: models.ImportOptions{OnDuplicate: models.DuplicateUpdate},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByTitles(anyCtx, new([]models.Books), []string{"Title B"}).
+					Run(func(_ context.Context, books *[]models.Books, _ []string) { *books = existing }).
+					Return(nil)
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 7).RunAndReturn(withQty(1))
+				mock.EXPECT().Update(anyCtx, &models.Books{ID: 7, Title: "Title B", Description: "Test Description", Qty: 10, Version: 3}).
+					Return(nil)
+				mock.EXPECT().CreateMovements(anyCtx, []models.StockMovement{{
+					BookID:   7,
+					Kind:     models.StockAdjust,
+					Quantity: 9,
+					Balance:  10,
+					Reason:   reasonEdited,
+					Actor:    models.DefaultActor,
+				}}).Return(nil)
+			},
+			expectedReport: &models.ImportReport{Updated: 1},
+		},
+		{
+			name: "Update replaces the bibliography",
+			rows: []models.ImportRow{{Line: 2, Book: models.CreateBooksRequest{
+				Title: "Title B", Description: "Test Description", Qty: 1,
+				BooksBibliography: models.BooksBibliography{ISBN: "0-441-17271-7", Publisher: "Ace"},
+			}}},
+			opts: models.ImportOptions{OnDuplicate: models.DuplicateUpdate},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByTitles(anyCtx, new([]models.Books), []string{"Title B"}).
+					Run(func(_ context.Context, books *[]models.Books, _ []string) {
+						*books = []models.Books{{ID: 7, Title: "Title B", Qty: 1, Version: 3,
+							BooksBibliography: models.BooksBibliography{ISBN: "9780000000002", Edition: "1st"}}}
+					}).
+					Return(nil)
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 7).RunAndReturn(withQty(1))
+				mock.EXPECT().Update(anyCtx, &models.Books{ID: 7, Title: "Title B", Description: "Test Description", Qty: 1, Version: 3,
+					BooksBibliography: models.BooksBibliography{ISBN: "9780441172719", Publisher: "Ace"}}).
+					Return(nil)
+			},
+			expectedReport: &models.ImportReport{Updated: 1},
+		},
+		{
+			name: "Update losing a race is reported",
+			rows: importRows("Title B"),
+			opts: models.ImportOptions{OnDuplicate: models.DuplicateUpdate},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByTitles(anyCtx, new([]models.Books), []string{"Title B"}).
+					Run(func(_ context.Context, books *[]models.Books, _ []string) { *books = existing }).
+					Return(nil)
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 7).RunAndReturn(withQty(1))
+				mock.EXPECT().Update(anyCtx, &models.Books{ID: 7, Title: "Title B", Description: "Test Description", Qty: 10, Version: 3}).
+					Return(models.ErrPreconditionFailed)
+			},
+			expectedReport: &models.ImportReport{Failed: 1, Errors: []models.ImportRowError{
+				{Line: 2, Code: models.ErrPreconditionFailed.Code, Message: models.PreconditionFailed},
+			}},
+		},
+		{
+			name: "Title repeated within the file fails",
+			rows: importRows("Title A", "TITLE A"),
+			opts: models.ImportOptions{OnDuplicate: models.DuplicateUpdate},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByTitles(anyCtx, new([]models.Books), []string{"Title A", "TITLE A"}).Return(nil)
+				mock.EXPECT().CreateInBatches(anyCtx, bulkBooks("Title A"), models.BulkBatchSize).RunAndReturn(assignIDs(1))
+				mock.EXPECT().CreateMovements(anyCtx, opening(1)).Return(nil)
+			},
+			expectedReport: &models.ImportReport{Created: 1, Failed: 1, Errors: []models.ImportRowError{titleTaken(3)}},
+		},
+		{
+			name: "Dry run writes nothing",
+			rows: importRows("Title A", "Title B"),
+			opts: models.ImportOptions{DryRun: true, OnDuplicate: models.DuplicateUpdate},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByTitles(anyCtx, new([]models.Books), []string{"Title A", "Title B"}).
+					Run(func(_ context.Context, books *[]models.Books, _ []string) { *books = existing }).
+					Return(nil)
+			},
+			expectedReport: &models.ImportReport{DryRun: true, Created: 1, Updated: 1},
+		},
+		{
+			name: "Failed title lookup",
+			rows: importRows("Title A"),
+			opts: models.ImportOptions{OnDuplicate: models.DuplicateFail},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByTitles(anyCtx, new([]models.Books), []string{"Title A"}).Return(gorm.ErrInvalidDB)
+			},
+			expectedReport: &models.ImportReport{},
+			wantErr:        true,
+			errType:        fmt.Errorf("repository error: %w", gorm.ErrInvalidDB),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseBooksRepository(t)
+			tt.mock(mock)
+
+			uc := newTestBooksUseCase(t, mock)
+
+			report := &models.ImportReport{}
+			err := uc.ImportBooks(context.Background(), tt.rows, tt.opts, report)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedReport, report)
+			}
+		})
+	}
+}
+
+func TestImportBooksReportsFailedCreate(t *testing.T) {
+	mock := mocks.NewMockusecaseBooksRepository(t)
+	mock.EXPECT().GetByTitles(anyCtx, new([]models.Books), []string{"Title A", "Title A"}).Return(nil)
+	mock.EXPECT().CreateInBatches(anyCtx, bulkBooks("Title A"), models.BulkBatchSize).Return(errors.New("boom"))
+	mock.EXPECT().Create(anyCtx, &models.Books{Title: "Title A", Description: "Test Description", Qty: 10}).Return(errors.New("boom"))
+
+	uc := newTestBooksUseCase(t, mock)
+
+	report := &models.ImportReport{}
+	err := uc.ImportBooks(context.Background(), importRows("Title A", "Title A"), models.ImportOptions{OnDuplicate: models.DuplicateFail}, report)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Failed)
+	assert.ElementsMatch(t, []string{models.ErrInternalServerError.Code, models.ErrBookTitleTaken.Code},
+		[]string{report.Errors[0].Code, report.Errors[1].Code})
+}
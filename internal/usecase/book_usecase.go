@@ -1,97 +1,329 @@
 package usecase
 
 import (
+	"context"
+	"crud-echo/internal/config"
 	"crud-echo/internal/models"
+	"crud-echo/pkg/logger"
 	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
 )
 
+// tracer comes from the global provider set up by tracing.New, until then
+// it is a no-op
+var tracer = otel.Tracer("crud-echo/internal/usecase")
+
+// UsecaseBooksRepository takes care of the stock of a book too, see
+// UsecaseStockRepository
 type UsecaseBooksRepository interface {
-	Create(book *models.Books) error
-	GetByID(book *models.Books, id int) error
-	GetAll(book *[]models.Books) error
-	Update(book *models.Books) error
-	Delete(book *models.Books) error
-	ExistsByTitle(title string) (bool, error)
+	UsecaseStockRepository
+	Create(ctx context.Context, book *models.Books) error
+	CreateInBatches(ctx context.Context, books []models.Books, batchSize int) error
+	GetByID(ctx context.Context, book *models.Books, id int) error
+	GetByISBN(ctx context.Context, book *models.Books, isbn string) error
+	GetByTitles(ctx context.Context, books *[]models.Books, titles []string) error
+	Each(ctx context.Context, fn func(book *models.Books) error) error
+	GetPage(ctx context.Context, books *[]models.Books, filter *models.BooksFilter, page *models.PageRequest) (*models.PageResult, error)
+	Update(ctx context.Context, book *models.Books) error
+	Patch(ctx context.Context, id int, patch *models.BooksPatch) error
+	Delete(ctx context.Context, book *models.Books) error
+	GetTrash(ctx context.Context, books *[]models.Books, page *models.PageRequest) (*models.PageResult, error)
+	Restore(ctx context.Context, id int) error
+	Purge(ctx context.Context, id int) error
+	Stats(ctx context.Context, stats *models.BooksStats) error
+	Search(ctx context.Context, results *[]models.BooksSearchResult, query string, limit int) error
+	TakenTitles(ctx context.Context, titles []string) ([]string, error)
+	GetMovements(ctx context.Context, movements *[]models.StockMovement, bookID int, page *models.PageRequest) (*models.PageResult, error)
+	ReplaceAuthors(ctx context.Context, bookID int, authorIDs []int) error
+	LoadAuthors(ctx context.Context, books []models.Books) error
 }
 
-type BooksUseCase struct {
-	bookRepo UsecaseBooksRepository
+// TxManager runs fn in a transaction, repository calls made with the ctx
+// it passes to fn take part in it
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
-func NewBooksUseCase(repo UsecaseBooksRepository) *BooksUseCase {
-	return &BooksUseCase{bookRepo: repo}
+// BooksUseCase keeps the catalogue and the stock of books. Copies coming in
+// go to the hold queue of the book before the shelf, like returns do
+type BooksUseCase struct {
+	bookRepo UsecaseBooksRepository
+	queue    *holdQueue
+	tx       TxManager
+	// when holds served by incoming stock become ready, swapped in tests
+	now func() time.Time
 }
 
-func (uc *BooksUseCase) CreateBook(bookRequest *models.CreateBooksRequest) (*models.Books, error) {
-	exists, err := uc.bookRepo.ExistsByTitle(bookRequest.Title)
+func NewBooksUseCase(repo UsecaseBooksRepository, holdRepo UsecaseHoldsRepository, tx TxManager, cfg *config.Config) (*BooksUseCase, error) {
+	queue, err := newHoldQueue(holdRepo, repo, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("repository error: %w", err)
-	}
-	if exists {
-		return nil, fmt.Errorf("repository error: %w", models.ErrResourceAlreadyExist)
+		return nil, err
 	}
 
+	return &BooksUseCase{bookRepo: repo, queue: queue, tx: tx, now: time.Now}, nil
+}
+
+func (uc *BooksUseCase) CreateBook(ctx context.Context, bookRequest *models.CreateBooksRequest) (*models.Books, error) {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.CreateBook")
+	defer span.End()
+
+	// no lookup first, a taken title is only known for sure once the unique
+	// index refuses the insert and the repository says ErrBookTitleTaken
 	bookData := &models.Books{
 		Title:       bookRequest.Title,
 		Description: bookRequest.Description,
 		Qty:         bookRequest.Qty,
+		Authors:     models.AuthorRefs(bookRequest.AuthorIDs),
+
+		BooksBibliography: bookRequest.Normalized(),
 	}
 
-	if err := uc.bookRepo.Create(bookData); err != nil {
+	if err := uc.create(ctx, bookData); err != nil {
 		return nil, fmt.Errorf("repository error: %w", err)
 	}
 	return bookData, nil
 }
 
-func (uc *BooksUseCase) GetBookByID(id int) (*models.BooksSummary, error) {
-	var book models.Books
-	if err := uc.bookRepo.GetByID(&book, id); err != nil {
+func (uc *BooksUseCase) GetBookByID(ctx context.Context, id int, include models.BooksInclude) (*models.BooksSummary, error) {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.GetBookByID")
+	defer span.End()
+
+	books := make([]models.Books, 1)
+	if err := uc.bookRepo.GetByID(ctx, &books[0], id); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	if err := uc.load(ctx, books, include); err != nil {
 		return nil, fmt.Errorf("repository error: %w", err)
 	}
 
-	return book.ToBooksSummary(), nil
+	return books[0].ToBooksSummary(), nil
 }
 
-func (uc *BooksUseCase) GetAllBooks(available bool) (*[]models.BooksSummary, error) {
-	var books []models.Books
-	var booksList []models.BooksSummary
-	if !available {
-		return nil, models.ErrInvalidParam
-	}
+// GetBookByISBN expects the ISBN-13 models.NormalizeISBN returns
+func (uc *BooksUseCase) GetBookByISBN(ctx context.Context, isbn string, include models.BooksInclude) (*models.BooksSummary, error) {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.GetBookByISBN")
+	defer span.End()
 
-	if err := uc.bookRepo.GetAll(&books); err != nil {
+	books := make([]models.Books, 1)
+	if err := uc.bookRepo.GetByISBN(ctx, &books[0], isbn); err != nil {
 		return nil, fmt.Errorf("repository error: %w", err)
 	}
+	if err := uc.load(ctx, books, include); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return books[0].ToBooksSummary(), nil
+}
+
+func (uc *BooksUseCase) GetAllBooks(ctx context.Context, filter *models.BooksFilter, page *models.PageRequest, include models.BooksInclude) (*[]models.BooksSummary, *models.PageMeta, error) {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.GetAllBooks")
+	defer span.End()
 
+	var books []models.Books
+
+	result, err := uc.bookRepo.GetPage(ctx, &books, filter, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository error: %w", err)
+	}
+	if err := uc.load(ctx, books, include); err != nil {
+		return nil, nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	booksList := make([]models.BooksSummary, 0, len(books))
 	for _, book := range books {
 		booksList = append(booksList, *book.ToBooksSummary())
 	}
-	return &booksList, nil
+
+	meta := &models.PageMeta{
+		Total:  result.Total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}
+	if len(books) == 0 || !page.SupportsCursor() {
+		return &booksList, meta, nil
+	}
+
+	// when walking backward we came from the next page, so it always exists
+	var hasNext, hasPrev bool
+	switch {
+	case page.Cursor == nil:
+		hasNext, hasPrev = result.HasMore, page.Offset > 0
+	case page.Cursor.IsBackward():
+		hasNext, hasPrev = true, result.HasMore
+	default:
+		hasNext, hasPrev = result.HasMore, true
+	}
+
+	if hasNext {
+		meta.NextCursor = books[len(books)-1].ToCursor(models.CursorDirectionNext).Encode()
+	}
+	if hasPrev {
+		meta.PrevCursor = books[0].ToCursor(models.CursorDirectionPrev).Encode()
+	}
+
+	return &booksList, meta, nil
+}
+
+func (uc *BooksUseCase) SearchBooks(ctx context.Context, query string, limit int) (*[]models.BooksSearchResult, error) {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.SearchBooks")
+	defer span.End()
+
+	results := make([]models.BooksSearchResult, 0)
+	if err := uc.bookRepo.Search(ctx, &results, query, limit); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return &results, nil
 }
 
-func (uc *BooksUseCase) UpdateBook(bookRequest *models.UpdateBooksRequest) error {
+func (uc *BooksUseCase) UpdateBook(ctx context.Context, bookRequest *models.UpdateBooksRequest) error {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.UpdateBook")
+	defer span.End()
+
 	bookData := &models.Books{
 		ID:          bookRequest.ID,
 		Title:       bookRequest.Title,
 		Description: bookRequest.Description,
 		Qty:         bookRequest.Qty,
+		Version:     bookRequest.Version,
+
+		BooksBibliography: bookRequest.Normalized(),
 	}
 
-	if err := uc.bookRepo.Update(bookData); err != nil {
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.update(ctx, bookData); err != nil {
+			return err
+		}
+		if bookRequest.AuthorIDs == nil {
+			return nil
+		}
+		return uc.bookRepo.ReplaceAuthors(ctx, bookData.ID, *bookRequest.AuthorIDs)
+	})
+	if err != nil {
 		return fmt.Errorf("repository error: %w", err)
 	}
 
 	return nil
 }
 
-func (uc *BooksUseCase) DeleteBook(bookRequest *models.DeleteBooksRequest) error {
+// PatchBook writes the fields the patch changed and returns the book as read
+// back in the same transaction, an empty patch only reads it
+func (uc *BooksUseCase) PatchBook(ctx context.Context, id int, patch *models.BooksPatch) (*models.BooksSummary, error) {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.PatchBook")
+	defer span.End()
+
+	var book models.Books
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if !patch.IsEmpty() {
+			if err := uc.patch(ctx, id, patch.Normalized()); err != nil {
+				return err
+			}
+		}
+		return uc.bookRepo.GetByID(ctx, &book, id)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return book.ToBooksSummary(), nil
+}
+
+func (uc *BooksUseCase) DeleteBook(ctx context.Context, bookRequest *models.DeleteBooksRequest) error {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.DeleteBook")
+	defer span.End()
+
 	bookData := &models.Books{
-		ID: bookRequest.ID,
+		ID:      bookRequest.ID,
+		Version: bookRequest.Version,
+	}
+
+	if err := uc.bookRepo.Delete(ctx, bookData); err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *BooksUseCase) GetTrashedBooks(ctx context.Context, page *models.PageRequest) (*[]models.BooksSummary, *models.PageMeta, error) {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.GetTrashedBooks")
+	defer span.End()
+
+	var books []models.Books
+
+	result, err := uc.bookRepo.GetTrash(ctx, &books, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	booksList := make([]models.BooksSummary, 0, len(books))
+	for _, book := range books {
+		booksList = append(booksList, *book.ToBooksSummary())
+	}
+
+	return &booksList, &models.PageMeta{
+		Total:  result.Total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}, nil
+}
+
+func (uc *BooksUseCase) RestoreBook(ctx context.Context, id int) (*models.BooksSummary, error) {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.RestoreBook")
+	defer span.End()
+
+	// read the book back in the same transaction, so what we return is the
+	// book as restored and not whatever a later write made of it
+	var book models.Books
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.bookRepo.Restore(ctx, id); err != nil {
+			return err
+		}
+		return uc.bookRepo.GetByID(ctx, &book, id)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
 	}
+	logger.FromContext(ctx).Info("book restored", zap.Int("id", id))
+
+	return book.ToBooksSummary(), nil
+}
 
-	if err := uc.bookRepo.Delete(bookData); err != nil {
+func (uc *BooksUseCase) PurgeBook(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.PurgeBook")
+	defer span.End()
+
+	// the returned loans go first, they come back if the book stays
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return uc.bookRepo.Purge(ctx, id)
+	})
+	if err != nil {
 		return fmt.Errorf("repository error: %w", err)
 	}
+	// there is no undo, leave a trace of who asked for it
+	logger.FromContext(ctx).Info("book purged", zap.Int("id", id))
+
+	return nil
+}
+
+func (uc *BooksUseCase) GetBooksStats(ctx context.Context) (*models.BooksStats, error) {
+	ctx, span := tracer.Start(ctx, "BooksUseCase.GetBooksStats")
+	defer span.End()
+
+	var stats models.BooksStats
+	if err := uc.bookRepo.Stats(ctx, &stats); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
 
+	return &stats, nil
+}
+
+// load fetches what include asks for for all of books at once
+func (uc *BooksUseCase) load(ctx context.Context, books []models.Books, include models.BooksInclude) error {
+	if include.Authors {
+		return uc.bookRepo.LoadAuthors(ctx, books)
+	}
 	return nil
 }
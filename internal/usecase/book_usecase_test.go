@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"context"
+	"crud-echo/internal/config"
 	"crud-echo/internal/mocks"
 	"crud-echo/internal/models"
 	"fmt"
@@ -8,11 +10,61 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
 var timeNow = time.Now
 
+// the usecase hands the repository a context carrying its own span
+var anyCtx = mock.Anything
+
+// inlineTx runs the unit of work as is, the repository mocks don't care
+// about transactions
+type inlineTx struct{}
+
+func (inlineTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// newTestBooksUseCase is for paths that never reach the hold queue, its
+// repository mock expects nothing
+func newTestBooksUseCase(t *testing.T, bookRepo *mocks.MockusecaseBooksRepository) *BooksUseCase {
+	return newTestBooksUseCaseWithHolds(t, bookRepo, mocks.NewMockusecaseHoldsRepository(t), timeNow())
+}
+
+func newTestBooksUseCaseWithHolds(t *testing.T, bookRepo *mocks.MockusecaseBooksRepository, holdRepo *mocks.MockusecaseHoldsRepository, now time.Time) *BooksUseCase {
+	uc, err := NewBooksUseCase(bookRepo, holdRepo, inlineTx{}, lendingConfig)
+	require.NoError(t, err)
+	uc.now = func() time.Time { return now }
+	return uc
+}
+
+func TestNewBooksUseCase(t *testing.T) {
+	tests := []struct {
+		name    string
+		lending *config.Lending
+		wantErr bool
+	}{
+		{name: "Valid hold pickup window", lending: &config.Lending{HoldPickupWindow: holdPickupWindow}},
+		{name: "Missing lending policy", wantErr: true},
+		{name: "Zero hold pickup window", lending: &config.Lending{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBooksUseCase(nil, nil, inlineTx{}, &config.Config{Lending: tt.lending})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestCreateBook(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -31,18 +83,97 @@ func TestCreateBook(t *testing.T) {
 			},
 			expectedID: 1,
 			mock: func(mock *mocks.MockusecaseBooksRepository) {
-				mock.EXPECT().ExistsByTitle("Test Title").Return(false, nil)
-				mock.EXPECT().Create(&models.Books{
+				mock.EXPECT().Create(anyCtx, &models.Books{
 					Title:       "Test Title",
 					Description: "Test Description",
 					Qty:         10,
-				}).RunAndReturn(func(book *models.Books) error {
+				}).RunAndReturn(func(_ context.Context, book *models.Books) error {
 					book.ID = 1 // hackaround? maybe not the right approach
 					return nil
 				})
+				mock.EXPECT().CreateMovements(anyCtx, []models.StockMovement{{
+					BookID:   1,
+					Kind:     models.StockReceive,
+					Quantity: 10,
+					Balance:  10,
+					Reason:   reasonInitialStock,
+					Actor:    models.DefaultActor,
+				}}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Success create book stores the ISBN-10 as ISBN-13",
+			bookRequest: &models.CreateBooksRequest{
+				Title:       "Test Title",
+				Description: "Test Description",
+				BooksBibliography: models.BooksBibliography{
+					ISBN:      "0-441-17271-7",
+					Publisher: "Ace",
+					Language:  "en",
+				},
+			},
+			expectedID: 1,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Create(anyCtx, &models.Books{
+					Title:       "Test Title",
+					Description: "Test Description",
+					BooksBibliography: models.BooksBibliography{
+						ISBN:      "9780441172719",
+						Publisher: "Ace",
+						Language:  "en",
+					},
+				}).RunAndReturn(func(_ context.Context, book *models.Books) error {
+					book.ID = 1
+					return nil
+				})
+				mock.EXPECT().CreateMovements(anyCtx, []models.StockMovement(nil)).Return(nil)
 			},
 			wantErr: false,
 		},
+		{
+			name: "Success create book without stock records no movement",
+			bookRequest: &models.CreateBooksRequest{
+				Title:       "Test Title",
+				Description: "Test Description",
+			},
+			expectedID: 1,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Create(anyCtx, &models.Books{
+					Title:       "Test Title",
+					Description: "Test Description",
+				}).RunAndReturn(func(_ context.Context, book *models.Books) error {
+					book.ID = 1
+					return nil
+				})
+				mock.EXPECT().CreateMovements(anyCtx, []models.StockMovement(nil)).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed create book due to opening movement",
+			bookRequest: &models.CreateBooksRequest{
+				Title:       "Test Title",
+				Description: "Test Description",
+				Qty:         10,
+			},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Create(anyCtx, &models.Books{
+					Title:       "Test Title",
+					Description: "Test Description",
+					Qty:         10,
+				}).Return(nil)
+				mock.EXPECT().CreateMovements(anyCtx, []models.StockMovement{{
+					Kind:     models.StockReceive,
+					Quantity: 10,
+					Balance:  10,
+					Reason:   reasonInitialStock,
+					Actor:    models.DefaultActor,
+				}}).Return(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", gorm.ErrInvalidDB),
+		},
 		{
 			name: "Failed create book due to title already exists",
 			bookRequest: &models.CreateBooksRequest{
@@ -51,10 +182,14 @@ func TestCreateBook(t *testing.T) {
 				Qty:         10,
 			},
 			mock: func(mock *mocks.MockusecaseBooksRepository) {
-				mock.EXPECT().ExistsByTitle("Test Title").Return(true, nil)
+				mock.EXPECT().Create(anyCtx, &models.Books{
+					Title:       "Test Title",
+					Description: "Test Description",
+					Qty:         10,
+				}).Return(models.ErrBookTitleTaken)
 			},
 			wantErr: true,
-			errType: fmt.Errorf("repository error: %w", models.ErrResourceAlreadyExist),
+			errType: fmt.Errorf("repository error: %w", models.ErrBookTitleTaken),
 		},
 		{
 			name: "Failed create book due to invalid DB",
@@ -64,8 +199,7 @@ func TestCreateBook(t *testing.T) {
 				Qty:         10,
 			},
 			mock: func(mock *mocks.MockusecaseBooksRepository) {
-				mock.EXPECT().ExistsByTitle("Test Title").Return(false, nil)
-				mock.EXPECT().Create(&models.Books{
+				mock.EXPECT().Create(anyCtx, &models.Books{
 					Title:       "Test Title",
 					Description: "Test Description",
 					Qty:         10,
@@ -81,9 +215,9 @@ func TestCreateBook(t *testing.T) {
 			mock := mocks.NewMockusecaseBooksRepository(t)
 			tt.mock(mock)
 
-			uc := NewBooksUseCase(mock)
+			uc := newTestBooksUseCase(t, mock)
 
-			book, err := uc.CreateBook(tt.bookRequest)
+			book, err := uc.CreateBook(context.Background(), tt.bookRequest)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -120,7 +254,7 @@ func TestGetBookByID(t *testing.T) {
 			},
 			id: 1,
 			mock: func(mock *mocks.MockusecaseBooksRepository) {
-				mock.EXPECT().GetByID(&models.Books{}, 1).RunAndReturn(func(book *models.Books, id int) error {
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).RunAndReturn(func(_ context.Context, book *models.Books, id int) error {
 					book.ID = id
 					book.Title = "Test Title"
 					book.Description = "Test Description"
@@ -141,7 +275,7 @@ func TestGetBookByID(t *testing.T) {
 			},
 			id: 99,
 			mock: func(mock *mocks.MockusecaseBooksRepository) {
-				mock.EXPECT().GetByID(&models.Books{}, 99).Return(gorm.ErrRecordNotFound)
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 99).Return(gorm.ErrRecordNotFound)
 			},
 			wantErr: true,
 			errType: fmt.Errorf("repository error: %w", gorm.ErrRecordNotFound),
@@ -157,7 +291,7 @@ func TestGetBookByID(t *testing.T) {
 			},
 			id: 1,
 			mock: func(mock *mocks.MockusecaseBooksRepository) {
-				mock.EXPECT().GetByID(&models.Books{}, 1).Return(gorm.ErrInvalidDB)
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).Return(gorm.ErrInvalidDB)
 			},
 			wantErr: true,
 			errType: fmt.Errorf("repository error: %w", gorm.ErrInvalidDB),
@@ -169,9 +303,9 @@ func TestGetBookByID(t *testing.T) {
 			mock := mocks.NewMockusecaseBooksRepository(t)
 			tt.mock(mock)
 
-			uc := NewBooksUseCase(mock)
+			uc := newTestBooksUseCase(t, mock)
 
-			book, err := uc.GetBookByID(tt.id)
+			book, err := uc.GetBookByID(context.Background(), tt.id, models.BooksInclude{})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -187,19 +321,94 @@ func TestGetBookByID(t *testing.T) {
 	}
 }
 
+func TestGetBookByISBN(t *testing.T) {
+	tests := []struct {
+		name         string
+		isbn         string
+		expectedBook *models.BooksSummary
+		mock         func(mock *mocks.MockusecaseBooksRepository)
+		wantErr      bool
+		errType      error
+	}{
+		{
+			name: "Success get book by ISBN",
+			isbn: "9780441172719",
+			expectedBook: &models.BooksSummary{
+				ID:                1,
+				Title:             "Dune",
+				BooksBibliography: models.BooksBibliography{ISBN: "9780441172719"},
+			},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByISBN(anyCtx, &models.Books{}, "9780441172719").RunAndReturn(func(_ context.Context, book *models.Books, isbn string) error {
+					book.ID = 1
+					book.Title = "Dune"
+					book.ISBN = isbn
+					return nil
+				})
+			},
+		},
+		{
+			name: "Failed get book by unknown ISBN",
+			isbn: "9780441172719",
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByISBN(anyCtx, &models.Books{}, "9780441172719").Return(models.ErrBookNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrBookNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseBooksRepository(t)
+			tt.mock(mock)
+
+			uc := newTestBooksUseCase(t, mock)
+
+			book, err := uc.GetBookByISBN(context.Background(), tt.isbn, models.BooksInclude{})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBook, book)
+			}
+		})
+	}
+}
+
 func TestGetAllBooks(t *testing.T) {
+	createdAt := timeNow().UTC().Truncate(time.Microsecond)
+	firstPage := []models.Books{
+		{
+			ID:          1,
+			Title:       "Test Title 1",
+			Description: "Test Description 1",
+			Qty:         10,
+			CreatedAt:   createdAt,
+		},
+		{
+			ID:          2,
+			Title:       "Test Title 2",
+			Description: "Test Description 2",
+			Qty:         20,
+			CreatedAt:   createdAt,
+		},
+	}
+
 	tests := []struct {
 		name          string
-		booksRequest  *[]models.Books
+		page          *models.PageRequest
 		expectedBooks *[]models.BooksSummary
-		available     bool
+		expectedMeta  *models.PageMeta
 		mock          func(mock *mocks.MockusecaseBooksRepository)
 		wantErr       bool
 		errType       error
 	}{
 		{
-			name:         "Success get all books",
-			booksRequest: &[]models.Books{},
+			name: "Success get first page of books",
+			page: &models.PageRequest{Limit: 2},
 			expectedBooks: &[]models.BooksSummary{
 				{
 					ID:          1,
@@ -214,55 +423,139 @@ func TestGetAllBooks(t *testing.T) {
 					Qty:         20,
 				},
 			},
-			available: true,
+			expectedMeta: &models.PageMeta{
+				NextCursor: firstPage[1].ToCursor(models.CursorDirectionNext).Encode(),
+				Total:      5,
+				Limit:      2,
+			},
 			mock: func(mock *mocks.MockusecaseBooksRepository) {
 				var arg []models.Books
-				mock.EXPECT().GetAll(&arg).RunAndReturn(func(books *[]models.Books) error {
-					*books = []models.Books{
-						{
-							ID:          1,
-							Title:       "Test Title 1",
-							Description: "Test Description 1",
-							Qty:         10,
-						},
-						{
-							ID:          2,
-							Title:       "Test Title 2",
-							Description: "Test Description 2",
-							Qty:         20,
-						},
-					}
-					return nil
+				mock.EXPECT().GetPage(anyCtx, &arg, &models.BooksFilter{}, &models.PageRequest{Limit: 2}).
+					RunAndReturn(func(_ context.Context, books *[]models.Books, filter *models.BooksFilter, page *models.PageRequest) (*models.PageResult, error) {
+						*books = firstPage
+						return &models.PageResult{Total: 5, HasMore: true}, nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "Success get last page of books with offset",
+			page: &models.PageRequest{Limit: 2, Offset: 2},
+			expectedBooks: &[]models.BooksSummary{
+				{
+					ID:          1,
+					Title:       "Test Title 1",
+					Description: "Test Description 1",
+					Qty:         10,
+				},
+				{
+					ID:          2,
+					Title:       "Test Title 2",
+					Description: "Test Description 2",
+					Qty:         20,
+				},
+			},
+			expectedMeta: &models.PageMeta{
+				PrevCursor: firstPage[0].ToCursor(models.CursorDirectionPrev).Encode(),
+				Total:      4,
+				Limit:      2,
+				Offset:     2,
+			},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				var arg []models.Books
+				mock.EXPECT().GetPage(anyCtx, &arg, &models.BooksFilter{}, &models.PageRequest{Limit: 2, Offset: 2}).
+					RunAndReturn(func(_ context.Context, books *[]models.Books, filter *models.BooksFilter, page *models.PageRequest) (*models.PageResult, error) {
+						*books = firstPage
+						return &models.PageResult{Total: 4, HasMore: false}, nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "Success walk backward to the first page with cursor",
+			page: &models.PageRequest{
+				Limit:  2,
+				Cursor: &models.Cursor{ID: 3, CreatedAt: createdAt, Direction: models.CursorDirectionPrev},
+			},
+			expectedBooks: &[]models.BooksSummary{
+				{
+					ID:          1,
+					Title:       "Test Title 1",
+					Description: "Test Description 1",
+					Qty:         10,
+				},
+				{
+					ID:          2,
+					Title:       "Test Title 2",
+					Description: "Test Description 2",
+					Qty:         20,
+				},
+			},
+			expectedMeta: &models.PageMeta{
+				NextCursor: firstPage[1].ToCursor(models.CursorDirectionNext).Encode(),
+				Total:      3,
+				Limit:      2,
+			},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				var arg []models.Books
+				mock.EXPECT().GetPage(anyCtx, &arg, &models.BooksFilter{}, &models.PageRequest{
+					Limit:  2,
+					Cursor: &models.Cursor{ID: 3, CreatedAt: createdAt, Direction: models.CursorDirectionPrev},
+				}).RunAndReturn(func(_ context.Context, books *[]models.Books, filter *models.BooksFilter, page *models.PageRequest) (*models.PageResult, error) {
+					*books = firstPage
+					return &models.PageResult{Total: 3, HasMore: false}, nil
 				})
 			},
 			wantErr: false,
 		},
 		{
-			name:         "Failed get all books because of invalid parameter",
-			booksRequest: &[]models.Books{},
-			available:    false,
-			mock:         func(mock *mocks.MockusecaseBooksRepository) {},
-			wantErr:      true,
-			errType:      fmt.Errorf("invalid parameter"),
+			name: "Success get sorted page of books without cursors",
+			page: &models.PageRequest{Limit: 2, Sort: []models.SortField{{Column: "title", Desc: true}}},
+			expectedBooks: &[]models.BooksSummary{
+				{
+					ID:          1,
+					Title:       "Test Title 1",
+					Description: "Test Description 1",
+					Qty:         10,
+				},
+				{
+					ID:          2,
+					Title:       "Test Title 2",
+					Description: "Test Description 2",
+					Qty:         20,
+				},
+			},
+			expectedMeta: &models.PageMeta{
+				Total: 5,
+				Limit: 2,
+			},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				var arg []models.Books
+				mock.EXPECT().GetPage(anyCtx, &arg, &models.BooksFilter{}, &models.PageRequest{Limit: 2, Sort: []models.SortField{{Column: "title", Desc: true}}}).
+					RunAndReturn(func(_ context.Context, books *[]models.Books, filter *models.BooksFilter, page *models.PageRequest) (*models.PageResult, error) {
+						*books = firstPage
+						return &models.PageResult{Total: 5, HasMore: true}, nil
+					})
+			},
+			wantErr: false,
 		},
 		{
-			name:         "Failed get all books because no books found in database",
-			booksRequest: &[]models.Books{},
-			available:    true,
+			name:          "Success get all books from an empty table",
+			page:          &models.PageRequest{Limit: 2},
+			expectedBooks: &[]models.BooksSummary{},
+			expectedMeta:  &models.PageMeta{Total: 0, Limit: 2},
 			mock: func(mock *mocks.MockusecaseBooksRepository) {
 				var arg []models.Books
-				mock.EXPECT().GetAll(&arg).Return(models.ErrNotFound)
+				mock.EXPECT().GetPage(anyCtx, &arg, &models.BooksFilter{}, &models.PageRequest{Limit: 2}).Return(&models.PageResult{}, nil)
 			},
-			wantErr: true,
-			errType: fmt.Errorf("repository error: %w", models.ErrNotFound),
+			wantErr: false,
 		},
 		{
-			name:         "Failed get all books because of database error",
-			booksRequest: &[]models.Books{},
-			available:    true,
+			name: "Failed get all books because of database error",
+			page: &models.PageRequest{Limit: 2},
 			mock: func(mock *mocks.MockusecaseBooksRepository) {
 				var arg []models.Books
-				mock.EXPECT().GetAll(&arg).Return(gorm.ErrInvalidDB)
+				mock.EXPECT().GetPage(anyCtx, &arg, &models.BooksFilter{}, &models.PageRequest{Limit: 2}).Return(nil, gorm.ErrInvalidDB)
 			},
 			wantErr: true,
 			errType: fmt.Errorf("repository error: %w", gorm.ErrInvalidDB),
@@ -274,15 +567,16 @@ func TestGetAllBooks(t *testing.T) {
 			mock := mocks.NewMockusecaseBooksRepository(t)
 			tt.mock(mock)
 
-			uc := NewBooksUseCase(mock)
+			uc := newTestBooksUseCase(t, mock)
 
-			books, err := uc.GetAllBooks(tt.available)
+			books, meta, err := uc.GetAllBooks(context.Background(), &models.BooksFilter{}, tt.page, models.BooksInclude{})
 
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Equal(t, tt.errType, err)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedMeta, meta)
 				assert.Equal(t, len(*tt.expectedBooks), len(*books))
 				for i, expected := range *tt.expectedBooks {
 					actual := (*books)[i]
@@ -297,6 +591,13 @@ func TestGetAllBooks(t *testing.T) {
 }
 
 func TestUpdateBook(t *testing.T) {
+	updated := &models.Books{
+		ID:          1,
+		Title:       "Updated Title",
+		Description: "Updated Description",
+		Qty:         15,
+	}
+
 	tests := []struct {
 		name        string
 		bookRequest *models.UpdateBooksRequest
@@ -313,12 +614,29 @@ func TestUpdateBook(t *testing.T) {
 				Qty:         15,
 			},
 			mock: func(mock *mocks.MockusecaseBooksRepository) {
-				mock.EXPECT().Update(&models.Books{
-					ID:          1,
-					Title:       "Updated Title",
-					Description: "Updated Description",
-					Qty:         15,
-				}).Return(nil)
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(10))
+				mock.EXPECT().Update(anyCtx, updated).Return(nil)
+				mock.EXPECT().CreateMovements(anyCtx, []models.StockMovement{{
+					BookID:   1,
+					Kind:     models.StockAdjust,
+					Quantity: 5,
+					Balance:  15,
+					Reason:   reasonEdited,
+					Actor:    models.DefaultActor,
+				}}).Return(nil)
+			},
+		},
+		{
+			name: "Success update book leaving qty alone records no movement",
+			bookRequest: &models.UpdateBooksRequest{
+				ID:          1,
+				Title:       "Updated Title",
+				Description: "Updated Description",
+				Qty:         15,
+			},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(15))
+				mock.EXPECT().Update(anyCtx, updated).Return(nil)
 			},
 		},
 		{
@@ -330,15 +648,10 @@ func TestUpdateBook(t *testing.T) {
 				Qty:         15,
 			},
 			mock: func(mock *mocks.MockusecaseBooksRepository) {
-				mock.EXPECT().Update(&models.Books{
-					ID:          1,
-					Title:       "Updated Title",
-					Description: "Updated Description",
-					Qty:         15,
-				}).Return(models.ErrNotFound)
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).Return(models.ErrBookNotFound)
 			},
 			wantErr: true,
-			errType: fmt.Errorf("repository error: %w", models.ErrNotFound),
+			errType: fmt.Errorf("repository error: %w", models.ErrBookNotFound),
 		},
 		{
 			name: "Failed update book due to invalid DB",
@@ -349,12 +662,8 @@ func TestUpdateBook(t *testing.T) {
 				Qty:         15,
 			},
 			mock: func(mock *mocks.MockusecaseBooksRepository) {
-				mock.EXPECT().Update(&models.Books{
-					ID:          1,
-					Title:       "Updated Title",
-					Description: "Updated Description",
-					Qty:         15,
-				}).Return(gorm.ErrInvalidDB)
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(10))
+				mock.EXPECT().Update(anyCtx, updated).Return(gorm.ErrInvalidDB)
 			},
 			wantErr: true,
 			errType: fmt.Errorf("repository error: %w", gorm.ErrInvalidDB),
@@ -365,15 +674,127 @@ func TestUpdateBook(t *testing.T) {
 			mock := mocks.NewMockusecaseBooksRepository(t)
 			tt.mock(mock)
 
-			uc := NewBooksUseCase(mock)
+			uc := newTestBooksUseCase(t, mock)
+
+			err := uc.UpdateBook(context.Background(), tt.bookRequest)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPatchBook(t *testing.T) {
+	qty := 0
+	title := "Patched Title"
+	isbn10 := "0-441-17271-7"
+	isbn13 := "9780441172719"
+
+	patched := &models.Books{ID: 1, Title: "Patched Title", Description: "Test Description", Qty: 0, Version: 4,
+		BooksBibliography: models.BooksBibliography{ISBN: isbn13}}
+	// readBack expects the book to be read again once the patch is written
+	readBack := func(mock *mocks.MockusecaseBooksRepository) {
+		mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).
+			Run(func(_ context.Context, book *models.Books, _ int) { *book = *patched }).
+			Return(nil).Once()
+	}
+
+	tests := []struct {
+		name         string
+		id           int
+		patch        *models.BooksPatch
+		mock         func(mock *mocks.MockusecaseBooksRepository)
+		expectedResp *models.BooksSummary
+		wantErr      bool
+		errType      error
+	}{
+		{
+			name:  "Success patch book qty to zero",
+			id:    1,
+			patch: &models.BooksPatch{Qty: &qty},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(4)).Once()
+				mock.EXPECT().Patch(anyCtx, 1, &models.BooksPatch{Qty: &qty}).Return(nil)
+				mock.EXPECT().CreateMovements(anyCtx, []models.StockMovement{{
+					BookID:   1,
+					Kind:     models.StockAdjust,
+					Quantity: -4,
+					Balance:  0,
+					Reason:   reasonEdited,
+					Actor:    models.DefaultActor,
+				}}).Return(nil)
+				readBack(mock)
+			},
+			expectedResp: patched.ToBooksSummary(),
+		},
+		{
+			name:  "Success patch book title needs no ledger",
+			id:    1,
+			patch: &models.BooksPatch{Title: &title},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Patch(anyCtx, 1, &models.BooksPatch{Title: &title}).Return(nil)
+				readBack(mock)
+			},
+			expectedResp: patched.ToBooksSummary(),
+		},
+		{
+			name:  "Success patch book isbn is normalized",
+			id:    1,
+			patch: &models.BooksPatch{ISBN: &isbn10},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Patch(anyCtx, 1, &models.BooksPatch{ISBN: &isbn13}).Return(nil)
+				readBack(mock)
+			},
+			expectedResp: patched.ToBooksSummary(),
+		},
+		{
+			name:         "Success patch book with nothing changed",
+			id:           1,
+			patch:        &models.BooksPatch{},
+			mock:         readBack,
+			expectedResp: patched.ToBooksSummary(),
+		},
+		{
+			name:  "Failed patch book due to book not found",
+			id:    99,
+			patch: &models.BooksPatch{Qty: &qty},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 99).Return(models.ErrBookNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrBookNotFound),
+		},
+		{
+			name:  "Failed patch book due to stale version",
+			id:    1,
+			patch: &models.BooksPatch{Qty: &qty},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(4)).Once()
+				mock.EXPECT().Patch(anyCtx, 1, &models.BooksPatch{Qty: &qty}).Return(models.ErrPreconditionFailed)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrPreconditionFailed),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseBooksRepository(t)
+			tt.mock(mock)
+
+			uc := newTestBooksUseCase(t, mock)
 
-			err := uc.UpdateBook(tt.bookRequest)
+			resp, err := uc.PatchBook(context.Background(), tt.id, tt.patch)
 
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Equal(t, tt.errType, err)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResp, resp)
 			}
 		})
 	}
@@ -393,7 +814,7 @@ func TestDeleteBook(t *testing.T) {
 				ID: 1,
 			},
 			mock: func(mock *mocks.MockusecaseBooksRepository) {
-				mock.EXPECT().Delete(&models.Books{
+				mock.EXPECT().Delete(anyCtx, &models.Books{
 					ID: 1,
 				}).Return(nil)
 			},
@@ -404,12 +825,12 @@ func TestDeleteBook(t *testing.T) {
 				ID: 99,
 			},
 			mock: func(mock *mocks.MockusecaseBooksRepository) {
-				mock.EXPECT().Delete(&models.Books{
+				mock.EXPECT().Delete(anyCtx, &models.Books{
 					ID: 99,
-				}).Return(models.ErrNotFound)
+				}).Return(models.ErrBookNotFound)
 			},
 			wantErr: true,
-			errType: fmt.Errorf("repository error: %w", models.ErrNotFound),
+			errType: fmt.Errorf("repository error: %w", models.ErrBookNotFound),
 		},
 		{
 			name: "Failed update book due to invalid DB",
@@ -417,7 +838,7 @@ func TestDeleteBook(t *testing.T) {
 				ID: 1,
 			},
 			mock: func(mock *mocks.MockusecaseBooksRepository) {
-				mock.EXPECT().Delete(&models.Books{
+				mock.EXPECT().Delete(anyCtx, &models.Books{
 					ID: 1,
 				}).Return(gorm.ErrInvalidDB)
 			},
@@ -430,15 +851,318 @@ func TestDeleteBook(t *testing.T) {
 			mock := mocks.NewMockusecaseBooksRepository(t)
 			tt.mock(mock)
 
-			uc := NewBooksUseCase(mock)
+			uc := newTestBooksUseCase(t, mock)
+
+			err := uc.DeleteBook(context.Background(), tt.bookRequest)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSearchBooks(t *testing.T) {
+	tests := []struct {
+		name            string
+		query           string
+		limit           int
+		expectedResults *[]models.BooksSearchResult
+		mock            func(mock *mocks.MockusecaseBooksRepository)
+		wantErr         bool
+		errType         error
+	}{
+		{
+			name:  "Success search books",
+			query: "dune",
+			limit: 10,
+			expectedResults: &[]models.BooksSearchResult{
+				{
+					BooksSummary: models.BooksSummary{ID: 2, Title: "Dune", Description: "Desert planet", Qty: 3},
+					Rank:         0.6,
+					Snippet:      "<mark>Dune</mark> - Desert planet",
+				},
+			},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Search(anyCtx, &[]models.BooksSearchResult{}, "dune", 10).
+					RunAndReturn(func(_ context.Context, results *[]models.BooksSearchResult, query string, limit int) error {
+						*results = append(*results, models.BooksSearchResult{
+							BooksSummary: models.BooksSummary{ID: 2, Title: "Dune", Description: "Desert planet", Qty: 3},
+							Rank:         0.6,
+							Snippet:      "<mark>Dune</mark> - Desert planet",
+						})
+						return nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name:            "Success search books with no match",
+			query:           "nothing",
+			limit:           10,
+			expectedResults: &[]models.BooksSearchResult{},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Search(anyCtx, &[]models.BooksSearchResult{}, "nothing", 10).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Failed search books due to invalid DB",
+			query: "dune",
+			limit: 10,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Search(anyCtx, &[]models.BooksSearchResult{}, "dune", 10).Return(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", gorm.ErrInvalidDB),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseBooksRepository(t)
+			tt.mock(mock)
+
+			uc := newTestBooksUseCase(t, mock)
+
+			results, err := uc.SearchBooks(context.Background(), tt.query, tt.limit)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResults, results)
+			}
+		})
+	}
+}
+
+func TestGetTrashedBooks(t *testing.T) {
+	deletedAt := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		page         *models.PageRequest
+		mock         func(mock *mocks.MockusecaseBooksRepository)
+		expectedResp *[]models.BooksSummary
+		expectedMeta *models.PageMeta
+		wantErr      bool
+		errType      error
+	}{
+		{
+			name: "Success get trashed books",
+			page: &models.PageRequest{Limit: 20},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				var arg []models.Books
+				mock.EXPECT().GetTrash(anyCtx, &arg, &models.PageRequest{Limit: 20}).
+					Run(func(_ context.Context, books *[]models.Books, page *models.PageRequest) {
+						*books = []models.Books{
+							{ID: 1, Title: "Test Title", Description: "Test Description", Qty: 10, Version: 2, DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+						}
+					}).
+					Return(&models.PageResult{Total: 1}, nil)
+			},
+			expectedResp: &[]models.BooksSummary{
+				{ID: 1, Title: "Test Title", Description: "Test Description", Qty: 10, Version: 2, DeletedAt: &deletedAt},
+			},
+			expectedMeta: &models.PageMeta{Total: 1, Limit: 20},
+		},
+		{
+			name: "Success get empty trash",
+			page: &models.PageRequest{Limit: 20},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				var arg []models.Books
+				mock.EXPECT().GetTrash(anyCtx, &arg, &models.PageRequest{Limit: 20}).
+					Return(&models.PageResult{}, nil)
+			},
+			expectedResp: &[]models.BooksSummary{},
+			expectedMeta: &models.PageMeta{Limit: 20},
+		},
+		{
+			name: "Failed get trashed books due to repository error",
+			page: &models.PageRequest{Limit: 20},
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				var arg []models.Books
+				mock.EXPECT().GetTrash(anyCtx, &arg, &models.PageRequest{Limit: 20}).
+					Return(nil, models.ErrInternalServerError)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrInternalServerError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseBooksRepository(t)
+			tt.mock(mock)
+
+			uc := newTestBooksUseCase(t, mock)
+
+			resp, meta, err := uc.GetTrashedBooks(context.Background(), tt.page)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResp, resp)
+				assert.Equal(t, tt.expectedMeta, meta)
+			}
+		})
+	}
+}
+
+func TestRestoreBook(t *testing.T) {
+	tests := []struct {
+		name         string
+		id           int
+		mock         func(mock *mocks.MockusecaseBooksRepository)
+		expectedResp *models.BooksSummary
+		wantErr      bool
+		errType      error
+	}{
+		{
+			name: "Success restore book",
+			id:   1,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Restore(anyCtx, 1).Return(nil)
+				mock.EXPECT().GetByID(anyCtx, &models.Books{}, 1).
+					Run(func(_ context.Context, book *models.Books, id int) {
+						*book = models.Books{ID: 1, Title: "Test Title", Description: "Test Description", Qty: 10, Version: 3}
+					}).
+					Return(nil)
+			},
+			expectedResp: &models.BooksSummary{ID: 1, Title: "Test Title", Description: "Test Description", Qty: 10, Version: 3},
+		},
+		{
+			name: "Failed restore book due to book not in trash",
+			id:   99,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Restore(anyCtx, 99).Return(models.ErrBookNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrBookNotFound),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseBooksRepository(t)
+			tt.mock(mock)
+
+			uc := newTestBooksUseCase(t, mock)
+
+			resp, err := uc.RestoreBook(context.Background(), tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResp, resp)
+			}
+		})
+	}
+}
+
+func TestPurgeBook(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		mock    func(mock *mocks.MockusecaseBooksRepository)
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "Success purge book",
+			id:   1,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Purge(anyCtx, 1).Return(nil)
+			},
+		},
+		{
+			name: "Failed purge book due to book not found",
+			id:   99,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Purge(anyCtx, 99).Return(models.ErrBookNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrBookNotFound),
+		},
+		{
+			name: "Failed purge book due to open loans",
+			id:   1,
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Purge(anyCtx, 1).Return(models.ErrBookHasOpenLoans)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrBookHasOpenLoans),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseBooksRepository(t)
+			tt.mock(mock)
+
+			uc := newTestBooksUseCase(t, mock)
+
+			err := uc.PurgeBook(context.Background(), tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetBooksStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		mock      func(mock *mocks.MockusecaseBooksRepository)
+		wantStats *models.BooksStats
+		wantErr   bool
+		errType   error
+	}{
+		{
+			name: "Success get books stats",
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Stats(anyCtx, &models.BooksStats{}).
+					Run(func(_ context.Context, stats *models.BooksStats) {
+						*stats = models.BooksStats{Total: 3, TotalQty: 17}
+					}).
+					Return(nil)
+			},
+			wantStats: &models.BooksStats{Total: 3, TotalQty: 17},
+		},
+		{
+			name: "Failed get books stats due to database error",
+			mock: func(mock *mocks.MockusecaseBooksRepository) {
+				mock.EXPECT().Stats(anyCtx, &models.BooksStats{}).Return(models.ErrInternalServerError)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrInternalServerError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseBooksRepository(t)
+			tt.mock(mock)
+
+			uc := newTestBooksUseCase(t, mock)
 
-			err := uc.DeleteBook(tt.bookRequest)
+			stats, err := uc.GetBooksStats(context.Background())
 
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Equal(t, tt.errType, err)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.wantStats, stats)
 			}
 		})
 	}
@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"crud-echo/pkg/logger"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+type UsecaseCategoriesRepository interface {
+	Create(ctx context.Context, category *models.Categories) error
+	GetByID(ctx context.Context, category *models.Categories, id int) error
+	GetByIDForUpdate(ctx context.Context, category *models.Categories, id int) error
+	GetAll(ctx context.Context, categories *[]models.Categories) error
+	Update(ctx context.Context, category *models.Categories) error
+	MoveSubtree(ctx context.Context, oldPath, newPath string) error
+	Delete(ctx context.Context, id int) error
+	ReplaceBookCategories(ctx context.Context, bookID int, categoryIDs []int) error
+	GetBookCategories(ctx context.Context, categories *[]models.Categories, bookID int) error
+}
+
+type CategoriesUseCase struct {
+	categoryRepo UsecaseCategoriesRepository
+	tx           TxManager
+}
+
+func NewCategoriesUseCase(repo UsecaseCategoriesRepository, tx TxManager) *CategoriesUseCase {
+	return &CategoriesUseCase{categoryRepo: repo, tx: tx}
+}
+
+func (uc *CategoriesUseCase) CreateCategory(ctx context.Context, categoryRequest *models.CreateCategoriesRequest) (*models.CategoriesSummary, error) {
+	ctx, span := tracer.Start(ctx, "CategoriesUseCase.CreateCategory")
+	defer span.End()
+
+	category := &models.Categories{
+		Name:     categoryRequest.Name,
+		Slug:     categoryRequest.Slug,
+		ParentID: categoryRequest.ParentID,
+	}
+
+	// the parent stays locked until the category is in
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return uc.categoryRepo.Create(ctx, category)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return category.ToCategoriesSummary(), nil
+}
+
+func (uc *CategoriesUseCase) GetCategoryByID(ctx context.Context, id int) (*models.CategoriesSummary, error) {
+	ctx, span := tracer.Start(ctx, "CategoriesUseCase.GetCategoryByID")
+	defer span.End()
+
+	var category models.Categories
+	if err := uc.categoryRepo.GetByID(ctx, &category, id); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return category.ToCategoriesSummary(), nil
+}
+
+// GetAllCategories lists the whole tree, every category right before its
+// subtree
+func (uc *CategoriesUseCase) GetAllCategories(ctx context.Context) (*[]models.CategoriesSummary, error) {
+	ctx, span := tracer.Start(ctx, "CategoriesUseCase.GetAllCategories")
+	defer span.End()
+
+	var categories []models.Categories
+	if err := uc.categoryRepo.GetAll(ctx, &categories); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return categoriesSummary(categories), nil
+}
+
+// UpdateCategory moves the category along with its subtree when the parent
+// changes, a category can't end up below itself
+func (uc *CategoriesUseCase) UpdateCategory(ctx context.Context, categoryRequest *models.UpdateCategoriesRequest) (*models.CategoriesSummary, error) {
+	ctx, span := tracer.Start(ctx, "CategoriesUseCase.UpdateCategory")
+	defer span.End()
+
+	category := &models.Categories{
+		ID:       categoryRequest.ID,
+		Name:     categoryRequest.Name,
+		Slug:     categoryRequest.Slug,
+		ParentID: categoryRequest.ParentID,
+	}
+
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// the parent is checked before anything is written, see
+		// database.fkCategoriesParentID
+		current, parent, err := uc.lockMove(ctx, category.ID, category.ParentID)
+		if err != nil {
+			return err
+		}
+		if sameParent(current.ParentID, category.ParentID) {
+			return uc.categoryRepo.Update(ctx, category)
+		}
+		if current.Contains(*parent) {
+			return models.ErrCategoryCycle
+		}
+		if err := uc.categoryRepo.Update(ctx, category); err != nil {
+			return err
+		}
+		return uc.categoryRepo.MoveSubtree(ctx, current.Path, models.CategoryPath(parent.Path, current.ID))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return category.ToCategoriesSummary(), nil
+}
+
+func (uc *CategoriesUseCase) DeleteCategory(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "CategoriesUseCase.DeleteCategory")
+	defer span.End()
+
+	if err := uc.categoryRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+	logger.FromContext(ctx).Info("category deleted", zap.Int("id", id))
+
+	return nil
+}
+
+// SetBookCategories replaces the categories of a book and returns the new set
+func (uc *CategoriesUseCase) SetBookCategories(ctx context.Context, bookID int, categoryIDs []int) (*[]models.CategoriesSummary, error) {
+	ctx, span := tracer.Start(ctx, "CategoriesUseCase.SetBookCategories")
+	defer span.End()
+
+	var categories []models.Categories
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.categoryRepo.ReplaceBookCategories(ctx, bookID, categoryIDs); err != nil {
+			return err
+		}
+		return uc.categoryRepo.GetBookCategories(ctx, &categories, bookID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return categoriesSummary(categories), nil
+}
+
+func (uc *CategoriesUseCase) GetBookCategories(ctx context.Context, bookID int) (*[]models.CategoriesSummary, error) {
+	ctx, span := tracer.Start(ctx, "CategoriesUseCase.GetBookCategories")
+	defer span.End()
+
+	var categories []models.Categories
+	if err := uc.categoryRepo.GetBookCategories(ctx, &categories, bookID); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return categoriesSummary(categories), nil
+}
+
+// lockMove locks the category being updated and the parent it goes under, in
+// id order so two moves crossing each other wait instead of deadlocking. With
+// both held neither path can change before the cycle check and the move
+func (uc *CategoriesUseCase) lockMove(ctx context.Context, id int, parentID *int) (current, parent *models.Categories, err error) {
+	current = &models.Categories{}
+	lockCurrent := func() error {
+		return uc.categoryRepo.GetByIDForUpdate(ctx, current, id)
+	}
+	lockParent := func() (err error) {
+		parent, err = uc.parent(ctx, parentID)
+		return err
+	}
+
+	locks := []func() error{lockCurrent, lockParent}
+	if parentID != nil && *parentID < id {
+		locks[0], locks[1] = lockParent, lockCurrent
+	}
+	for _, lock := range locks {
+		if err := lock(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return current, parent, nil
+}
+
+// parent locks the category something goes under, no id means the root. A
+// parent that doesn't exist is the client's mistake, not a 404
+func (uc *CategoriesUseCase) parent(ctx context.Context, id *int) (*models.Categories, error) {
+	if id == nil {
+		return &models.Categories{Path: models.CategoryRootPath}, nil
+	}
+
+	var parent models.Categories
+	if err := uc.categoryRepo.GetByIDForUpdate(ctx, &parent, *id); err != nil {
+		if errors.Is(err, models.ErrCategoryNotFound) {
+			return nil, models.ErrUnknownCategory.Wrap(err)
+		}
+		return nil, err
+	}
+
+	return &parent, nil
+}
+
+func sameParent(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func categoriesSummary(categories []models.Categories) *[]models.CategoriesSummary {
+	list := make([]models.CategoriesSummary, 0, len(categories))
+	for _, category := range categories {
+		list = append(list, *category.ToCategoriesSummary())
+	}
+	return &list
+}
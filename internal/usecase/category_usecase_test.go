@@ -0,0 +1,364 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreateCategory(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name             string
+		categoryRequest  *models.CreateCategoriesRequest
+		mock             func(mock *mocks.MockusecaseCategoriesRepository)
+		expectedCategory *models.CategoriesSummary
+		wantErr          bool
+		errType          error
+	}{
+		{
+			name:            "Success create root category",
+			categoryRequest: &models.CreateCategoriesRequest{Name: "Fiction", Slug: "fiction"},
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				mock.EXPECT().Create(anyCtx, &models.Categories{Name: "Fiction", Slug: "fiction"}).
+					RunAndReturn(func(_ context.Context, category *models.Categories) error {
+						category.ID = 1
+						return nil
+					})
+			},
+			expectedCategory: &models.CategoriesSummary{ID: 1, Name: "Fiction", Slug: "fiction"},
+		},
+		{
+			name:            "Success create child category",
+			categoryRequest: &models.CreateCategoriesRequest{Name: "Fantasy", Slug: "fantasy", ParentID: &parentID},
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				mock.EXPECT().Create(anyCtx, &models.Categories{Name: "Fantasy", Slug: "fantasy", ParentID: &parentID}).
+					RunAndReturn(func(_ context.Context, category *models.Categories) error {
+						category.ID = 4
+						return nil
+					})
+			},
+			expectedCategory: &models.CategoriesSummary{ID: 4, Name: "Fantasy", Slug: "fantasy", ParentID: &parentID},
+		},
+		{
+			name:            "Failed create category due to unknown parent",
+			categoryRequest: &models.CreateCategoriesRequest{Name: "Fantasy", Slug: "fantasy", ParentID: &parentID},
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				mock.EXPECT().Create(anyCtx, &models.Categories{Name: "Fantasy", Slug: "fantasy", ParentID: &parentID}).
+					Return(models.ErrUnknownCategory.Wrap(models.ErrCategoryNotFound))
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrUnknownCategory.Wrap(models.ErrCategoryNotFound)),
+		},
+		{
+			name:            "Failed create category due to slug taken",
+			categoryRequest: &models.CreateCategoriesRequest{Name: "Fiction", Slug: "fiction"},
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				mock.EXPECT().Create(anyCtx, &models.Categories{Name: "Fiction", Slug: "fiction"}).
+					Return(models.ErrCategorySlugTaken)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrCategorySlugTaken),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseCategoriesRepository(t)
+			tt.mock(mock)
+
+			uc := NewCategoriesUseCase(mock, inlineTx{})
+
+			category, err := uc.CreateCategory(context.Background(), tt.categoryRequest)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCategory, category)
+			}
+		})
+	}
+}
+
+func TestGetAllCategories(t *testing.T) {
+	parentID := 1
+
+	tests := []struct {
+		name               string
+		mock               func(mock *mocks.MockusecaseCategoriesRepository)
+		expectedCategories *[]models.CategoriesSummary
+		wantErr            bool
+		errType            error
+	}{
+		{
+			name: "Success get all categories",
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				mock.EXPECT().GetAll(anyCtx, new([]models.Categories)).
+					RunAndReturn(func(_ context.Context, categories *[]models.Categories) error {
+						*categories = []models.Categories{
+							{ID: 1, Name: "Fiction", Slug: "fiction", Path: "/1/"},
+							{ID: 4, Name: "Fantasy", Slug: "fantasy", ParentID: &parentID, Path: "/1/4/"},
+						}
+						return nil
+					})
+			},
+			expectedCategories: &[]models.CategoriesSummary{
+				{ID: 1, Name: "Fiction", Slug: "fiction"},
+				{ID: 4, Name: "Fantasy", Slug: "fantasy", ParentID: &parentID},
+			},
+		},
+		{
+			name: "Failed get all categories due to invalid DB",
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				mock.EXPECT().GetAll(anyCtx, new([]models.Categories)).Return(gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", gorm.ErrInvalidDB),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseCategoriesRepository(t)
+			tt.mock(mock)
+
+			uc := NewCategoriesUseCase(mock, inlineTx{})
+
+			categories, err := uc.GetAllCategories(context.Background())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCategories, categories)
+			}
+		})
+	}
+}
+
+func TestUpdateCategory(t *testing.T) {
+	fiction, nonFiction, fantasy := 1, 2, 4
+
+	// fantasy sits below fiction, every update locks it
+	current := func(repo *mocks.MockusecaseCategoriesRepository) *mock.Call {
+		return repo.EXPECT().GetByIDForUpdate(anyCtx, &models.Categories{}, 4).
+			RunAndReturn(func(_ context.Context, category *models.Categories, id int) error {
+				*category = models.Categories{ID: id, Name: "Fantasy", Slug: "fantasy", ParentID: &fiction, Path: "/1/4/"}
+				return nil
+			}).Once()
+	}
+
+	tests := []struct {
+		name             string
+		categoryRequest  *models.UpdateCategoriesRequest
+		mock             func(mock *mocks.MockusecaseCategoriesRepository)
+		expectedCategory *models.CategoriesSummary
+		wantErr          bool
+		errType          error
+	}{
+		{
+			name:            "Success rename category",
+			categoryRequest: &models.UpdateCategoriesRequest{ID: 4, Name: "High Fantasy", Slug: "high-fantasy", ParentID: &fiction},
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				current(mock)
+				mock.EXPECT().GetByIDForUpdate(anyCtx, &models.Categories{}, 1).
+					RunAndReturn(func(_ context.Context, category *models.Categories, id int) error {
+						*category = models.Categories{ID: id, Path: "/1/"}
+						return nil
+					})
+				mock.EXPECT().Update(anyCtx, &models.Categories{ID: 4, Name: "High Fantasy", Slug: "high-fantasy", ParentID: &fiction}).Return(nil)
+			},
+			expectedCategory: &models.CategoriesSummary{ID: 4, Name: "High Fantasy", Slug: "high-fantasy", ParentID: &fiction},
+		},
+		{
+			name:            "Success move category to another parent",
+			categoryRequest: &models.UpdateCategoriesRequest{ID: 4, Name: "Fantasy", Slug: "fantasy", ParentID: &nonFiction},
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				// the lower id is locked first
+				parent := mock.EXPECT().GetByIDForUpdate(anyCtx, &models.Categories{}, 2).
+					RunAndReturn(func(_ context.Context, category *models.Categories, id int) error {
+						*category = models.Categories{ID: id, Path: "/2/"}
+						return nil
+					})
+				current(mock).NotBefore(parent.Call)
+				mock.EXPECT().Update(anyCtx, &models.Categories{ID: 4, Name: "Fantasy", Slug: "fantasy", ParentID: &nonFiction}).Return(nil)
+				mock.EXPECT().MoveSubtree(anyCtx, "/1/4/", "/2/4/").Return(nil)
+			},
+			expectedCategory: &models.CategoriesSummary{ID: 4, Name: "Fantasy", Slug: "fantasy", ParentID: &nonFiction},
+		},
+		{
+			name:            "Success move category to the root",
+			categoryRequest: &models.UpdateCategoriesRequest{ID: 4, Name: "Fantasy", Slug: "fantasy"},
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				current(mock)
+				mock.EXPECT().Update(anyCtx, &models.Categories{ID: 4, Name: "Fantasy", Slug: "fantasy"}).Return(nil)
+				mock.EXPECT().MoveSubtree(anyCtx, "/1/4/", "/4/").Return(nil)
+			},
+			expectedCategory: &models.CategoriesSummary{ID: 4, Name: "Fantasy", Slug: "fantasy"},
+		},
+		{
+			name:            "Failed move category below itself",
+			categoryRequest: &models.UpdateCategoriesRequest{ID: 4, Name: "Fantasy", Slug: "fantasy", ParentID: &fantasy},
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				current(mock)
+				mock.EXPECT().GetByIDForUpdate(anyCtx, &models.Categories{}, 4).
+					RunAndReturn(func(_ context.Context, category *models.Categories, id int) error {
+						*category = models.Categories{ID: id, Path: "/1/4/"}
+						return nil
+					})
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrCategoryCycle),
+		},
+		{
+			name:            "Failed move category below one of its descendants",
+			categoryRequest: &models.UpdateCategoriesRequest{ID: 4, Name: "Fantasy", Slug: "fantasy", ParentID: new(int)},
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				current(mock)
+				mock.EXPECT().GetByIDForUpdate(anyCtx, &models.Categories{}, 0).
+					RunAndReturn(func(_ context.Context, category *models.Categories, id int) error {
+						*category = models.Categories{ID: 9, Path: "/1/4/9/"}
+						return nil
+					})
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrCategoryCycle),
+		},
+		{
+			name:            "Failed update category due to category not found",
+			categoryRequest: &models.UpdateCategoriesRequest{ID: 99, Name: "Fantasy", Slug: "fantasy"},
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				mock.EXPECT().GetByIDForUpdate(anyCtx, &models.Categories{}, 99).Return(models.ErrCategoryNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrCategoryNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseCategoriesRepository(t)
+			tt.mock(mock)
+
+			uc := NewCategoriesUseCase(mock, inlineTx{})
+
+			category, err := uc.UpdateCategory(context.Background(), tt.categoryRequest)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCategory, category)
+			}
+		})
+	}
+}
+
+func TestDeleteCategory(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		mock    func(mock *mocks.MockusecaseCategoriesRepository)
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "Success delete category",
+			id:   4,
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				mock.EXPECT().Delete(anyCtx, 4).Return(nil)
+			},
+		},
+		{
+			name: "Failed delete category with children",
+			id:   1,
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				mock.EXPECT().Delete(anyCtx, 1).Return(models.ErrCategoryHasChildren)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrCategoryHasChildren),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseCategoriesRepository(t)
+			tt.mock(mock)
+
+			uc := NewCategoriesUseCase(mock, inlineTx{})
+
+			err := uc.DeleteCategory(context.Background(), tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSetBookCategories(t *testing.T) {
+	tests := []struct {
+		name               string
+		categoryIDs        []int
+		mock               func(mock *mocks.MockusecaseCategoriesRepository)
+		expectedCategories *[]models.CategoriesSummary
+		wantErr            bool
+		errType            error
+	}{
+		{
+			name:        "Success set categories of a book",
+			categoryIDs: []int{4},
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				mock.EXPECT().ReplaceBookCategories(anyCtx, 1, []int{4}).Return(nil)
+				mock.EXPECT().GetBookCategories(anyCtx, new([]models.Categories), 1).
+					RunAndReturn(func(_ context.Context, categories *[]models.Categories, _ int) error {
+						*categories = []models.Categories{{ID: 4, Name: "Fantasy", Slug: "fantasy"}}
+						return nil
+					})
+			},
+			expectedCategories: &[]models.CategoriesSummary{{ID: 4, Name: "Fantasy", Slug: "fantasy"}},
+		},
+		{
+			name:        "Failed set categories due to unknown category",
+			categoryIDs: []int{99},
+			mock: func(mock *mocks.MockusecaseCategoriesRepository) {
+				mock.EXPECT().ReplaceBookCategories(anyCtx, 1, []int{99}).Return(models.ErrUnknownCategory)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrUnknownCategory),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseCategoriesRepository(t)
+			tt.mock(mock)
+
+			uc := NewCategoriesUseCase(mock, inlineTx{})
+
+			categories, err := uc.SetBookCategories(context.Background(), 1, tt.categoryIDs)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCategories, categories)
+			}
+		})
+	}
+}
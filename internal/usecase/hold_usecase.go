@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/config"
+	"crud-echo/internal/models"
+	"crud-echo/pkg/logger"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type UsecaseHoldsRepository interface {
+	Create(ctx context.Context, hold *models.Holds) error
+	GetByID(ctx context.Context, hold *models.Holds, id int) error
+	GetForUpdate(ctx context.Context, hold *models.Holds, id int) error
+	GetOpenForMember(ctx context.Context, hold *models.Holds, bookID, memberID int) error
+	NextWaiting(ctx context.Context, hold *models.Holds, bookID int) error
+	GetExpired(ctx context.Context, holds *[]models.Holds, bookID int, at time.Time) error
+	Position(ctx context.Context, hold *models.Holds) (int, error)
+	Update(ctx context.Context, hold *models.Holds) error
+}
+
+// HoldsUseCase lines members up for books that are out of copies, first come
+// first served. Copies reach the queue through returns, see LoansUseCase, and
+// stock coming in, see BooksUseCase.MoveStock
+type HoldsUseCase struct {
+	holdRepo  UsecaseHoldsRepository
+	stockRepo UsecaseStockRepository
+	queue     *holdQueue
+	tx        TxManager
+	// when holds are placed and fall expired, swapped in tests
+	now func() time.Time
+}
+
+func NewHoldsUseCase(holdRepo UsecaseHoldsRepository, stockRepo UsecaseStockRepository, tx TxManager, cfg *config.Config) (*HoldsUseCase, error) {
+	queue, err := newHoldQueue(holdRepo, stockRepo, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HoldsUseCase{
+		holdRepo:  holdRepo,
+		stockRepo: stockRepo,
+		queue:     queue,
+		tx:        tx,
+		now:       time.Now,
+	}, nil
+}
+
+// PlaceHold puts the member at the end of the queue of the book. It fails
+// with ErrCopiesAvailable while the book is on the shelf and with
+// ErrHoldAlreadyExists when the member is in line already
+func (uc *HoldsUseCase) PlaceHold(ctx context.Context, holdRequest *models.CreateHoldsRequest) (*models.HoldsSummary, error) {
+	ctx, span := tracer.Start(ctx, "HoldsUseCase.PlaceHold")
+	defer span.End()
+
+	now := uc.now()
+	var hold models.Holds
+	var position int
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// with the book locked no copy can come back between the qty check
+		// and the insert, so nobody waits while a copy sits on the shelf
+		if err := uc.stockRepo.GetByIDForUpdate(ctx, &models.Books{}, holdRequest.BookID); err != nil {
+			return err
+		}
+		// copies nobody picked up may be back on the shelf after this, so
+		// the qty is read once it's done
+		if err := uc.queue.expire(ctx, holdRequest.BookID, now); err != nil {
+			return err
+		}
+		var book models.Books
+		if err := uc.stockRepo.GetByIDForUpdate(ctx, &book, holdRequest.BookID); err != nil {
+			return err
+		}
+		if book.Qty > 0 {
+			return models.ErrCopiesAvailable
+		}
+
+		hold = models.Holds{
+			BookID:    holdRequest.BookID,
+			MemberID:  holdRequest.MemberID,
+			Status:    models.HoldStatusWaiting,
+			CreatedAt: now,
+		}
+		if err := uc.holdRepo.Create(ctx, &hold); err != nil {
+			return err
+		}
+
+		var err error
+		position, err = uc.holdRepo.Position(ctx, &hold)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	logger.FromContext(ctx).Info("hold placed",
+		zap.Int("hold_id", hold.ID),
+		zap.Int("book_id", hold.BookID),
+		zap.Int("member_id", hold.MemberID),
+		zap.Int("position", position),
+	)
+
+	return hold.ToHoldsSummary(position, now), nil
+}
+
+// GetHoldByID includes the position in the queue while the hold waits
+func (uc *HoldsUseCase) GetHoldByID(ctx context.Context, id int) (*models.HoldsSummary, error) {
+	ctx, span := tracer.Start(ctx, "HoldsUseCase.GetHoldByID")
+	defer span.End()
+
+	var hold models.Holds
+	if err := uc.holdRepo.GetByID(ctx, &hold, id); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	var position int
+	if hold.Status == models.HoldStatusWaiting {
+		var err error
+		if position, err = uc.holdRepo.Position(ctx, &hold); err != nil {
+			return nil, fmt.Errorf("repository error: %w", err)
+		}
+	}
+
+	return hold.ToHoldsSummary(position, uc.now()), nil
+}
+
+// CancelHold takes the member out of the queue, the copy of a ready hold goes
+// on to the next in line
+func (uc *HoldsUseCase) CancelHold(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "HoldsUseCase.CancelHold")
+	defer span.End()
+
+	now := uc.now()
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// the book is locked before the hold, the order checkouts and
+		// returns take them in
+		var hold models.Holds
+		if err := uc.holdRepo.GetByID(ctx, &hold, id); err != nil {
+			return err
+		}
+		if err := uc.stockRepo.GetByIDForUpdate(ctx, &models.Books{}, hold.BookID); err != nil {
+			return err
+		}
+		hold = models.Holds{}
+		if err := uc.holdRepo.GetForUpdate(ctx, &hold, id); err != nil {
+			return err
+		}
+		if !hold.Open() {
+			return models.ErrHoldClosed
+		}
+
+		hadCopy := hold.Status == models.HoldStatusReady
+		hold.Status = models.HoldStatusCancelled
+		if err := uc.holdRepo.Update(ctx, &hold); err != nil {
+			return err
+		}
+		if !hadCopy {
+			return nil
+		}
+		return uc.queue.passCopy(ctx, hold.BookID, now, hold.StockReason())
+	})
+	if err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+
+	return nil
+}
+
+// holdQueue moves the queue of a book along, shared by the books, the loans
+// and the holds usecases. Its methods expect to run inside a transaction,
+// with the book locked
+type holdQueue struct {
+	holdRepo  UsecaseHoldsRepository
+	stockRepo UsecaseStockRepository
+	// how long a copy stays set aside for a ready hold
+	window time.Duration
+}
+
+func newHoldQueue(holdRepo UsecaseHoldsRepository, stockRepo UsecaseStockRepository, cfg *config.Config) (*holdQueue, error) {
+	if cfg.Lending == nil || cfg.Lending.HoldPickupWindow <= 0 {
+		return nil, errors.New("lending.holdPickupWindow has to be positive")
+	}
+
+	return &holdQueue{holdRepo: holdRepo, stockRepo: stockRepo, window: cfg.Lending.HoldPickupWindow}, nil
+}
+
+// passCopy sets a copy coming back aside for the oldest waiting hold on the
+// book, or puts it on the shelf when nobody waits. reason goes to the ledger
+// in the latter case
+func (q *holdQueue) passCopy(ctx context.Context, bookID int, now time.Time, reason string) error {
+	var hold models.Holds
+	err := q.holdRepo.NextWaiting(ctx, &hold, bookID)
+	if errors.Is(err, models.ErrHoldNotFound) {
+		return moveCopy(ctx, q.stockRepo, bookID, models.StockChange{Kind: models.StockReturn, Quantity: 1, Reason: reason})
+	} else if err != nil {
+		return err
+	}
+
+	return q.ready(ctx, &hold, now)
+}
+
+// serveShelf sets copies on the shelf of book aside for the holds waiting
+// for it, oldest first, until either runs out. Each leaves the shelf as a
+// lend, the checkout of the hold takes no copy again. book is read back
+// after every one
+func (q *holdQueue) serveShelf(ctx context.Context, book *models.Books, now time.Time) error {
+	for book.Qty > 0 {
+		var hold models.Holds
+		err := q.holdRepo.NextWaiting(ctx, &hold, book.ID)
+		if errors.Is(err, models.ErrHoldNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if err := q.ready(ctx, &hold, now); err != nil {
+			return err
+		}
+		change := models.StockChange{Kind: models.StockLend, Quantity: -1, Reason: hold.StockReason()}
+		if err := q.stockRepo.AddQty(ctx, book.ID, change.Quantity, book); err != nil {
+			return err
+		}
+		if err := q.stockRepo.CreateMovements(ctx, []models.StockMovement{newMovement(ctx, book.ID, change, book.Qty)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ready gives hold the copy set aside for it, for the pickup window from now
+func (q *holdQueue) ready(ctx context.Context, hold *models.Holds, now time.Time) error {
+	expiresAt := now.Add(q.window)
+	hold.Status = models.HoldStatusReady
+	hold.ReadyAt = &now
+	hold.ExpiresAt = &expiresAt
+	if err := q.holdRepo.Update(ctx, hold); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("hold ready",
+		zap.Int("hold_id", hold.ID),
+		zap.Int("book_id", hold.BookID),
+		zap.Int("member_id", hold.MemberID),
+		zap.Time("expires_at", expiresAt),
+	)
+
+	return nil
+}
+
+// expire closes the ready holds on the book nobody picked up in time and
+// passes their copies on. There is no sweeper, the queue of a book catches
+// up whenever it is touched
+func (q *holdQueue) expire(ctx context.Context, bookID int, now time.Time) error {
+	var holds []models.Holds
+	if err := q.holdRepo.GetExpired(ctx, &holds, bookID, now); err != nil {
+		return err
+	}
+
+	for _, hold := range holds {
+		hold.Status = models.HoldStatusExpired
+		if err := q.holdRepo.Update(ctx, &hold); err != nil {
+			return err
+		}
+		logger.FromContext(ctx).Info("hold expired", zap.Int("hold_id", hold.ID), zap.Int("book_id", hold.BookID))
+
+		if err := q.passCopy(ctx, bookID, now, hold.StockReason()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fulfil closes the open hold of the member on the book as they check it out,
+// setAside tells whether its copy is off the shelf already
+func (q *holdQueue) fulfil(ctx context.Context, bookID, memberID int) (setAside bool, err error) {
+	var hold models.Holds
+	err = q.holdRepo.GetOpenForMember(ctx, &hold, bookID, memberID)
+	if errors.Is(err, models.ErrHoldNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	setAside = hold.Status == models.HoldStatusReady
+	hold.Status = models.HoldStatusFulfilled
+	return setAside, q.holdRepo.Update(ctx, &hold)
+}
@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHoldsUseCase(t *testing.T, holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository, now time.Time) *HoldsUseCase {
+	uc, err := NewHoldsUseCase(holdRepo, stockRepo, inlineTx{}, lendingConfig)
+	require.NoError(t, err)
+	uc.now = func() time.Time { return now }
+	return uc
+}
+
+func TestPlaceHold(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	request := &models.CreateHoldsRequest{BookID: 1, MemberID: 7}
+	newHold := &models.Holds{BookID: 1, MemberID: 7, Status: models.HoldStatusWaiting, CreatedAt: now}
+
+	tests := []struct {
+		name         string
+		mock         func(holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository)
+		expectedHold *models.HoldsSummary
+		wantErr      bool
+		errType      error
+	}{
+		{
+			name: "Success place hold",
+			mock: func(holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				lockedBook(stockRepo)
+				noExpiredHolds(holdRepo, now)
+				stockRepo.EXPECT().GetByIDForUpdate(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(0)).Once()
+				holdRepo.EXPECT().Create(anyCtx, newHold).
+					RunAndReturn(func(_ context.Context, hold *models.Holds) error {
+						hold.ID = 3
+						return nil
+					})
+				holdRepo.EXPECT().Position(anyCtx, &models.Holds{ID: 3, BookID: 1, MemberID: 7, Status: models.HoldStatusWaiting, CreatedAt: now}).
+					Return(2, nil)
+			},
+			expectedHold: &models.HoldsSummary{
+				ID:        3,
+				BookID:    1,
+				MemberID:  7,
+				Status:    models.HoldStatusWaiting,
+				Position:  2,
+				CreatedAt: now,
+			},
+		},
+		{
+			name: "Failed place hold on book with copies",
+			mock: func(holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				lockedBook(stockRepo)
+				noExpiredHolds(holdRepo, now)
+				stockRepo.EXPECT().GetByIDForUpdate(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(2)).Once()
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrCopiesAvailable),
+		},
+		{
+			name: "Failed place second hold on same book",
+			mock: func(holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				lockedBook(stockRepo)
+				noExpiredHolds(holdRepo, now)
+				stockRepo.EXPECT().GetByIDForUpdate(anyCtx, &models.Books{}, 1).RunAndReturn(withQty(0)).Once()
+				holdRepo.EXPECT().Create(anyCtx, newHold).Return(models.ErrHoldAlreadyExists)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrHoldAlreadyExists),
+		},
+		{
+			name: "Failed place hold on unknown book",
+			mock: func(holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				stockRepo.EXPECT().GetByIDForUpdate(anyCtx, &models.Books{}, 1).Return(models.ErrBookNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrBookNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holdRepo := mocks.NewMockusecaseHoldsRepository(t)
+			stockRepo := mocks.NewMockusecaseStockRepository(t)
+			tt.mock(holdRepo, stockRepo)
+
+			uc := newTestHoldsUseCase(t, holdRepo, stockRepo, now)
+
+			hold, err := uc.PlaceHold(context.Background(), request)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedHold, hold)
+			}
+		})
+	}
+}
+
+func TestGetHoldByID(t *testing.T) {
+	createdAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	readyAt := createdAt.Add(24 * time.Hour)
+	expiresAt := readyAt.Add(holdPickupWindow)
+	now := readyAt.Add(time.Hour)
+
+	tests := []struct {
+		name         string
+		now          time.Time
+		mock         func(holdRepo *mocks.MockusecaseHoldsRepository)
+		expectedHold *models.HoldsSummary
+		wantErr      bool
+		errType      error
+	}{
+		{
+			name: "Success get waiting hold with position",
+			now:  now,
+			mock: func(holdRepo *mocks.MockusecaseHoldsRepository) {
+				waiting := models.Holds{ID: 3, BookID: 1, MemberID: 7, Status: models.HoldStatusWaiting, CreatedAt: createdAt}
+				holdRepo.EXPECT().GetByID(anyCtx, &models.Holds{}, 3).RunAndReturn(withHold(waiting))
+				holdRepo.EXPECT().Position(anyCtx, &waiting).Return(4, nil)
+			},
+			expectedHold: &models.HoldsSummary{
+				ID:        3,
+				BookID:    1,
+				MemberID:  7,
+				Status:    models.HoldStatusWaiting,
+				Position:  4,
+				CreatedAt: createdAt,
+			},
+		},
+		{
+			name: "Success get ready hold",
+			now:  now,
+			mock: func(holdRepo *mocks.MockusecaseHoldsRepository) {
+				holdRepo.EXPECT().GetByID(anyCtx, &models.Holds{}, 3).RunAndReturn(withHold(models.Holds{
+					ID: 3, BookID: 1, MemberID: 7, Status: models.HoldStatusReady, CreatedAt: createdAt, ReadyAt: &readyAt, ExpiresAt: &expiresAt,
+				}))
+			},
+			expectedHold: &models.HoldsSummary{
+				ID:        3,
+				BookID:    1,
+				MemberID:  7,
+				Status:    models.HoldStatusReady,
+				CreatedAt: createdAt,
+				ReadyAt:   &readyAt,
+				ExpiresAt: &expiresAt,
+			},
+		},
+		{
+			name: "Success get ready hold past its pickup window",
+			now:  expiresAt.Add(time.Minute),
+			mock: func(holdRepo *mocks.MockusecaseHoldsRepository) {
+				holdRepo.EXPECT().GetByID(anyCtx, &models.Holds{}, 3).RunAndReturn(withHold(models.Holds{
+					ID: 3, BookID: 1, MemberID: 7, Status: models.HoldStatusReady, CreatedAt: createdAt, ReadyAt: &readyAt, ExpiresAt: &expiresAt,
+				}))
+			},
+			expectedHold: &models.HoldsSummary{
+				ID:        3,
+				BookID:    1,
+				MemberID:  7,
+				Status:    models.HoldStatusExpired,
+				CreatedAt: createdAt,
+				ReadyAt:   &readyAt,
+				ExpiresAt: &expiresAt,
+			},
+		},
+		{
+			name: "Failed get unknown hold",
+			now:  now,
+			mock: func(holdRepo *mocks.MockusecaseHoldsRepository) {
+				holdRepo.EXPECT().GetByID(anyCtx, &models.Holds{}, 3).Return(models.ErrHoldNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrHoldNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holdRepo := mocks.NewMockusecaseHoldsRepository(t)
+			tt.mock(holdRepo)
+
+			uc := newTestHoldsUseCase(t, holdRepo, mocks.NewMockusecaseStockRepository(t), tt.now)
+
+			hold, err := uc.GetHoldByID(context.Background(), 3)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedHold, hold)
+			}
+		})
+	}
+}
+
+func TestCancelHold(t *testing.T) {
+	now := time.Date(2026, 10, 5, 12, 0, 0, 0, time.UTC)
+	readyAt := now.Add(-24 * time.Hour)
+	expiresAt := readyAt.Add(holdPickupWindow)
+	nextExpiresAt := now.Add(holdPickupWindow)
+
+	tests := []struct {
+		name    string
+		mock    func(holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository)
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "Success cancel waiting hold",
+			mock: func(holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				waiting := models.Holds{ID: 3, BookID: 1, MemberID: 7, Status: models.HoldStatusWaiting}
+				holdRepo.EXPECT().GetByID(anyCtx, &models.Holds{}, 3).RunAndReturn(withHold(waiting))
+				lockedBook(stockRepo)
+				holdRepo.EXPECT().GetForUpdate(anyCtx, &models.Holds{}, 3).RunAndReturn(withHold(waiting))
+				waiting.Status = models.HoldStatusCancelled
+				holdRepo.EXPECT().Update(anyCtx, &waiting).Return(nil)
+			},
+		},
+		{
+			name: "Success cancel ready hold passes copy to next in line",
+			mock: func(holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				ready := models.Holds{ID: 3, BookID: 1, MemberID: 7, Status: models.HoldStatusReady, ReadyAt: &readyAt, ExpiresAt: &expiresAt}
+				holdRepo.EXPECT().GetByID(anyCtx, &models.Holds{}, 3).RunAndReturn(withHold(ready))
+				lockedBook(stockRepo)
+				holdRepo.EXPECT().GetForUpdate(anyCtx, &models.Holds{}, 3).RunAndReturn(withHold(ready))
+				ready.Status = models.HoldStatusCancelled
+				holdRepo.EXPECT().Update(anyCtx, &ready).Return(nil)
+				holdRepo.EXPECT().NextWaiting(anyCtx, &models.Holds{}, 1).
+					RunAndReturn(withHold(models.Holds{ID: 4, BookID: 1, MemberID: 8, Status: models.HoldStatusWaiting}))
+				holdRepo.EXPECT().Update(anyCtx, &models.Holds{
+					ID:        4,
+					BookID:    1,
+					MemberID:  8,
+					Status:    models.HoldStatusReady,
+					ReadyAt:   &now,
+					ExpiresAt: &nextExpiresAt,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "Success cancel ready hold puts copy back on shelf",
+			mock: func(holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				ready := models.Holds{ID: 3, BookID: 1, MemberID: 7, Status: models.HoldStatusReady, ReadyAt: &readyAt, ExpiresAt: &expiresAt}
+				holdRepo.EXPECT().GetByID(anyCtx, &models.Holds{}, 3).RunAndReturn(withHold(ready))
+				lockedBook(stockRepo)
+				holdRepo.EXPECT().GetForUpdate(anyCtx, &models.Holds{}, 3).RunAndReturn(withHold(ready))
+				ready.Status = models.HoldStatusCancelled
+				holdRepo.EXPECT().Update(anyCtx, &ready).Return(nil)
+				holdRepo.EXPECT().NextWaiting(anyCtx, &models.Holds{}, 1).Return(models.ErrHoldNotFound)
+				stockRepo.EXPECT().AddQty(anyCtx, 1, 1, &models.Books{}).RunAndReturn(withBalance(1))
+				stockRepo.EXPECT().CreateMovements(anyCtx, movedCopy(models.StockReturn, 1, 1, "hold 3")).Return(nil)
+			},
+		},
+		{
+			name: "Failed cancel fulfilled hold",
+			mock: func(holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				fulfilled := models.Holds{ID: 3, BookID: 1, MemberID: 7, Status: models.HoldStatusFulfilled}
+				holdRepo.EXPECT().GetByID(anyCtx, &models.Holds{}, 3).RunAndReturn(withHold(fulfilled))
+				lockedBook(stockRepo)
+				holdRepo.EXPECT().GetForUpdate(anyCtx, &models.Holds{}, 3).RunAndReturn(withHold(fulfilled))
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrHoldClosed),
+		},
+		{
+			name: "Failed cancel unknown hold",
+			mock: func(holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				holdRepo.EXPECT().GetByID(anyCtx, &models.Holds{}, 3).Return(models.ErrHoldNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrHoldNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holdRepo := mocks.NewMockusecaseHoldsRepository(t)
+			stockRepo := mocks.NewMockusecaseStockRepository(t)
+			tt.mock(holdRepo, stockRepo)
+
+			uc := newTestHoldsUseCase(t, holdRepo, stockRepo, now)
+
+			err := uc.CancelHold(context.Background(), 3)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/config"
+	"crud-echo/internal/models"
+	"crud-echo/pkg/logger"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type UsecaseLoansRepository interface {
+	Create(ctx context.Context, loan *models.Loans) error
+	GetByID(ctx context.Context, loan *models.Loans, id int) error
+	GetForUpdate(ctx context.Context, loan *models.Loans, id int) error
+	Update(ctx context.Context, loan *models.Loans) error
+}
+
+// UsecaseStockRepository moves the qty of a book along with its ledger, the
+// books repository does both
+type UsecaseStockRepository interface {
+	GetByIDForUpdate(ctx context.Context, book *models.Books, id int) error
+	AddQty(ctx context.Context, id int, delta int, book *models.Books) error
+	CreateMovements(ctx context.Context, movements []models.StockMovement) error
+}
+
+// LoansUseCase lends copies of books to members. A loan takes its copy off
+// books.qty, so qty is what is left on the shelf. Returned copies go to the
+// hold queue of the book before the shelf
+type LoansUseCase struct {
+	loanRepo  UsecaseLoansRepository
+	stockRepo UsecaseStockRepository
+	queue     *holdQueue
+	tx        TxManager
+	policy    models.LoanPolicy
+	// when loans start, end and fall overdue, swapped in tests
+	now func() time.Time
+}
+
+func NewLoansUseCase(loanRepo UsecaseLoansRepository, holdRepo UsecaseHoldsRepository, stockRepo UsecaseStockRepository, tx TxManager, cfg *config.Config) (*LoansUseCase, error) {
+	if cfg.Lending == nil || cfg.Lending.LoanPeriod <= 0 {
+		return nil, errors.New("lending.loanPeriod has to be positive")
+	}
+	if cfg.Lending.MaxRenewals < 0 {
+		return nil, errors.New("lending.maxRenewals can't be negative")
+	}
+	queue, err := newHoldQueue(holdRepo, stockRepo, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoansUseCase{
+		loanRepo:  loanRepo,
+		stockRepo: stockRepo,
+		queue:     queue,
+		tx:        tx,
+		policy: models.LoanPolicy{
+			Period:      cfg.Lending.LoanPeriod,
+			MaxRenewals: cfg.Lending.MaxRenewals,
+		},
+		now: time.Now,
+	}, nil
+}
+
+// CheckoutBook lends a copy of the book to the member, due one loan period
+// from now. It fails with ErrNoCopiesAvailable once every copy is out or
+// set aside for holds, unless one is set aside for this member
+func (uc *LoansUseCase) CheckoutBook(ctx context.Context, loanRequest *models.CreateLoansRequest) (*models.LoansSummary, error) {
+	ctx, span := tracer.Start(ctx, "LoansUseCase.CheckoutBook")
+	defer span.End()
+
+	now := uc.now()
+	var loan models.Loans
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		loan = models.Loans{
+			BookID:   loanRequest.BookID,
+			MemberID: loanRequest.MemberID,
+			LoanedAt: now,
+			DueAt:    now.Add(uc.policy.Period),
+		}
+		// the book is locked first, so checkouts, returns and holds of it
+		// run one at a time
+		if err := uc.stockRepo.GetByIDForUpdate(ctx, &models.Books{}, loan.BookID); err != nil {
+			return err
+		}
+		if err := uc.queue.expire(ctx, loan.BookID, now); err != nil {
+			return err
+		}
+		// the insert finds out whether the member exists
+		if err := uc.loanRepo.Create(ctx, &loan); err != nil {
+			return err
+		}
+		setAside, err := uc.queue.fulfil(ctx, loan.BookID, loan.MemberID)
+		if err != nil || setAside {
+			return err
+		}
+		return moveCopy(ctx, uc.stockRepo, loan.BookID, models.StockChange{Kind: models.StockLend, Quantity: -1, Reason: loan.StockReason()})
+	})
+	if errors.Is(err, models.ErrInsufficientStock) {
+		err = models.ErrNoCopiesAvailable.Wrap(err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	logger.FromContext(ctx).Info("book checked out",
+		zap.Int("loan_id", loan.ID),
+		zap.Int("book_id", loan.BookID),
+		zap.Int("member_id", loan.MemberID),
+	)
+
+	return loan.ToLoansSummary(now), nil
+}
+
+func (uc *LoansUseCase) GetLoanByID(ctx context.Context, id int) (*models.LoansSummary, error) {
+	ctx, span := tracer.Start(ctx, "LoansUseCase.GetLoanByID")
+	defer span.End()
+
+	var loan models.Loans
+	if err := uc.loanRepo.GetByID(ctx, &loan, id); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return loan.ToLoansSummary(uc.now()), nil
+}
+
+// ReturnLoan hands the copy to the next hold in line, or puts it back on the
+// shelf when nobody waits. Late returns are taken too
+func (uc *LoansUseCase) ReturnLoan(ctx context.Context, id int) (*models.LoansSummary, error) {
+	ctx, span := tracer.Start(ctx, "LoansUseCase.ReturnLoan")
+	defer span.End()
+
+	now := uc.now()
+	var loan models.Loans
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		loan = models.Loans{}
+		if err := uc.loanRepo.GetForUpdate(ctx, &loan, id); err != nil {
+			return err
+		}
+		if loan.ReturnedAt != nil {
+			return models.ErrLoanAlreadyReturned
+		}
+		if err := uc.stockRepo.GetByIDForUpdate(ctx, &models.Books{}, loan.BookID); err != nil {
+			return err
+		}
+
+		loan.ReturnedAt = &now
+		if err := uc.loanRepo.Update(ctx, &loan); err != nil {
+			return err
+		}
+		if err := uc.queue.expire(ctx, loan.BookID, now); err != nil {
+			return err
+		}
+		return uc.queue.passCopy(ctx, loan.BookID, now, loan.StockReason())
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	logger.FromContext(ctx).Info("book returned",
+		zap.Int("loan_id", loan.ID),
+		zap.Int("book_id", loan.BookID),
+		zap.Bool("late", now.After(loan.DueAt)),
+	)
+
+	return loan.ToLoansSummary(now), nil
+}
+
+// RenewLoan pushes the due date back by another loan period, as long as the
+// loan is neither overdue nor out of renewals
+func (uc *LoansUseCase) RenewLoan(ctx context.Context, id int) (*models.LoansSummary, error) {
+	ctx, span := tracer.Start(ctx, "LoansUseCase.RenewLoan")
+	defer span.End()
+
+	now := uc.now()
+	var loan models.Loans
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		loan = models.Loans{}
+		if err := uc.loanRepo.GetForUpdate(ctx, &loan, id); err != nil {
+			return err
+		}
+		switch {
+		case loan.ReturnedAt != nil:
+			return models.ErrLoanAlreadyReturned
+		case loan.Status(now) == models.LoanStatusOverdue:
+			return models.ErrLoanOverdue
+		case loan.Renewals >= uc.policy.MaxRenewals:
+			return models.ErrLoanRenewalLimit
+		}
+
+		loan.DueAt = loan.DueAt.Add(uc.policy.Period)
+		loan.Renewals++
+		return uc.loanRepo.Update(ctx, &loan)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return loan.ToLoansSummary(now), nil
+}
+
+// moveCopy takes a copy off the shelf or puts it back, recording it in the
+// ledger of the book
+func moveCopy(ctx context.Context, stockRepo UsecaseStockRepository, bookID int, change models.StockChange) error {
+	var book models.Books
+	if err := stockRepo.AddQty(ctx, bookID, change.Quantity, &book); err != nil {
+		return err
+	}
+
+	return stockRepo.CreateMovements(ctx, []models.StockMovement{newMovement(ctx, bookID, change, book.Qty)})
+}
@@ -0,0 +1,459 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/config"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	loanPeriod       = 14 * 24 * time.Hour
+	holdPickupWindow = 3 * 24 * time.Hour
+)
+
+var lendingConfig = &config.Config{Lending: &config.Lending{
+	LoanPeriod:       loanPeriod,
+	MaxRenewals:      2,
+	HoldPickupWindow: holdPickupWindow,
+}}
+
+// movedCopy is the ledger row of a copy of book 1 going on or off the shelf
+func movedCopy(kind string, delta, balance int, reason string) []models.StockMovement {
+	return []models.StockMovement{{
+		BookID:   1,
+		Kind:     kind,
+		Quantity: delta,
+		Balance:  balance,
+		Reason:   reason,
+		Actor:    models.DefaultActor,
+	}}
+}
+
+// withBalance stands in for AddQty leaving qty copies of the book
+func withBalance(qty int) func(context.Context, int, int, *models.Books) error {
+	return func(_ context.Context, id int, _ int, book *models.Books) error {
+		*book = models.Books{ID: id, Qty: qty}
+		return nil
+	}
+}
+
+// withLoan stands in for GetForUpdate finding loan
+func withLoan(loan models.Loans) func(context.Context, *models.Loans, int) error {
+	return func(_ context.Context, l *models.Loans, _ int) error {
+		*l = loan
+		return nil
+	}
+}
+
+// withHold stands in for GetForUpdate or NextWaiting finding hold
+func withHold(hold models.Holds) func(context.Context, *models.Holds, int) error {
+	return func(_ context.Context, h *models.Holds, _ int) error {
+		*h = hold
+		return nil
+	}
+}
+
+// withOpenHold stands in for GetOpenForMember finding hold
+func withOpenHold(hold models.Holds) func(context.Context, *models.Holds, int, int) error {
+	return func(_ context.Context, h *models.Holds, _, _ int) error {
+		*h = hold
+		return nil
+	}
+}
+
+// noExpiredHolds expects the queue of book 1 to be checked for holds nobody
+// picked up
+func noExpiredHolds(holdRepo *mocks.MockusecaseHoldsRepository, now time.Time) {
+	holdRepo.EXPECT().GetExpired(anyCtx, new([]models.Holds), 1, now).Return(nil)
+}
+
+// lockedBook expects book 1 to be locked before its qty or its queue moves
+func lockedBook(stockRepo *mocks.MockusecaseStockRepository) {
+	stockRepo.EXPECT().GetByIDForUpdate(anyCtx, &models.Books{}, 1).Return(nil).Once()
+}
+
+func newTestLoansUseCase(t *testing.T, loanRepo *mocks.MockusecaseLoansRepository, holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository, now time.Time) *LoansUseCase {
+	uc, err := NewLoansUseCase(loanRepo, holdRepo, stockRepo, inlineTx{}, lendingConfig)
+	require.NoError(t, err)
+	uc.now = func() time.Time { return now }
+	return uc
+}
+
+func TestNewLoansUseCase(t *testing.T) {
+	tests := []struct {
+		name    string
+		lending *config.Lending
+		wantErr bool
+	}{
+		{name: "Valid lending policy", lending: &config.Lending{LoanPeriod: loanPeriod, HoldPickupWindow: holdPickupWindow}},
+		{name: "Missing lending policy", wantErr: true},
+		{name: "Zero loan period", lending: &config.Lending{MaxRenewals: 2, HoldPickupWindow: holdPickupWindow}, wantErr: true},
+		{name: "Negative max renewals", lending: &config.Lending{LoanPeriod: loanPeriod, MaxRenewals: -1, HoldPickupWindow: holdPickupWindow}, wantErr: true},
+		{name: "Zero hold pickup window", lending: &config.Lending{LoanPeriod: loanPeriod, MaxRenewals: 2}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLoansUseCase(nil, nil, nil, inlineTx{}, &config.Config{Lending: tt.lending})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckoutBook(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	newLoan := &models.Loans{BookID: 1, MemberID: 7, LoanedAt: now, DueAt: now.Add(loanPeriod)}
+	expiresAt := now.Add(time.Hour)
+	readyHold := models.Holds{ID: 3, BookID: 1, MemberID: 7, Status: models.HoldStatusReady, ReadyAt: &now, ExpiresAt: &expiresAt}
+	waitingHold := models.Holds{ID: 3, BookID: 1, MemberID: 7, Status: models.HoldStatusWaiting}
+	createLoan := func(loanRepo *mocks.MockusecaseLoansRepository) {
+		loanRepo.EXPECT().Create(anyCtx, newLoan).
+			RunAndReturn(func(_ context.Context, loan *models.Loans) error {
+				loan.ID = 1
+				return nil
+			})
+	}
+	expectedLoan := &models.LoansSummary{
+		ID:       1,
+		BookID:   1,
+		MemberID: 7,
+		Status:   models.LoanStatusActive,
+		LoanedAt: now,
+		DueAt:    now.Add(loanPeriod),
+	}
+
+	tests := []struct {
+		name         string
+		request      *models.CreateLoansRequest
+		mock         func(loanRepo *mocks.MockusecaseLoansRepository, holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository)
+		expectedLoan *models.LoansSummary
+		wantErr      bool
+		errType      error
+	}{
+		{
+			name:    "Success checkout book",
+			request: &models.CreateLoansRequest{BookID: 1, MemberID: 7},
+			mock: func(loanRepo *mocks.MockusecaseLoansRepository, holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				lockedBook(stockRepo)
+				noExpiredHolds(holdRepo, now)
+				createLoan(loanRepo)
+				holdRepo.EXPECT().GetOpenForMember(anyCtx, &models.Holds{}, 1, 7).Return(models.ErrHoldNotFound)
+				stockRepo.EXPECT().AddQty(anyCtx, 1, -1, &models.Books{}).RunAndReturn(withBalance(2))
+				stockRepo.EXPECT().CreateMovements(anyCtx, movedCopy(models.StockLend, -1, 2, "loan 1")).Return(nil)
+			},
+			expectedLoan: expectedLoan,
+		},
+		{
+			name:    "Success checkout copy set aside for ready hold",
+			request: &models.CreateLoansRequest{BookID: 1, MemberID: 7},
+			mock: func(loanRepo *mocks.MockusecaseLoansRepository, holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				lockedBook(stockRepo)
+				noExpiredHolds(holdRepo, now)
+				createLoan(loanRepo)
+				holdRepo.EXPECT().GetOpenForMember(anyCtx, &models.Holds{}, 1, 7).RunAndReturn(withOpenHold(readyHold))
+				fulfilled := readyHold
+				fulfilled.Status = models.HoldStatusFulfilled
+				holdRepo.EXPECT().Update(anyCtx, &fulfilled).Return(nil)
+			},
+			expectedLoan: expectedLoan,
+		},
+		{
+			name:    "Success checkout from shelf closes waiting hold",
+			request: &models.CreateLoansRequest{BookID: 1, MemberID: 7},
+			mock: func(loanRepo *mocks.MockusecaseLoansRepository, holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				lockedBook(stockRepo)
+				noExpiredHolds(holdRepo, now)
+				createLoan(loanRepo)
+				holdRepo.EXPECT().GetOpenForMember(anyCtx, &models.Holds{}, 1, 7).RunAndReturn(withOpenHold(waitingHold))
+				fulfilled := waitingHold
+				fulfilled.Status = models.HoldStatusFulfilled
+				holdRepo.EXPECT().Update(anyCtx, &fulfilled).Return(nil)
+				stockRepo.EXPECT().AddQty(anyCtx, 1, -1, &models.Books{}).RunAndReturn(withBalance(0))
+				stockRepo.EXPECT().CreateMovements(anyCtx, movedCopy(models.StockLend, -1, 0, "loan 1")).Return(nil)
+			},
+			expectedLoan: expectedLoan,
+		},
+		{
+			name:    "Failed checkout book without copies left",
+			request: &models.CreateLoansRequest{BookID: 1, MemberID: 7},
+			mock: func(loanRepo *mocks.MockusecaseLoansRepository, holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				lockedBook(stockRepo)
+				noExpiredHolds(holdRepo, now)
+				loanRepo.EXPECT().Create(anyCtx, newLoan).Return(nil)
+				holdRepo.EXPECT().GetOpenForMember(anyCtx, &models.Holds{}, 1, 7).Return(models.ErrHoldNotFound)
+				stockRepo.EXPECT().AddQty(anyCtx, 1, -1, &models.Books{}).Return(models.ErrInsufficientStock)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrNoCopiesAvailable.Wrap(models.ErrInsufficientStock)),
+		},
+		{
+			name:    "Failed checkout unknown book",
+			request: &models.CreateLoansRequest{BookID: 1, MemberID: 7},
+			mock: func(loanRepo *mocks.MockusecaseLoansRepository, holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				stockRepo.EXPECT().GetByIDForUpdate(anyCtx, &models.Books{}, 1).Return(models.ErrBookNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrBookNotFound),
+		},
+		{
+			name:    "Failed checkout book for unknown member",
+			request: &models.CreateLoansRequest{BookID: 1, MemberID: 7},
+			mock: func(loanRepo *mocks.MockusecaseLoansRepository, holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				lockedBook(stockRepo)
+				noExpiredHolds(holdRepo, now)
+				loanRepo.EXPECT().Create(anyCtx, newLoan).Return(models.ErrMemberNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrMemberNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loanRepo := mocks.NewMockusecaseLoansRepository(t)
+			holdRepo := mocks.NewMockusecaseHoldsRepository(t)
+			stockRepo := mocks.NewMockusecaseStockRepository(t)
+			tt.mock(loanRepo, holdRepo, stockRepo)
+
+			uc := newTestLoansUseCase(t, loanRepo, holdRepo, stockRepo, now)
+
+			loan, err := uc.CheckoutBook(context.Background(), tt.request)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedLoan, loan)
+			}
+		})
+	}
+}
+
+func TestReturnLoan(t *testing.T) {
+	loanedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	now := loanedAt.Add(20 * 24 * time.Hour)
+	earlier := loanedAt.Add(24 * time.Hour)
+	out := models.Loans{ID: 1, BookID: 1, MemberID: 7, LoanedAt: loanedAt, DueAt: loanedAt.Add(loanPeriod)}
+	returned := out
+	returned.ReturnedAt = &earlier
+	returning := out
+	returning.ReturnedAt = &now
+	expiresAt := now.Add(holdPickupWindow)
+	stale := now.Add(-time.Hour)
+	expectedLoan := &models.LoansSummary{
+		ID:         1,
+		BookID:     1,
+		MemberID:   7,
+		Status:     models.LoanStatusReturned,
+		LoanedAt:   loanedAt,
+		DueAt:      loanedAt.Add(loanPeriod),
+		ReturnedAt: &now,
+	}
+
+	tests := []struct {
+		name         string
+		mock         func(loanRepo *mocks.MockusecaseLoansRepository, holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository)
+		expectedLoan *models.LoansSummary
+		wantErr      bool
+		errType      error
+	}{
+		{
+			name: "Success return overdue loan to shelf",
+			mock: func(loanRepo *mocks.MockusecaseLoansRepository, holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				loanRepo.EXPECT().GetForUpdate(anyCtx, &models.Loans{}, 1).RunAndReturn(withLoan(out))
+				lockedBook(stockRepo)
+				loanRepo.EXPECT().Update(anyCtx, &returning).Return(nil)
+				noExpiredHolds(holdRepo, now)
+				holdRepo.EXPECT().NextWaiting(anyCtx, &models.Holds{}, 1).Return(models.ErrHoldNotFound)
+				stockRepo.EXPECT().AddQty(anyCtx, 1, 1, &models.Books{}).RunAndReturn(withBalance(3))
+				stockRepo.EXPECT().CreateMovements(anyCtx, movedCopy(models.StockReturn, 1, 3, "loan 1")).Return(nil)
+			},
+			expectedLoan: expectedLoan,
+		},
+		{
+			name: "Success return loan to next hold in line",
+			mock: func(loanRepo *mocks.MockusecaseLoansRepository, holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				loanRepo.EXPECT().GetForUpdate(anyCtx, &models.Loans{}, 1).RunAndReturn(withLoan(out))
+				lockedBook(stockRepo)
+				loanRepo.EXPECT().Update(anyCtx, &returning).Return(nil)
+				noExpiredHolds(holdRepo, now)
+				holdRepo.EXPECT().NextWaiting(anyCtx, &models.Holds{}, 1).
+					RunAndReturn(withHold(models.Holds{ID: 5, BookID: 1, MemberID: 8, Status: models.HoldStatusWaiting}))
+				holdRepo.EXPECT().Update(anyCtx, &models.Holds{
+					ID:        5,
+					BookID:    1,
+					MemberID:  8,
+					Status:    models.HoldStatusReady,
+					ReadyAt:   &now,
+					ExpiresAt: &expiresAt,
+				}).Return(nil)
+			},
+			expectedLoan: expectedLoan,
+		},
+		{
+			name: "Success return loan after expiring hold nobody picked up",
+			mock: func(loanRepo *mocks.MockusecaseLoansRepository, holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				loanRepo.EXPECT().GetForUpdate(anyCtx, &models.Loans{}, 1).RunAndReturn(withLoan(out))
+				lockedBook(stockRepo)
+				loanRepo.EXPECT().Update(anyCtx, &returning).Return(nil)
+				expired := models.Holds{ID: 4, BookID: 1, MemberID: 9, Status: models.HoldStatusReady, ExpiresAt: &stale}
+				holdRepo.EXPECT().GetExpired(anyCtx, new([]models.Holds), 1, now).
+					RunAndReturn(func(_ context.Context, holds *[]models.Holds, _ int, _ time.Time) error {
+						*holds = []models.Holds{expired}
+						return nil
+					})
+				expired.Status = models.HoldStatusExpired
+				holdRepo.EXPECT().Update(anyCtx, &expired).Return(nil)
+				// both the copy of the expired hold and the returned one find
+				// nobody in line
+				holdRepo.EXPECT().NextWaiting(anyCtx, &models.Holds{}, 1).Return(models.ErrHoldNotFound).Times(2)
+				stockRepo.EXPECT().AddQty(anyCtx, 1, 1, &models.Books{}).RunAndReturn(withBalance(1)).Once()
+				stockRepo.EXPECT().CreateMovements(anyCtx, movedCopy(models.StockReturn, 1, 1, "hold 4")).Return(nil)
+				stockRepo.EXPECT().AddQty(anyCtx, 1, 1, &models.Books{}).RunAndReturn(withBalance(2)).Once()
+				stockRepo.EXPECT().CreateMovements(anyCtx, movedCopy(models.StockReturn, 1, 2, "loan 1")).Return(nil)
+			},
+			expectedLoan: expectedLoan,
+		},
+		{
+			name: "Failed return loan already returned",
+			mock: func(loanRepo *mocks.MockusecaseLoansRepository, holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				loanRepo.EXPECT().GetForUpdate(anyCtx, &models.Loans{}, 1).RunAndReturn(withLoan(returned))
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrLoanAlreadyReturned),
+		},
+		{
+			name: "Failed return unknown loan",
+			mock: func(loanRepo *mocks.MockusecaseLoansRepository, holdRepo *mocks.MockusecaseHoldsRepository, stockRepo *mocks.MockusecaseStockRepository) {
+				loanRepo.EXPECT().GetForUpdate(anyCtx, &models.Loans{}, 1).Return(models.ErrLoanNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrLoanNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loanRepo := mocks.NewMockusecaseLoansRepository(t)
+			holdRepo := mocks.NewMockusecaseHoldsRepository(t)
+			stockRepo := mocks.NewMockusecaseStockRepository(t)
+			tt.mock(loanRepo, holdRepo, stockRepo)
+
+			uc := newTestLoansUseCase(t, loanRepo, holdRepo, stockRepo, now)
+
+			loan, err := uc.ReturnLoan(context.Background(), 1)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedLoan, loan)
+			}
+		})
+	}
+}
+
+func TestRenewLoan(t *testing.T) {
+	loanedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	dueAt := loanedAt.Add(loanPeriod)
+	now := loanedAt.Add(10 * 24 * time.Hour)
+	out := models.Loans{ID: 1, BookID: 1, MemberID: 7, LoanedAt: loanedAt, DueAt: dueAt, Renewals: 1}
+
+	tests := []struct {
+		name         string
+		now          time.Time
+		mock         func(loanRepo *mocks.MockusecaseLoansRepository)
+		expectedLoan *models.LoansSummary
+		wantErr      bool
+		errType      error
+	}{
+		{
+			name: "Success renew loan",
+			now:  now,
+			mock: func(loanRepo *mocks.MockusecaseLoansRepository) {
+				loanRepo.EXPECT().GetForUpdate(anyCtx, &models.Loans{}, 1).RunAndReturn(withLoan(out))
+				loanRepo.EXPECT().Update(anyCtx, &models.Loans{
+					ID:       1,
+					BookID:   1,
+					MemberID: 7,
+					LoanedAt: loanedAt,
+					DueAt:    dueAt.Add(loanPeriod),
+					Renewals: 2,
+				}).Return(nil)
+			},
+			expectedLoan: &models.LoansSummary{
+				ID:       1,
+				BookID:   1,
+				MemberID: 7,
+				Status:   models.LoanStatusActive,
+				LoanedAt: loanedAt,
+				DueAt:    dueAt.Add(loanPeriod),
+				Renewals: 2,
+			},
+		},
+		{
+			name: "Failed renew overdue loan",
+			now:  dueAt.Add(time.Minute),
+			mock: func(loanRepo *mocks.MockusecaseLoansRepository) {
+				loanRepo.EXPECT().GetForUpdate(anyCtx, &models.Loans{}, 1).RunAndReturn(withLoan(out))
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrLoanOverdue),
+		},
+		{
+			name: "Failed renew loan out of renewals",
+			now:  now,
+			mock: func(loanRepo *mocks.MockusecaseLoansRepository) {
+				renewed := out
+				renewed.Renewals = 2
+				loanRepo.EXPECT().GetForUpdate(anyCtx, &models.Loans{}, 1).RunAndReturn(withLoan(renewed))
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrLoanRenewalLimit),
+		},
+		{
+			name: "Failed renew returned loan",
+			now:  now,
+			mock: func(loanRepo *mocks.MockusecaseLoansRepository) {
+				returned := out
+				returned.ReturnedAt = &loanedAt
+				loanRepo.EXPECT().GetForUpdate(anyCtx, &models.Loans{}, 1).RunAndReturn(withLoan(returned))
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrLoanAlreadyReturned),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loanRepo := mocks.NewMockusecaseLoansRepository(t)
+			tt.mock(loanRepo)
+
+			uc := newTestLoansUseCase(t, loanRepo, mocks.NewMockusecaseHoldsRepository(t), mocks.NewMockusecaseStockRepository(t), tt.now)
+
+			loan, err := uc.RenewLoan(context.Background(), 1)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedLoan, loan)
+			}
+		})
+	}
+}
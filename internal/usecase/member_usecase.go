@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"fmt"
+	"time"
+)
+
+type UsecaseMembersRepository interface {
+	Create(ctx context.Context, member *models.Members) error
+	GetByID(ctx context.Context, member *models.Members, id int) error
+	GetPage(ctx context.Context, members *[]models.Members, name string, page *models.PageRequest) (*models.PageResult, error)
+	Update(ctx context.Context, member *models.Members) error
+	GetLoans(ctx context.Context, loans *[]models.Loans, memberID int, filter *models.LoansFilter, page *models.PageRequest) (*models.PageResult, error)
+}
+
+type MembersUseCase struct {
+	memberRepo UsecaseMembersRepository
+	// what overdue is judged against, swapped in tests
+	now func() time.Time
+}
+
+func NewMembersUseCase(repo UsecaseMembersRepository) *MembersUseCase {
+	return &MembersUseCase{memberRepo: repo, now: time.Now}
+}
+
+func (uc *MembersUseCase) CreateMember(ctx context.Context, memberRequest *models.CreateMembersRequest) (*models.MembersSummary, error) {
+	ctx, span := tracer.Start(ctx, "MembersUseCase.CreateMember")
+	defer span.End()
+
+	// no lookup first, the unique key says ErrMemberEmailTaken
+	member := &models.Members{
+		Name:  memberRequest.Name,
+		Email: models.NormalizeEmail(memberRequest.Email),
+	}
+
+	if err := uc.memberRepo.Create(ctx, member); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return member.ToMembersSummary(), nil
+}
+
+func (uc *MembersUseCase) GetMemberByID(ctx context.Context, id int) (*models.MembersSummary, error) {
+	ctx, span := tracer.Start(ctx, "MembersUseCase.GetMemberByID")
+	defer span.End()
+
+	var member models.Members
+	if err := uc.memberRepo.GetByID(ctx, &member, id); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return member.ToMembersSummary(), nil
+}
+
+func (uc *MembersUseCase) GetAllMembers(ctx context.Context, name string, page *models.PageRequest) (*[]models.MembersSummary, *models.PageMeta, error) {
+	ctx, span := tracer.Start(ctx, "MembersUseCase.GetAllMembers")
+	defer span.End()
+
+	var members []models.Members
+
+	result, err := uc.memberRepo.GetPage(ctx, &members, name, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	membersList := make([]models.MembersSummary, 0, len(members))
+	for _, member := range members {
+		membersList = append(membersList, *member.ToMembersSummary())
+	}
+
+	return &membersList, &models.PageMeta{
+		Total:  result.Total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}, nil
+}
+
+func (uc *MembersUseCase) UpdateMember(ctx context.Context, memberRequest *models.UpdateMembersRequest) (*models.MembersSummary, error) {
+	ctx, span := tracer.Start(ctx, "MembersUseCase.UpdateMember")
+	defer span.End()
+
+	member := &models.Members{
+		ID:    memberRequest.ID,
+		Name:  memberRequest.Name,
+		Email: models.NormalizeEmail(memberRequest.Email),
+	}
+
+	if err := uc.memberRepo.Update(ctx, member); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return member.ToMembersSummary(), nil
+}
+
+// GetMemberLoans lists the loans of a member, status narrows them to active,
+// overdue or returned ones
+func (uc *MembersUseCase) GetMemberLoans(ctx context.Context, id int, status string, page *models.PageRequest) (*[]models.LoansSummary, *models.PageMeta, error) {
+	ctx, span := tracer.Start(ctx, "MembersUseCase.GetMemberLoans")
+	defer span.End()
+
+	// an unknown member is a 404, not an empty list
+	var member models.Members
+	if err := uc.memberRepo.GetByID(ctx, &member, id); err != nil {
+		return nil, nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	now := uc.now()
+	var loans []models.Loans
+	result, err := uc.memberRepo.GetLoans(ctx, &loans, id, &models.LoansFilter{Status: status, At: now}, page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	loansList := make([]models.LoansSummary, 0, len(loans))
+	for _, loan := range loans {
+		loansList = append(loansList, *loan.ToLoansSummary(now))
+	}
+
+	return &loansList, &models.PageMeta{
+		Total:  result.Total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}, nil
+}
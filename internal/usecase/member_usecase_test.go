@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateMember(t *testing.T) {
+	tests := []struct {
+		name           string
+		memberRequest  *models.CreateMembersRequest
+		mock           func(mock *mocks.MockusecaseMembersRepository)
+		expectedMember *models.MembersSummary
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name:          "Success create member with normalized email",
+			memberRequest: &models.CreateMembersRequest{Name: "Paul Atreides", Email: " Paul@Arrakis.org "},
+			mock: func(mock *mocks.MockusecaseMembersRepository) {
+				mock.EXPECT().Create(anyCtx, &models.Members{Name: "Paul Atreides", Email: "paul@arrakis.org"}).
+					RunAndReturn(func(_ context.Context, member *models.Members) error {
+						member.ID = 1
+						return nil
+					})
+			},
+			expectedMember: &models.MembersSummary{ID: 1, Name: "Paul Atreides", Email: "paul@arrakis.org"},
+		},
+		{
+			name:          "Failed create member due to email taken",
+			memberRequest: &models.CreateMembersRequest{Name: "Paul Atreides", Email: "paul@arrakis.org"},
+			mock: func(mock *mocks.MockusecaseMembersRepository) {
+				mock.EXPECT().Create(anyCtx, &models.Members{Name: "Paul Atreides", Email: "paul@arrakis.org"}).
+					Return(models.ErrMemberEmailTaken)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrMemberEmailTaken),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseMembersRepository(t)
+			tt.mock(mock)
+
+			uc := NewMembersUseCase(mock)
+
+			member, err := uc.CreateMember(context.Background(), tt.memberRequest)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedMember, member)
+			}
+		})
+	}
+}
+
+func TestUpdateMember(t *testing.T) {
+	tests := []struct {
+		name           string
+		memberRequest  *models.UpdateMembersRequest
+		mock           func(mock *mocks.MockusecaseMembersRepository)
+		expectedMember *models.MembersSummary
+		wantErr        bool
+		errType        error
+	}{
+		{
+			name:          "Success update member",
+			memberRequest: &models.UpdateMembersRequest{ID: 1, Name: "Paul Atreides", Email: "MuadDib@Arrakis.org"},
+			mock: func(mock *mocks.MockusecaseMembersRepository) {
+				mock.EXPECT().Update(anyCtx, &models.Members{ID: 1, Name: "Paul Atreides", Email: "muaddib@arrakis.org"}).Return(nil)
+			},
+			expectedMember: &models.MembersSummary{ID: 1, Name: "Paul Atreides", Email: "muaddib@arrakis.org"},
+		},
+		{
+			name:          "Failed update member not found",
+			memberRequest: &models.UpdateMembersRequest{ID: 99, Name: "Paul Atreides", Email: "paul@arrakis.org"},
+			mock: func(mock *mocks.MockusecaseMembersRepository) {
+				mock.EXPECT().Update(anyCtx, &models.Members{ID: 99, Name: "Paul Atreides", Email: "paul@arrakis.org"}).
+					Return(models.ErrMemberNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrMemberNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseMembersRepository(t)
+			tt.mock(mock)
+
+			uc := NewMembersUseCase(mock)
+
+			member, err := uc.UpdateMember(context.Background(), tt.memberRequest)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedMember, member)
+			}
+		})
+	}
+}
+
+func TestGetAllMembers(t *testing.T) {
+	page := &models.PageRequest{Limit: 10}
+
+	tests := []struct {
+		name            string
+		mock            func(mock *mocks.MockusecaseMembersRepository)
+		expectedMembers *[]models.MembersSummary
+		expectedMeta    *models.PageMeta
+		wantErr         bool
+		errType         error
+	}{
+		{
+			name: "Success get all members",
+			mock: func(mock *mocks.MockusecaseMembersRepository) {
+				mock.EXPECT().GetPage(anyCtx, new([]models.Members), "paul", page).
+					RunAndReturn(func(_ context.Context, members *[]models.Members, _ string, _ *models.PageRequest) (*models.PageResult, error) {
+						*members = []models.Members{{ID: 1, Name: "Paul Atreides", Email: "paul@arrakis.org"}}
+						return &models.PageResult{Total: 1}, nil
+					})
+			},
+			expectedMembers: &[]models.MembersSummary{{ID: 1, Name: "Paul Atreides", Email: "paul@arrakis.org"}},
+			expectedMeta:    &models.PageMeta{Total: 1, Limit: 10},
+		},
+		{
+			name: "Failed get all members due to invalid DB",
+			mock: func(mock *mocks.MockusecaseMembersRepository) {
+				mock.EXPECT().GetPage(anyCtx, new([]models.Members), "paul", page).Return(nil, gorm.ErrInvalidDB)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", gorm.ErrInvalidDB),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseMembersRepository(t)
+			tt.mock(mock)
+
+			uc := NewMembersUseCase(mock)
+
+			members, meta, err := uc.GetAllMembers(context.Background(), "paul", page)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedMembers, members)
+				assert.Equal(t, tt.expectedMeta, meta)
+			}
+		})
+	}
+}
+
+func TestGetMemberLoans(t *testing.T) {
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	page := &models.PageRequest{Limit: 10}
+
+	tests := []struct {
+		name          string
+		id            int
+		status        string
+		mock          func(mock *mocks.MockusecaseMembersRepository)
+		expectedLoans *[]models.LoansSummary
+		expectedMeta  *models.PageMeta
+		wantErr       bool
+		errType       error
+	}{
+		{
+			name: "Success get loans of a member",
+			id:   7,
+			mock: func(mock *mocks.MockusecaseMembersRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Members{}, 7).Return(nil)
+				mock.EXPECT().GetLoans(anyCtx, new([]models.Loans), 7, &models.LoansFilter{At: now}, page).
+					RunAndReturn(func(_ context.Context, loans *[]models.Loans, _ int, _ *models.LoansFilter, _ *models.PageRequest) (*models.PageResult, error) {
+						*loans = []models.Loans{
+							{ID: 2, BookID: 1, MemberID: 7, LoanedAt: now.Add(-24 * time.Hour), DueAt: now.Add(24 * time.Hour)},
+							{ID: 1, BookID: 3, MemberID: 7, LoanedAt: now.Add(-72 * time.Hour), DueAt: now.Add(-time.Hour)},
+						}
+						return &models.PageResult{Total: 2}, nil
+					})
+			},
+			expectedLoans: &[]models.LoansSummary{
+				{ID: 2, BookID: 1, MemberID: 7, Status: models.LoanStatusActive, LoanedAt: now.Add(-24 * time.Hour), DueAt: now.Add(24 * time.Hour)},
+				{ID: 1, BookID: 3, MemberID: 7, Status: models.LoanStatusOverdue, LoanedAt: now.Add(-72 * time.Hour), DueAt: now.Add(-time.Hour)},
+			},
+			expectedMeta: &models.PageMeta{Total: 2, Limit: 10},
+		},
+		{
+			name:   "Success get returned loans of a member",
+			id:     7,
+			status: models.LoanStatusReturned,
+			mock: func(mock *mocks.MockusecaseMembersRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Members{}, 7).Return(nil)
+				mock.EXPECT().GetLoans(anyCtx, new([]models.Loans), 7, &models.LoansFilter{Status: models.LoanStatusReturned, At: now}, page).
+					Return(&models.PageResult{}, nil)
+			},
+			expectedLoans: &[]models.LoansSummary{},
+			expectedMeta:  &models.PageMeta{Limit: 10},
+		},
+		{
+			name: "Failed get loans of unknown member",
+			id:   99,
+			mock: func(mock *mocks.MockusecaseMembersRepository) {
+				mock.EXPECT().GetByID(anyCtx, &models.Members{}, 99).Return(models.ErrMemberNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrMemberNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseMembersRepository(t)
+			tt.mock(mock)
+
+			uc := NewMembersUseCase(mock)
+			uc.now = func() time.Time { return now }
+
+			loans, meta, err := uc.GetMemberLoans(context.Background(), tt.id, tt.status, page)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedLoans, loans)
+				assert.Equal(t, tt.expectedMeta, meta)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"crud-echo/pkg/logger"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+type UsecaseTagsRepository interface {
+	Create(ctx context.Context, tag *models.Tags) error
+	GetPage(ctx context.Context, tags *[]models.Tags, name string, page *models.PageRequest) (*models.PageResult, error)
+	Delete(ctx context.Context, id int) error
+	ReplaceBookTags(ctx context.Context, bookID int, names []string) error
+	GetBookTags(ctx context.Context, tags *[]models.Tags, bookID int) error
+}
+
+type TagsUseCase struct {
+	tagRepo UsecaseTagsRepository
+	tx      TxManager
+}
+
+func NewTagsUseCase(repo UsecaseTagsRepository, tx TxManager) *TagsUseCase {
+	return &TagsUseCase{tagRepo: repo, tx: tx}
+}
+
+func (uc *TagsUseCase) CreateTag(ctx context.Context, tagRequest *models.CreateTagsRequest) (*models.TagsSummary, error) {
+	ctx, span := tracer.Start(ctx, "TagsUseCase.CreateTag")
+	defer span.End()
+
+	tag := &models.Tags{Name: models.NormalizeTag(tagRequest.Name)}
+
+	if err := uc.tagRepo.Create(ctx, tag); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return tag.ToTagsSummary(), nil
+}
+
+// GetAllTags lists tags by name, name is a prefix
+func (uc *TagsUseCase) GetAllTags(ctx context.Context, name string, page *models.PageRequest) (*[]models.TagsSummary, *models.PageMeta, error) {
+	ctx, span := tracer.Start(ctx, "TagsUseCase.GetAllTags")
+	defer span.End()
+
+	var tags []models.Tags
+
+	result, err := uc.tagRepo.GetPage(ctx, &tags, models.NormalizeTag(name), page)
+	if err != nil {
+		return nil, nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return tagsSummary(tags), &models.PageMeta{
+		Total:  result.Total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}, nil
+}
+
+func (uc *TagsUseCase) DeleteTag(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "TagsUseCase.DeleteTag")
+	defer span.End()
+
+	if err := uc.tagRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+	logger.FromContext(ctx).Info("tag deleted", zap.Int("id", id))
+
+	return nil
+}
+
+// SetBookTags replaces the tags of a book and returns the new set, tags
+// that don't exist yet are created on the way
+func (uc *TagsUseCase) SetBookTags(ctx context.Context, bookID int, names []string) (*[]models.TagsSummary, error) {
+	ctx, span := tracer.Start(ctx, "TagsUseCase.SetBookTags")
+	defer span.End()
+
+	var tags []models.Tags
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.tagRepo.ReplaceBookTags(ctx, bookID, models.NormalizeTags(names)); err != nil {
+			return err
+		}
+		return uc.tagRepo.GetBookTags(ctx, &tags, bookID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return tagsSummary(tags), nil
+}
+
+func (uc *TagsUseCase) GetBookTags(ctx context.Context, bookID int) (*[]models.TagsSummary, error) {
+	ctx, span := tracer.Start(ctx, "TagsUseCase.GetBookTags")
+	defer span.End()
+
+	var tags []models.Tags
+	if err := uc.tagRepo.GetBookTags(ctx, &tags, bookID); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+
+	return tagsSummary(tags), nil
+}
+
+func tagsSummary(tags []models.Tags) *[]models.TagsSummary {
+	list := make([]models.TagsSummary, 0, len(tags))
+	for _, tag := range tags {
+		list = append(list, *tag.ToTagsSummary())
+	}
+	return &list
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"crud-echo/internal/mocks"
+	"crud-echo/internal/models"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		tagRequest  *models.CreateTagsRequest
+		mock        func(mock *mocks.MockusecaseTagsRepository)
+		expectedTag *models.TagsSummary
+		wantErr     bool
+		errType     error
+	}{
+		{
+			name:       "Success create tag stored lower case",
+			tagRequest: &models.CreateTagsRequest{Name: "Award Winner"},
+			mock: func(mock *mocks.MockusecaseTagsRepository) {
+				mock.EXPECT().Create(anyCtx, &models.Tags{Name: "award winner"}).
+					RunAndReturn(func(_ context.Context, tag *models.Tags) error {
+						tag.ID = 1
+						return nil
+					})
+			},
+			expectedTag: &models.TagsSummary{ID: 1, Name: "award winner"},
+		},
+		{
+			name:       "Failed create tag due to name taken",
+			tagRequest: &models.CreateTagsRequest{Name: "classic"},
+			mock: func(mock *mocks.MockusecaseTagsRepository) {
+				mock.EXPECT().Create(anyCtx, &models.Tags{Name: "classic"}).Return(models.ErrTagNameTaken)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrTagNameTaken),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseTagsRepository(t)
+			tt.mock(mock)
+
+			uc := NewTagsUseCase(mock, inlineTx{})
+
+			tag, err := uc.CreateTag(context.Background(), tt.tagRequest)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTag, tag)
+			}
+		})
+	}
+}
+
+func TestGetAllTags(t *testing.T) {
+	page := &models.PageRequest{Limit: 10}
+
+	mock := mocks.NewMockusecaseTagsRepository(t)
+	mock.EXPECT().GetPage(anyCtx, new([]models.Tags), "cl", page).
+		RunAndReturn(func(_ context.Context, tags *[]models.Tags, _ string, _ *models.PageRequest) (*models.PageResult, error) {
+			*tags = []models.Tags{{ID: 1, Name: "classic"}}
+			return &models.PageResult{Total: 1}, nil
+		})
+
+	uc := NewTagsUseCase(mock, inlineTx{})
+
+	tags, meta, err := uc.GetAllTags(context.Background(), " CL", page)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &[]models.TagsSummary{{ID: 1, Name: "classic"}}, tags)
+	assert.Equal(t, &models.PageMeta{Total: 1, Limit: 10}, meta)
+}
+
+func TestDeleteTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		mock    func(mock *mocks.MockusecaseTagsRepository)
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "Success delete tag",
+			id:   1,
+			mock: func(mock *mocks.MockusecaseTagsRepository) {
+				mock.EXPECT().Delete(anyCtx, 1).Return(nil)
+			},
+		},
+		{
+			name: "Failed delete tag due to tag not found",
+			id:   99,
+			mock: func(mock *mocks.MockusecaseTagsRepository) {
+				mock.EXPECT().Delete(anyCtx, 99).Return(models.ErrTagNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrTagNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseTagsRepository(t)
+			tt.mock(mock)
+
+			uc := NewTagsUseCase(mock, inlineTx{})
+
+			err := uc.DeleteTag(context.Background(), tt.id)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSetBookTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		names        []string
+		mock         func(mock *mocks.MockusecaseTagsRepository)
+		expectedTags *[]models.TagsSummary
+		wantErr      bool
+		errType      error
+	}{
+		{
+			name:  "Success set tags of a book normalized and without repeats",
+			names: []string{"Classic", " award", "classic"},
+			mock: func(mock *mocks.MockusecaseTagsRepository) {
+				mock.EXPECT().ReplaceBookTags(anyCtx, 1, []string{"classic", "award"}).Return(nil)
+				mock.EXPECT().GetBookTags(anyCtx, new([]models.Tags), 1).
+					RunAndReturn(func(_ context.Context, tags *[]models.Tags, _ int) error {
+						*tags = []models.Tags{{ID: 2, Name: "award"}, {ID: 1, Name: "classic"}}
+						return nil
+					})
+			},
+			expectedTags: &[]models.TagsSummary{{ID: 2, Name: "award"}, {ID: 1, Name: "classic"}},
+		},
+		{
+			name:  "Success clear tags of a book",
+			names: nil,
+			mock: func(mock *mocks.MockusecaseTagsRepository) {
+				mock.EXPECT().ReplaceBookTags(anyCtx, 1, []string{}).Return(nil)
+				mock.EXPECT().GetBookTags(anyCtx, new([]models.Tags), 1).Return(nil)
+			},
+			expectedTags: &[]models.TagsSummary{},
+		},
+		{
+			name:  "Failed set tags due to book not found",
+			names: []string{"classic"},
+			mock: func(mock *mocks.MockusecaseTagsRepository) {
+				mock.EXPECT().ReplaceBookTags(anyCtx, 1, []string{"classic"}).Return(models.ErrBookNotFound)
+			},
+			wantErr: true,
+			errType: fmt.Errorf("repository error: %w", models.ErrBookNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mocks.NewMockusecaseTagsRepository(t)
+			tt.mock(mock)
+
+			uc := NewTagsUseCase(mock, inlineTx{})
+
+			tags, err := uc.SetBookTags(context.Background(), 1, tt.names)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errType, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTags, tags)
+			}
+		})
+	}
+}
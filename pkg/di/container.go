@@ -8,12 +8,23 @@ import (
 	"crud-echo/internal/inbound/server"
 	"crud-echo/internal/outbound/database"
 	"crud-echo/internal/usecase"
+	"crud-echo/pkg/health"
+	"crud-echo/pkg/logger"
+	"crud-echo/pkg/metrics"
+	"crud-echo/pkg/migrations"
 	"crud-echo/pkg/postgres"
+	"crud-echo/pkg/tracing"
 
 	"github.com/go-playground/validator/v10"
 	"go.uber.org/dig"
 )
 
+type readinessCheckers struct {
+	dig.In
+
+	Checkers []health.Checker `group:"readiness"`
+}
+
 func BuildContainer(configPath string) (*dig.Container, error) {
 	container := dig.New()
 
@@ -24,8 +35,48 @@ func BuildContainer(configPath string) (*dig.Container, error) {
 		return nil, err
 	}
 
+	// logger, request handlers get a copy carrying the request ID through
+	// logger.FromContext
+	if err := container.Provide(logger.New); err != nil {
+		return nil, err
+	}
+
+	// metrics, shared by the server middleware, the gorm plugin and /metrics
+	if err := container.Provide(metrics.New); err != nil {
+		return nil, err
+	}
+
+	// tracing, also sets the global provider and the W3C propagator
+	if err := container.Provide(tracing.New); err != nil {
+		return nil, err
+	}
+
 	// db
-	if err := container.Provide(postgres.NewDB, dig.As(new(database.RepositoryDBConn))); err != nil {
+	if err := container.Provide(postgres.NewDB); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(func(db *postgres.PostgresDB) database.RepositoryDBConn {
+		return db
+	}); err != nil {
+		return nil, err
+	}
+
+	// migrations, run on boot through RepositoryDBConn.Migrate or by hand
+	// with the migrate subcommand, both go through PostgresDB.Migrator
+	if err := container.Provide((*postgres.PostgresDB).Migrator); err != nil {
+		return nil, err
+	}
+
+	// health, anything provided to the "readiness" group is checked by /readyz
+	if err := container.Provide(database.NewPingChecker, dig.Group("readiness")); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(migrations.NewPendingChecker, dig.Group("readiness")); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(func(in readinessCheckers) *health.Registry {
+		return health.NewRegistry(in.Checkers...)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -36,12 +87,55 @@ func BuildContainer(configPath string) (*dig.Container, error) {
 
 	// repo
 	// using dig.As to implement/specify the interface
-	if err := container.Provide(database.NewBooksRepository, dig.As(new(usecase.UsecaseBooksRepository))); err != nil {
+	if err := container.Provide(database.NewBooksRepository,
+		dig.As(new(usecase.UsecaseBooksRepository), new(usecase.UsecaseStockRepository))); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(database.NewAuthorsRepository, dig.As(new(usecase.UsecaseAuthorsRepository))); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(database.NewCategoriesRepository, dig.As(new(usecase.UsecaseCategoriesRepository))); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(database.NewTagsRepository, dig.As(new(usecase.UsecaseTagsRepository))); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(database.NewMembersRepository, dig.As(new(usecase.UsecaseMembersRepository))); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(database.NewLoansRepository, dig.As(new(usecase.UsecaseLoansRepository))); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(database.NewHoldsRepository, dig.As(new(usecase.UsecaseHoldsRepository))); err != nil {
+		return nil, err
+	}
+
+	// transactions, repositories join the one found in the context
+	if err := container.Provide(database.NewTxManager, dig.As(new(usecase.TxManager))); err != nil {
 		return nil, err
 	}
 
 	// usecase
-	if err := container.Provide(usecase.NewBooksUseCase, dig.As(new(handlers.HandlerBookUsecase))); err != nil {
+	if err := container.Provide(usecase.NewBooksUseCase,
+		dig.As(new(handlers.HandlerBookUsecase), new(metrics.BooksStatsSource))); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(usecase.NewAuthorsUseCase, dig.As(new(handlers.HandlerAuthorUsecase))); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(usecase.NewCategoriesUseCase, dig.As(new(handlers.HandlerCategoryUsecase))); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(usecase.NewTagsUseCase, dig.As(new(handlers.HandlerTagUsecase))); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(usecase.NewMembersUseCase, dig.As(new(handlers.HandlerMemberUsecase))); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(usecase.NewLoansUseCase, dig.As(new(handlers.HandlerLoanUsecase))); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(usecase.NewHoldsUseCase, dig.As(new(handlers.HandlerHoldUsecase))); err != nil {
 		return nil, err
 	}
 
@@ -64,6 +158,27 @@ func BuildContainer(configPath string) (*dig.Container, error) {
 	if err := container.Provide(handlers.NewBooksHandler); err != nil {
 		return nil, err
 	}
+	if err := container.Provide(handlers.NewAuthorsHandler); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(handlers.NewCategoriesHandler); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(handlers.NewTagsHandler); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(handlers.NewMembersHandler); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(handlers.NewLoansHandler); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(handlers.NewHoldsHandler); err != nil {
+		return nil, err
+	}
+	if err := container.Provide(handlers.NewHealthHandler); err != nil {
+		return nil, err
+	}
 
 	return container, nil
 }
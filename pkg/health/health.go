@@ -0,0 +1,130 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	// DefaultTimeout bounds each check so one hung dependency can't hang the probe
+	DefaultTimeout = 2 * time.Second
+)
+
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// Checker is a single dependency readiness depends on. Checkers are provided
+// to the DI container in the "readiness" group and picked up by the registry
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+type checkerFunc struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+func (c checkerFunc) Name() string                    { return c.name }
+func (c checkerFunc) Check(ctx context.Context) error { return c.fn(ctx) }
+
+// NewChecker turns a plain function into a Checker
+func NewChecker(name string, fn func(ctx context.Context) error) Checker {
+	return checkerFunc{name: name, fn: fn}
+}
+
+type Result struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+func (r Report) IsUp() bool {
+	return r.Status == StatusUp
+}
+
+type Registry struct {
+	mu           sync.RWMutex
+	checkers     []Checker
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+func NewRegistry(checkers ...Checker) *Registry {
+	return &Registry{checkers: checkers, timeout: DefaultTimeout}
+}
+
+func (r *Registry) Register(c Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checkers = append(r.checkers, c)
+}
+
+// SetShuttingDown makes every following readiness check fail so the
+// orchestrator stops routing traffic here while requests drain
+func (r *Registry) SetShuttingDown() {
+	r.shuttingDown.Store(true)
+}
+
+// Check runs all checkers concurrently, the report is up only if all of them are
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.RLock()
+	checkers := append([]Checker(nil), r.checkers...)
+	r.mu.RUnlock()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]Result, len(checkers)+1)}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := r.run(ctx, c)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[c.Name()] = result
+		}()
+	}
+	wg.Wait()
+
+	shutdown := Result{Status: StatusUp}
+	if r.shuttingDown.Load() {
+		shutdown = Result{Status: StatusDown, Error: ErrShuttingDown.Error()}
+	}
+	report.Checks["shutdown"] = shutdown
+
+	for _, result := range report.Checks {
+		if result.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
+
+func (r *Registry) run(ctx context.Context, c Checker) Result {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.Check(ctx)
+	latency := float64(time.Since(start).Microseconds()) / 1000
+
+	if err != nil {
+		return Result{Status: StatusDown, LatencyMs: latency, Error: err.Error()}
+	}
+	return Result{Status: StatusUp, LatencyMs: latency}
+}
@@ -0,0 +1,91 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	ok := NewChecker("ok", func(ctx context.Context) error { return nil })
+	broken := NewChecker("broken", func(ctx context.Context) error { return errors.New("connection refused") })
+	hung := NewChecker("hung", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	tests := []struct {
+		name           string
+		checkers       []Checker
+		shuttingDown   bool
+		expectedStatus string
+		expectedChecks map[string]string
+		expectedErrors map[string]string
+	}{
+		{
+			name:           "Up when every check passes",
+			checkers:       []Checker{ok},
+			expectedStatus: StatusUp,
+			expectedChecks: map[string]string{"ok": StatusUp, "shutdown": StatusUp},
+		},
+		{
+			name:           "Up with no checkers registered",
+			expectedStatus: StatusUp,
+			expectedChecks: map[string]string{"shutdown": StatusUp},
+		},
+		{
+			name:           "Down when a check fails",
+			checkers:       []Checker{ok, broken},
+			expectedStatus: StatusDown,
+			expectedChecks: map[string]string{"ok": StatusUp, "broken": StatusDown, "shutdown": StatusUp},
+			expectedErrors: map[string]string{"broken": "connection refused"},
+		},
+		{
+			name:           "Down when a check times out",
+			checkers:       []Checker{hung},
+			expectedStatus: StatusDown,
+			expectedChecks: map[string]string{"hung": StatusDown, "shutdown": StatusUp},
+			expectedErrors: map[string]string{"hung": context.DeadlineExceeded.Error()},
+		},
+		{
+			name:           "Down while shutting down",
+			checkers:       []Checker{ok},
+			shuttingDown:   true,
+			expectedStatus: StatusDown,
+			expectedChecks: map[string]string{"ok": StatusUp, "shutdown": StatusDown},
+			expectedErrors: map[string]string{"shutdown": ErrShuttingDown.Error()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry(tt.checkers...)
+			registry.timeout = 50 * time.Millisecond
+			if tt.shuttingDown {
+				registry.SetShuttingDown()
+			}
+
+			report := registry.Check(context.Background())
+
+			assert.Equal(t, tt.expectedStatus, report.Status)
+			checks := make(map[string]string, len(report.Checks))
+			for name, result := range report.Checks {
+				checks[name] = result.Status
+				assert.Equal(t, tt.expectedErrors[name], result.Error)
+			}
+			assert.Equal(t, tt.expectedChecks, checks)
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(NewChecker("cache", func(ctx context.Context) error { return nil }))
+
+	report := registry.Check(context.Background())
+
+	assert.True(t, report.IsUp())
+	assert.Contains(t, report.Checks, "cache")
+}
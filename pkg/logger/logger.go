@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"context"
+	"crud-echo/internal/config"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	EncodingJSON    = "json"
+	EncodingConsole = "console"
+)
+
+type ctxKey struct{}
+
+// New builds the one logger the container hands out, json for shipping
+// somewhere and console for reading in a terminal
+func New(cfg *config.Config) (*zap.Logger, error) {
+	level, err := zapcore.ParseLevel(cfg.Log.Level)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level: %w", err)
+	}
+
+	zc := zap.NewProductionConfig()
+	switch cfg.Log.Encoding {
+	case EncodingJSON:
+	case EncodingConsole:
+		zc.EncoderConfig = zap.NewDevelopmentEncoderConfig()
+	default:
+		return nil, fmt.Errorf("invalid log encoding %q, want %s or %s", cfg.Log.Encoding, EncodingJSON, EncodingConsole)
+	}
+	zc.Encoding = cfg.Log.Encoding
+	zc.Level = zap.NewAtomicLevelAt(level)
+	zc.EncoderConfig.TimeKey = "time"
+	zc.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	return zc.Build()
+}
+
+// WithContext stores a logger, usually one already carrying the request ID,
+// for FromContext further down the call chain
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext returns the logger stored by WithContext, or a no-op one
+// outside of a request
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(ctxKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return zap.NewNop()
+}
@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"context"
+	"crud-echo/internal/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name          string
+		log           config.Log
+		expectedLevel zapcore.Level
+		wantErr       bool
+	}{
+		{
+			name:          "JSON at info",
+			log:           config.Log{Level: "info", Encoding: EncodingJSON},
+			expectedLevel: zapcore.InfoLevel,
+		},
+		{
+			name:          "Console at debug",
+			log:           config.Log{Level: "debug", Encoding: EncodingConsole},
+			expectedLevel: zapcore.DebugLevel,
+		},
+		{
+			name:    "Unknown level",
+			log:     config.Log{Level: "loud", Encoding: EncodingJSON},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown encoding",
+			log:     config.Log{Level: "info", Encoding: "xml"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := New(&config.Config{Log: &tt.log})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLevel, l.Level())
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	l := zap.NewExample()
+
+	assert.Same(t, l, FromContext(WithContext(context.Background(), l)))
+	assert.NotNil(t, FromContext(context.Background()), "falls back to a no-op logger")
+}
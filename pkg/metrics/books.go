@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"context"
+	"crud-echo/internal/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type BooksStatsSource interface {
+	GetBooksStats(ctx context.Context) (*models.BooksStats, error)
+}
+
+var (
+	booksTotalDesc = prometheus.NewDesc(
+		"books_total",
+		"Books in the catalogue, trashed ones excluded.",
+		nil, nil,
+	)
+	booksQtyDesc = prometheus.NewDesc(
+		"books_quantity_total",
+		"Copies on hand across all books, trashed ones excluded.",
+		nil, nil,
+	)
+)
+
+// booksCollector queries the stats on every scrape instead of keeping gauges
+// in sync with each write, so it can't drift from what is in the database
+type booksCollector struct {
+	src BooksStatsSource
+}
+
+func NewBooksCollector(src BooksStatsSource) prometheus.Collector {
+	return booksCollector{src: src}
+}
+
+func (c booksCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- booksTotalDesc
+	ch <- booksQtyDesc
+}
+
+func (c booksCollector) Collect(ch chan<- prometheus.Metric) {
+	stats, err := c.src.GetBooksStats(context.Background())
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(booksTotalDesc, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(booksTotalDesc, prometheus.GaugeValue, float64(stats.Total))
+	ch <- prometheus.MustNewConstMetric(booksQtyDesc, prometheus.GaugeValue, float64(stats.TotalQty))
+}
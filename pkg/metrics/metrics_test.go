@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"context"
+	"crud-echo/internal/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedRoute  string
+		expectedStatus string
+	}{
+		{
+			name:           "Labels by route template, not the raw path",
+			method:         http.MethodGet,
+			path:           "/book/42",
+			expectedRoute:  "/book/:id",
+			expectedStatus: "200",
+		},
+		{
+			name:           "Status from an HTTPError returned by the handler",
+			method:         http.MethodGet,
+			path:           "/book/0",
+			expectedRoute:  "/book/:id",
+			expectedStatus: "404",
+		},
+		{
+			name:           "Status 500 for a plain error",
+			method:         http.MethodGet,
+			path:           "/book/-1",
+			expectedRoute:  "/book/:id",
+			expectedStatus: "500",
+		},
+		{
+			name:           "Unmatched paths share one label",
+			method:         http.MethodGet,
+			path:           "/wp-admin/setup.php",
+			expectedRoute:  unmatchedRoute,
+			expectedStatus: "404",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New()
+			e := echo.New()
+			e.Use(m.Middleware())
+			e.GET("/book/:id", func(c echo.Context) error {
+				switch c.Param("id") {
+				case "0":
+					return echo.NewHTTPError(http.StatusNotFound)
+				case "-1":
+					return errors.New("boom")
+				}
+				return c.NoContent(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			counter := m.httpRequests.WithLabelValues(tt.method, tt.expectedRoute, tt.expectedStatus)
+			assert.Equal(t, float64(1), testutil.ToFloat64(counter))
+			assert.Equal(t, 1, testutil.CollectAndCount(m.httpRequests))
+			assert.Equal(t, 1, testutil.CollectAndCount(m.httpDuration))
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	m := New()
+	m.httpRequests.WithLabelValues(http.MethodGet, "/books", "200").Inc()
+
+	e := echo.New()
+	e.GET("/metrics", m.Handler())
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `http_requests_total{method="GET",route="/books",status="200"} 1`)
+	assert.Contains(t, rec.Body.String(), "go_goroutines")
+}
+
+func TestGormPlugin(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	m := New()
+	assert.NoError(t, gdb.Use(m.GormPlugin()))
+
+	mock.ExpectQuery(`SELECT \* FROM "books"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(`SELECT \* FROM "books"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`SELECT \* FROM "books"`).
+		WillReturnError(errors.New("connection reset"))
+
+	var book models.Books
+	assert.NoError(t, gdb.First(&book).Error)
+	assert.ErrorIs(t, gdb.First(&book).Error, gorm.ErrRecordNotFound)
+	assert.Error(t, gdb.First(&book).Error)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, 1, testutil.CollectAndCount(m.dbDuration))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.dbErrors.WithLabelValues("query", "books")),
+		"record not found should not count as an error")
+}
+
+type statsSource struct {
+	stats *models.BooksStats
+	err   error
+}
+
+func (s statsSource) GetBooksStats(ctx context.Context) (*models.BooksStats, error) {
+	return s.stats, s.err
+}
+
+func TestBooksCollector(t *testing.T) {
+	t.Run("Reports the stats as gauges", func(t *testing.T) {
+		c := NewBooksCollector(statsSource{stats: &models.BooksStats{Total: 3, TotalQty: 17}})
+
+		expected := `
+# HELP books_quantity_total Copies on hand across all books, trashed ones excluded.
+# TYPE books_quantity_total gauge
+books_quantity_total 17
+# HELP books_total Books in the catalogue, trashed ones excluded.
+# TYPE books_total gauge
+books_total 3
+`
+		assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected)))
+	})
+
+	t.Run("Fails the scrape when the stats can't be read", func(t *testing.T) {
+		m := New()
+		assert.NoError(t, m.Register(NewBooksCollector(statsSource{err: errors.New("connection refused")})))
+
+		_, err := m.registry.Gather()
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"context"
+	"crud-echo/pkg/health"
+	"errors"
+	"fmt"
+)
+
+var ErrPendingMigrations = errors.New("migrations pending")
+
+// NewPendingChecker reports not ready until every embedded migration has
+// been applied, so a replica never serves a schema it doesn't expect
+func NewPendingChecker(m *Migrator) health.Checker {
+	return health.NewChecker("migrations", func(ctx context.Context) error {
+		pending, err := m.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if pending > 0 {
+			return fmt.Errorf("%w: %d", ErrPendingMigrations, pending)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,457 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+var appliedAt = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+// what Postgres reports on a database no migration has run against
+var errNoTable = &pgconn.PgError{Code: pgUndefinedTable, Message: `relation "schema_migrations" does not exist`}
+
+var testMigrations = []Migration{
+	{Version: 1, Name: "create_a", Up: "CREATE TABLE a (id int)", Down: "DROP TABLE a", Checksum: "sum1"},
+	{Version: 2, Name: "create_b", Up: "CREATE TABLE b (id int)", Down: "DROP TABLE b", Checksum: "sum2"},
+}
+
+func setupTestMigrator(t *testing.T) (*Migrator, sqlmock.Sqlmock, func()) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	cleanup := func() {
+		db.Close()
+	}
+
+	return &Migrator{db: db, migrations: testMigrations}, mock, cleanup
+}
+
+func expectLock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_lock($1)`)).
+		WithArgs(lockKey).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_unlock($1)`)).
+		WithArgs(lockKey).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectApplied(mock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+	mock.ExpectQuery(`SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version`).
+		WillReturnRows(rows)
+}
+
+func appliedRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"version", "name", "checksum", "applied_at"})
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name             string
+		fsys             fstest.MapFS
+		expectedVersions []int64
+		wantErr          bool
+		errType          error
+	}{
+		{
+			name: "Success load migrations in version order",
+			fsys: fstest.MapFS{
+				"0002_create_b.up.sql":   {Data: []byte("CREATE TABLE b (id int)")},
+				"0002_create_b.down.sql": {Data: []byte("DROP TABLE b")},
+				"0001_create_a.up.sql":   {Data: []byte("CREATE TABLE a (id int)")},
+				"0001_create_a.down.sql": {Data: []byte("DROP TABLE a")},
+				"README.md":              {Data: []byte("not a migration")},
+			},
+			expectedVersions: []int64{1, 2},
+		},
+		{
+			name: "Failed load due to missing down script",
+			fsys: fstest.MapFS{
+				"0001_create_a.up.sql": {Data: []byte("CREATE TABLE a (id int)")},
+			},
+			wantErr: true,
+			errType: ErrInvalidMigration,
+		},
+		{
+			name: "Failed load due to malformed file name",
+			fsys: fstest.MapFS{
+				"create_a.sql": {Data: []byte("CREATE TABLE a (id int)")},
+			},
+			wantErr: true,
+			errType: ErrInvalidMigration,
+		},
+		{
+			name: "Failed load due to version used twice",
+			fsys: fstest.MapFS{
+				"0001_create_a.up.sql":   {Data: []byte("CREATE TABLE a (id int)")},
+				"0001_create_a.down.sql": {Data: []byte("DROP TABLE a")},
+				"0001_create_b.up.sql":   {Data: []byte("CREATE TABLE b (id int)")},
+			},
+			wantErr: true,
+			errType: ErrInvalidMigration,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := Load(tt.fsys)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.errType)
+			} else {
+				assert.NoError(t, err)
+				versions := make([]int64, 0, len(migrations))
+				for _, m := range migrations {
+					versions = append(versions, m.Version)
+					assert.Len(t, m.Checksum, 64)
+				}
+				assert.Equal(t, tt.expectedVersions, versions)
+			}
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	m, err := New(nil)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, m.migrations)
+	for i, mig := range m.migrations {
+		assert.Equal(t, int64(i+1), mig.Version, "versions should have no gaps")
+		assert.NotEmpty(t, mig.Up)
+		assert.NotEmpty(t, mig.Down)
+	}
+}
+
+func TestUp(t *testing.T) {
+	tests := []struct {
+		name             string
+		mock             func(mock sqlmock.Sqlmock)
+		expectedVersions []int64
+		wantErr          bool
+		errType          error
+	}{
+		{
+			name: "Success apply pending migrations",
+			mock: func(mock sqlmock.Sqlmock) {
+				expectLock(mock)
+				expectApplied(mock, appliedRows().AddRow(1, "create_a", "sum1", appliedAt))
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE b (id int)")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`)).
+					WithArgs(2, "create_b", "sum2").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				expectUnlock(mock)
+			},
+			expectedVersions: []int64{2},
+		},
+		{
+			name: "Success nothing to apply",
+			mock: func(mock sqlmock.Sqlmock) {
+				expectLock(mock)
+				expectApplied(mock, appliedRows().
+					AddRow(1, "create_a", "sum1", appliedAt).
+					AddRow(2, "create_b", "sum2", appliedAt))
+				expectUnlock(mock)
+			},
+		},
+		{
+			name: "Failed apply due to modified migration",
+			mock: func(mock sqlmock.Sqlmock) {
+				expectLock(mock)
+				expectApplied(mock, appliedRows().AddRow(1, "create_a", "edited", appliedAt))
+				expectUnlock(mock)
+			},
+			wantErr: true,
+			errType: ErrChecksumMismatch,
+		},
+		{
+			name: "Failed apply due to broken migration",
+			mock: func(mock sqlmock.Sqlmock) {
+				expectLock(mock)
+				expectApplied(mock, appliedRows())
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE a (id int)")).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+				expectUnlock(mock)
+			},
+			wantErr: true,
+			errType: sql.ErrConnDone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, mock, cleanup := setupTestMigrator(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			applied, err := m.Up(context.Background())
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.errType)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedVersions, versionsOf(applied))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDown(t *testing.T) {
+	tests := []struct {
+		name             string
+		steps            int
+		mock             func(mock sqlmock.Sqlmock)
+		expectedVersions []int64
+		wantErr          bool
+		errType          error
+	}{
+		{
+			name:  "Success revert last migration",
+			steps: 1,
+			mock: func(mock sqlmock.Sqlmock) {
+				expectLock(mock)
+				expectApplied(mock, appliedRows().
+					AddRow(1, "create_a", "sum1", appliedAt).
+					AddRow(2, "create_b", "sum2", appliedAt))
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("DROP TABLE b")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM schema_migrations WHERE version = $1`)).
+					WithArgs(2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				expectUnlock(mock)
+			},
+			expectedVersions: []int64{2},
+		},
+		{
+			name:  "Success revert more steps than applied",
+			steps: 5,
+			mock: func(mock sqlmock.Sqlmock) {
+				expectLock(mock)
+				expectApplied(mock, appliedRows().AddRow(1, "create_a", "sum1", appliedAt))
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("DROP TABLE a")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM schema_migrations WHERE version = $1`)).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				expectUnlock(mock)
+			},
+			expectedVersions: []int64{1},
+		},
+		{
+			name:  "Failed revert due to migration missing from build",
+			steps: 1,
+			mock: func(mock sqlmock.Sqlmock) {
+				expectLock(mock)
+				expectApplied(mock, appliedRows().AddRow(3, "create_c", "sum3", appliedAt))
+				expectUnlock(mock)
+			},
+			wantErr: true,
+			errType: ErrUnknownMigration,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, mock, cleanup := setupTestMigrator(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			reverted, err := m.Down(context.Background(), tt.steps)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.errType)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedVersions, versionsOf(reverted))
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestRedo(t *testing.T) {
+	m, mock, cleanup := setupTestMigrator(t)
+	defer cleanup()
+
+	expectLock(mock)
+	expectApplied(mock, appliedRows().
+		AddRow(1, "create_a", "sum1", appliedAt).
+		AddRow(2, "create_b", "sum2", appliedAt))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE b")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM schema_migrations WHERE version = $1`)).
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectApplied(mock, appliedRows().AddRow(1, "create_a", "sum1", appliedAt))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE b (id int)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`)).
+		WithArgs(2, "create_b", "sum2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	redone, err := m.Redo(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2}, versionsOf(redone))
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name             string
+		mock             func(mock sqlmock.Sqlmock)
+		expectedStatuses []Status
+		wantErr          bool
+	}{
+		{
+			name: "Success list applied and pending migrations",
+			mock: func(mock sqlmock.Sqlmock) {
+				expectApplied(mock, appliedRows().AddRow(1, "create_a", "sum1", appliedAt))
+			},
+			expectedStatuses: []Status{
+				{Version: 1, Name: "create_a", AppliedAt: &appliedAt},
+				{Version: 2, Name: "create_b"},
+			},
+		},
+		{
+			name: "Success list every migration as pending before the table exists",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version`).
+					WillReturnError(errNoTable)
+			},
+			expectedStatuses: []Status{
+				{Version: 1, Name: "create_a"},
+				{Version: 2, Name: "create_b"},
+			},
+		},
+		{
+			name: "Failed list migrations due to database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version`).
+					WillReturnError(errors.New("connection reset"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, mock, cleanup := setupTestMigrator(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			statuses, err := m.Status(context.Background())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatuses, statuses)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestPending(t *testing.T) {
+	tests := []struct {
+		name            string
+		mock            func(mock sqlmock.Sqlmock)
+		expectedPending int
+		wantErr         bool
+	}{
+		{
+			name: "Success count pending migrations",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT version FROM schema_migrations`).
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+			},
+			expectedPending: 1,
+		},
+		{
+			name: "Success count every migration as pending before the table exists",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT version FROM schema_migrations`).
+					WillReturnError(errNoTable)
+			},
+			expectedPending: 2,
+		},
+		{
+			name: "Failed count pending migrations due to database error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT version FROM schema_migrations`).
+					WillReturnError(errors.New("connection reset"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, mock, cleanup := setupTestMigrator(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			pending, err := m.Pending(context.Background())
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPending, pending)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func versionsOf(migrations []Migration) []int64 {
+	var versions []int64
+	for _, m := range migrations {
+		versions = append(versions, m.Version)
+	}
+	return versions
+}
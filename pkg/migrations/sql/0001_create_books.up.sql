@@ -0,0 +1,17 @@
+-- databases created before migrations existed already have the table from
+-- AutoMigrate, so everything here is written to be a no-op on those
+CREATE TABLE IF NOT EXISTS books (
+    id          bigserial PRIMARY KEY,
+    title       varchar(50)  NOT NULL,
+    description varchar(255) NOT NULL,
+    qty         bigint       NOT NULL,
+    version     bigint       NOT NULL DEFAULT 1,
+    created_at  timestamptz  NOT NULL,
+    updated_at  timestamptz,
+    deleted_at  timestamptz
+);
+
+ALTER TABLE books ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+ALTER TABLE books ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_books_deleted_at ON books (deleted_at);
@@ -0,0 +1,18 @@
+-- titles are unique among live books, ignoring case and surrounding spaces,
+-- trashed books don't count so a title can be reused once its book is
+-- deleted (restoring that book then fails while the title is taken)
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT lower(btrim(title)) FROM books
+        WHERE deleted_at IS NULL
+        GROUP BY lower(btrim(title))
+        HAVING count(*) > 1
+    ) THEN
+        RAISE EXCEPTION 'books has live rows sharing a title, rename or delete them before migrating';
+    END IF;
+END
+$$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_books_title_unique ON books (lower(btrim(title)))
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE books DROP CONSTRAINT IF EXISTS books_qty_non_negative;
+DROP TABLE IF EXISTS stock_movements;
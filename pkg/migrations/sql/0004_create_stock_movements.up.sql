@@ -0,0 +1,30 @@
+-- every change to books.qty is recorded here, quantity is the signed change
+-- and balance the qty it left the book with
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id         bigserial    PRIMARY KEY,
+    book_id    bigint       NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    kind       varchar(16)  NOT NULL CHECK (kind IN ('receive', 'sell', 'lend', 'return', 'adjust')),
+    quantity   bigint       NOT NULL CHECK (quantity <> 0),
+    balance    bigint       NOT NULL,
+    reason     varchar(255) NOT NULL DEFAULT '',
+    actor      varchar(100) NOT NULL DEFAULT '',
+    created_at timestamptz  NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_book_id ON stock_movements (book_id, id);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'books_qty_non_negative') THEN
+        ALTER TABLE books ADD CONSTRAINT books_qty_non_negative CHECK (qty >= 0);
+    END IF;
+END
+$$;
+
+-- books that already had stock start their ledger with it, so the
+-- movements of every book add up to its qty
+INSERT INTO stock_movements (book_id, kind, quantity, balance, reason, actor, created_at)
+SELECT id, 'adjust', qty, qty, 'opening balance', 'migration', now()
+FROM books
+WHERE qty > 0
+  AND NOT EXISTS (SELECT 1 FROM stock_movements WHERE stock_movements.book_id = books.id);
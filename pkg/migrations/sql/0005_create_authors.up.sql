@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS authors (
+    id         bigserial     PRIMARY KEY,
+    name       varchar(100)  NOT NULL,
+    bio        varchar(1000) NOT NULL DEFAULT '',
+    created_at timestamptz   NOT NULL,
+    updated_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_authors_name ON authors (lower(name));
+
+-- links go away with either side, a book in the trash keeps its authors
+CREATE TABLE IF NOT EXISTS book_authors (
+    book_id   bigint NOT NULL,
+    author_id bigint NOT NULL,
+    PRIMARY KEY (book_id, author_id),
+    CONSTRAINT book_authors_book_id_fkey FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+    CONSTRAINT book_authors_author_id_fkey FOREIGN KEY (author_id) REFERENCES authors (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_authors_author_id ON book_authors (author_id);
@@ -0,0 +1,42 @@
+-- path lists the ids from the root down to the category itself, "/1/4/",
+-- so a subtree is everything whose path starts with the root's
+CREATE TABLE IF NOT EXISTS categories (
+    id         bigserial     PRIMARY KEY,
+    name       varchar(100)  NOT NULL,
+    slug       varchar(100)  NOT NULL,
+    parent_id  bigint,
+    path       varchar(1000) NOT NULL DEFAULT '',
+    created_at timestamptz   NOT NULL,
+    updated_at timestamptz,
+    CONSTRAINT categories_slug_key UNIQUE (slug),
+    CONSTRAINT categories_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES categories (id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_categories_path ON categories (path varchar_pattern_ops);
+
+CREATE TABLE IF NOT EXISTS book_categories (
+    book_id     bigint NOT NULL,
+    category_id bigint NOT NULL,
+    PRIMARY KEY (book_id, category_id),
+    CONSTRAINT book_categories_book_id_fkey FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+    CONSTRAINT book_categories_category_id_fkey FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_categories_category_id ON book_categories (category_id);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id         bigserial   PRIMARY KEY,
+    name       varchar(50) NOT NULL,
+    created_at timestamptz NOT NULL,
+    CONSTRAINT tags_name_key UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS book_tags (
+    book_id bigint NOT NULL,
+    tag_id  bigint NOT NULL,
+    PRIMARY KEY (book_id, tag_id),
+    CONSTRAINT book_tags_book_id_fkey FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+    CONSTRAINT book_tags_tag_id_fkey FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_tags_tag_id ON book_tags (tag_id);
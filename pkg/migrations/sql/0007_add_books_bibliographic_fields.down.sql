@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_books_isbn_unique;
+ALTER TABLE books
+    DROP COLUMN IF EXISTS isbn,
+    DROP COLUMN IF EXISTS publisher,
+    DROP COLUMN IF EXISTS publication_year,
+    DROP COLUMN IF EXISTS language,
+    DROP COLUMN IF EXISTS page_count,
+    DROP COLUMN IF EXISTS edition;
@@ -0,0 +1,13 @@
+-- everything here is optional, empty strings and NULLs mean unknown. ISBNs
+-- are stored as ISBN-13 without hyphens, ISBN-10s are converted on the way in
+ALTER TABLE books
+    ADD COLUMN IF NOT EXISTS isbn             varchar(13)  NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS publisher        varchar(100) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS publication_year smallint     CHECK (publication_year BETWEEN 1 AND 9999),
+    ADD COLUMN IF NOT EXISTS language         varchar(35)  NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS page_count       integer      CHECK (page_count > 0),
+    ADD COLUMN IF NOT EXISTS edition          varchar(50)  NOT NULL DEFAULT '';
+
+-- like titles, an ISBN only has to be unique among live books
+CREATE UNIQUE INDEX IF NOT EXISTS idx_books_isbn_unique ON books (isbn)
+    WHERE deleted_at IS NULL AND isbn <> '';
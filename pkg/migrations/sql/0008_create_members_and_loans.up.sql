@@ -0,0 +1,32 @@
+-- emails are stored lower case, so the plain unique key is case-insensitive
+CREATE TABLE IF NOT EXISTS members (
+    id         bigserial    PRIMARY KEY,
+    name       varchar(100) NOT NULL,
+    email      varchar(255) NOT NULL,
+    created_at timestamptz  NOT NULL,
+    updated_at timestamptz,
+    CONSTRAINT members_email_key UNIQUE (email)
+);
+
+CREATE INDEX IF NOT EXISTS idx_members_name ON members (lower(name));
+
+-- a loan holds one copy of a book from loaned_at until returned_at, the copy
+-- is taken off books.qty meanwhile. Members with loans can't go away, their
+-- history goes with a purged book
+CREATE TABLE IF NOT EXISTS loans (
+    id          bigserial   PRIMARY KEY,
+    book_id     bigint      NOT NULL,
+    member_id   bigint      NOT NULL,
+    loaned_at   timestamptz NOT NULL,
+    due_at      timestamptz NOT NULL,
+    returned_at timestamptz,
+    renewals    integer     NOT NULL DEFAULT 0,
+    updated_at  timestamptz,
+    CONSTRAINT loans_book_id_fkey FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+    CONSTRAINT loans_member_id_fkey FOREIGN KEY (member_id) REFERENCES members (id) ON DELETE RESTRICT,
+    CONSTRAINT loans_due_after_loaned CHECK (due_at > loaned_at),
+    CONSTRAINT loans_renewals_non_negative CHECK (renewals >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_loans_member_id ON loans (member_id, loaned_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_loans_book_id ON loans (book_id) WHERE returned_at IS NULL;
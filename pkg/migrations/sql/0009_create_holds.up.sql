@@ -0,0 +1,24 @@
+-- a hold keeps a member's place in line for a book that is out of copies.
+-- It waits until a returned copy is set aside for it, then is ready until
+-- expires_at for the member to check it out
+CREATE TABLE IF NOT EXISTS holds (
+    id         bigserial   PRIMARY KEY,
+    book_id    bigint      NOT NULL,
+    member_id  bigint      NOT NULL,
+    status     varchar(10) NOT NULL DEFAULT 'waiting',
+    created_at timestamptz NOT NULL,
+    ready_at   timestamptz,
+    expires_at timestamptz,
+    updated_at timestamptz,
+    CONSTRAINT holds_book_id_fkey FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+    CONSTRAINT holds_member_id_fkey FOREIGN KEY (member_id) REFERENCES members (id) ON DELETE RESTRICT,
+    CONSTRAINT holds_status_check CHECK (status IN ('waiting', 'ready', 'fulfilled', 'cancelled', 'expired')),
+    CONSTRAINT holds_ready_expires CHECK (status <> 'ready' OR expires_at IS NOT NULL)
+);
+
+-- a member holds a title once at a time, closed holds don't count
+CREATE UNIQUE INDEX IF NOT EXISTS idx_holds_open_unique ON holds (book_id, member_id)
+    WHERE status IN ('waiting', 'ready');
+-- the queue of a book, oldest first
+CREATE INDEX IF NOT EXISTS idx_holds_queue ON holds (book_id, created_at, id) WHERE status = 'waiting';
+CREATE INDEX IF NOT EXISTS idx_holds_ready ON holds (book_id, expires_at) WHERE status = 'ready';
@@ -0,0 +1,3 @@
+ALTER TABLE loans
+    DROP CONSTRAINT IF EXISTS loans_book_id_fkey,
+    ADD CONSTRAINT loans_book_id_fkey FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE;
@@ -0,0 +1,6 @@
+-- purging a book used to take its open loans along, the copies out there
+-- vanished from the books. Loans now keep their book, the purge clears the
+-- history of returned loans itself and stops at the ones still out
+ALTER TABLE loans
+    DROP CONSTRAINT IF EXISTS loans_book_id_fkey,
+    ADD CONSTRAINT loans_book_id_fkey FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE RESTRICT;
@@ -1,10 +1,14 @@
 package postgres
 
 import (
+	"context"
 	"crud-echo/internal/config"
-	"crud-echo/internal/models"
+	"crud-echo/pkg/metrics"
+	"crud-echo/pkg/migrations"
+	"crud-echo/pkg/tracing"
 	"fmt"
 
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -14,7 +18,7 @@ type PostgresDB struct {
 	DB *gorm.DB
 }
 
-func NewDB(cfg *config.Config) (*PostgresDB, error) {
+func NewDB(cfg *config.Config, m *metrics.Metrics, tp *sdktrace.TracerProvider) (*PostgresDB, error) {
 	dsn := "user=rif password=angelcf511 dbname=project_1 port=5432 sslmode=disable TimeZone=Asia/Shanghai"
 	dsn = fmt.Sprintf("user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=%s",
 		// NOTE: don't know why, but this will only work if the host is not specified
@@ -28,13 +32,27 @@ func NewDB(cfg *config.Config) (*PostgresDB, error) {
 	)
 
 	database, err := gorm.Open(postgres.Open(dsn))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to postgres database: %w", err)
+	}
 	if cfg.Database.LogMode {
 		database.Logger = logger.Default.LogMode(logger.Info)
 	} else {
 		database.Logger = logger.Default.LogMode(logger.Silent)
 	}
+
+	if err := database.Use(m.GormPlugin()); err != nil {
+		return nil, fmt.Errorf("failed to register metrics plugin: %w", err)
+	}
+	if err := database.Use(tracing.GormPlugin(tp)); err != nil {
+		return nil, fmt.Errorf("failed to register tracing plugin: %w", err)
+	}
+	sqlDB, err := database.DB()
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to postgres database: %w", err)
+		return nil, err
+	}
+	if err := m.RegisterDBStats(sqlDB, "postgres"); err != nil {
+		return nil, fmt.Errorf("failed to register pool metrics: %w", err)
 	}
 
 	psqldb := new(PostgresDB)
@@ -43,8 +61,39 @@ func NewDB(cfg *config.Config) (*PostgresDB, error) {
 	return psqldb, nil
 }
 
+// Migrate applies any pending versioned migrations, replicas booting at the
+// same time wait on each other instead of racing
 func (psqldb *PostgresDB) Migrate() error {
-	return psqldb.DB.AutoMigrate(&models.Books{})
+	migrator, err := psqldb.Migrator()
+	if err != nil {
+		return err
+	}
+
+	if _, err := migrator.Up(context.Background()); err != nil {
+		return fmt.Errorf("failed to migrate: %w", err)
+	}
+
+	return nil
+}
+
+// Migrator builds the versioned migrator on top of the connection pool
+func (psqldb *PostgresDB) Migrator() (*migrations.Migrator, error) {
+	sqlDB, err := psqldb.DB.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	return migrations.New(sqlDB)
+}
+
+// Close closes the underlying connection pool
+func (psqldb *PostgresDB) Close() error {
+	sqlDB, err := psqldb.DB.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.Close()
 }
 
 func (psqldb *PostgresDB) GetDB() *gorm.DB {
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+	"crud-echo/internal/config"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+var ErrUnknownExporter = errors.New("unknown tracing exporter")
+
+// New builds the tracer provider and makes it the global one, so otel.Tracer
+// works anywhere without passing the provider around. Inbound traceparent
+// headers are honoured whatever the exporter, sampling follows the parent
+// when there is one
+func New(cfg *config.Config) (*sdktrace.TracerProvider, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	res, err := resource.New(context.Background(),
+		resource.WithAttributes(semconv.ServiceName(cfg.Tracing.ServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tracing resource: %w", err)
+	}
+
+	sampler := sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.Tracing.SampleRatio))
+	opts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+
+	switch cfg.Tracing.Exporter {
+	case ExporterNone, "":
+		sampler = sdktrace.NeverSample()
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithSyncer(exporter))
+	case ExporterOTLP:
+		var clientOpts []otlptracehttp.Option
+		if cfg.Tracing.Endpoint != "" {
+			clientOpts = append(clientOpts, otlptracehttp.WithEndpoint(cfg.Tracing.Endpoint))
+		}
+		if cfg.Tracing.Insecure {
+			clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+		}
+		// doesn't dial yet, an unreachable collector only shows up as
+		// dropped batches
+		exporter, err := otlptracehttp.New(context.Background(), clientOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create otlp exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownExporter, cfg.Tracing.Exporter)
+	}
+
+	tp := sdktrace.NewTracerProvider(append(opts, sdktrace.WithSampler(sampler))...)
+	otel.SetTracerProvider(tp)
+
+	return tp, nil
+}
@@ -0,0 +1,140 @@
+package tracing
+
+import (
+	"context"
+	"crud-echo/internal/config"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		exporter    string
+		wantSampled bool
+		wantErr     error
+	}{
+		{
+			name:        "None never samples",
+			exporter:    ExporterNone,
+			wantSampled: false,
+		},
+		{
+			name:        "Stdout samples everything",
+			exporter:    ExporterStdout,
+			wantSampled: true,
+		},
+		{
+			name:        "OTLP samples everything",
+			exporter:    ExporterOTLP,
+			wantSampled: true,
+		},
+		{
+			name:     "Unknown exporter",
+			exporter: "zipkin",
+			wantErr:  ErrUnknownExporter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tp, err := New(&config.Config{Tracing: &config.Tracing{
+				Exporter:    tt.exporter,
+				Endpoint:    "localhost:4318",
+				Insecure:    true,
+				ServiceName: "test",
+				SampleRatio: 1,
+			}})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			defer tp.Shutdown(context.Background())
+
+			_, span := tp.Tracer("test").Start(context.Background(), "span")
+			defer span.End()
+			assert.Equal(t, tt.wantSampled, span.SpanContext().IsSampled())
+		})
+	}
+}
+
+func TestNewHonoursTraceparent(t *testing.T) {
+	_, err := New(&config.Config{Tracing: &config.Tracing{Exporter: ExporterNone, ServiceName: "test"}})
+	assert.NoError(t, err)
+
+	header := http.Header{}
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(header))
+	sc := trace.SpanContextFromContext(ctx)
+
+	assert.True(t, sc.IsRemote())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", sc.SpanID().String())
+}
+
+func TestGormPlugin(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	assert.NoError(t, gdb.Use(GormPlugin(tp)))
+
+	mock.ExpectQuery(`SELECT \* FROM "books"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`SELECT \* FROM "books"`).
+		WillReturnError(errors.New("connection reset"))
+
+	type books struct{ ID int }
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "BooksUseCase.GetBookByID")
+	var book books
+	assert.ErrorIs(t, gdb.WithContext(ctx).First(&book, 1).Error, gorm.ErrRecordNotFound)
+	assert.Error(t, gdb.WithContext(ctx).First(&book, 2).Error)
+	parent.End()
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+
+	notFound, failed := spans[0], spans[1]
+	for _, span := range []sdktrace.ReadOnlySpan{notFound, failed} {
+		assert.Equal(t, "gorm.query", span.Name())
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+
+		attrs := map[string]string{}
+		for _, kv := range span.Attributes() {
+			attrs[string(kv.Key)] = kv.Value.Emit()
+		}
+		assert.Contains(t, attrs["db.query.text"], `SELECT * FROM "books" WHERE "books"."id" = $1`)
+		assert.Equal(t, "books", attrs["db.collection.name"])
+		assert.Equal(t, "postgresql", attrs["db.system"])
+	}
+
+	assert.Equal(t, codes.Unset, notFound.Status().Code, "record not found is not an error")
+	assert.Equal(t, codes.Error, failed.Status().Code)
+}